@@ -0,0 +1,241 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Gltrace dumps, compares and replays the traces of GL calls written by
+// gl.Tracer.
+//
+// Usage:
+//
+//	gltrace dump trace
+//	gltrace diff [-n max] old new
+//	gltrace replay [-v] trace
+//
+// Dump prints the calls of a trace, one per line, numbered from 0.
+//
+// Diff compares two traces, including the data of the slices that the calls
+// take, and prints the fewest calls to remove from the old trace, marked -,
+// and add from the new, marked +, to make one the other, up to max of them.
+// Calls that differ only in their data are printed as removed and added. It
+// exits with status 1 if the traces differ.
+//
+// Replay makes the calls of a trace on a glfake.Context, which checks them
+// as an OpenGL ES 2.0 implementation would, and prints the calls that cause
+// GL errors, or every call with -v. It exits with status 1 if any call
+// causes an error.
+package main // import "github.com/as/shiny/cmd/gltrace"
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+
+	"github.com/as/shiny/gl"
+	"github.com/as/shiny/gl/glfake"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: gltrace dump trace\n")
+	fmt.Fprintf(os.Stderr, "       gltrace diff [-n max] old new\n")
+	fmt.Fprintf(os.Stderr, "       gltrace replay [-v] trace\n")
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gltrace: ")
+	if len(os.Args) < 2 {
+		usage()
+	}
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	fs.Usage = usage
+	max := fs.Int("n", 10, "print at most `max` differing calls")
+	verbose := fs.Bool("v", false, "print every call")
+	fs.Parse(os.Args[2:])
+	args := fs.Args()
+
+	switch {
+	case os.Args[1] == "dump" && len(args) == 1:
+		dump(args[0])
+	case os.Args[1] == "diff" && len(args) == 2:
+		if diff(args[0], args[1], *max) {
+			os.Exit(1)
+		}
+	case os.Args[1] == "replay" && len(args) == 1:
+		if replay(args[0], *verbose) {
+			os.Exit(1)
+		}
+	default:
+		usage()
+	}
+}
+
+// trace reads the trace in the named file, and calls f with each of its
+// calls, in order.
+func trace(name string, f func(*gl.TraceCall)) {
+	file, err := os.Open(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	r, err := gl.NewTraceReader(file)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		f(c)
+	}
+}
+
+func dump(name string) {
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	i := 0
+	trace(name, func(c *gl.TraceCall) {
+		fmt.Fprintf(w, "%d\t%v\n", i, c)
+		i++
+	})
+}
+
+// diff prints the calls that differ between the traces in the named files,
+// and reports whether there are any.
+func diff(oldName, newName string, max int) bool {
+	var old, cur []*gl.TraceCall
+	trace(oldName, func(c *gl.TraceCall) { old = append(old, c) })
+	trace(newName, func(c *gl.TraceCall) { cur = append(cur, c) })
+	ok, nk := keys(old), keys(cur)
+
+	// Only the calls between the common prefix and suffix are compared.
+	p := 0
+	for p < len(ok) && p < len(nk) && ok[p] == nk[p] {
+		p++
+	}
+	s := 0
+	for s < len(ok)-p && s < len(nk)-p && ok[len(ok)-1-s] == nk[len(nk)-1-s] {
+		s++
+	}
+
+	n := 0
+	for _, e := range edits(ok[p:len(ok)-s], nk[p:len(nk)-s]) {
+		if n < max {
+			if e.old {
+				fmt.Printf("-%d\t%v\n", p+e.i, old[p+e.i])
+			} else {
+				fmt.Printf("+%d\t%v\n", p+e.i, cur[p+e.i])
+			}
+		}
+		n++
+	}
+	if n > max {
+		fmt.Printf("and %d more\n", n-max)
+	}
+	return n > 0
+}
+
+// keys returns a hash of each call, including the data of its slices.
+func keys(calls []*gl.TraceCall) []uint64 {
+	k := make([]uint64, len(calls))
+	for i, c := range calls {
+		h := fnv.New64a()
+		fmt.Fprint(h, c.Name, c.Args, c.Results)
+		k[i] = h.Sum64()
+	}
+	return k
+}
+
+// An edit is the removal of the i'th old call, or the addition of the i'th
+// new call.
+type edit struct {
+	old bool
+	i   int
+}
+
+// maxDiff is the largest product of the numbers of old and new calls that
+// edits finds the shortest edit script for.
+const maxDiff = 1 << 22
+
+// edits returns the edits that turn a into b. They are the fewest, unless a
+// and b are so long that finding those would take too much time and memory,
+// in which case a is replaced with b.
+func edits(a, b []uint64) []edit {
+	var es []edit
+	if len(a)*len(b) > maxDiff {
+		for i := range a {
+			es = append(es, edit{true, i})
+		}
+		for i := range b {
+			es = append(es, edit{false, i})
+		}
+		return es
+	}
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			es = append(es, edit{true, i})
+			i++
+		default:
+			es = append(es, edit{false, j})
+			j++
+		}
+	}
+	return es
+}
+
+// replay replays the trace in the named file on a glfake.Context, and
+// reports whether any call caused an error.
+func replay(name string, verbose bool) bool {
+	fake := glfake.NewContext()
+	r := gl.NewReplayer(fake)
+	failed := false
+	i := 0
+	trace(name, func(c *gl.TraceCall) {
+		if _, err := r.Replay(c); err != nil {
+			log.Fatalf("%s: call %d: %v", name, i, err)
+		}
+		calls := fake.Calls()
+		fake.ResetCalls()
+		for _, fc := range calls {
+			if fc.Err != 0 {
+				failed = true
+			}
+			if fc.Err != 0 || verbose {
+				fmt.Printf("%d\t%v\n", i, fc)
+			}
+		}
+		i++
+	})
+	return failed
+}
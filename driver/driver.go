@@ -3,6 +3,12 @@
 // license that can be found in the LICENSE file.
 
 // Package driver provides the default driver for accessing a screen.
+//
+// The drivers available to a program are registered at init time, by the
+// platform-specific files in this package, and one of them is chosen at run
+// time. By default, Main picks the highest priority driver whose probe
+// succeeds. Setting the SHINY_DRIVER environment variable to a driver's name,
+// such as "x11" or "gl", or calling MainWith, selects that driver instead.
 package driver // import "github.com/as/shiny/driver"
 
 // TODO: figure out what to say about the responsibility for users of this
@@ -11,15 +17,36 @@ package driver // import "github.com/as/shiny/driver"
 // or OpenGL library.
 
 import (
+	"os"
+
+	"github.com/as/shiny/driver/internal/errscreen"
 	"github.com/as/shiny/screen"
 )
 
+// EnvDriver is the name of the environment variable that, if non-empty,
+// overrides the driver chosen by Main.
+const EnvDriver = "SHINY_DRIVER"
+
 // Main is called by the program's main function to run the graphical
 // application.
 //
 // It calls f on the Screen, possibly in a separate goroutine, as some OS-
 // specific libraries require being on 'the main thread'. It returns when f
 // returns.
+//
+// If no registered driver can be used, f is called with a Screen whose
+// methods all return an error describing why each driver was rejected.
 func Main(f func(screen.Screen)) {
-	main(f)
+	MainWith(os.Getenv(EnvDriver), f)
+}
+
+// MainWith is like Main, but uses the driver registered under the given
+// name. An empty name means to pick the best available driver, as Main does.
+func MainWith(name string, f func(screen.Screen)) {
+	d, err := choose(name)
+	if err != nil {
+		f(errscreen.Stub(err))
+		return
+	}
+	d.main(f)
 }
@@ -8,9 +8,8 @@ package driver
 
 import (
 	"github.com/as/shiny/driver/gldriver"
-	"github.com/as/shiny/screen"
 )
 
-func main(f func(screen.Screen)) {
-	gldriver.Main(f)
+func init() {
+	Register("gl", 20, gldriver.Probe, gldriver.Main)
 }
@@ -0,0 +1,22 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build gldriver
+// +build linux,!android windows
+
+package driver
+
+// The OpenGL driver links against EGL and OpenGL ES libraries that are not
+// installed everywhere, so on platforms with a native driver it is only
+// compiled in when building with the gldriver tag. It is registered with a
+// lower priority than the native driver, and can be selected at run time
+// with SHINY_DRIVER=gl.
+
+import (
+	"github.com/as/shiny/driver/gldriver"
+)
+
+func init() {
+	Register("gl", 10, gldriver.Probe, gldriver.Main)
+}
@@ -6,9 +6,8 @@ package driver
 
 import (
 	"github.com/as/shiny/driver/windriver"
-	"github.com/as/shiny/screen"
 )
 
-func main(f func(screen.Screen)) {
-	windriver.Main(f)
+func init() {
+	Register("windows", 20, windriver.Probe, windriver.Main)
 }
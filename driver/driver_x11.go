@@ -8,9 +8,8 @@ package driver
 
 import (
 	"github.com/as/shiny/driver/x11driver"
-	"github.com/as/shiny/screen"
 )
 
-func main(f func(screen.Screen)) {
-	x11driver.Main(f)
+func init() {
+	Register("x11", 20, x11driver.Probe, x11driver.Main)
 }
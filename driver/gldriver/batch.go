@@ -0,0 +1,268 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gldriver
+
+import (
+	"image"
+	"image/draw"
+	"log"
+	"math"
+
+	"github.com/as/shiny/gl"
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+func (w *windowImpl) DrawBatch(ops []screen.BatchOp) {
+	w.s.glctxMu.Lock()
+	defer w.s.glctxMu.Unlock()
+
+	w.bindBackBuffer()
+	w.s.flush()
+	drawBatch(w.s, w.glctx, w, ops)
+}
+
+func (t *textureImpl) DrawBatch(ops []screen.BatchOp) {
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
+
+	t.bindFramebuffer()
+	defer t.unbindFramebuffer()
+	drawBatch(t.s, t.s.glctx, t, ops)
+}
+
+// batchKey is what consecutive ops of a Batch have in common when they are
+// drawn together, in one draw call.
+type batchKey struct {
+	// tex is the texture to draw, or nil for uniform colors, which can
+	// differ from quad to quad, as can their opacity.
+	tex     *textureImpl
+	op      draw.Op
+	filter  screen.Filter
+	opacity float64
+	clip    image.Rectangle
+}
+
+// batchable returns the batchKey for op, and whether op can be drawn
+// together with others at all. Those that have a mask, or whose op needs
+// the fragment shader to read the dst, cannot.
+func batchable(op *screen.BatchOp) (batchKey, bool) {
+	if op.Opts.Mask != nil {
+		return batchKey{}, false
+	}
+	if _, ok := blendFuncs[op.Op]; !ok && op.Op != draw.Src {
+		return batchKey{}, false
+	}
+	k := batchKey{op: op.Op, clip: op.Opts.Clip}
+	if op.Src != nil {
+		k.tex = op.Src.(*textureImpl)
+		k.filter = op.Opts.GetFilter()
+		k.opacity = op.Opts.GetOpacity()
+	}
+	return k, true
+}
+
+// drawBatch draws the ops onto the currently bound framebuffer dst. Runs of
+// consecutive ops with the same batchKey are drawn with one DrawArrays call.
+//
+// drawBatch must only be called while holding screenImpl.glctxMu.
+func drawBatch(s *screenImpl, glctx gl.Context, dst target, ops []screen.BatchOp) {
+	if err := s.initBatch(glctx); err != nil {
+		log.Printf("gldriver: %v", err)
+		for i := range ops {
+			drawOne(s, glctx, dst, &ops[i])
+		}
+		return
+	}
+	for len(ops) > 0 {
+		k, ok := batchable(&ops[0])
+		if !ok {
+			drawOne(s, glctx, dst, &ops[0])
+			ops = ops[1:]
+			continue
+		}
+		n := 1
+		for ; n < len(ops); n++ {
+			if k1, ok := batchable(&ops[n]); !ok || k1 != k {
+				break
+			}
+		}
+		drawQuads(s, glctx, dst, k, ops[:n])
+		ops = ops[n:]
+	}
+}
+
+// drawOne draws a single op, like the Drawer method it was recorded from.
+//
+// drawOne must only be called while holding screenImpl.glctxMu.
+func drawOne(s *screenImpl, glctx gl.Context, dst target, op *screen.BatchOp) {
+	if op.Src == nil {
+		doFill(s, glctx, dst, op.Src2dst, op.Color, op.SR, op.Op, &op.Opts)
+		return
+	}
+	t := op.Src.(*textureImpl)
+	if sr := op.SR.Intersect(t.Bounds()); !sr.Empty() {
+		doDraw(s, glctx, dst, op.Src2dst, t, sr, op.Op, &op.Opts)
+	}
+}
+
+// drawQuads draws the ops, which all have the batchKey k, as one list of
+// triangles, two per op.
+//
+// drawQuads must only be called while holding screenImpl.glctxMu.
+func drawQuads(s *screenImpl, glctx gl.Context, dst target, k batchKey, ops []screen.BatchOp) {
+	// Each vertex is a clip space position followed by either texture
+	// coordinates or an alpha-premultiplied color.
+	b := s.batch.buf[:0]
+	for i := range ops {
+		op := &ops[i]
+		sr := op.SR
+		if k.tex != nil {
+			sr = sr.Intersect(k.tex.Bounds())
+		}
+		if sr.Empty() {
+			continue
+		}
+		m := quadMVP(dst, op.Src2dst, sr)
+		var attr [4][]float32
+		if k.tex != nil {
+			tw, th := float32(k.tex.size.X), float32(k.tex.size.Y)
+			px, py := float32(sr.Min.X)/tw, float32(sr.Min.Y)/th
+			qx, sy := float32(sr.Max.X)/tw, float32(sr.Max.Y)/th
+			attr = [4][]float32{{px, py}, {qx, py}, {px, sy}, {qx, sy}}
+		} else {
+			// The color is alpha-premultiplied, so all four channels scale.
+			cr, cg, cb, ca := op.Color.RGBA()
+			f := float32(op.Opts.GetOpacity()) / 65535
+			c := []float32{float32(cr) * f, float32(cg) * f, float32(cb) * f, float32(ca) * f}
+			attr = [4][]float32{c, c, c, c}
+		}
+		// The corners are in the same order as quadCoords, and each quad is
+		// two triangles.
+		for _, j := range [6]int{0, 1, 2, 2, 1, 3} {
+			p := m.Transform(f64.Vec2{float64(j & 1), float64(j >> 1)})
+			b = appendF32(b, float32(p[0]), float32(p[1]))
+			b = appendF32(b, attr[j]...)
+		}
+	}
+	s.batch.buf = b
+	if len(b) == 0 {
+		return
+	}
+
+	if _, ok := useOp(glctx, k.op); !ok {
+		return
+	}
+	defer useClip(glctx, dst, &screen.DrawOptions{Clip: k.clip})()
+
+	glctx.BindBuffer(gl.ARRAY_BUFFER, s.batch.vbo)
+	glctx.BufferData(gl.ARRAY_BUFFER, b, gl.STREAM_DRAW)
+
+	var pos, attr gl.Attrib
+	var stride, count int
+	if k.tex != nil {
+		glctx.UseProgram(s.batch.texture.program)
+		glctx.ActiveTexture(gl.TEXTURE0)
+		glctx.BindTexture(gl.TEXTURE_2D, k.tex.id)
+		k.tex.setFilter(glctx, k.filter)
+		glctx.Uniform1i(s.batch.texture.sample, 0)
+		glctx.Uniform1f(s.batch.texture.alpha, float32(k.opacity))
+		pos, attr, stride, count = s.batch.texture.pos, s.batch.texture.inUV, 4*4, 2
+	} else {
+		glctx.UseProgram(s.batch.fill.program)
+		pos, attr, stride, count = s.batch.fill.pos, s.batch.fill.inColor, 6*4, 4
+	}
+	glctx.EnableVertexAttribArray(pos)
+	glctx.VertexAttribPointer(pos, 2, gl.FLOAT, false, stride, 0)
+	glctx.EnableVertexAttribArray(attr)
+	glctx.VertexAttribPointer(attr, count, gl.FLOAT, false, stride, 2*4)
+
+	glctx.DrawArrays(gl.TRIANGLES, 0, len(b)/stride)
+
+	glctx.DisableVertexAttribArray(pos)
+	glctx.DisableVertexAttribArray(attr)
+}
+
+// appendF32 is like f32Bytes, with a little-endian byte order, but appends to
+// b instead of allocating.
+func appendF32(b []byte, values ...float32) []byte {
+	for _, v := range values {
+		u := math.Float32bits(v)
+		b = append(b, byte(u), byte(u>>8), byte(u>>16), byte(u>>24))
+	}
+	return b
+}
+
+// initBatch compiles the batch programs, if they are not already compiled.
+//
+// initBatch must only be called while holding screenImpl.glctxMu.
+func (s *screenImpl) initBatch(glctx gl.Context) error {
+	if glctx.IsProgram(s.batch.texture.program) {
+		return nil
+	}
+	p, err := compileProgram(glctx, batchTextureVertexSrc, batchTextureFragmentSrc)
+	if err != nil {
+		return err
+	}
+	s.batch.texture.program = p
+	s.batch.texture.pos = glctx.GetAttribLocation(p, "pos")
+	s.batch.texture.inUV = glctx.GetAttribLocation(p, "inUV")
+	s.batch.texture.sample = glctx.GetUniformLocation(p, "sample")
+	s.batch.texture.alpha = glctx.GetUniformLocation(p, "alpha")
+
+	p, err = compileProgram(glctx, batchFillVertexSrc, batchFillFragmentSrc)
+	if err != nil {
+		glctx.DeleteProgram(s.batch.texture.program)
+		return err
+	}
+	s.batch.fill.program = p
+	s.batch.fill.pos = glctx.GetAttribLocation(p, "pos")
+	s.batch.fill.inColor = glctx.GetAttribLocation(p, "inColor")
+
+	s.batch.vbo = glctx.CreateBuffer()
+	return nil
+}
+
+// The batch programs take positions that are already in clip space, and
+// per-vertex texture coordinates or colors, so that quads with different
+// transforms can be drawn in one draw call.
+
+const batchTextureVertexSrc = `#version 100
+attribute vec2 pos;
+attribute vec2 inUV;
+varying vec2 uv;
+void main() {
+	gl_Position = vec4(pos, 0, 1);
+	uv = inUV;
+}
+`
+
+const batchTextureFragmentSrc = `#version 100
+precision mediump float;
+varying vec2 uv;
+uniform sampler2D sample;
+uniform float alpha;
+void main() {
+	gl_FragColor = texture2D(sample, uv) * alpha;
+}
+`
+
+const batchFillVertexSrc = `#version 100
+attribute vec2 pos;
+attribute vec4 inColor;
+varying vec4 color;
+void main() {
+	gl_Position = vec4(pos, 0, 1);
+	color = inColor;
+}
+`
+
+const batchFillFragmentSrc = `#version 100
+precision mediump float;
+varying vec4 color;
+void main() {
+	gl_FragColor = color;
+}
+`
@@ -6,7 +6,10 @@ package gldriver
 
 import (
 	"image"
+	"image/draw"
 
+	"github.com/as/shiny/driver/internal/swizzle"
+	"github.com/as/shiny/gl"
 	"github.com/as/shiny/screen"
 )
 
@@ -18,10 +21,66 @@ type bufferImpl struct {
 	rgba image.RGBA
 	size image.Point
 
+	// img is &rgba for FormatRGBA, which GL takes directly. The other
+	// formats are converted to and from RGBA.
+	img    draw.Image
+	format screen.Format
+
 	t screen.Texture
 }
 
 func (b *bufferImpl) Release()                {}
 func (b *bufferImpl) Size() image.Point       { return b.size }
 func (b *bufferImpl) Bounds() image.Rectangle { return image.Rectangle{Max: b.size} }
-func (b *bufferImpl) RGBA() *image.RGBA       { return &b.rgba }
+func (b *bufferImpl) Image() draw.Image       { return b.img }
+func (b *bufferImpl) Format() screen.Format   { return b.format }
+
+func (b *bufferImpl) RGBA() *image.RGBA {
+	if b.format != screen.FormatRGBA {
+		return nil
+	}
+	return &b.rgba
+}
+
+// download reads the pixels in sr of the currently bound framebuffer, whose
+// bounds are given, into b such that sr.Min maps to dp. If flip is true, the
+// framebuffer's first row is at the top of bounds, as for the back buffer,
+// and is at the bottom otherwise.
+//
+// download must only be called while holding screenImpl.glctxMu.
+func (b *bufferImpl) download(glctx gl.Context, bounds image.Rectangle, flip bool, sr image.Rectangle, dp image.Point) {
+	delta := dp.Sub(sr.Min)
+	sr = sr.Intersect(bounds).Intersect(b.Bounds().Sub(delta))
+	if sr.Empty() {
+		return
+	}
+	dp = sr.Min.Add(delta)
+
+	// ReadPixels packs rows tightly and in GL's bottom-up order, which
+	// generally matches neither b's stride nor, if flip is true, b's row
+	// order. Read into a scratch buffer and copy out row by row.
+	//
+	// TODO: use GL_PACK_ROW_LENGTH with glPixelStorei in ES 3.0, instead of
+	// reading through a scratch buffer.
+	width := 4 * sr.Dx()
+	pix := make([]byte, width*sr.Dy())
+	y := sr.Min.Y
+	if flip {
+		y = bounds.Dy() - sr.Max.Y
+	}
+	glctx.ReadPixels(pix, sr.Min.X, y, sr.Dx(), sr.Dy(), gl.RGBA, gl.UNSIGNED_BYTE)
+
+	for i := 0; i < sr.Dy(); i++ {
+		row := i
+		if flip {
+			row = sr.Dy() - 1 - i
+		}
+		if b.format != screen.FormatRGBA {
+			y := dp.Y + row
+			swizzle.Store(b.img, image.Rect(dp.X, y, dp.X+sr.Dx(), y+1), pix[i*width:], width, false)
+			continue
+		}
+		p := b.rgba.PixOffset(dp.X, dp.Y+row)
+		copy(b.rgba.Pix[p:p+width], pix[i*width:])
+	}
+}
@@ -22,6 +22,7 @@ void startDriver();
 void stopDriver();
 void makeCurrentContext(uintptr_t ctx);
 void flushContext(uintptr_t ctx);
+int makeShareCurrent();
 uintptr_t doNewWindow(int width, int height, char* title);
 void doShowWindow(uintptr_t id);
 void doCloseWindow(uintptr_t id);
@@ -32,10 +33,12 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"image"
 	"log"
 	"runtime"
 	"unsafe"
 
+	"github.com/as/shiny/driver/internal/errscreen"
 	"github.com/as/shiny/driver/internal/lifecycler"
 	"github.com/as/shiny/event/key"
 	"github.com/as/shiny/event/lifecycle"
@@ -44,6 +47,7 @@ import (
 	"github.com/as/shiny/event/size"
 	"github.com/as/shiny/geom"
 	"github.com/as/shiny/gl"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/screen"
 )
 
@@ -68,7 +72,9 @@ func showWindow(w *windowImpl) {
 
 //export preparedOpenGL
 func preparedOpenGL(id, ctx, vba uintptr) {
-	w := theScreen.window
+	theScreen.mu.Lock()
+	w := theScreen.windows[id]
+	theScreen.mu.Unlock()
 	w.ctx = ctx
 	go drawLoop(w, vba)
 }
@@ -79,6 +85,11 @@ func closeWindow(id uintptr) {
 
 var mainCallback func(screen.Screen)
 
+// probe always succeeds, as Cocoa and OpenGL are part of every OS X install.
+func probe() error {
+	return nil
+}
+
 func main(f func(screen.Screen)) error {
 	initThreadID = C.threadID()
 	mainCallback = f
@@ -89,6 +100,15 @@ func main(f func(screen.Screen)) error {
 
 //export driverStarted
 func driverStarted() {
+	share, err := newShareContext()
+	if err != nil {
+		go func() {
+			mainCallback(errscreen.Stub(err))
+			C.stopDriver()
+		}()
+		return
+	}
+	theScreen.glctx = share
 	go func() {
 		mainCallback(theScreen)
 		C.stopDriver()
@@ -96,14 +116,19 @@ func driverStarted() {
 }
 
 //export drawgl
-func drawgl(id uintptr) {
-	w := theScreen.window
+func drawgl(id uintptr, x, y, width, height int32) {
+	theScreen.mu.Lock()
+	w := theScreen.windows[id]
+	theScreen.mu.Unlock()
 	if w == nil {
 		panic("dead")
 		return // closing window
 	}
 	// TODO: is this necessary?
-	screen.SendPaint(paint.Event{External: true})
+	w.clock.SendPaint(paint.Event{
+		External: true,
+		Damage:   imageutil.NewRegion(image.Rect(int(x), int(y), int(x+width), int(y+height))),
+	})
 	<-w.drawDone
 }
 
@@ -154,7 +179,9 @@ func drawLoop(w *windowImpl, vba uintptr) {
 
 //export setGeom
 func setGeom(id uintptr, ppp float32, widthPx, heightPx int) {
-	w := theScreen.window
+	theScreen.mu.Lock()
+	w := theScreen.windows[id]
+	theScreen.mu.Unlock()
 	if w == nil {
 		panic("closing window")
 		return // closing window
@@ -593,5 +620,8 @@ func cocoaKeyCode(vkcode uint16) key.Code {
 }
 
 func surfaceCreate() error {
-	return errors.New("gldriver: surface creation not implemented on darwin")
+	if C.makeShareCurrent() == 0 {
+		return errors.New("gldriver: surface creation failed")
+	}
+	return nil
 }
@@ -41,6 +41,39 @@ void flushContext(uintptr_t context) {
 	[ctx flushBuffer];
 }
 
+// shareContext is the hidden context whose objects, such as textures, are
+// shared with the context of every window.
+NSOpenGLContext* shareContext;
+
+NSOpenGLPixelFormat* pixelFormat() {
+	NSOpenGLPixelFormatAttribute attr[] = {
+		NSOpenGLPFAOpenGLProfile, NSOpenGLProfileVersion3_2Core,
+		NSOpenGLPFAColorSize,     24,
+		NSOpenGLPFAAlphaSize,     8,
+		NSOpenGLPFADepthSize,     16,
+		//NSOpenGLPFADoubleBuffer,	//TODO(as):option to set this
+		NSOpenGLPFAAllowOfflineRenderers,
+		0
+	};
+	return [[NSOpenGLPixelFormat alloc] initWithAttributes:attr];
+}
+
+// makeShareCurrent creates the share context, and makes it current on the
+// calling thread. It returns 0 on failure.
+int makeShareCurrent() {
+	shareContext = [[NSOpenGLContext alloc] initWithFormat:pixelFormat() shareContext:nil];
+	if (shareContext == nil) {
+		return 0;
+	}
+	[shareContext makeCurrentContext];
+
+	// As in prepareOpenGL, bind a default VBA.
+	GLuint vba;
+	glGenVertexArrays(1, &vba);
+	glBindVertexArray(vba);
+	return 1;
+}
+
 uint64 threadID() {
 	uint64 id;
 	if (pthread_threadid_np(pthread_self(), &id)) {
@@ -105,7 +138,16 @@ uint64 threadID() {
 - (void)drawRect:(NSRect)theRect {
 	// Called during resize. Do an extra draw if we are visible.
 	// This gets rid of flicker when resizing.
-	drawgl((GoUintptr)self);
+	//
+	// theRect is the damage, in Cocoa pixels with the origin at the bottom
+	// left. Pass it on in actual pixels, with the origin at the top left.
+	double scale = [self.window.screen backingScaleFactor];
+	double h = [self bounds].size.height;
+	drawgl((GoUintptr)self,
+		theRect.origin.x * scale,
+		(h - theRect.origin.y - theRect.size.height) * scale,
+		theRect.size.width * scale,
+		theRect.size.height * scale);
 }
 
 - (void)mouseEventNS:(NSEvent *)theEvent {
@@ -278,17 +320,9 @@ uintptr_t doNewWindow(int width, int height, char* title) {
 		[window cascadeTopLeftFromPoint:NSMakePoint(20,20)];
 		[window setAcceptsMouseMovedEvents:YES];
 
-		NSOpenGLPixelFormatAttribute attr[] = {
-			NSOpenGLPFAOpenGLProfile, NSOpenGLProfileVersion3_2Core,
-			NSOpenGLPFAColorSize,     24,
-			NSOpenGLPFAAlphaSize,     8,
-			NSOpenGLPFADepthSize,     16,
-			//NSOpenGLPFADoubleBuffer,	//TODO(as):option to set this
-			NSOpenGLPFAAllowOfflineRenderers,
-			0
-		};
-		id pixFormat = [[NSOpenGLPixelFormat alloc] initWithAttributes:attr];
+		NSOpenGLPixelFormat* pixFormat = pixelFormat();
 		view = [[ScreenGLView alloc] initWithFrame:rect pixelFormat:pixFormat];
+		[view setOpenGLContext:[[NSOpenGLContext alloc] initWithFormat:pixFormat shareContext:shareContext]];
 		[window setContentView:view];
 		[window setDelegate:view];
 		[window makeFirstResponder:view];
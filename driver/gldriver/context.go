@@ -10,8 +10,10 @@ import (
 	"github.com/as/shiny/gl"
 )
 
-// NewContext creates an OpenGL ES context with a dedicated processing thread.
-func NewContext() (gl.Context, error) {
+// newShareContext creates the hidden share context, whose objects are shared
+// with the context of every window, with a dedicated processing thread. The
+// platform's surfaceCreate makes it current on that thread.
+func newShareContext() (gl.Context, error) {
 	glctx, worker := gl.NewContext()
 
 	errCh := make(chan error)
@@ -0,0 +1,215 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gldriver
+
+import (
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/as/shiny/gl"
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+func (w *windowImpl) DrawEffect(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, e *screen.Effect, op screen.Op, opts *screen.DrawOptions) error {
+	w.s.glctxMu.Lock()
+	defer w.s.glctxMu.Unlock()
+
+	w.bindBackBuffer()
+	w.s.flush()
+	return doEffect(w.s, w.glctx, w, src2dst, src.(*textureImpl), sr, e, op, opts)
+}
+
+func (t *textureImpl) DrawEffect(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, e *screen.Effect, op screen.Op, opts *screen.DrawOptions) error {
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
+
+	t.bindFramebuffer()
+	defer t.unbindFramebuffer()
+	return doEffect(t.s, t.s.glctx, t, src2dst, src.(*textureImpl), sr, e, op, opts)
+}
+
+// effectProgram is the program for the Source of a screen.Effect.
+type effectProgram struct {
+	// err is the error from compiling the program, which is not retried.
+	err error
+
+	program gl.Program
+	pos     gl.Attrib
+	mvp     gl.Uniform
+	pvp     gl.Uniform
+	sample  gl.Uniform
+	invSize gl.Uniform
+	srcRect gl.Uniform
+	alpha   gl.Uniform
+	blend   blendUniforms
+
+	// uniforms are the locations of the Effect's own uniforms, looked up
+	// as they are first set.
+	uniforms map[string]gl.Uniform
+}
+
+// effectProgram returns the program for an Effect's Source, compiling it the
+// first time that it is drawn.
+//
+// effectProgram must only be called while holding screenImpl.glctxMu.
+func (s *screenImpl) effectProgram(glctx gl.Context, src string) (*effectProgram, error) {
+	if p, ok := s.effects[src]; ok && (p.err != nil || glctx.IsProgram(p.program)) {
+		return p, p.err
+	}
+	if s.effects == nil {
+		s.effects = make(map[string]*effectProgram)
+	}
+	p := &effectProgram{uniforms: make(map[string]gl.Uniform)}
+	s.effects[src] = p
+	p.program, p.err = compileProgram(glctx, effectVertexSrc, effectFragmentSrc(src))
+	if p.err != nil {
+		return p, p.err
+	}
+	p.pos = glctx.GetAttribLocation(p.program, "pos")
+	p.mvp = glctx.GetUniformLocation(p.program, "mvp")
+	p.pvp = glctx.GetUniformLocation(p.program, "pvp")
+	p.sample = glctx.GetUniformLocation(p.program, "sample")
+	p.invSize = glctx.GetUniformLocation(p.program, "invSize")
+	p.srcRect = glctx.GetUniformLocation(p.program, "srcRect")
+	p.alpha = glctx.GetUniformLocation(p.program, "alpha")
+	p.blend = getBlendUniforms(glctx, p.program)
+	return p, nil
+}
+
+// doEffect draws sr of the texture t, inset by -e.Margin, through e onto the
+// currently bound framebuffer dst.
+//
+// doEffect must only be called while holding screenImpl.glctxMu.
+func doEffect(s *screenImpl, glctx gl.Context, dst target, src2dst f64.Aff3, t *textureImpl, sr image.Rectangle, e *screen.Effect, op screen.Op, opts *screen.DrawOptions) error {
+	sr = sr.Intersect(t.Bounds())
+	if sr.Empty() {
+		return nil
+	}
+	p, err := s.effectProgram(glctx, e.Source)
+	if err != nil {
+		return err
+	}
+	mode, ok := useOp(glctx, op)
+	if !ok {
+		// TODO: support more ops.
+		return nil
+	}
+	r := sr.Inset(-e.Margin)
+	glctx.UseProgram(p.program)
+	if !useBlendMode(s, glctx, &p.blend, mode, dst, quadBounds(src2dst, r), opts) {
+		return nil
+	}
+	defer useClip(glctx, dst, opts)()
+
+	// The unit quad is mapped to r, both in dst space by mvp, and in src
+	// space by pvp, for the effect's p.
+	writeAff3(glctx, p.mvp, quadMVP(dst, src2dst, r))
+	writeAff3(glctx, p.pvp, f64.Aff3{
+		float64(r.Dx()), 0, float64(r.Min.X),
+		0, float64(r.Dy()), float64(r.Min.Y),
+	})
+	glctx.Uniform2f(p.invSize, 1/float32(t.size.X), 1/float32(t.size.Y))
+	glctx.Uniform4f(p.srcRect, float32(sr.Min.X), float32(sr.Min.Y), float32(sr.Max.X), float32(sr.Max.Y))
+	glctx.Uniform1f(p.alpha, float32(opts.GetOpacity()))
+	if err := p.setUniforms(glctx, e.Uniforms); err != nil {
+		return err
+	}
+
+	glctx.ActiveTexture(gl.TEXTURE0)
+	glctx.BindTexture(gl.TEXTURE_2D, t.id)
+	t.setFilter(glctx, opts.GetFilter())
+	glctx.Uniform1i(p.sample, 0)
+
+	// The texture program's quad, which NewTexture made before t, is
+	// also the unit quad.
+	glctx.BindBuffer(gl.ARRAY_BUFFER, s.texture.quad)
+	glctx.EnableVertexAttribArray(p.pos)
+	glctx.VertexAttribPointer(p.pos, 2, gl.FLOAT, false, 0, 0)
+
+	glctx.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
+
+	glctx.DisableVertexAttribArray(p.pos)
+	return nil
+}
+
+// setUniforms sets the Effect's uniforms of the current program p, in the
+// order of their names, so that the GL calls are deterministic.
+//
+// setUniforms must only be called while holding screenImpl.glctxMu.
+func (p *effectProgram) setUniforms(glctx gl.Context, values map[string][]float32) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		u, ok := p.uniforms[name]
+		if !ok {
+			u = glctx.GetUniformLocation(p.program, name)
+			p.uniforms[name] = u
+		}
+		v := values[name]
+		switch len(v) {
+		case 1:
+			glctx.Uniform1fv(u, v)
+		case 2:
+			glctx.Uniform2fv(u, v)
+		case 3:
+			glctx.Uniform3fv(u, v)
+		case 4:
+			glctx.Uniform4fv(u, v)
+		case 9:
+			glctx.UniformMatrix3fv(u, v)
+		case 16:
+			glctx.UniformMatrix4fv(u, v)
+		default:
+			return fmt.Errorf("gldriver: effect uniform %q has %d values", name, len(v))
+		}
+	}
+	return nil
+}
+
+const effectVertexSrc = `#version 100
+uniform mat3 mvp;
+uniform mat3 pvp;
+attribute vec3 pos;
+varying vec2 p;
+void main() {
+	vec3 q = pos;
+	q.z = 1.0;
+	gl_Position = vec4(mvp * q, 1);
+	p = (pvp * q).xy;
+}
+`
+
+// effectFragmentSrc returns the fragment shader for an Effect's Source. The
+// src function clamps p to the centers of the edge pixels of srcRect, so
+// that linear filtering does not sample the texture outside of it.
+func effectFragmentSrc(src string) string {
+	return `#version 100
+#ifdef GL_FRAGMENT_PRECISION_HIGH
+precision highp float;
+#else
+precision mediump float;
+#endif
+varying vec2 p;
+uniform sampler2D sample;
+uniform vec2 invSize;
+uniform vec4 srcRect;
+uniform float alpha;
+vec4 src(vec2 q) {
+	if (q.x < srcRect.x || q.y < srcRect.y || q.x > srcRect.z || q.y > srcRect.w) {
+		return vec4(0.0);
+	}
+	return texture2D(sample, clamp(q, srcRect.xy + 0.5, srcRect.zw - 0.5) * invSize);
+}
+` + blendSrc + src + `
+void main() {
+	gl_FragColor = blend(effect(p) * alpha);
+}
+`
+}
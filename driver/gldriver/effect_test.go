@@ -0,0 +1,79 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gldriver
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/as/shiny/effect"
+	"github.com/as/shiny/gl"
+	"github.com/as/shiny/gl/glfake"
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+func TestDrawEffect(t *testing.T) {
+	glctx := glfake.NewContext()
+	s := &screenImpl{glctx: glctx}
+	s.texture.quad = glctx.CreateBuffer()
+	glctx.BindBuffer(gl.ARRAY_BUFFER, s.texture.quad)
+	glctx.BufferData(gl.ARRAY_BUFFER, quadCoords, gl.STATIC_DRAW)
+	newTexture := func(size image.Point) *textureImpl {
+		t := &textureImpl{s: s, id: glctx.CreateTexture(), size: size, filter: gl.LINEAR}
+		glctx.BindTexture(gl.TEXTURE_2D, t.id)
+		glctx.TexImage2D(gl.TEXTURE_2D, 0, size.X, size.Y, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+		return t
+	}
+	src := newTexture(image.Point{16, 16})
+	dst := newTexture(image.Point{64, 64})
+
+	effects := map[string]*screen.Effect{
+		"blur":           effect.Blur(2),
+		"colorMatrix":    effect.ColorMatrix([20]float32{0: 1, 6: 1, 12: 1, 18: 1}),
+		"dropShadow":     effect.DropShadow(2, 2, 1, color.Black),
+		"gamma":          effect.Gamma(2.2),
+		"roundedCorners": effect.RoundedCorners(4),
+	}
+	for name, e := range effects {
+		glctx.ResetCalls()
+		for i := 0; i < 2; i++ {
+			err := doEffect(s, glctx, dst, f64.Aff3{1, 0, 8, 0, 1, 8}, src, src.Bounds(), e, screen.Over, nil)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		compiles := 0
+		for _, c := range glctx.Calls() {
+			if c.Err != 0 {
+				t.Errorf("%s: %v", name, c)
+			}
+			if c.Name == "LinkProgram" {
+				compiles++
+			}
+		}
+		if compiles != 1 {
+			t.Errorf("%s: compiled %d times, want once", name, compiles)
+		}
+	}
+
+	bad := &screen.Effect{Source: "#error bad\n"}
+	for i := 0; i < 2; i++ {
+		err := doEffect(s, glctx, dst, f64.Aff3{1, 0, 0, 0, 1, 0}, src, src.Bounds(), bad, screen.Over, nil)
+		if err == nil || !strings.Contains(err.Error(), "shader compile") {
+			t.Errorf("bad Source: got %v, want a shader compile error", err)
+		}
+	}
+
+	odd := &screen.Effect{
+		Source:   "uniform vec4 v;\nvec4 effect(vec2 p) { return v; }\n",
+		Uniforms: map[string][]float32{"v": {1, 2, 3, 4, 5}},
+	}
+	if err := doEffect(s, glctx, dst, f64.Aff3{1, 0, 0, 0, 1, 0}, src, src.Bounds(), odd, screen.Over, nil); err == nil {
+		t.Errorf("uniform of 5 values: got nil error")
+	}
+}
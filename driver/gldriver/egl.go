@@ -13,7 +13,10 @@ const (
 	_EGL_NO_CONTEXT = 0
 	_EGL_NO_DISPLAY = 0
 
+	_EGL_DEFAULT_DISPLAY = 0
+
 	_EGL_OPENGL_ES2_BIT = 0x04 // EGL_RENDERABLE_TYPE mask
+	_EGL_PBUFFER_BIT    = 0x01 // EGL_SURFACE_TYPE mask
 	_EGL_WINDOW_BIT     = 0x04 // EGL_SURFACE_TYPE mask
 
 	_EGL_OPENGL_ES_API   = 0x30A0
@@ -28,6 +31,8 @@ const (
 	_EGL_STENCIL_SIZE    = 0x3026
 	_EGL_SAMPLE_BUFFERS  = 0x3032
 	_EGL_CONFIG_CAVEAT   = 0x3027
+	_EGL_HEIGHT          = 0x3056
+	_EGL_WIDTH           = 0x3057
 	_EGL_NONE            = 0x3038
 
 	_EGL_CONTEXT_CLIENT_VERSION = 0x3098
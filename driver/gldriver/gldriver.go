@@ -28,30 +28,16 @@ func Main(f func(screen.Screen)) {
 	}
 }
 
-func mul(a, b f64.Aff3) f64.Aff3 {
-	return f64.Aff3{
-		a[0]*b[0] + a[1]*b[3],
-		a[0]*b[1] + a[1]*b[4],
-		a[0]*b[2] + a[1]*b[5] + a[2],
-
-		a[3]*b[0] + a[4]*b[3],
-		a[3]*b[1] + a[4]*b[4],
-		a[3]*b[2] + a[4]*b[5] + a[5],
-	}
+// Probe reports whether this driver can be used on the running system. It
+// does not open a window or create a GL context.
+func Probe() error {
+	return probe()
 }
 
-// writeAff3 must only be called while holding windowImpl.glctxMu.
+// writeAff3 must only be called while holding screenImpl.glctxMu.
 func writeAff3(glctx gl.Context, u gl.Uniform, a f64.Aff3) {
-	var m [9]float32
-	m[0*3+0] = float32(a[0*3+0])
-	m[0*3+1] = float32(a[1*3+0])
-	m[0*3+2] = 0
-	m[1*3+0] = float32(a[0*3+1])
-	m[1*3+1] = float32(a[1*3+1])
-	m[1*3+2] = 0
-	m[2*3+0] = float32(a[0*3+2])
-	m[2*3+1] = float32(a[1*3+2])
-	m[2*3+2] = 1
+	// OpenGL takes matrices in column major order.
+	m := a.Mat3().Transpose().F32()
 	glctx.UniformMatrix3fv(u, m[:])
 }
 
@@ -85,7 +71,7 @@ func f32Bytes(byteOrder binary.ByteOrder, values ...float32) []byte {
 	return b
 }
 
-// compileProgram must only be called while holding windowImpl.glctxMu.
+// compileProgram must only be called while holding screenImpl.glctxMu.
 func compileProgram(glctx gl.Context, vSrc, fSrc string) (gl.Program, error) {
 	program := glctx.CreateProgram()
 	if program.Value == 0 {
@@ -94,11 +80,13 @@ func compileProgram(glctx gl.Context, vSrc, fSrc string) (gl.Program, error) {
 
 	vertexShader, err := compileShader(glctx, gl.VERTEX_SHADER, vSrc)
 	if err != nil {
+		glctx.DeleteProgram(program)
 		return gl.Program{}, err
 	}
 	fragmentShader, err := compileShader(glctx, gl.FRAGMENT_SHADER, fSrc)
 	if err != nil {
 		glctx.DeleteShader(vertexShader)
+		glctx.DeleteProgram(program)
 		return gl.Program{}, err
 	}
 
@@ -117,7 +105,7 @@ func compileProgram(glctx gl.Context, vSrc, fSrc string) (gl.Program, error) {
 	return program, nil
 }
 
-// compileShader must only be called while holding windowImpl.glctxMu.
+// compileShader must only be called while holding screenImpl.glctxMu.
 func compileShader(glctx gl.Context, shaderType gl.Enum, src string) (gl.Shader, error) {
 	shader := glctx.CreateShader(shaderType)
 	if shader.Value == 0 {
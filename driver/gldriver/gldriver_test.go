@@ -0,0 +1,68 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gldriver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/as/shiny/gl/glfake"
+)
+
+func TestCompileProgram(t *testing.T) {
+	testCases := []struct {
+		name       string
+		vSrc, fSrc string
+	}{
+		{"texture", textureVertexSrc, textureFragmentSrc},
+		{"fill", fillVertexSrc, fillFragmentSrc},
+		{"batchTexture", batchTextureVertexSrc, batchTextureFragmentSrc},
+		{"batchFill", batchFillVertexSrc, batchFillFragmentSrc},
+	}
+	for _, tc := range testCases {
+		glctx := glfake.NewContext()
+		p, err := compileProgram(glctx, tc.vSrc, tc.fSrc)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		var got []string
+		for _, c := range glctx.Calls() {
+			if c.Err != 0 {
+				t.Errorf("%s: %v", tc.name, c)
+			}
+			got = append(got, c.Name)
+		}
+		want := []string{
+			"CreateProgram",
+			"CreateShader", "ShaderSource", "CompileShader", "GetShaderi",
+			"CreateShader", "ShaderSource", "CompileShader", "GetShaderi",
+			"AttachShader", "AttachShader", "LinkProgram",
+			"DeleteShader", "DeleteShader",
+			"GetProgrami",
+		}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("%s: calls:\ngot  %v\nwant %v", tc.name, got, want)
+		}
+		glctx.DeleteProgram(p)
+		if live := glctx.Live(); live != (glfake.Counts{}) {
+			t.Errorf("%s: leaked %+v", tc.name, live)
+		}
+	}
+}
+
+func TestCompileProgramError(t *testing.T) {
+	glctx := glfake.NewContext()
+	_, err := compileProgram(glctx, textureVertexSrc, "#version 100\n#error bad\nvoid main() {}\n")
+	if err == nil || !strings.Contains(err.Error(), "shader compile") {
+		t.Fatalf("got %v, want a shader compile error", err)
+	}
+	if live := glctx.Live(); live != (glfake.Counts{}) {
+		t.Errorf("leaked %+v", live)
+	}
+	if got := glctx.GetError(); got != 0 {
+		t.Errorf("GetError: got %s", glfake.EnumString(got))
+	}
+}
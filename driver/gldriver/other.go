@@ -22,6 +22,14 @@ func showWindow(id *windowImpl) {}
 func closeWindow(id uintptr)    {}
 func drawLoop(w *windowImpl)    {}
 
-func main(f func(screen.Screen)) error {
+func probe() error {
 	return fmt.Errorf("gldriver: unsupported GOOS/GOARCH %s/%s", runtime.GOOS, runtime.GOARCH)
 }
+
+func main(f func(screen.Screen)) error {
+	return probe()
+}
+
+func surfaceCreate() error {
+	return probe()
+}
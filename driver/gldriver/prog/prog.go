@@ -18,7 +18,7 @@ func main() {
 	}
 	win := dev.Window()
 	D := screen.Dev
-	buf, _ := dev.NewBuffer(image.Pt(512, 512))
+	buf, _ := dev.NewBuffer(image.Pt(512, 512), nil)
 	red := image.NewUniform(color.RGBA{255, 0, 0, 255})
 	blue := image.NewUniform(color.RGBA{0, 0, 255, 255})
 	draw.Draw(buf.RGBA(), buf.RGBA().Bounds(), blue, image.ZP, draw.Src)
@@ -43,7 +43,7 @@ func main() {
 			win.Publish()
 		case <-D.Size:
 			log.Println("size")
-			buf, _ = dev.NewBuffer(image.Pt(512, 512))
+			buf, _ = dev.NewBuffer(image.Pt(512, 512), nil)
 			draw.Draw(buf.RGBA(), buf.RGBA().Bounds(), blue, image.ZP, draw.Src)
 
 		}
@@ -21,8 +21,13 @@ type screenImpl struct {
 		pos     gl.Attrib
 		mvp     gl.Uniform
 		uvp     gl.Uniform
+		muvp    gl.Uniform
 		inUV    gl.Attrib
 		sample  gl.Uniform
+		mask    gl.Uniform
+		useMask gl.Uniform
+		alpha   gl.Uniform
+		blend   blendUniforms
 		quad    gl.Buffer
 	}
 	fill struct {
@@ -30,37 +35,115 @@ type screenImpl struct {
 		pos     gl.Attrib
 		mvp     gl.Uniform
 		color   gl.Uniform
+		muvp    gl.Uniform
+		mask    gl.Uniform
+		useMask gl.Uniform
+		blend   blendUniforms
 		quad    gl.Buffer
 	}
 
-	mu     sync.Mutex
-	window *windowImpl
+	// batch holds the programs for drawing a screen.Batch, as per
+	// drawBatch, and the vertex buffer and its CPU-side contents.
+	batch struct {
+		texture struct {
+			program gl.Program
+			pos     gl.Attrib
+			inUV    gl.Attrib
+			sample  gl.Uniform
+			alpha   gl.Uniform
+		}
+		fill struct {
+			program gl.Program
+			pos     gl.Attrib
+			inColor gl.Attrib
+		}
+		vbo gl.Buffer
+		buf []byte
+	}
+
+	// dstCopy is a scratch texture holding a copy of the dst pixels, for
+	// those blend modes that read them in the fragment shader.
+	dstCopy gl.Texture
+
+	// effects are the programs for the screen.Effects that have been
+	// drawn, by their Source.
+	effects map[string]*effectProgram
+
+	// glctxMu is a mutex that enforces the atomicity of methods like
+	// Texture.Upload or Window.Draw that are conceptually one operation
+	// but are implemented by multiple OpenGL calls. OpenGL is a stateful
+	// API, so interleaving OpenGL calls from separate higher-level
+	// operations causes inconsistencies, even though each gl.Context is
+	// itself safe for concurrent calls. It covers glctx and the context of
+	// every window, as the objects that they share, such as programs,
+	// have state of their own, such as their uniforms' values.
+	glctxMu sync.Mutex
+
+	// glctx is the hidden share context, created when the driver starts.
+	// The context of every window shares its textures, buffers and
+	// programs. Textures are created, uploaded to and drawn onto with
+	// glctx, so that they belong to no window, and can be drawn onto any
+	// window.
+	glctx gl.Context
+
+	// unflushed is whether calls that change textures have been made on
+	// glctx since it was last flushed. Another context only sees those
+	// changes after the calls are flushed. It is protected by glctxMu.
+	unflushed bool
+
+	// uploads are the UploadAsyncs that the GPU may not have completed, in
+	// the order that they were made. It is protected by glctxMu.
+	uploads []pendingUpload
+
+	mu      sync.Mutex
+	windows map[uintptr]*windowImpl
 }
 
-func (s *screenImpl) NewBuffer(size image.Point) (retBuf screen.Buffer, retErr error) {
-	m := image.NewRGBA(image.Rectangle{Max: size})
-	return &bufferImpl{
-		buf:  m.Pix,
-		rgba: *m,
-		size: size,
-	}, nil
+// blendUniforms are the locations of the uniforms declared by blendSrc.
+type blendUniforms struct {
+	mode    gl.Uniform
+	dst     gl.Uniform
+	dstRect gl.Uniform
 }
 
-func (s *screenImpl) NewTexture(size image.Point) (screen.Texture, error) {
-	// TODO: can we compile these programs eagerly instead of lazily?
+func getBlendUniforms(glctx gl.Context, p gl.Program) blendUniforms {
+	return blendUniforms{
+		mode:    glctx.GetUniformLocation(p, "mode"),
+		dst:     glctx.GetUniformLocation(p, "dst"),
+		dstRect: glctx.GetUniformLocation(p, "dstRect"),
+	}
+}
 
-	// Find a GL context for this texture.
-	// TODO: this might be correct. Some GL objects can be shared
-	// across contexts. But this needs a review of the spec to make
-	// sure it's correct, and some testing would be nice.
-	w := s.window
-	if w == nil {
-		return nil, fmt.Errorf("gldriver: no window available")
+func (s *screenImpl) NewBuffer(size image.Point, opts *screen.NewBufferOptions) (retBuf screen.Buffer, retErr error) {
+	format := opts.GetFormat()
+	if !format.Valid() {
+		return nil, fmt.Errorf("gldriver: invalid buffer format %v", format)
 	}
+	b := &bufferImpl{
+		size:   size,
+		format: format,
+	}
+	if format == screen.FormatRGBA {
+		m := image.NewRGBA(image.Rectangle{Max: size})
+		b.buf = m.Pix
+		b.rgba = *m
+		b.img = &b.rgba
+	} else {
+		b.img = format.NewImage(image.Rectangle{Max: size})
+	}
+	return b, nil
+}
 
-	w.glctxMu.Lock()
-	defer w.glctxMu.Unlock()
-	glctx := w.glctx
+// NewTexture creates a texture in the share context, so that it can be
+// created before any window, and drawn onto every window. Its mipmaps, if
+// any, are allocated, and as transparent as the texture, from the start, but
+// they are not sampled until GenerateMipmaps is first called.
+func (s *screenImpl) NewTexture(size image.Point, opts *screen.NewTextureOptions) (screen.Texture, error) {
+	// TODO: can we compile these programs eagerly instead of lazily?
+
+	s.glctxMu.Lock()
+	defer s.glctxMu.Unlock()
+	glctx := s.glctx
 	if glctx == nil {
 		return nil, fmt.Errorf("gldriver: no GL context available")
 	}
@@ -76,16 +159,30 @@ func (s *screenImpl) NewTexture(size image.Point) (screen.Texture, error) {
 		s.texture.uvp = glctx.GetUniformLocation(p, "uvp")
 		s.texture.inUV = glctx.GetAttribLocation(p, "inUV")
 		s.texture.sample = glctx.GetUniformLocation(p, "sample")
+		s.texture.muvp = glctx.GetUniformLocation(p, "muvp")
+		s.texture.mask = glctx.GetUniformLocation(p, "mask")
+		s.texture.useMask = glctx.GetUniformLocation(p, "useMask")
+		s.texture.alpha = glctx.GetUniformLocation(p, "alpha")
+		s.texture.blend = getBlendUniforms(glctx, p)
 		s.texture.quad = glctx.CreateBuffer()
 
 		glctx.BindBuffer(gl.ARRAY_BUFFER, s.texture.quad)
 		glctx.BufferData(gl.ARRAY_BUFFER, quadCoords, gl.STATIC_DRAW)
 	}
 
+	mipmaps := opts.GetMipmaps()
+	if _, ok := glctx.(gl.Context3); !ok && !(powerOfTwo(size.X) && powerOfTwo(size.Y)) {
+		// OpenGL ES 2.0 has no mipmaps for other sizes: glGenerateMipmap
+		// fails, and a mipmapped minification filter samples black. Such a
+		// texture is minified bilinearly instead.
+		mipmaps = false
+	}
 	t := &textureImpl{
-		w:    w,
-		id:   glctx.CreateTexture(),
-		size: size,
+		s:       s,
+		id:      glctx.CreateTexture(),
+		size:    size,
+		mipmaps: mipmaps,
+		filter:  gl.LINEAR,
 	}
 
 	glctx.BindTexture(gl.TEXTURE_2D, t.id)
@@ -94,10 +191,32 @@ func (s *screenImpl) NewTexture(size image.Point) (screen.Texture, error) {
 	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
 	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
 	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	if t.mipmaps {
+		glctx.GenerateMipmap(gl.TEXTURE_2D)
+	}
+	s.unflushed = true
 
 	return t, nil
 }
 
+// powerOfTwo returns whether n is a positive power of two.
+func powerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// flush flushes the calls made on the share context, if they may have
+// changed textures since it was last flushed, so that a window's context
+// sees their results.
+//
+// flush must only be called while holding screenImpl.glctxMu.
+func (s *screenImpl) flush() {
+	if !s.unflushed {
+		return
+	}
+	s.unflushed = false
+	s.glctx.Flush()
+}
+
 func optsSize(opts *screen.NewWindowOptions) (width, height int) {
 	width, height = 1024, 768
 	if opts != nil {
@@ -125,10 +244,13 @@ func (s *screenImpl) NewWindow(opts *screen.NewWindowOptions) (screen.Window, er
 	}
 	initWindow(w)
 
-	if s.window != nil {
-		panic("newwindow")
+	s.mu.Lock()
+	if s.windows == nil {
+		s.windows = make(map[uintptr]*windowImpl)
 	}
-	s.window = w
+	s.windows[id] = w
+	s.mu.Unlock()
+
 	showWindow(w)
 	return w, nil
 }
@@ -9,36 +9,164 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"time"
 
+	"github.com/as/shiny/driver/internal/drawer"
+	"github.com/as/shiny/driver/internal/swizzle"
 	"github.com/as/shiny/gl"
+	"github.com/as/shiny/math/f64"
 	"github.com/as/shiny/screen"
 )
 
+// textureImpl is a texture of the share context, screenImpl.glctx. Only
+// that context draws onto it, so only that context has its framebuffer.
 type textureImpl struct {
-	w    *windowImpl
+	s    *screenImpl
 	id   gl.Texture
 	fb   gl.Framebuffer
 	size image.Point
+
+	// mipmaps is whether the texture has mipmaps, as per
+	// screen.NewTextureOptions.
+	mipmaps bool
+	// generated is whether GenerateMipmaps has been called. Until it is, the
+	// mipmaps are transparent, so they are not sampled.
+	generated bool
+
+	// filter is the GL minification filter last set for the texture,
+	// gl.LINEAR by default. The magnification filter follows from it. Like
+	// the texture, it is shared by every context. It is protected by
+	// screenImpl.glctxMu.
+	filter gl.Enum
 }
 
 func (t *textureImpl) Size() image.Point       { return t.size }
 func (t *textureImpl) Bounds() image.Rectangle { return image.Rectangle{Max: t.size} }
 
 func (t *textureImpl) Release() {
-	t.w.glctxMu.Lock()
-	defer t.w.glctxMu.Unlock()
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
 
 	if t.fb.Value != 0 {
-		t.w.glctx.DeleteFramebuffer(t.fb)
+		t.s.glctx.DeleteFramebuffer(t.fb)
 		t.fb = gl.Framebuffer{}
 	}
-	t.w.glctx.DeleteTexture(t.id)
+	t.s.glctx.DeleteTexture(t.id)
 	t.id = gl.Texture{}
 }
 
 func (t *textureImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
-	buf := src.(*bufferImpl)
+	dr, pix, stride := t.uploadPixels(dp, src.(*bufferImpl), sr)
+	if dr.Empty() {
+		return
+	}
+
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
+
+	t.s.glctx.BindTexture(gl.TEXTURE_2D, t.id)
+	t.s.unflushed = true
+
+	width := dr.Dx()
+	if width*4 == stride {
+		t.s.glctx.TexSubImage2D(gl.TEXTURE_2D, 0, dr.Min.X, dr.Min.Y, width, dr.Dy(), gl.RGBA, gl.UNSIGNED_BYTE, pix)
+		return
+	}
+	// TODO: can we use GL_UNPACK_ROW_LENGTH with glPixelStorei for stride in
+	// ES 3.0, instead of uploading the pixels row-by-row?
+	for y, p := dr.Min.Y, 0; y < dr.Max.Y; y++ {
+		t.s.glctx.TexSubImage2D(gl.TEXTURE_2D, 0, dr.Min.X, y, width, 1, gl.RGBA, gl.UNSIGNED_BYTE, pix[p:])
+		p += stride
+	}
+}
+
+// UploadAsync uploads through a pixel buffer object, if the share context
+// is a gl.Context3. The pixels are copied into it before UploadAsync
+// returns, and a sync object tells when the GPU has copied them from it into
+// t. Otherwise, UploadAsync is like Upload.
+//
+// Either way, src may be modified or released as soon as UploadAsync
+// returns. The returned channel is only closed later, once t holds the new
+// pixels.
+func (t *textureImpl) UploadAsync(dp image.Point, src screen.Buffer, sr image.Rectangle) <-chan struct{} {
+	glctx, ok := t.s.glctx.(gl.Context3)
+	if !ok {
+		t.Upload(dp, src, sr)
+		return screen.Closed
+	}
+	dr, pix, stride := t.uploadPixels(dp, src.(*bufferImpl), sr)
+	if dr.Empty() {
+		return screen.Closed
+	}
+
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
+
+	pbo := glctx.CreateBuffer()
+	glctx.BindBuffer(gl.PIXEL_UNPACK_BUFFER, pbo)
+	glctx.BufferData(gl.PIXEL_UNPACK_BUFFER, pix[:(dr.Dy()-1)*stride+4*dr.Dx()], gl.STREAM_DRAW)
+	glctx.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(stride/4))
+	glctx.BindTexture(gl.TEXTURE_2D, t.id)
+	glctx.TexSubImage2DOffset(gl.TEXTURE_2D, 0, dr.Min.X, dr.Min.Y, dr.Dx(), dr.Dy(), gl.RGBA, gl.UNSIGNED_BYTE, 0)
+	glctx.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+	// Leaving the pixel buffer bound would make the other calls that take
+	// pixels, such as TexImage2D, read them from it instead.
+	glctx.BindBuffer(gl.PIXEL_UNPACK_BUFFER, gl.Buffer{})
+
+	fence := glctx.FenceSync(gl.SYNC_GPU_COMMANDS_COMPLETE, 0)
+	t.s.unflushed = true
+	t.s.flush()
+
+	done := make(chan struct{})
+	t.s.uploads = append(t.s.uploads, pendingUpload{fence, pbo, done})
+	if len(t.s.uploads) == 1 {
+		go t.s.waitUploads(glctx)
+	}
+	return done
+}
+
+// pendingUpload is an UploadAsync that the GPU may not have completed.
+type pendingUpload struct {
+	fence gl.Sync
+	pbo   gl.Buffer
+	done  chan struct{}
+}
+
+// uploadPollInterval is how often waitUploads checks whether an upload is
+// complete.
+const uploadPollInterval = time.Millisecond
 
+// waitUploads waits for the fences of s's pending uploads to be signaled,
+// deleting each one and its pixel buffer object and closing its done
+// channel, until there are none left. A fence is only signaled once the
+// commands before it are complete, so only the oldest is checked. It polls,
+// instead of calling ClientWaitSync, which would block every other call on
+// the share context until the upload is complete.
+//
+// Only one waitUploads runs at a time: UploadAsync starts it when it adds
+// the first pending upload.
+func (s *screenImpl) waitUploads(glctx gl.Context3) {
+	s.glctxMu.Lock()
+	defer s.glctxMu.Unlock()
+	for len(s.uploads) > 0 {
+		u := s.uploads[0]
+		if glctx.GetSynci(u.fence, gl.SYNC_STATUS) != gl.SIGNALED {
+			s.glctxMu.Unlock()
+			time.Sleep(uploadPollInterval)
+			s.glctxMu.Lock()
+			continue
+		}
+		glctx.DeleteSync(u.fence)
+		glctx.DeleteBuffer(u.pbo)
+		close(u.done)
+		s.uploads = s.uploads[:copy(s.uploads, s.uploads[1:])]
+	}
+}
+
+// uploadPixels returns the rectangle dr of t that an upload of the src
+// rectangle sr of buf to dp changes, and buf's pixels for it, with their
+// stride. Only dr's last row may be shorter than the stride.
+func (t *textureImpl) uploadPixels(dp image.Point, buf *bufferImpl, sr image.Rectangle) (dr image.Rectangle, pix []byte, stride int) {
 	// src2dst is added to convert from the src coordinate space to the dst
 	// coordinate space. It is subtracted to convert the other way.
 	src2dst := dp.Sub(sr.Min)
@@ -47,67 +175,150 @@ func (t *textureImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectang
 	sr = sr.Intersect(buf.Bounds())
 
 	// Clip to the destination.
-	dr := sr.Add(src2dst)
+	dr = sr.Add(src2dst)
 	dr = dr.Intersect(t.Bounds())
 	if dr.Empty() {
-		return
+		return dr, nil, 0
 	}
 
 	// Bring dr.Min in dst-space back to src-space to get the pixel buffer offset.
-	pix := buf.rgba.Pix[buf.rgba.PixOffset(dr.Min.X-src2dst.X, dr.Min.Y-src2dst.Y):]
+	stride = buf.rgba.Stride
+	if buf.format == screen.FormatRGBA {
+		pix = buf.rgba.Pix[buf.rgba.PixOffset(dr.Min.X-src2dst.X, dr.Min.Y-src2dst.Y):]
+	} else {
+		// Convert the other formats to RGBA, packed tightly.
+		stride = 4 * dr.Dx()
+		pix = make([]byte, stride*dr.Dy())
+		swizzle.Convert(pix, stride, image.Point{}, buf.img, dr.Sub(src2dst), false)
+	}
+	return dr, pix, stride
+}
 
-	t.w.glctxMu.Lock()
-	defer t.w.glctxMu.Unlock()
+func (t *textureImpl) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
 
-	t.w.glctx.BindTexture(gl.TEXTURE_2D, t.id)
+	t.bindFramebuffer()
+	defer t.unbindFramebuffer()
+	dst.(*bufferImpl).download(t.s.glctx, t.Bounds(), false, sr, dp)
+}
 
-	width := dr.Dx()
-	if width*4 == buf.rgba.Stride {
-		t.w.glctx.TexSubImage2D(gl.TEXTURE_2D, 0, dr.Min.X, dr.Min.Y, width, dr.Dy(), gl.RGBA, gl.UNSIGNED_BYTE, pix)
+func (t *textureImpl) Fill(dr image.Rectangle, src color.Color, op draw.Op) {
+	t.DrawUniform(f64.Aff3{1, 0, 0, 0, 1, 0}, src, dr, op, nil)
+}
+
+func (t *textureImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	st := src.(*textureImpl)
+	sr = sr.Intersect(st.Bounds())
+	if sr.Empty() {
 		return
 	}
-	// TODO: can we use GL_UNPACK_ROW_LENGTH with glPixelStorei for stride in
-	// ES 3.0, instead of uploading the pixels row-by-row?
-	for y, p := dr.Min.Y, 0; y < dr.Max.Y; y++ {
-		t.w.glctx.TexSubImage2D(gl.TEXTURE_2D, 0, dr.Min.X, y, width, 1, gl.RGBA, gl.UNSIGNED_BYTE, pix[p:])
-		p += buf.rgba.Stride
-	}
+
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
+
+	t.bindFramebuffer()
+	defer t.unbindFramebuffer()
+	doDraw(t.s, t.s.glctx, t, src2dst, st, sr, op, opts)
 }
 
-func (t *textureImpl) Fill(dr image.Rectangle, src color.Color, op draw.Op) {
-	minX := float64(dr.Min.X)
-	minY := float64(dr.Min.Y)
-	maxX := float64(dr.Max.X)
-	maxY := float64(dr.Max.Y)
-	mvp := calcMVP(
-		t.size.X, t.size.Y,
-		minX, minY,
-		maxX, minY,
-		minX, maxY,
-	)
-
-	glctx := t.w.glctx
-
-	t.w.glctxMu.Lock()
-	defer t.w.glctxMu.Unlock()
+func (t *textureImpl) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
 
+	t.bindFramebuffer()
+	defer t.unbindFramebuffer()
+	doFill(t.s, t.s.glctx, t, src2dst, src, sr, op, opts)
+}
+
+func (t *textureImpl) Copy(dp image.Point, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	drawer.Copy(t, dp, src, sr, op, opts)
+}
+
+func (t *textureImpl) Scale(dr image.Rectangle, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	drawer.Scale(t, dr, src, sr, op, opts)
+}
+
+// bindFramebuffer makes t the target of subsequent GL draw calls, creating
+// t's framebuffer object if necessary. Each call must be paired with a call
+// to unbindFramebuffer.
+//
+// bindFramebuffer must only be called while holding screenImpl.glctxMu.
+func (t *textureImpl) bindFramebuffer() {
+	glctx := t.s.glctx
+	t.s.unflushed = true
 	create := t.fb.Value == 0
 	if create {
-		//t.fb = glctx.CreateFramebuffer()
+		t.fb = glctx.CreateFramebuffer()
 	}
 	glctx.BindFramebuffer(gl.FRAMEBUFFER, t.fb)
 	if create {
 		glctx.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, t.id, 0)
 	}
-
 	glctx.Viewport(0, 0, t.size.X, t.size.Y)
-	doFill(t.w.s, t.w.glctx, mvp, src, op)
+}
+
+// unbindFramebuffer restores the share context's own framebuffer, also
+// known as gl.Framebuffer{Value: 0}, as the target of GL draw calls.
+//
+// unbindFramebuffer must only be called while holding screenImpl.glctxMu.
+func (t *textureImpl) unbindFramebuffer() {
+	t.s.glctx.BindFramebuffer(gl.FRAMEBUFFER, gl.Framebuffer{Value: 0})
+}
 
-	// We can't restore the GL state (i.e. bind the back buffer, also known as
-	// gl.Framebuffer{Value: 0}) right away, since we don't necessarily know
-	// the right viewport size yet. It is valid to call textureImpl.Fill before
-	// we've gotten our first size.Event. We bind it lazily instead.
-	//t.w.backBufferBound = false
+// setFilter sets t's minification and magnification filters, if they differ
+// from those last set. A texture with mipmaps is minified trilinearly, except
+// with FilterNearest and FilterBilinear, or before its mipmaps are first
+// generated. t must be bound to the active texture unit of glctx.
+//
+// setFilter must only be called while holding screenImpl.glctxMu.
+func (t *textureImpl) setFilter(glctx gl.Context, f screen.Filter) {
+	mag, min := gl.Enum(gl.LINEAR), gl.Enum(gl.LINEAR)
+	switch {
+	case f == screen.FilterNearest:
+		mag, min = gl.NEAREST, gl.NEAREST
+	case t.mipmaps && t.generated && f != screen.FilterBilinear:
+		min = gl.LINEAR_MIPMAP_LINEAR
+	}
+	if t.filter == min {
+		return
+	}
+	t.filter = min
+	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, int(mag))
+	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, int(min))
+}
+
+// GenerateMipmaps generates the mipmaps with glGenerateMipmap.
+func (t *textureImpl) GenerateMipmaps() {
+	if !t.mipmaps {
+		return
+	}
+	t.s.glctxMu.Lock()
+	defer t.s.glctxMu.Unlock()
+
+	t.s.glctx.BindTexture(gl.TEXTURE_2D, t.id)
+	t.s.glctx.GenerateMipmap(gl.TEXTURE_2D)
+	t.generated = true
+	t.s.unflushed = true
+}
+
+func (t *textureImpl) bounds() image.Rectangle { return t.Bounds() }
+func (t *textureImpl) copyFormat() gl.Enum     { return gl.RGBA }
+
+func (t *textureImpl) scissorBox(r image.Rectangle) (x, y, width, height int32) {
+	// Like for mvp, the Y-axis is not flipped.
+	return int32(r.Min.X), int32(r.Min.Y), int32(r.Dx()), int32(r.Dy())
+}
+
+// mvp is like windowImpl.mvp, but for drawing into t's framebuffer.
+//
+// A texture's first row, at Y = 0 in pixel space, is at the bottom of its
+// framebuffer, where vertex shader space has Y = -1. Unlike for the window's
+// back buffer, the Y-axis is therefore not flipped.
+func (t *textureImpl) mvp(tlx, tly, trx, try, blx, bly float64) f64.Aff3 {
+	m := calcMVP(t.size.X, t.size.Y, tlx, tly, trx, try, blx, bly)
+	m[3], m[4], m[5] = -m[3], -m[4], -m[5]
+	return m
 }
 
 var quadCoords = f32Bytes(binary.LittleEndian,
@@ -120,40 +331,90 @@ var quadCoords = f32Bytes(binary.LittleEndian,
 const textureVertexSrc = `#version 100
 uniform mat3 mvp;
 uniform mat3 uvp;
+uniform mat3 muvp;
 attribute vec3 pos;
 attribute vec2 inUV;
 varying vec2 uv;
+varying vec2 muv;
 void main() {
 	vec3 p = pos;
 	p.z = 1.0;
 	gl_Position = vec4(mvp * p, 1);
 	uv = (uvp * vec3(inUV, 1)).xy;
+	muv = (muvp * vec3(inUV, 1)).xy;
 }
 `
 
 const textureFragmentSrc = `#version 100
 precision mediump float;
 varying vec2 uv;
+varying vec2 muv;
 uniform sampler2D sample;
+uniform sampler2D mask;
+uniform float useMask;
+uniform float alpha;
+` + blendSrc + `
 void main() {
-	gl_FragColor = texture2D(sample, uv);
+	float m = mix(1.0, texture2D(mask, muv).a, useMask);
+	gl_FragColor = blend(texture2D(sample, uv) * (alpha * m));
 }
 `
 
 const fillVertexSrc = `#version 100
 uniform mat3 mvp;
+uniform mat3 muvp;
 attribute vec3 pos;
+varying vec2 muv;
 void main() {
 	vec3 p = pos;
 	p.z = 1.0;
 	gl_Position = vec4(mvp * p, 1);
+	muv = (muvp * vec3(pos.xy, 1)).xy;
 }
 `
 
 const fillFragmentSrc = `#version 100
 precision mediump float;
+varying vec2 muv;
 uniform vec4 color;
+uniform sampler2D mask;
+uniform float useMask;
+` + blendSrc + `
 void main() {
-	gl_FragColor = color;
+	gl_FragColor = blend(color * mix(1.0, texture2D(mask, muv).a, useMask));
+}
+`
+
+// blendSrc is the fragment shader code for the blend modes that can't be
+// done with fixed-function blending, as per the blendModes map. It blends
+// the alpha-premultiplied src color with the dst color, read from the
+// screen's dst copy texture. The dstRect uniform holds the origin of the
+// copy, in gl_FragCoord space, and the reciprocal of its size.
+const blendSrc = `
+uniform int mode;
+uniform sampler2D dst;
+uniform vec4 dstRect;
+vec3 blendFunc(vec3 cs, vec3 cd) {
+	if (mode == 1) {
+		return cs * cd;
+	} else if (mode == 2) {
+		return mix(2.0*cs*cd, 1.0 - 2.0*(1.0-cs)*(1.0-cd), step(0.5, cd));
+	} else if (mode == 3) {
+		return min(cs, cd);
+	} else if (mode == 4) {
+		return max(cs, cd);
+	}
+	return abs(cs - cd);
+}
+vec4 blend(vec4 s) {
+	if (mode == 0) {
+		return s;
+	}
+	vec4 d = texture2D(dst, (gl_FragCoord.xy - dstRect.xy) * dstRect.zw);
+	// Where either alpha is zero, the blendFunc term vanishes.
+	vec3 cs = s.rgb / max(s.a, 1.0/255.0);
+	vec3 cd = d.rgb / max(d.a, 1.0/255.0);
+	vec3 c = s.rgb*(1.0-d.a) + d.rgb*(1.0-s.a) + s.a*d.a*blendFunc(cs, cd);
+	return vec4(c, s.a + d.a - s.a*d.a);
 }
 `
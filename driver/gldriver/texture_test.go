@@ -0,0 +1,198 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gldriver
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/as/shiny/gl"
+	"github.com/as/shiny/gl/glfake"
+	"github.com/as/shiny/screen"
+)
+
+func TestUploadAsync(t *testing.T) {
+	s := &screenImpl{glctx: glfake.NewContext()}
+	tex, err := s.NewTexture(image.Point{8, 8}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, _ := s.NewBuffer(image.Point{8, 8}, nil)
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			src.RGBA().SetRGBA(x, y, red)
+		}
+	}
+
+	// glfake is not a gl.Context3, so the upload is already complete.
+	done := screen.UploadAsync(tex, image.Point{2, 2}, src, image.Rect(0, 0, 4, 4))
+	select {
+	case <-done:
+	default:
+		t.Fatal("the upload is not complete")
+	}
+
+	dst, _ := s.NewBuffer(image.Point{8, 8}, nil)
+	tex.Download(tex.Bounds(), dst, image.Point{})
+	for _, tc := range []struct {
+		p    image.Point
+		want color.RGBA
+	}{
+		{image.Point{1, 1}, color.RGBA{}},
+		{image.Point{2, 2}, red},
+		{image.Point{5, 5}, red},
+		{image.Point{6, 6}, color.RGBA{}},
+	} {
+		if got := dst.RGBA().RGBAAt(tc.p.X, tc.p.Y); got != tc.want {
+			t.Errorf("at %v: got %v, want %v", tc.p, got, tc.want)
+		}
+	}
+}
+
+// syncContext is a gl.Context3 whose fences are signaled when their Value
+// is at most signaled. It records the deleted fences and buffers. Its other
+// methods panic.
+type syncContext struct {
+	gl.Context3
+	signaled uintptr
+	deleted  []uintptr
+}
+
+func (c *syncContext) GetSynci(s gl.Sync, pname gl.Enum) int {
+	if s.Value <= c.signaled {
+		return gl.SIGNALED
+	}
+	return gl.UNSIGNALED
+}
+
+func (c *syncContext) DeleteSync(s gl.Sync)     { c.deleted = append(c.deleted, s.Value) }
+func (c *syncContext) DeleteBuffer(b gl.Buffer) { c.deleted = append(c.deleted, uintptr(b.Value)) }
+
+func TestWaitUploads(t *testing.T) {
+	glctx := &syncContext{signaled: 2}
+	s := &screenImpl{}
+	var done []chan struct{}
+	for i := 1; i <= 3; i++ {
+		done = append(done, make(chan struct{}))
+		s.uploads = append(s.uploads, pendingUpload{gl.Sync{Value: uintptr(i)}, gl.Buffer{Value: uint32(10 * i)}, done[i-1]})
+	}
+
+	// The third fence is only signaled once waitUploads has completed the
+	// first two.
+	go s.waitUploads(glctx)
+	<-done[1]
+	s.glctxMu.Lock()
+	glctx.signaled = 3
+	s.glctxMu.Unlock()
+	<-done[2]
+
+	s.glctxMu.Lock()
+	defer s.glctxMu.Unlock()
+	if want := []uintptr{1, 10, 2, 20, 3, 30}; !reflect.DeepEqual(glctx.deleted, want) {
+		t.Errorf("deleted %v, want %v", glctx.deleted, want)
+	}
+	if len(s.uploads) != 0 {
+		t.Errorf("%d uploads are still pending", len(s.uploads))
+	}
+}
+
+func TestGenerateMipmaps(t *testing.T) {
+	glctx := glfake.NewContext()
+	s := &screenImpl{glctx: glctx}
+	tex, err := s.NewTexture(image.Point{4, 4}, &screen.NewTextureOptions{Mipmaps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := tex.(*textureImpl).id
+
+	// The left half is opaque white, and the right half is transparent.
+	src, _ := s.NewBuffer(image.Point{2, 4}, nil)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 2; x++ {
+			src.RGBA().SetRGBA(x, y, color.RGBA{0xff, 0xff, 0xff, 0xff})
+		}
+	}
+	tex.Upload(image.Point{}, src, src.Bounds())
+
+	// alpha returns the alpha of the 1×1 level 2, which is nil if it was
+	// defined without data.
+	alpha := func() byte {
+		m, ok := glctx.TexImage(id, 2)
+		if !ok {
+			t.Fatal("no level 2")
+		}
+		if m.Pix == nil {
+			return 0
+		}
+		return m.Pix[3]
+	}
+	minFilter := func() gl.Enum {
+		v := make([]int32, 1)
+		glctx.BindTexture(gl.TEXTURE_2D, id)
+		glctx.GetTexParameteriv(v, gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER)
+		return gl.Enum(v[0])
+	}
+	if a := alpha(); a != 0 {
+		t.Errorf("level 2 before GenerateMipmaps: got alpha %#x, want 0", a)
+	}
+	// The transparent mipmaps are not sampled yet.
+	tex.(*textureImpl).setFilter(glctx, screen.FilterDefault)
+	if got := minFilter(); got != gl.LINEAR {
+		t.Errorf("before GenerateMipmaps: got %s, want LINEAR", glfake.EnumString(got))
+	}
+	tex.GenerateMipmaps()
+	if a := alpha(); a != 0x80 {
+		t.Errorf("level 2: got alpha %#x, want 0x80", a)
+	}
+	for _, tc := range []struct {
+		f    screen.Filter
+		want gl.Enum
+	}{
+		{screen.FilterDefault, gl.LINEAR_MIPMAP_LINEAR},
+		{screen.FilterNearest, gl.NEAREST},
+		{screen.FilterBilinear, gl.LINEAR},
+		{screen.FilterBest, gl.LINEAR_MIPMAP_LINEAR},
+	} {
+		tex.(*textureImpl).setFilter(glctx, tc.f)
+		if got := minFilter(); got != tc.want {
+			t.Errorf("filter %v: got %s, want %s", tc.f, glfake.EnumString(got), glfake.EnumString(tc.want))
+		}
+	}
+
+	plain, _ := s.NewTexture(image.Point{4, 4}, nil)
+	plain.GenerateMipmaps()
+	if _, ok := glctx.TexImage(plain.(*textureImpl).id, 1); ok {
+		t.Errorf("a texture without mipmaps has a level 1")
+	}
+}
+
+func TestMipmapsNonPowerOfTwo(t *testing.T) {
+	// glfake is not a gl.Context3, so it is like OpenGL ES 2.0, which has no
+	// mipmaps for sizes that are not powers of two.
+	glctx := glfake.NewContext()
+	s := &screenImpl{glctx: glctx}
+	for _, size := range []image.Point{{3, 4}, {4, 6}, {0, 4}} {
+		tex, err := s.NewTexture(size, &screen.NewTextureOptions{Mipmaps: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ti := tex.(*textureImpl)
+		tex.GenerateMipmaps()
+		if _, ok := glctx.TexImage(ti.id, 1); ok {
+			t.Errorf("size %v: got a level 1, want none", size)
+		}
+
+		glctx.BindTexture(gl.TEXTURE_2D, ti.id)
+		ti.setFilter(glctx, screen.FilterDefault)
+		v := make([]int32, 1)
+		glctx.GetTexParameteriv(v, gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER)
+		if got, want := gl.Enum(v[0]), gl.Enum(gl.LINEAR); got != want {
+			t.Errorf("size %v: got min filter %s, want %s", size, glfake.EnumString(got), glfake.EnumString(want))
+		}
+	}
+}
@@ -29,7 +29,21 @@ const useLifecycler = false
 // TODO: change this to true, after manual testing on Win32.
 const handleSizeEventsAtChannelReceive = false
 
+// probe always succeeds. The ANGLE libraries are located, or downloaded,
+// when the GL context is created.
+func probe() error {
+	return nil
+}
+
 func main(f func(screen.Screen)) error {
+	if err := initEGL(); err != nil {
+		return err
+	}
+	share, err := newShareContext()
+	if err != nil {
+		return err
+	}
+	theScreen.glctx = share
 	return win32.Main(func() { f(theScreen) })
 }
 
@@ -40,6 +54,7 @@ var (
 	eglGetError              = gl.LibEGL.NewProc("eglGetError")
 	eglBindAPI               = gl.LibEGL.NewProc("eglBindAPI")
 	eglCreateWindowSurface   = gl.LibEGL.NewProc("eglCreateWindowSurface")
+	eglCreatePbufferSurface  = gl.LibEGL.NewProc("eglCreatePbufferSurface")
 	eglCreateContext         = gl.LibEGL.NewProc("eglCreateContext")
 	eglMakeCurrent           = gl.LibEGL.NewProc("eglMakeCurrent")
 	eglSwapInterval          = gl.LibEGL.NewProc("eglSwapInterval")
@@ -53,7 +68,7 @@ type eglInt int32
 
 var rgb888 = [...]eglInt{
 	_EGL_RENDERABLE_TYPE, _EGL_OPENGL_ES2_BIT,
-	_EGL_SURFACE_TYPE, _EGL_WINDOW_BIT,
+	_EGL_SURFACE_TYPE, _EGL_WINDOW_BIT | _EGL_PBUFFER_BIT,
 	_EGL_BLUE_SIZE, 8,
 	_EGL_GREEN_SIZE, 8,
 	_EGL_RED_SIZE, 8,
@@ -62,6 +77,16 @@ var rgb888 = [...]eglInt{
 	_EGL_NONE,
 }
 
+// eglShare is the EGL state that initEGL sets up, when the driver starts:
+// the display and config of every window, and the hidden share context
+// with its pbuffer surface.
+var eglShare struct {
+	display uintptr // EGLDisplay
+	config  eglConfig
+	ctx     uintptr // EGLContext
+	surface uintptr // EGLSurface
+}
+
 type ctxWin32 struct {
 	ctx     uintptr
 	display uintptr // EGLDisplay
@@ -174,8 +199,7 @@ func paintEvent(hwnd syscall.Handle, e paint.Event) {
 		return
 	}
 
-	// TODO: the paint.Event should have External: true.
-	w.Send(paint.Event{})
+	w.clock.SendPaint(e)
 }
 
 func sizeEvent(hwnd syscall.Handle, e size.Event) {
@@ -215,13 +239,13 @@ func sizeEvent(hwnd syscall.Handle, e size.Event) {
 		// do correctly with our async events channel model. We want
 		// the call to Viewport to be made the instant before the
 		// paint.Event is received.
-		w.glctxMu.Lock()
+		w.s.glctxMu.Lock()
 		w.glctx.Viewport(0, 0, e.WidthPx, e.HeightPx)
 		w.glctx.ClearColor(0, 0, 0, 1)
 		w.glctx.Clear(gl.COLOR_BUFFER_BIT)
-		w.glctxMu.Unlock()
+		w.s.glctxMu.Unlock()
 
-		w.Send(paint.Event{})
+		w.clock.SendPaint(paint.Event{})
 	}()
 }
 
@@ -232,7 +256,9 @@ func eglErr() error {
 	return nil
 }
 
-func createEGLSurface(hwnd syscall.Handle, w *windowImpl) error {
+// initEGL initializes the EGL display, and creates the share context, whose
+// objects, such as textures, are shared with the context of every window.
+func initEGL() error {
 	var displayAttribPlatforms = [][]eglInt{
 		// Default
 		{
@@ -271,18 +297,16 @@ func createEGLSurface(hwnd syscall.Handle, w *windowImpl) error {
 		},
 	}
 
-	dc, err := win32.GetDC(hwnd)
-	if err != nil {
-		return fmt.Errorf("win32.GetDC failed: %v", err)
-	}
-
+	// There is no window yet, so the display is the default one, rather
+	// than that of a window's device context. Every window's surface is
+	// created on it, so that its context can share with the share context.
 	var display uintptr = _EGL_NO_DISPLAY
 	for i, displayAttrib := range displayAttribPlatforms {
 		lastTry := i == len(displayAttribPlatforms)-1
 
 		display, _, _ = eglGetPlatformDisplayEXT.Call(
 			_EGL_PLATFORM_ANGLE_ANGLE,
-			uintptr(dc),
+			_EGL_DEFAULT_DISPLAY,
 			uintptr(unsafe.Pointer(&displayAttrib[0])),
 		)
 
@@ -322,19 +346,57 @@ func createEGLSurface(hwnd syscall.Handle, w *windowImpl) error {
 		return errors.New("eglChooseConfig found no valid config")
 	}
 
+	context, _, _ := eglCreateContext.Call(
+		display,
+		uintptr(config),
+		_EGL_NO_CONTEXT,
+		uintptr(unsafe.Pointer(&contextAttribs[0])),
+	)
+	if context == _EGL_NO_CONTEXT {
+		return fmt.Errorf("eglCreateContext failed: %v", eglErr())
+	}
+
+	// The share context never draws to its surface, only to the
+	// framebuffers of textures, so the surface is as small as possible.
+	pbufferAttribs := [...]eglInt{
+		_EGL_WIDTH, 1,
+		_EGL_HEIGHT, 1,
+		_EGL_NONE,
+	}
+	surface, _, _ := eglCreatePbufferSurface.Call(
+		display,
+		uintptr(config),
+		uintptr(unsafe.Pointer(&pbufferAttribs[0])),
+	)
+	if surface == _EGL_NO_SURFACE {
+		return fmt.Errorf("eglCreatePbufferSurface failed: %v", eglErr())
+	}
+
+	eglShare.display = display
+	eglShare.config = config
+	eglShare.ctx = context
+	eglShare.surface = surface
+	return nil
+}
+
+var contextAttribs = [...]eglInt{
+	_EGL_CONTEXT_CLIENT_VERSION, 2,
+	_EGL_NONE,
+}
+
+// createEGLSurface creates the EGL surface of the window, and its context,
+// which shares with the share context.
+func createEGLSurface(hwnd syscall.Handle, w *windowImpl) error {
+	display, config := eglShare.display, eglShare.config
 	surface, _, _ := eglCreateWindowSurface.Call(display, uintptr(config), uintptr(hwnd), 0, 0)
 	if surface == _EGL_NO_SURFACE {
 		return fmt.Errorf("eglCreateWindowSurface failed: %v", eglErr())
 	}
 
-	contextAttribs := [...]eglInt{
-		_EGL_CONTEXT_CLIENT_VERSION, 2,
-		_EGL_NONE,
-	}
 	context, _, _ := eglCreateContext.Call(
 		display,
 		uintptr(config),
-		_EGL_NO_CONTEXT,
+		eglShare.ctx,
 		uintptr(unsafe.Pointer(&contextAttribs[0])),
 	)
 	if context == _EGL_NO_CONTEXT {
@@ -352,6 +414,10 @@ func createEGLSurface(hwnd syscall.Handle, w *windowImpl) error {
 	return nil
 }
 
+// surfaceCreate makes the share context current on the calling thread.
 func surfaceCreate() error {
-	return errors.New("gldriver: surface creation not implemented on windows")
+	if ret, _, _ := eglMakeCurrent.Call(eglShare.display, eglShare.surface, eglShare.surface, eglShare.ctx); ret == 0 {
+		return fmt.Errorf("eglMakeCurrent failed: %v", eglErr())
+	}
+	return nil
 }
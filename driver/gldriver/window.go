@@ -8,11 +8,14 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"math"
 	"sync"
+	"time"
 
 	"github.com/as/shiny/driver/internal/drawer"
 	"github.com/as/shiny/event/size"
 	"github.com/as/shiny/gl"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/math/f64"
 	"github.com/as/shiny/screen"
 )
@@ -31,27 +34,35 @@ type windowImpl struct {
 	publishDone chan screen.PublishResult
 	drawDone    chan struct{}
 
-	// glctxMu is a mutex that enforces the atomicity of methods like
-	// Texture.Upload or Window.Draw that are conceptually one operation
-	// but are implemented by multiple OpenGL calls. OpenGL is a stateful
-	// API, so interleaving OpenGL calls from separate higher-level
-	// operations causes inconsistencies.
-	glctxMu sync.Mutex
-	glctx   gl.Context
-	worker  gl.Worker
+	// glctx is the window's context, which shares objects with the share
+	// context, screenImpl.glctx. It is protected by screenImpl.glctxMu.
+	glctx  gl.Context
+	worker gl.Worker
 	// backBufferBound is whether the default Framebuffer, with ID 0, also
 	// known as the back buffer or the window's Framebuffer, is bound and its
 	// viewport is known to equal the window size. It can become false when we
 	// bind to a texture's Framebuffer or when the window size changes.
 	backBufferBound bool
 
-	// szMu protects only sz. If you need to hold both glctxMu and szMu, the
-	// lock ordering is to lock glctxMu first (and unlock it last).
+	// szMu protects only sz. If you need to hold both screenImpl.glctxMu
+	// and szMu, the lock ordering is to lock glctxMu first (and unlock it
+	// last).
 	szMu sync.Mutex
 	sz   size.Event
+
+	// damage is the union of the rectangles of a run of expose events, which
+	// is sent as one paint event after the run's last event.
+	damage imageutil.Region
+
+	// clock numbers the window's frames.
+	clock screen.FrameClock
 }
 
 func (w *windowImpl) Release() {
+	w.s.mu.Lock()
+	delete(w.s.windows, w.id)
+	w.s.mu.Unlock()
+
 	closeWindow(w.id)
 }
 
@@ -60,13 +71,23 @@ func (w *windowImpl) Device() *screen.Device {
 
 }
 func (w *windowImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
+	w.upload(dp, src, sr, false)
+}
+
+func (w *windowImpl) UploadAsync(dp image.Point, src screen.Buffer, sr image.Rectangle) <-chan struct{} {
+	return w.upload(dp, src, sr, true)
+}
+
+// upload uploads src to its texture, asynchronously if async is set, and
+// draws the texture onto w.
+func (w *windowImpl) upload(dp image.Point, src screen.Buffer, sr image.Rectangle, async bool) <-chan struct{} {
 	if sr.Empty() {
-		return
+		return screen.Closed
 	}
 	{
 		src := src.(*bufferImpl)
 		if src.t == nil || src.t.Size() != src.Size() {
-			t, err := w.s.NewTexture(src.Size())
+			t, err := w.s.NewTexture(src.Size(), nil)
 			if err != nil {
 				panic(err)
 			}
@@ -76,22 +97,136 @@ func (w *windowImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangl
 			src.t = t
 		} else {
 		}
-		src.t.Upload(sr.Min, src, sr)
+		var done <-chan struct{}
+		if async {
+			done = src.t.(*textureImpl).UploadAsync(sr.Min, src, sr)
+		} else {
+			src.t.Upload(sr.Min, src, sr)
+			done = screen.Closed
+		}
 		dp = dp.Sub(sr.Min)
 		w.Draw(f64.Aff3{
 			1, 0, float64(dp.X),
 			0, 1, float64(dp.Y),
 		}, src.t, sr, draw.Src, nil)
+		return done
 	}
 }
 
-func useOp(glctx gl.Context, op draw.Op) {
-	if op == draw.Over {
+func (w *windowImpl) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
+	w.szMu.Lock()
+	sz := w.sz
+	w.szMu.Unlock()
+
+	w.s.glctxMu.Lock()
+	defer w.s.glctxMu.Unlock()
+
+	w.bindBackBuffer()
+	bounds := image.Rect(0, 0, sz.WidthPx, sz.HeightPx)
+	dst.(*bufferImpl).download(w.glctx, bounds, true, sr, dp)
+}
+
+// blendFuncs are the sfactor and dfactor arguments to glBlendFunc for those
+// ops that fixed-function blending implements. Colors are alpha-premultiplied.
+var blendFuncs = map[draw.Op][2]gl.Enum{
+	screen.Clear:       {gl.ZERO, gl.ZERO},
+	screen.Src:         {gl.ONE, gl.ZERO},
+	screen.Dst:         {gl.ZERO, gl.ONE},
+	screen.Over:        {gl.ONE, gl.ONE_MINUS_SRC_ALPHA},
+	screen.DstOver:     {gl.ONE_MINUS_DST_ALPHA, gl.ONE},
+	screen.In:          {gl.DST_ALPHA, gl.ZERO},
+	screen.DstIn:       {gl.ZERO, gl.SRC_ALPHA},
+	screen.Out:         {gl.ONE_MINUS_DST_ALPHA, gl.ZERO},
+	screen.DstOut:      {gl.ZERO, gl.ONE_MINUS_SRC_ALPHA},
+	screen.Atop:        {gl.DST_ALPHA, gl.ONE_MINUS_SRC_ALPHA},
+	screen.DstAtop:     {gl.ONE_MINUS_DST_ALPHA, gl.SRC_ALPHA},
+	screen.Xor:         {gl.ONE_MINUS_DST_ALPHA, gl.ONE_MINUS_SRC_ALPHA},
+	screen.Add:         {gl.ONE, gl.ONE},
+	screen.BlendScreen: {gl.ONE, gl.ONE_MINUS_SRC_COLOR},
+}
+
+// blendModes are the values of the blendSrc mode uniform for those ops that
+// need to read the dst color in a fragment shader.
+var blendModes = map[draw.Op]int{
+	screen.BlendMultiply:   1,
+	screen.BlendOverlay:    2,
+	screen.BlendDarken:     3,
+	screen.BlendLighten:    4,
+	screen.BlendDifference: 5,
+}
+
+// useOp sets up blending for op. It returns the blend mode for the fragment
+// shader, which is zero if fixed-function blending implements op, and
+// whether op is supported at all.
+func useOp(glctx gl.Context, op draw.Op) (mode int, ok bool) {
+	if op == draw.Src {
+		glctx.Disable(gl.BLEND)
+		return 0, true
+	}
+	if f, ok := blendFuncs[op]; ok {
 		glctx.Enable(gl.BLEND)
-		glctx.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
-	} else {
+		glctx.BlendFunc(f[0], f[1])
+		return 0, true
+	}
+	if mode, ok := blendModes[op]; ok {
+		// The fragment shader does the blending.
 		glctx.Disable(gl.BLEND)
+		return mode, true
 	}
+	return 0, false
+}
+
+// useBlendMode sets the blend mode uniforms of the current program and, for
+// a non-zero mode, copies the region of the currently bound framebuffer dst
+// that the quad can affect to the screen's dst copy texture, so that the
+// fragment shader can read the dst colors. It returns false if there is
+// nothing to draw.
+//
+// useBlendMode must only be called while holding screenImpl.glctxMu.
+func useBlendMode(s *screenImpl, glctx gl.Context, u *blendUniforms, mode int, dst target, quad image.Rectangle, opts *screen.DrawOptions) bool {
+	glctx.Uniform1i(u.mode, mode)
+	if mode == 0 {
+		return true
+	}
+	r := quad.Intersect(dst.bounds())
+	if clip := opts.GetClip(); !clip.Empty() {
+		r = r.Intersect(clip)
+	}
+	if r.Empty() {
+		return false
+	}
+	x, y, w, h := dst.scissorBox(r)
+
+	glctx.ActiveTexture(gl.TEXTURE2)
+	if s.dstCopy.Value == 0 {
+		s.dstCopy = glctx.CreateTexture()
+		glctx.BindTexture(gl.TEXTURE_2D, s.dstCopy)
+		glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+		glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+		glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	} else {
+		glctx.BindTexture(gl.TEXTURE_2D, s.dstCopy)
+	}
+	glctx.CopyTexImage2D(gl.TEXTURE_2D, 0, dst.copyFormat(), int(x), int(y), int(w), int(h), 0)
+	glctx.ActiveTexture(gl.TEXTURE0)
+
+	glctx.Uniform1i(u.dst, 2)
+	glctx.Uniform4f(u.dstRect, float32(x), float32(y), 1/float32(w), 1/float32(h))
+	return true
+}
+
+// quadBounds returns the smallest rectangle of dst pixels containing the quad
+// that src2dst maps sr onto.
+func quadBounds(src2dst f64.Aff3, sr image.Rectangle) image.Rectangle {
+	minX, minY := math.Inf(+1), math.Inf(+1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range [4]image.Point{sr.Min, {sr.Max.X, sr.Min.Y}, sr.Max, {sr.Min.X, sr.Max.Y}} {
+		q := src2dst.Transform(f64.Vec2{float64(p.X), float64(p.Y)})
+		minX, maxX = math.Min(minX, q[0]), math.Max(maxX, q[0])
+		minY, maxY = math.Min(minY, q[1]), math.Max(maxY, q[1])
+	}
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
 }
 
 func (w *windowImpl) bindBackBuffer() {
@@ -108,17 +243,16 @@ func (w *windowImpl) bindBackBuffer() {
 	w.glctx.Viewport(0, 0, sz.WidthPx, sz.HeightPx)
 }
 
-func (w *windowImpl) fill(mvp f64.Aff3, src color.Color, op draw.Op) {
-	w.glctxMu.Lock()
-	defer w.glctxMu.Unlock()
-
-	//w.bindBackBuffer()
-
-	doFill(w.s, w.glctx, mvp, src, op)
-}
-
-func doFill(s *screenImpl, glctx gl.Context, mvp f64.Aff3, src color.Color, op draw.Op) {
-	useOp(glctx, op)
+// doFill fills the quad that src2dst maps sr onto, in the currently bound
+// framebuffer dst, with the uniform color src.
+//
+// doFill must only be called while holding screenImpl.glctxMu.
+func doFill(s *screenImpl, glctx gl.Context, dst target, src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	mode, ok := useOp(glctx, op)
+	if !ok {
+		// TODO: support more ops.
+		return
+	}
 	if !glctx.IsProgram(s.fill.program) {
 		p, err := compileProgram(glctx, fillVertexSrc, fillFragmentSrc)
 		if err != nil {
@@ -129,23 +263,34 @@ func doFill(s *screenImpl, glctx gl.Context, mvp f64.Aff3, src color.Color, op d
 		s.fill.pos = glctx.GetAttribLocation(p, "pos")
 		s.fill.mvp = glctx.GetUniformLocation(p, "mvp")
 		s.fill.color = glctx.GetUniformLocation(p, "color")
+		s.fill.muvp = glctx.GetUniformLocation(p, "muvp")
+		s.fill.mask = glctx.GetUniformLocation(p, "mask")
+		s.fill.useMask = glctx.GetUniformLocation(p, "useMask")
+		s.fill.blend = getBlendUniforms(glctx, p)
 		s.fill.quad = glctx.CreateBuffer()
 
 		glctx.BindBuffer(gl.ARRAY_BUFFER, s.fill.quad)
 		glctx.BufferData(gl.ARRAY_BUFFER, quadCoords, gl.STATIC_DRAW)
 	}
 	glctx.UseProgram(s.fill.program)
+	if !useBlendMode(s, glctx, &s.fill.blend, mode, dst, quadBounds(src2dst, sr), opts) {
+		return
+	}
+	defer useClip(glctx, dst, opts)()
 
-	writeAff3(glctx, s.fill.mvp, mvp)
+	writeAff3(glctx, s.fill.mvp, quadMVP(dst, src2dst, sr))
 
+	// The color is alpha-premultiplied, so all four channels scale.
 	r, g, b, a := src.RGBA()
+	k := float32(opts.GetOpacity()) / 65535
 	glctx.Uniform4f(
 		s.fill.color,
-		float32(r)/65535,
-		float32(g)/65535,
-		float32(b)/65535,
-		float32(a)/65535,
+		float32(r)*k,
+		float32(g)*k,
+		float32(b)*k,
+		float32(a)*k,
 	)
+	useMask(glctx, s.fill.mask, s.fill.useMask, s.fill.muvp, sr, opts)
 
 	glctx.BindBuffer(gl.ARRAY_BUFFER, s.fill.quad)
 	glctx.EnableVertexAttribArray(s.fill.pos)
@@ -157,30 +302,16 @@ func doFill(s *screenImpl, glctx gl.Context, mvp f64.Aff3, src color.Color, op d
 }
 
 func (w *windowImpl) Fill(dr image.Rectangle, src color.Color, op draw.Op) {
-	minX := float64(dr.Min.X)
-	minY := float64(dr.Min.Y)
-	maxX := float64(dr.Max.X)
-	maxY := float64(dr.Max.Y)
-	w.fill(w.mvp(
-		minX, minY,
-		maxX, minY,
-		minX, maxY,
-	), src, op)
+	w.DrawUniform(f64.Aff3{1, 0, 0, 0, 1, 0}, src, dr, op, nil)
 }
 
 func (w *windowImpl) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
-	minX := float64(sr.Min.X)
-	minY := float64(sr.Min.Y)
-	maxX := float64(sr.Max.X)
-	maxY := float64(sr.Max.Y)
-	w.fill(w.mvp(
-		src2dst[0]*minX+src2dst[1]*minY+src2dst[2],
-		src2dst[3]*minX+src2dst[4]*minY+src2dst[5],
-		src2dst[0]*maxX+src2dst[1]*minY+src2dst[2],
-		src2dst[3]*maxX+src2dst[4]*minY+src2dst[5],
-		src2dst[0]*minX+src2dst[1]*maxY+src2dst[2],
-		src2dst[3]*minX+src2dst[4]*maxY+src2dst[5],
-	), src, op)
+	w.s.glctxMu.Lock()
+	defer w.s.glctxMu.Unlock()
+
+	w.bindBackBuffer()
+	w.s.flush()
+	doFill(w.s, w.glctx, w, src2dst, src, sr, op, opts)
 }
 
 func (w *windowImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
@@ -190,30 +321,34 @@ func (w *windowImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectang
 		return
 	}
 
-	w.glctxMu.Lock()
-	defer w.glctxMu.Unlock()
+	w.s.glctxMu.Lock()
+	defer w.s.glctxMu.Unlock()
 
 	//if !w.backBufferBound {
 	w.bindBackBuffer()
 	//}
+	w.s.flush()
 
-	useOp(w.glctx, op)
-	w.glctx.UseProgram(w.s.texture.program)
-
-	// Start with src-space left, top, right and bottom.
-	srcL := float64(sr.Min.X)
-	srcT := float64(sr.Min.Y)
-	srcR := float64(sr.Max.X)
-	srcB := float64(sr.Max.Y)
-	// Transform to dst-space via the src2dst matrix, then to a MVP matrix.
-	writeAff3(w.glctx, w.s.texture.mvp, w.mvp(
-		src2dst[0]*srcL+src2dst[1]*srcT+src2dst[2],
-		src2dst[3]*srcL+src2dst[4]*srcT+src2dst[5],
-		src2dst[0]*srcR+src2dst[1]*srcT+src2dst[2],
-		src2dst[3]*srcR+src2dst[4]*srcT+src2dst[5],
-		src2dst[0]*srcL+src2dst[1]*srcB+src2dst[2],
-		src2dst[3]*srcL+src2dst[4]*srcB+src2dst[5],
-	))
+	doDraw(w.s, w.glctx, w, src2dst, t, sr, op, opts)
+}
+
+// doDraw draws sr of the texture t onto the currently bound framebuffer dst.
+//
+// doDraw must only be called while holding screenImpl.glctxMu.
+func doDraw(s *screenImpl, glctx gl.Context, dst target, src2dst f64.Aff3, t *textureImpl, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	mode, ok := useOp(glctx, op)
+	if !ok {
+		// TODO: support more ops.
+		return
+	}
+	glctx.UseProgram(s.texture.program)
+	if !useBlendMode(s, glctx, &s.texture.blend, mode, dst, quadBounds(src2dst, sr), opts) {
+		return
+	}
+	defer useClip(glctx, dst, opts)()
+
+	// Transform sr to dst-space via the src2dst matrix, then to a MVP matrix.
+	writeAff3(glctx, s.texture.mvp, quadMVP(dst, src2dst, sr))
 
 	// OpenGL's fragment shaders' UV coordinates run from (0,0)-(1,1),
 	// unlike vertex shaders' XY coordinates running from (-1,+1)-(+1,-1).
@@ -245,27 +380,30 @@ func (w *windowImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectang
 	//	a10 +   0 + a12 = qy = py
 	//	  0 + a01 + a02 = sx = px
 	//	  0 + a11 + a12 = sy
-	writeAff3(w.glctx, w.s.texture.uvp, f64.Aff3{
+	writeAff3(glctx, s.texture.uvp, f64.Aff3{
 		qx - px, 0, px,
 		0, sy - py, py,
 	})
 
-	w.glctx.ActiveTexture(gl.TEXTURE0)
-	w.glctx.BindTexture(gl.TEXTURE_2D, t.id)
-	w.glctx.Uniform1i(w.s.texture.sample, 0)
+	glctx.ActiveTexture(gl.TEXTURE0)
+	glctx.BindTexture(gl.TEXTURE_2D, t.id)
+	t.setFilter(glctx, opts.GetFilter())
+	glctx.Uniform1i(s.texture.sample, 0)
+	glctx.Uniform1f(s.texture.alpha, float32(opts.GetOpacity()))
+	useMask(glctx, s.texture.mask, s.texture.useMask, s.texture.muvp, sr, opts)
 
-	w.glctx.BindBuffer(gl.ARRAY_BUFFER, w.s.texture.quad)
-	w.glctx.EnableVertexAttribArray(w.s.texture.pos)
-	w.glctx.VertexAttribPointer(w.s.texture.pos, 2, gl.FLOAT, false, 0, 0)
+	glctx.BindBuffer(gl.ARRAY_BUFFER, s.texture.quad)
+	glctx.EnableVertexAttribArray(s.texture.pos)
+	glctx.VertexAttribPointer(s.texture.pos, 2, gl.FLOAT, false, 0, 0)
 
-	w.glctx.BindBuffer(gl.ARRAY_BUFFER, w.s.texture.quad)
-	w.glctx.EnableVertexAttribArray(w.s.texture.inUV)
-	w.glctx.VertexAttribPointer(w.s.texture.inUV, 2, gl.FLOAT, false, 0, 0)
+	glctx.BindBuffer(gl.ARRAY_BUFFER, s.texture.quad)
+	glctx.EnableVertexAttribArray(s.texture.inUV)
+	glctx.VertexAttribPointer(s.texture.inUV, 2, gl.FLOAT, false, 0, 0)
 
-	w.glctx.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
+	glctx.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
 
-	w.glctx.DisableVertexAttribArray(w.s.texture.pos)
-	w.glctx.DisableVertexAttribArray(w.s.texture.inUV)
+	glctx.DisableVertexAttribArray(s.texture.pos)
+	glctx.DisableVertexAttribArray(s.texture.inUV)
 }
 
 func (w *windowImpl) Copy(dp image.Point, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
@@ -276,6 +414,87 @@ func (w *windowImpl) Scale(dr image.Rectangle, src screen.Texture, sr image.Rect
 	drawer.Scale(w, dr, src, sr, op, opts)
 }
 
+// target is a framebuffer that doDraw and doFill can draw onto: either a
+// window's back buffer or a texture's framebuffer.
+type target interface {
+	// mvp converts a quad in the target's pixel space to a Model View
+	// Projection matrix, as per calcMVP.
+	mvp(tlx, tly, trx, try, blx, bly float64) f64.Aff3
+	// scissorBox converts a rectangle in the target's pixel space to the
+	// arguments of gl.Context.Scissor.
+	scissorBox(r image.Rectangle) (x, y, width, height int32)
+	// bounds returns the target's bounds in pixel space.
+	bounds() image.Rectangle
+	// copyFormat returns the internal format for copying the target's
+	// pixels to a texture with gl.Context.CopyTexImage2D.
+	copyFormat() gl.Enum
+}
+
+// quadMVP returns the Model View Projection matrix for the quad that src2dst
+// maps sr onto in dst.
+func quadMVP(dst target, src2dst f64.Aff3, sr image.Rectangle) f64.Aff3 {
+	// Map the src-space top-left, top-right and bottom-left corners.
+	tl := src2dst.Transform(f64.Vec2{float64(sr.Min.X), float64(sr.Min.Y)})
+	tr := src2dst.Transform(f64.Vec2{float64(sr.Max.X), float64(sr.Min.Y)})
+	bl := src2dst.Transform(f64.Vec2{float64(sr.Min.X), float64(sr.Max.Y)})
+	return dst.mvp(tl[0], tl[1], tr[0], tr[1], bl[0], bl[1])
+}
+
+// useClip enables the scissor test if opts has a clip rectangle, and returns
+// a function that disables it again.
+func useClip(glctx gl.Context, dst target, opts *screen.DrawOptions) (done func()) {
+	clip := opts.GetClip()
+	if clip.Empty() {
+		return func() {}
+	}
+	glctx.Enable(gl.SCISSOR_TEST)
+	glctx.Scissor(dst.scissorBox(clip))
+	return func() { glctx.Disable(gl.SCISSOR_TEST) }
+}
+
+// useMask binds the mask of opts, if any, to texture unit 1 and sets the
+// mask uniforms of the current program, such that the mask is sampled at the
+// same src-space coordinates as the unit quad mapped to sr.
+func useMask(glctx gl.Context, sample, use, muvp gl.Uniform, sr image.Rectangle, opts *screen.DrawOptions) {
+	m, _ := opts.GetMask().(*textureImpl)
+	if m == nil {
+		glctx.Uniform1f(use, 0)
+		return
+	}
+	mw := float64(m.size.X)
+	mh := float64(m.size.Y)
+	writeAff3(glctx, muvp, f64.Aff3{
+		float64(sr.Dx()) / mw, 0, float64(sr.Min.X) / mw,
+		0, float64(sr.Dy()) / mh, float64(sr.Min.Y) / mh,
+	})
+	glctx.ActiveTexture(gl.TEXTURE1)
+	glctx.BindTexture(gl.TEXTURE_2D, m.id)
+	glctx.ActiveTexture(gl.TEXTURE0)
+	glctx.Uniform1i(sample, 1)
+	glctx.Uniform1f(use, 1)
+}
+
+func (w *windowImpl) bounds() image.Rectangle {
+	w.szMu.Lock()
+	sz := w.sz
+	w.szMu.Unlock()
+
+	return image.Rect(0, 0, sz.WidthPx, sz.HeightPx)
+}
+
+// copyFormat returns gl.RGB: the back buffer might not have an alpha channel,
+// and the window is opaque when published anyway.
+func (w *windowImpl) copyFormat() gl.Enum { return gl.RGB }
+
+func (w *windowImpl) scissorBox(r image.Rectangle) (x, y, width, height int32) {
+	w.szMu.Lock()
+	sz := w.sz
+	w.szMu.Unlock()
+
+	// The back buffer's Y-axis points upwards.
+	return int32(r.Min.X), int32(sz.HeightPx - r.Max.Y), int32(r.Dx()), int32(r.Dy())
+}
+
 func (w *windowImpl) mvp(tlx, tly, trx, try, blx, bly float64) f64.Aff3 {
 	w.szMu.Lock()
 	sz := w.sz
@@ -325,12 +544,13 @@ func (w *windowImpl) Publish() screen.PublishResult {
 	//
 	// This enforces that the final receive (for this paint cycle) on
 	// gl.WorkAvailable happens before the send on publish.
-	//w.glctxMu.Lock()
+	//w.s.glctxMu.Lock()
 	w.glctx.Flush()
-	//w.glctxMu.Unlock()
+	//w.s.glctxMu.Unlock()
 
 	w.publish <- struct{}{}
 	res := <-w.publishDone
+	w.clock.Published(time.Now())
 
 	select {
 	case w.drawDone <- struct{}{}:
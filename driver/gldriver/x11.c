@@ -18,7 +18,9 @@ Atom wm_take_focus;
 
 EGLConfig e_config;
 EGLContext e_ctx;
+EGLContext e_share_ctx;
 EGLDisplay e_dpy;
+EGLSurface e_share_surf;
 Colormap x_colormap;
 Display *x_dpy;
 XVisualInfo *x_visual_info;
@@ -86,7 +88,8 @@ startDriver() {
 
 	static const EGLint attribs[] = {
 		EGL_RENDERABLE_TYPE, EGL_OPENGL_ES2_BIT,
-		EGL_SURFACE_TYPE, EGL_WINDOW_BIT,
+		// The share context's surface is a pbuffer.
+		EGL_SURFACE_TYPE, EGL_WINDOW_BIT | EGL_PBUFFER_BIT,
 		EGL_BLUE_SIZE, 8,
 		EGL_GREEN_SIZE, 8,
 		EGL_RED_SIZE, 8,
@@ -125,7 +128,14 @@ startDriver() {
 		EGL_CONTEXT_CLIENT_VERSION, 3,
 		EGL_NONE
 	};
-	e_ctx = eglCreateContext(e_dpy, e_config, EGL_NO_CONTEXT, ctx_attribs);
+	// The windows' context shares its objects, such as textures, with the
+	// hidden share context, which surfaceCreate makes current.
+	e_share_ctx = eglCreateContext(e_dpy, e_config, EGL_NO_CONTEXT, ctx_attribs);
+	if (!e_share_ctx) {
+		fprintf(stderr, "share eglCreateContext failed: %s\n", eglGetErrorStr());
+		exit(1);
+	}
+	e_ctx = eglCreateContext(e_dpy, e_config, e_share_ctx, ctx_attribs);
 	if (!e_ctx) {
 		fprintf(stderr, "eglCreateContext failed: %s\n", eglGetErrorStr());
 		exit(1);
@@ -176,14 +186,13 @@ processEvents() {
 			onFocus(ev.xmotion.window, ev.type == FocusIn);
 			break;
 		case Expose:
-			// A non-zero Count means that there are more expose events coming. For
+			// A non-zero count means that there are more expose events coming. For
 			// example, a non-rectangular exposure (e.g. from a partially overlapped
 			// window) will result in multiple expose events whose dirty rectangles
-			// combine to define the dirty region. Go's paint events do not provide
-			// dirty regions, so we only pass on the final X11 expose event.
-			if (ev.xexpose.count == 0) {
-				onExpose(ev.xexpose.window);
-			}
+			// combine to define the dirty region. onExpose accumulates them, and
+			// sends a paint event after the final one.
+			onExpose(ev.xexpose.window, ev.xexpose.x, ev.xexpose.y,
+				ev.xexpose.width, ev.xexpose.height, ev.xexpose.count);
 			break;
 		case ConfigureNotify:
 			onConfigure(ev.xconfigure.window, ev.xconfigure.x, ev.xconfigure.y,
@@ -277,51 +286,26 @@ doShowWindow(uintptr_t id) {
 	return (uintptr_t)(surf);
 }
 
+// surfaceCreate makes the share context current on the calling thread, on a
+// pbuffer surface. The share context never draws to the surface itself, only
+// to the framebuffers of textures, so the surface is as small as possible.
 uintptr_t
 surfaceCreate() {
-	static const EGLint ctx_attribs[] = {
-		EGL_CONTEXT_CLIENT_VERSION, 3,
-		EGL_NONE
-	};
-	EGLContext ctx = eglCreateContext(e_dpy, e_config, EGL_NO_CONTEXT, ctx_attribs);
-	if (!ctx) {
-		fprintf(stderr, "surface eglCreateContext failed: %s\n", eglGetErrorStr());
-		return 0;
-	}
-
-	static const EGLint cfg_attribs[] = {
-		EGL_RENDERABLE_TYPE, EGL_OPENGL_ES2_BIT,
-		EGL_SURFACE_TYPE, EGL_PBUFFER_BIT,
-		EGL_BLUE_SIZE, 8,
-		EGL_GREEN_SIZE, 8,
-		EGL_RED_SIZE, 8,
-		EGL_DEPTH_SIZE, 16,
-		EGL_CONFIG_CAVEAT, EGL_NONE,
-		EGL_NONE
-	};
-	EGLConfig cfg;
-	EGLint num_configs;
-	if (!eglChooseConfig(e_dpy, cfg_attribs, &cfg, 1, &num_configs)) {
-		fprintf(stderr, "gldriver: surface eglChooseConfig failed: %s\n", eglGetErrorStr());
-		return 0;
-	}
-
-	// TODO: use the size of the monitor as a bound for texture size.
 	static const EGLint attribs[] = {
-		EGL_WIDTH, 4096,
-		EGL_HEIGHT, 3072,
+		EGL_WIDTH, 1,
+		EGL_HEIGHT, 1,
 		EGL_NONE
 	};
-	EGLSurface surface = eglCreatePbufferSurface(e_dpy, cfg, attribs);
-	if (!surface) {
+	e_share_surf = eglCreatePbufferSurface(e_dpy, e_config, attribs);
+	if (!e_share_surf) {
 		fprintf(stderr, "gldriver: surface eglCreatePbufferSurface failed: %s\n", eglGetErrorStr());
 		return 0;
 	}
 
-	if (!eglMakeCurrent(e_dpy, surface, surface, ctx)) {
+	if (!eglMakeCurrent(e_dpy, e_share_surf, e_share_surf, e_share_ctx)) {
 		fprintf(stderr, "gldriver: surface eglMakeCurrent failed: %s\n", eglGetErrorStr());
 		return 0;
 	}
 
-	return (uintptr_t)surface;
+	return (uintptr_t)e_share_surf;
 }
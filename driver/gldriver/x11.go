@@ -26,6 +26,8 @@ uintptr_t surfaceCreate();
 import "C"
 import (
 	"errors"
+	"image"
+	"os"
 	"runtime"
 	"time"
 	"unsafe"
@@ -37,6 +39,7 @@ import (
 	"github.com/as/shiny/event/size"
 	"github.com/as/shiny/geom"
 	"github.com/as/shiny/gl"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/screen"
 )
 
@@ -108,13 +111,8 @@ var (
 	publishc   = make(chan *windowImpl)
 	uic        = make(chan uiClosure)
 
-	// TODO: don't assume that there is only one window, and hence only
-	// one (global) GL context.
-	//
-	// TODO: should we be able to make a shiny.Texture before having a
-	// shiny.Window's GL context? Should something like gl.IsProgram be a
-	// method instead of a function, and have each shiny.Window have its own
-	// gl.Context?
+	// glctx is the context of every window. The windows take turns to
+	// make it current, with their own surfaces, on the main thread.
 	glctx  gl.Context
 	worker gl.Worker
 )
@@ -125,12 +123,27 @@ type uiClosure struct {
 	retc chan uintptr
 }
 
-func main(f func(screen.Screen)) error {
+func probe() error {
 	if gl.Version() == "GL_ES_2_0" {
 		return errors.New("gldriver: ES 3 required on X11")
 	}
+	if os.Getenv("DISPLAY") == "" {
+		return errors.New("gldriver: DISPLAY is not set")
+	}
+	return nil
+}
+
+func main(f func(screen.Screen)) error {
+	if err := probe(); err != nil {
+		return err
+	}
 	C.startDriver()
 	glctx, worker = gl.NewContext()
+	share, err := newShareContext()
+	if err != nil {
+		return err
+	}
+	theScreen.glctx = share
 
 	closec := make(chan struct{})
 	go func() {
@@ -177,7 +190,7 @@ func main(f func(screen.Screen)) error {
 }
 
 //export onExpose
-func onExpose(id uintptr) {
+func onExpose(id uintptr, x, y, width, height, count int32) {
 	theScreen.mu.Lock()
 	w := theScreen.windows[id]
 	theScreen.mu.Unlock()
@@ -186,7 +199,14 @@ func onExpose(id uintptr) {
 		return
 	}
 
-	w.Send(paint.Event{External: true})
+	// A non-zero count means that more expose events follow, whose
+	// rectangles are part of the same damage.
+	w.damage = w.damage.Union(imageutil.NewRegion(image.Rect(int(x), int(y), int(x+width), int(y+height))))
+	if count != 0 {
+		return
+	}
+	w.clock.SendPaint(paint.Event{External: true, Damage: w.damage})
+	w.damage = imageutil.Region{}
 }
 
 //export onKeysym
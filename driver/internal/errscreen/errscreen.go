@@ -20,6 +20,10 @@ type stub struct {
 	err error
 }
 
-func (s stub) NewBuffer(size image.Point) (screen.Buffer, error)              { return nil, s.err }
-func (s stub) NewTexture(size image.Point) (screen.Texture, error)            { return nil, s.err }
+func (s stub) NewBuffer(size image.Point, opts *screen.NewBufferOptions) (screen.Buffer, error) {
+	return nil, s.err
+}
+func (s stub) NewTexture(size image.Point, opts *screen.NewTextureOptions) (screen.Texture, error) {
+	return nil, s.err
+}
 func (s stub) NewWindow(opts *screen.NewWindowOptions) (screen.Window, error) { return nil, s.err }
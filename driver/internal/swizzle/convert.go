@@ -0,0 +1,138 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/as/shiny/imageutil"
+)
+
+// RGB565ToRGBA expands the little-endian 5-6-5 pixels in p to opaque RGBA
+// pixels in q, which must be at least 2*len(p) bytes long.
+func RGB565ToRGBA(p, q []byte) {
+	n := len(p) / 2
+	q = q[:4*n]
+	for i := 0; i < n; i++ {
+		v := uint16(p[2*i]) | uint16(p[2*i+1])<<8
+		r, g, b := uint8(v>>11), uint8(v>>5&0x3f), uint8(v&0x1f)
+		q[4*i+0] = r<<3 | r>>2
+		q[4*i+1] = g<<2 | g>>4
+		q[4*i+2] = b<<3 | b>>2
+		q[4*i+3] = 0xff
+	}
+}
+
+// Convert converts the pixels in sr of src, which must be inside src's
+// bounds, to 4-byte pixels in dst, whose rows are stride bytes apart, such
+// that sr.Min maps to dp. The pixels are alpha-premultiplied RGBA, or BGRA
+// if bgra is true.
+//
+// The image types that the screen.Formats use, and image.NRGBA, are
+// converted row by row, with the others going through their At method.
+func Convert(dst []byte, stride int, dp image.Point, src image.Image, sr image.Rectangle, bgra bool) {
+	width := 4 * sr.Dx()
+	if width == 0 {
+		return
+	}
+	for y := sr.Min.Y; y < sr.Max.Y; y++ {
+		i := (dp.Y+y-sr.Min.Y)*stride + 4*dp.X
+		q := dst[i : i+width]
+		swizzled := false
+
+		switch m := src.(type) {
+		case *image.RGBA:
+			j := m.PixOffset(sr.Min.X, y)
+			if bgra {
+				Swizzle(m.Pix[j:j+width], q)
+				swizzled = true
+			} else {
+				copy(q, m.Pix[j:j+width])
+			}
+		case *imageutil.BGRA:
+			j := m.PixOffset(sr.Min.X, y)
+			if bgra {
+				copy(q, m.Pix[j:j+width])
+			} else {
+				Swizzle(m.Pix[j:j+width], q)
+			}
+			swizzled = true
+		case *image.NRGBA:
+			j := m.PixOffset(sr.Min.X, y)
+			Premultiply(m.Pix[j:j+width], q)
+		case *image.Gray:
+			j := m.PixOffset(sr.Min.X, y)
+			GrayToRGBA(m.Pix[j:j+sr.Dx()], q)
+			swizzled = true
+		case *imageutil.RGB565:
+			j := m.PixOffset(sr.Min.X, y)
+			RGB565ToRGBA(m.Pix[j:j+2*sr.Dx()], q)
+		case *imageutil.YCbCr:
+			YCbCrToRGBA(&m.YCbCr, image.Rect(sr.Min.X, y, sr.Max.X, y+1), q, stride)
+		case *image.YCbCr:
+			YCbCrToRGBA(m, image.Rect(sr.Min.X, y, sr.Max.X, y+1), q, stride)
+		default:
+			for x, k := sr.Min.X, 0; x < sr.Max.X; x, k = x+1, k+4 {
+				r, g, b, a := src.At(x, y).RGBA()
+				q[k+0] = uint8(r >> 8)
+				q[k+1] = uint8(g >> 8)
+				q[k+2] = uint8(b >> 8)
+				q[k+3] = uint8(a >> 8)
+			}
+		}
+
+		if bgra && !swizzled {
+			Swizzle(q, q)
+		}
+	}
+}
+
+// Store is the inverse of Convert. It stores the 4-byte pixels in src, whose
+// rows are stride bytes apart and are RGBA, or BGRA if bgra is true, in the
+// rectangle dr of dst, which must be inside dst's bounds. The first pixel of
+// src maps to dr.Min.
+func Store(dst draw.Image, dr image.Rectangle, src []byte, stride int, bgra bool) {
+	width := 4 * dr.Dx()
+	if width == 0 {
+		return
+	}
+	switch m := dst.(type) {
+	case *image.RGBA:
+		for y := dr.Min.Y; y < dr.Max.Y; y++ {
+			p, j := src[(y-dr.Min.Y)*stride:][:width], m.PixOffset(dr.Min.X, y)
+			if bgra {
+				Swizzle(p, m.Pix[j:j+width])
+			} else {
+				copy(m.Pix[j:j+width], p)
+			}
+		}
+	case *imageutil.BGRA:
+		for y := dr.Min.Y; y < dr.Max.Y; y++ {
+			p, j := src[(y-dr.Min.Y)*stride:][:width], m.PixOffset(dr.Min.X, y)
+			if bgra {
+				copy(m.Pix[j:j+width], p)
+			} else {
+				Swizzle(p, m.Pix[j:j+width])
+			}
+		}
+	case *image.NRGBA:
+		for y := dr.Min.Y; y < dr.Max.Y; y++ {
+			p, j := src[(y-dr.Min.Y)*stride:][:width], m.PixOffset(dr.Min.X, y)
+			if bgra {
+				Swizzle(p, m.Pix[j:j+width])
+				Unpremultiply(m.Pix[j:j+width], m.Pix[j:j+width])
+			} else {
+				Unpremultiply(p, m.Pix[j:j+width])
+			}
+		}
+	default:
+		var p image.Image = &image.RGBA{Pix: src, Stride: stride, Rect: image.Rectangle{Max: dr.Size()}}
+		if bgra {
+			p = &imageutil.BGRA{Pix: src, Stride: stride, Rect: image.Rectangle{Max: dr.Size()}}
+		}
+		draw.Draw(dst, dr, p, image.Point{}, draw.Src)
+	}
+}
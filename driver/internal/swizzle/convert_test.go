@@ -0,0 +1,133 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+
+	"github.com/as/shiny/imageutil"
+)
+
+// convertImages returns an image of each of the types that Convert has a
+// fast path for, and one that it does not, filled with random pixels.
+func convertImages(r image.Rectangle) map[string]draw.Image {
+	rng := rand.New(rand.NewSource(1))
+	m := map[string]draw.Image{
+		"RGBA":   image.NewRGBA(r),
+		"BGRA":   imageutil.NewBGRA(r),
+		"Gray":   image.NewGray(r),
+		"RGB565": imageutil.NewRGB565(r),
+		"YCbCr":  imageutil.NewYCbCr(r, image.YCbCrSubsampleRatio420),
+		"NRGBA":  image.NewNRGBA(r),
+	}
+	for _, dst := range m {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				a := uint8(rng.Intn(256))
+				dst.Set(x, y, color.RGBA{
+					uint8(rng.Intn(int(a) + 1)),
+					uint8(rng.Intn(int(a) + 1)),
+					uint8(rng.Intn(int(a) + 1)),
+					a,
+				})
+			}
+		}
+	}
+	return m
+}
+
+// rgbaAt returns the 8-bit, alpha-premultiplied color of m at (x, y), the
+// way that Convert computes it.
+func rgbaAt(m image.Image, x, y int) color.RGBA {
+	if c, ok := m.At(x, y).(color.YCbCr); ok {
+		r, g, b := color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+		return color.RGBA{r, g, b, 0xff}
+	}
+	r, g, b, a := m.At(x, y).RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
+
+func TestConvert(t *testing.T) {
+	bounds := image.Rect(-3, 2, 14, 11)
+	sr := image.Rect(-1, 3, 12, 10)
+	dp := image.Point{2, 1}
+	const stride = 4 * 20
+
+	for name, src := range convertImages(bounds) {
+		for _, bgra := range []bool{false, true} {
+			dst := make([]byte, stride*12)
+			Convert(dst, stride, dp, src, sr, bgra)
+
+			for y := sr.Min.Y; y < sr.Max.Y; y++ {
+				for x := sr.Min.X; x < sr.Max.X; x++ {
+					i := (dp.Y+y-sr.Min.Y)*stride + 4*(dp.X+x-sr.Min.X)
+					got := color.RGBA{dst[i], dst[i+1], dst[i+2], dst[i+3]}
+					if bgra {
+						got.R, got.B = got.B, got.R
+					}
+					if want := rgbaAt(src, x, y); got != want {
+						t.Fatalf("%s, bgra=%t: (%d, %d): got %v, want %v", name, bgra, x, y, got, want)
+					}
+				}
+			}
+			// The pixels outside of sr's destination are untouched.
+			for i := 0; i < stride*dp.Y; i++ {
+				if dst[i] != 0 {
+					t.Fatalf("%s, bgra=%t: byte %d was written", name, bgra, i)
+				}
+			}
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	bounds := image.Rect(0, 0, 9, 7)
+	dr := image.Rect(1, 2, 8, 6)
+	const stride = 4 * 10
+
+	for name, src := range convertImages(bounds) {
+		if name == "YCbCr" || name == "NRGBA" {
+			// Their colors are shared between pixels, or not
+			// alpha-premultiplied, so a round trip is lossy.
+			continue
+		}
+		for _, bgra := range []bool{false, true} {
+			pix := make([]byte, stride*dr.Dy())
+			Convert(pix, stride, image.Point{}, src, dr, bgra)
+
+			dst := convertImages(bounds)[name]
+			before := rgbaAt(dst, 0, 0)
+			Store(dst, dr, pix, stride, bgra)
+
+			for y := dr.Min.Y; y < dr.Max.Y; y++ {
+				for x := dr.Min.X; x < dr.Max.X; x++ {
+					if got, want := rgbaAt(dst, x, y), rgbaAt(src, x, y); got != want {
+						t.Fatalf("%s, bgra=%t: (%d, %d): got %v, want %v", name, bgra, x, y, got, want)
+					}
+				}
+			}
+			if got := rgbaAt(dst, 0, 0); got != before {
+				t.Errorf("%s, bgra=%t: pixel outside dr changed", name, bgra)
+			}
+		}
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	r := image.Rect(0, 0, 256, 256)
+	for name, src := range convertImages(r) {
+		b.Run(name, func(b *testing.B) {
+			dst := make([]byte, 4*r.Dx()*r.Dy())
+			b.SetBytes(int64(len(dst)))
+			for i := 0; i < b.N; i++ {
+				Convert(dst, 4*r.Dx(), image.Point{}, src, r, true)
+			}
+		})
+	}
+}
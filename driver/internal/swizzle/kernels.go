@@ -0,0 +1,196 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+import (
+	"image"
+	"image/color"
+)
+
+// kernel is a pixel conversion function, with a pure Go fallback. The
+// vectorized fns only convert whole blocks, so they are only given whole
+// blocks, and the rest of the pixels go through pure.
+type kernel struct {
+	fn, pure func(p, q []byte)
+	// block is the number of bytes of p that fn converts at a time.
+	block int
+	// scale is the number of bytes of q that each byte of p converts to.
+	scale int
+}
+
+func (k *kernel) run(p, q []byte) {
+	n := len(p) - len(p)%k.block
+	if n > 0 {
+		k.fn(p[:n], q[:n*k.scale])
+	}
+	if n < len(p) {
+		k.pure(p[n:], q[n*k.scale:])
+	}
+}
+
+// ycbcrKernel is like kernel, for converting a row of Y'CbCr pixels, where
+// every sub pixels share a Cb and Cr sample.
+type ycbcrKernel struct {
+	fn, pure func(y, cb, cr, q []byte)
+	// block is the number of pixels that fn converts at a time, and is a
+	// multiple of sub.
+	block int
+	sub   int
+}
+
+func (k *ycbcrKernel) run(y, cb, cr, q []byte) {
+	n := len(y) - len(y)%k.block
+	if n > 0 {
+		k.fn(y[:n], cb[:n/k.sub], cr[:n/k.sub], q[:4*n])
+	}
+	if n < len(y) {
+		k.pure(y[n:], cb[n/k.sub:], cr[n/k.sub:], q[4*n:])
+	}
+}
+
+// The kernels are replaced by vectorized ones at init, where the CPU
+// supports them.
+var (
+	grayKernel      = kernel{pureGray, pureGray, 1, 4}
+	grayAlphaKernel = kernel{pureGrayAlpha, pureGrayAlpha, 2, 2}
+	premulKernel    = kernel{purePremultiply, purePremultiply, 4, 1}
+	unpremulKernel  = kernel{pureUnpremultiply, pureUnpremultiply, 4, 1}
+	ycbcr444Kernel  = ycbcrKernel{pureYCbCr444, pureYCbCr444, 1, 1}
+	ycbcr422Kernel  = ycbcrKernel{pureYCbCr422, pureYCbCr422, 2, 2}
+)
+
+// GrayToRGBA expands the 8-bit gray pixels in p to opaque RGBA pixels in q,
+// which must be at least 4*len(p) bytes long. Gray pixels are the same in
+// BGRA order.
+func GrayToRGBA(p, q []byte) {
+	grayKernel.run(p, q[:4*len(p)])
+}
+
+// GrayAlphaToRGBA expands the 8-bit gray and alpha pairs in p to RGBA
+// pixels in q, which must be at least 2*len(p) bytes long. The gray is not
+// multiplied by the alpha, so the pixels are alpha-premultiplied if and only
+// if p's are.
+func GrayAlphaToRGBA(p, q []byte) {
+	p = p[:len(p)&^1]
+	grayAlphaKernel.run(p, q[:2*len(p)])
+}
+
+// Premultiply converts the non-alpha-premultiplied RGBA pixels in p, like
+// those of an image.NRGBA, to alpha-premultiplied ones in q, which must be
+// at least as long as p. It gives the same result as image/draw, and p and q
+// may be the same.
+func Premultiply(p, q []byte) {
+	p = p[:len(p)&^3]
+	premulKernel.run(p, q[:len(p)])
+}
+
+// Unpremultiply is the inverse of Premultiply, and gives the same result as
+// color.NRGBAModel. Fully transparent pixels are converted to zero.
+func Unpremultiply(p, q []byte) {
+	p = p[:len(p)&^3]
+	unpremulKernel.run(p, q[:len(p)])
+}
+
+// YCbCrToRGBA converts the pixels in r of m, which must be inside m's bounds,
+// to opaque RGBA pixels. Each row is written to q, stride bytes after the
+// previous one.
+//
+// The 4:4:4, 4:2:2 and 4:2:0 subsample ratios are converted a row at a time,
+// and the others a pixel at a time.
+func YCbCrToRGBA(m *image.YCbCr, r image.Rectangle, q []byte, stride int) {
+	var k *ycbcrKernel
+	switch m.SubsampleRatio {
+	case image.YCbCrSubsampleRatio444:
+		k = &ycbcr444Kernel
+	case image.YCbCrSubsampleRatio422, image.YCbCrSubsampleRatio420:
+		k = &ycbcr422Kernel
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		row := q[(y-r.Min.Y)*stride:]
+		x := r.Min.X
+		// The kernels expect the first pixel to be the first of those that
+		// share its chroma, which m.COffset only makes even x for x >= 0.
+		for ; x < r.Max.X && (k == nil || x < 0 || x%k.sub != 0); x++ {
+			yi, ci := m.YOffset(x, y), m.COffset(x, y)
+			i := 4 * (x - r.Min.X)
+			pureYCbCr444(m.Y[yi:yi+1], m.Cb[ci:ci+1], m.Cr[ci:ci+1], row[i:i+4])
+		}
+		if x == r.Max.X {
+			continue
+		}
+		n := r.Max.X - x
+		c := (n + k.sub - 1) / k.sub
+		yi, ci := m.YOffset(x, y), m.COffset(x, y)
+		k.run(m.Y[yi:yi+n], m.Cb[ci:ci+c], m.Cr[ci:ci+c], row[4*(x-r.Min.X):])
+	}
+}
+
+func pureGray(p, q []byte) {
+	for i, v := range p {
+		q[4*i+0] = v
+		q[4*i+1] = v
+		q[4*i+2] = v
+		q[4*i+3] = 0xff
+	}
+}
+
+func pureGrayAlpha(p, q []byte) {
+	for i := 0; i+1 < len(p); i += 2 {
+		v := p[i]
+		q[2*i+0] = v
+		q[2*i+1] = v
+		q[2*i+2] = v
+		q[2*i+3] = p[i+1]
+	}
+}
+
+func purePremultiply(p, q []byte) {
+	for i := 0; i+3 < len(p); i += 4 {
+		a := uint32(p[i+3]) * 0x101
+		q[i+0] = uint8(uint32(p[i+0]) * a / 0xff >> 8)
+		q[i+1] = uint8(uint32(p[i+1]) * a / 0xff >> 8)
+		q[i+2] = uint8(uint32(p[i+2]) * a / 0xff >> 8)
+		q[i+3] = p[i+3]
+	}
+}
+
+func pureUnpremultiply(p, q []byte) {
+	for i := 0; i+3 < len(p); i += 4 {
+		switch a := uint32(p[i+3]); a {
+		case 0:
+			q[i+0], q[i+1], q[i+2], q[i+3] = 0, 0, 0, 0
+		case 0xff:
+			q[i+0], q[i+1], q[i+2], q[i+3] = p[i+0], p[i+1], p[i+2], p[i+3]
+		default:
+			a *= 0x101
+			q[i+0] = uint8(uint32(p[i+0]) * 0x101 * 0xffff / a >> 8)
+			q[i+1] = uint8(uint32(p[i+1]) * 0x101 * 0xffff / a >> 8)
+			q[i+2] = uint8(uint32(p[i+2]) * 0x101 * 0xffff / a >> 8)
+			q[i+3] = p[i+3]
+		}
+	}
+}
+
+// pureYCbCr444 converts pixels that each have their own chroma.
+func pureYCbCr444(y, cb, cr, q []byte) {
+	for i, v := range y {
+		r, g, b := color.YCbCrToRGB(v, cb[i], cr[i])
+		q[4*i+0] = r
+		q[4*i+1] = g
+		q[4*i+2] = b
+		q[4*i+3] = 0xff
+	}
+}
+
+// pureYCbCr422 converts pixels whose chroma is shared by pairs of them.
+func pureYCbCr422(y, cb, cr, q []byte) {
+	for i, v := range y {
+		r, g, b := color.YCbCrToRGB(v, cb[i/2], cr[i/2])
+		q[4*i+0] = r
+		q[4*i+1] = g
+		q[4*i+2] = b
+		q[4*i+3] = 0xff
+	}
+}
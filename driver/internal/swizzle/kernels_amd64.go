@@ -0,0 +1,26 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+func init() {
+	// SSE2 is part of the amd64 baseline.
+	premulKernel.fn, premulKernel.block = premul16sd, 16
+	unpremulKernel.fn, unpremulKernel.block = unpremul16sd, 16
+	if useSSSE3 {
+		grayKernel.fn, grayKernel.block = gray16sd, 16
+		grayAlphaKernel.fn, grayAlphaKernel.block = grayAlpha16sd, 16
+	}
+	if useAVX2 {
+		ycbcr444Kernel.fn, ycbcr444Kernel.block = ycbcr444x8sd, 8
+		ycbcr422Kernel.fn, ycbcr422Kernel.block = ycbcr422x8sd, 8
+	}
+}
+
+func premul16sd(p, q []byte)           // kernels_amd64.s:/premul16sd/
+func unpremul16sd(p, q []byte)         // kernels_amd64.s:/unpremul16sd/
+func gray16sd(p, q []byte)             // kernels_amd64.s:/gray16sd/
+func grayAlpha16sd(p, q []byte)        // kernels_amd64.s:/grayAlpha16sd/
+func ycbcr444x8sd(y, cb, cr, q []byte) // kernels_amd64.s:/ycbcr444x8sd/
+func ycbcr422x8sd(y, cb, cr, q []byte) // kernels_amd64.s:/ycbcr422x8sd/
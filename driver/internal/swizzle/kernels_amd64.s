@@ -0,0 +1,371 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// The kernels convert whole blocks only: kernel.run in kernels.go gives the
+// remainder to the pure Go kernels.
+
+// PSHUFB masks that expand 4 gray bytes each to 4 RGB triples, leaving the
+// alpha bytes zero.
+DATA ·graytab<>+0x00(SB)/8, $0x8001010180000000
+DATA ·graytab<>+0x08(SB)/8, $0x8003030380020202
+DATA ·graytab<>+0x10(SB)/8, $0x8005050580040404
+DATA ·graytab<>+0x18(SB)/8, $0x8007070780060606
+DATA ·graytab<>+0x20(SB)/8, $0x8009090980080808
+DATA ·graytab<>+0x28(SB)/8, $0x800b0b0b800a0a0a
+DATA ·graytab<>+0x30(SB)/8, $0x800d0d0d800c0c0c
+DATA ·graytab<>+0x38(SB)/8, $0x800f0f0f800e0e0e
+GLOBL ·graytab<>(SB), (NOPTR+RODATA), $64
+
+// PSHUFB masks that expand 4 gray and alpha pairs each to 4 RGBA pixels.
+DATA ·grayalphatab<>+0x00(SB)/8, $0x0302020201000000
+DATA ·grayalphatab<>+0x08(SB)/8, $0x0706060605040404
+DATA ·grayalphatab<>+0x10(SB)/8, $0x0b0a0a0a09080808
+DATA ·grayalphatab<>+0x18(SB)/8, $0x0f0e0e0e0d0c0c0c
+GLOBL ·grayalphatab<>(SB), (NOPTR+RODATA), $32
+
+// func gray16sd(p, q []byte)
+//
+// gray16sd expands 16 gray pixels at a time. It requires SSSE3.
+TEXT ·gray16sd(SB),NOSPLIT,$0
+	MOVQ	p+0(FP), SI
+	MOVQ	p_len+8(FP), CX
+	MOVQ	q+24(FP), DI
+	ADDQ	SI, CX
+
+	MOVOU	·graytab<>+0x00(SB), X4
+	MOVOU	·graytab<>+0x10(SB), X5
+	MOVOU	·graytab<>+0x20(SB), X6
+	MOVOU	·graytab<>+0x30(SB), X7
+	// X8 is the opaque alpha, 0xff000000 in each dword.
+	PCMPEQL	X8, X8
+	PSLLL	$24, X8
+
+loop:
+	CMPQ	SI, CX
+	JAE	done
+	MOVOU	(SI), X0
+	MOVO	X0, X1
+	PSHUFB	X4, X1
+	POR	X8, X1
+	MOVOU	X1, 0(DI)
+	MOVO	X0, X1
+	PSHUFB	X5, X1
+	POR	X8, X1
+	MOVOU	X1, 16(DI)
+	MOVO	X0, X1
+	PSHUFB	X6, X1
+	POR	X8, X1
+	MOVOU	X1, 32(DI)
+	MOVO	X0, X1
+	PSHUFB	X7, X1
+	POR	X8, X1
+	MOVOU	X1, 48(DI)
+	ADDQ	$16, SI
+	ADDQ	$64, DI
+	JMP	loop
+done:
+	RET
+
+// func grayAlpha16sd(p, q []byte)
+//
+// grayAlpha16sd expands 8 gray and alpha pairs, 16 bytes, at a time. It
+// requires SSSE3.
+TEXT ·grayAlpha16sd(SB),NOSPLIT,$0
+	MOVQ	p+0(FP), SI
+	MOVQ	p_len+8(FP), CX
+	MOVQ	q+24(FP), DI
+	ADDQ	SI, CX
+
+	MOVOU	·grayalphatab<>+0x00(SB), X4
+	MOVOU	·grayalphatab<>+0x10(SB), X5
+
+loop:
+	CMPQ	SI, CX
+	JAE	done
+	MOVOU	(SI), X0
+	MOVO	X0, X1
+	PSHUFB	X4, X1
+	MOVOU	X1, 0(DI)
+	PSHUFB	X5, X0
+	MOVOU	X0, 16(DI)
+	ADDQ	$16, SI
+	ADDQ	$32, DI
+	JMP	loop
+done:
+	RET
+
+// func premul16sd(p, q []byte)
+//
+// premul16sd premultiplies 4 pixels at a time, with SSE2. Each color c of a
+// pixel with alpha a becomes c*a*0x101/0xff>>8, as in image/draw, which is
+// (c*a*0x8101)>>23 for all 8-bit c and a.
+TEXT ·premul16sd(SB),NOSPLIT,$0
+	MOVQ	p+0(FP), SI
+	MOVQ	p_len+8(FP), CX
+	MOVQ	q+24(FP), DI
+	ADDQ	SI, CX
+
+	PXOR	X8, X8
+	// X9 is 0x8101 in each word.
+	MOVL	$0x81018101, AX
+	MOVD	AX, X9
+	PSHUFD	$0, X9, X9
+	// X10 selects the alpha bytes, and X11 the others.
+	PCMPEQL	X10, X10
+	PSLLL	$24, X10
+	PCMPEQL	X11, X11
+	PSRLL	$8, X11
+
+loop:
+	CMPQ	SI, CX
+	JAE	done
+	MOVOU	(SI), X0
+
+	// Widen the bytes to words, two pixels per register.
+	MOVO	X0, X1
+	PUNPCKLBW	X8, X1
+	MOVO	X0, X2
+	PUNPCKHBW	X8, X2
+
+	// Broadcast each pixel's alpha to its four words.
+	PSHUFLW	$0xff, X1, X3
+	PSHUFHW	$0xff, X3, X3
+	PSHUFLW	$0xff, X2, X4
+	PSHUFHW	$0xff, X4, X4
+
+	PMULLW	X3, X1
+	PMULHUW	X9, X1
+	PSRLW	$7, X1
+	PMULLW	X4, X2
+	PMULHUW	X9, X2
+	PSRLW	$7, X2
+	PACKUSWB	X2, X1
+
+	// Keep the original alpha.
+	PAND	X11, X1
+	PAND	X10, X0
+	POR	X0, X1
+	MOVOU	X1, (DI)
+	ADDQ	$16, SI
+	ADDQ	$16, DI
+	JMP	loop
+done:
+	RET
+
+// UNPREMUL unpremultiplies the pixel in P, four dwords c, with SSE2. Each
+// becomes c*0xffff/a>>8, as in color.NRGBAModel, which is the floor of
+// c*65535/(256*a). That is computed in float32, and the truncated quotient
+// is corrected by at most one, with exact float32 products. A zero alpha
+// gives zero. The result is left in U, with only its low bytes kept, and
+// its alpha is to be replaced by the original.
+//
+// X8 is zero, X9 is 65535.0 and X10 is 256.0 in each lane, and X12 is 0xff
+// in each dword.
+#define UNPREMUL(P, D, Q, T, V, U) \
+	PSHUFD	$0xff, P, D; \
+	MOVO	D, U; \
+	PCMPEQL	X8, U; \
+	CVTPL2PS	D, D; \
+	MULPS	X10, D; \
+	CVTPL2PS	P, P; \
+	MULPS	X9, P; \
+	MOVO	P, Q; \
+	DIVPS	D, Q; \
+	CVTTPS2PL	Q, Q; \
+	CVTPL2PS	Q, T; \
+	MULPS	D, T; \
+	MOVO	P, V; \
+	CMPPS	T, V, $1; \
+	PADDL	V, Q; \
+	ADDPS	D, T; \
+	CMPPS	P, T, $2; \
+	PSUBL	T, Q; \
+	PANDN	Q, U; \
+	PAND	X12, U
+
+// func unpremul16sd(p, q []byte)
+//
+// unpremul16sd unpremultiplies 4 pixels at a time, with SSE2.
+TEXT ·unpremul16sd(SB),NOSPLIT,$0
+	MOVQ	p+0(FP), SI
+	MOVQ	p_len+8(FP), CX
+	MOVQ	q+24(FP), DI
+	ADDQ	SI, CX
+
+	PXOR	X8, X8
+	MOVL	$0x477fff00, AX // 65535.0
+	MOVD	AX, X9
+	PSHUFD	$0, X9, X9
+	MOVL	$0x43800000, AX // 256.0
+	MOVD	AX, X10
+	PSHUFD	$0, X10, X10
+	PCMPEQL	X12, X12
+	PSRLL	$24, X12
+	// X15 selects the alpha bytes, and X11 the others.
+	PCMPEQL	X15, X15
+	PSLLL	$24, X15
+	PCMPEQL	X11, X11
+	PSRLL	$8, X11
+
+loop:
+	CMPQ	SI, CX
+	JAE	done
+	MOVOU	(SI), X0
+	MOVO	X0, X14
+
+	// Widen the bytes to dwords, one pixel per register.
+	MOVO	X0, X1
+	PUNPCKLBW	X8, X1
+	MOVO	X0, X2
+	PUNPCKHBW	X8, X2
+	MOVO	X1, X3
+	PUNPCKLWL	X8, X3
+	MOVO	X1, X4
+	PUNPCKHWL	X8, X4
+	MOVO	X2, X5
+	PUNPCKLWL	X8, X5
+	MOVO	X2, X6
+	PUNPCKHWL	X8, X6
+
+	UNPREMUL(X3, X0, X1, X2, X7, X13)
+	MOVO	X13, X3
+	UNPREMUL(X4, X0, X1, X2, X7, X13)
+	MOVO	X13, X4
+	UNPREMUL(X5, X0, X1, X2, X7, X13)
+	MOVO	X13, X5
+	UNPREMUL(X6, X0, X1, X2, X7, X13)
+
+	PACKSSLW	X4, X3
+	PACKSSLW	X13, X5
+	PACKUSWB	X5, X3
+
+	// Keep the original alpha.
+	PAND	X11, X3
+	PAND	X15, X14
+	POR	X14, X3
+	MOVOU	X3, (DI)
+	ADDQ	$16, SI
+	ADDQ	$16, DI
+	JMP	loop
+done:
+	RET
+
+// YCBCR converts the 8 pixels whose Y' is in Y1 and whose Cb and Cr, minus
+// 128, are in Y2 and Y3, one per dword, to RGBA in Y4. It computes the same
+// as color.YCbCrToRGB, clamping with the signed dword min and max instead
+// of branches.
+//
+// Y8 is 0x10101, Y9 is 91881, Y10 is 22554, Y11 is 46802, Y12 is 116130,
+// Y13 is zero, Y14 is 255 and Y15 is 0xff000000 in each dword.
+#define YCBCR \
+	VPMULLD	Y8, Y1, Y1; \
+	VPMULLD	Y9, Y3, Y4; \
+	VPADDD	Y1, Y4, Y4; \
+	VPSRAD	$16, Y4, Y4; \
+	VPMAXSD	Y13, Y4, Y4; \
+	VPMINSD	Y14, Y4, Y4; \
+	VPMULLD	Y10, Y2, Y5; \
+	VPMULLD	Y11, Y3, Y6; \
+	VPSUBD	Y5, Y1, Y5; \
+	VPSUBD	Y6, Y5, Y5; \
+	VPSRAD	$16, Y5, Y5; \
+	VPMAXSD	Y13, Y5, Y5; \
+	VPMINSD	Y14, Y5, Y5; \
+	VPMULLD	Y12, Y2, Y6; \
+	VPADDD	Y1, Y6, Y6; \
+	VPSRAD	$16, Y6, Y6; \
+	VPMAXSD	Y13, Y6, Y6; \
+	VPMINSD	Y14, Y6, Y6; \
+	VPSLLD	$8, Y5, Y5; \
+	VPSLLD	$16, Y6, Y6; \
+	VPOR	Y5, Y4, Y4; \
+	VPOR	Y6, Y4, Y4; \
+	VPOR	Y15, Y4, Y4
+
+#define BROADCAST(c, Y) \
+	MOVL	c, AX; \
+	MOVD	AX, X0; \
+	VPBROADCASTD	X0, Y
+
+#define YCBCR_CONSTANTS \
+	BROADCAST($0x10101, Y8); \
+	BROADCAST($91881, Y9); \
+	BROADCAST($22554, Y10); \
+	BROADCAST($46802, Y11); \
+	BROADCAST($116130, Y12); \
+	VPXOR	Y13, Y13, Y13; \
+	BROADCAST($255, Y14); \
+	BROADCAST($0xff000000, Y15); \
+	BROADCAST($128, Y7)
+
+// func ycbcr444x8sd(y, cb, cr, q []byte)
+//
+// ycbcr444x8sd converts 8 pixels at a time, each with its own chroma. It
+// requires AVX2.
+TEXT ·ycbcr444x8sd(SB),NOSPLIT,$0
+	MOVQ	y+0(FP), SI
+	MOVQ	y_len+8(FP), CX
+	MOVQ	cb+24(FP), BX
+	MOVQ	cr+48(FP), DX
+	MOVQ	q+72(FP), DI
+	ADDQ	SI, CX
+	YCBCR_CONSTANTS
+
+loop:
+	CMPQ	SI, CX
+	JAE	done
+	VPMOVZXBD	(SI), Y1
+	VPMOVZXBD	(BX), Y2
+	VPMOVZXBD	(DX), Y3
+	VPSUBD	Y7, Y2, Y2
+	VPSUBD	Y7, Y3, Y3
+	YCBCR
+	VMOVDQU	Y4, (DI)
+	ADDQ	$8, SI
+	ADDQ	$8, BX
+	ADDQ	$8, DX
+	ADDQ	$32, DI
+	JMP	loop
+done:
+	VZEROUPPER
+	RET
+
+// func ycbcr422x8sd(y, cb, cr, q []byte)
+//
+// ycbcr422x8sd converts 8 pixels at a time, each pair of which shares its
+// chroma. It requires AVX2.
+TEXT ·ycbcr422x8sd(SB),NOSPLIT,$0
+	MOVQ	y+0(FP), SI
+	MOVQ	y_len+8(FP), CX
+	MOVQ	cb+24(FP), BX
+	MOVQ	cr+48(FP), DX
+	MOVQ	q+72(FP), DI
+	ADDQ	SI, CX
+	YCBCR_CONSTANTS
+
+loop:
+	CMPQ	SI, CX
+	JAE	done
+	VPMOVZXBD	(SI), Y1
+	// Duplicate each of the 4 chroma bytes before widening them.
+	VMOVD	(BX), X2
+	VPUNPCKLBW	X2, X2, X2
+	VPMOVZXBD	X2, Y2
+	VMOVD	(DX), X3
+	VPUNPCKLBW	X3, X3, X3
+	VPMOVZXBD	X3, Y3
+	VPSUBD	Y7, Y2, Y2
+	VPSUBD	Y7, Y3, Y3
+	YCBCR
+	VMOVDQU	Y4, (DI)
+	ADDQ	$8, SI
+	ADDQ	$4, BX
+	ADDQ	$4, DX
+	ADDQ	$32, DI
+	JMP	loop
+done:
+	VZEROUPPER
+	RET
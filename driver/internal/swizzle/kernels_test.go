@@ -0,0 +1,272 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+// kernels are the kernels that convert a single slice, by name. Their fn is
+// the vectorized one selected at init, if any.
+var kernels = map[string]*kernel{
+	"gray":      &grayKernel,
+	"grayalpha": &grayAlphaKernel,
+	"premul":    &premulKernel,
+	"unpremul":  &unpremulKernel,
+}
+
+var ycbcrKernels = map[string]*ycbcrKernel{
+	"ycbcr444": &ycbcr444Kernel,
+	"ycbcr422": &ycbcr422Kernel,
+}
+
+func TestPremultiply(t *testing.T) {
+	// Every combination of a color and alpha.
+	p := make([]byte, 4*256*256)
+	for a := 0; a < 256; a++ {
+		for c := 0; c < 256; c++ {
+			i := 4 * (a*256 + c)
+			p[i+0], p[i+1], p[i+2], p[i+3] = uint8(c), uint8(255-c), uint8(c/2), uint8(a)
+		}
+	}
+	q := make([]byte, len(p))
+	Premultiply(p, q)
+	for i := 0; i < len(p); i += 4 {
+		want := color.RGBAModel.Convert(color.NRGBA{p[i], p[i+1], p[i+2], p[i+3]}).(color.RGBA)
+		if got := (color.RGBA{q[i], q[i+1], q[i+2], q[i+3]}); got != want {
+			t.Fatalf("%v: got %v, want %v", p[i:i+4], got, want)
+		}
+	}
+
+	// In place.
+	Premultiply(p, p)
+	if !bytes.Equal(p, q) {
+		t.Errorf("in place: got a different result")
+	}
+}
+
+func TestUnpremultiply(t *testing.T) {
+	// Every combination of a color and alpha, including colors greater than
+	// their alpha, which are not valid alpha-premultiplied colors.
+	p := make([]byte, 4*256*256)
+	for a := 0; a < 256; a++ {
+		for c := 0; c < 256; c++ {
+			i := 4 * (a*256 + c)
+			p[i+0], p[i+1], p[i+2], p[i+3] = uint8(c), uint8(255-c), uint8(c/2), uint8(a)
+		}
+	}
+	q := make([]byte, len(p))
+	Unpremultiply(p, q)
+	for i := 0; i < len(p); i += 4 {
+		want := color.NRGBAModel.Convert(color.RGBA{p[i], p[i+1], p[i+2], p[i+3]}).(color.NRGBA)
+		if got := (color.NRGBA{q[i], q[i+1], q[i+2], q[i+3]}); got != want {
+			t.Fatalf("%v: got %v, want %v", p[i:i+4], got, want)
+		}
+	}
+}
+
+func TestGrayToRGBA(t *testing.T) {
+	p := make([]byte, 256)
+	for i := range p {
+		p[i] = uint8(i)
+	}
+	q := make([]byte, 4*len(p))
+	GrayToRGBA(p, q)
+	for i, v := range p {
+		want := color.RGBAModel.Convert(color.Gray{v}).(color.RGBA)
+		if got := (color.RGBA{q[4*i], q[4*i+1], q[4*i+2], q[4*i+3]}); got != want {
+			t.Fatalf("%d: got %v, want %v", v, got, want)
+		}
+	}
+
+	ga := make([]byte, 2*256)
+	for i := 0; i < 256; i++ {
+		ga[2*i], ga[2*i+1] = uint8(i), uint8(255-i)
+	}
+	GrayAlphaToRGBA(ga, q)
+	for i := 0; i < 256; i++ {
+		want := color.RGBA{uint8(i), uint8(i), uint8(i), uint8(255 - i)}
+		if got := (color.RGBA{q[4*i], q[4*i+1], q[4*i+2], q[4*i+3]}); got != want {
+			t.Fatalf("gray alpha %d: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestYCbCrKernels(t *testing.T) {
+	// Every combination of Y', Cb and Cr, in rows of 256 Y' values.
+	y := make([]byte, 256)
+	for i := range y {
+		y[i] = uint8(i)
+	}
+	cb := make([]byte, 256)
+	cr := make([]byte, 256)
+	q := make([]byte, 4*256)
+	for b := 0; b < 256; b++ {
+		for r := 0; r < 256; r++ {
+			for i := range cb {
+				cb[i], cr[i] = uint8(b), uint8(r)
+			}
+			for name, k := range ycbcrKernels {
+				k.run(y, cb, cr, q)
+				for i := range y {
+					cr, cg, cb := color.YCbCrToRGB(y[i], uint8(b), uint8(r))
+					want := color.RGBA{cr, cg, cb, 0xff}
+					if got := (color.RGBA{q[4*i], q[4*i+1], q[4*i+2], q[4*i+3]}); got != want {
+						t.Fatalf("%s: %d, %d, %d: got %v, want %v", name, y[i], b, r, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestYCbCrToRGBA(t *testing.T) {
+	ratios := []image.YCbCrSubsampleRatio{
+		image.YCbCrSubsampleRatio444,
+		image.YCbCrSubsampleRatio422,
+		image.YCbCrSubsampleRatio420,
+		image.YCbCrSubsampleRatio440,
+		image.YCbCrSubsampleRatio411,
+		image.YCbCrSubsampleRatio410,
+	}
+	rng := rand.New(rand.NewSource(1))
+	bounds := image.Rect(-5, -3, 37, 20)
+	for _, ratio := range ratios {
+		m := image.NewYCbCr(bounds, ratio)
+		rng.Read(m.Y)
+		rng.Read(m.Cb)
+		rng.Read(m.Cr)
+		for _, r := range []image.Rectangle{
+			bounds,
+			image.Rect(0, 0, 32, 16),
+			image.Rect(1, 2, 30, 19),
+			image.Rect(-3, 1, 3, 2),
+			image.Rect(7, 7, 8, 8),
+		} {
+			stride := 4*r.Dx() + 12
+			q := make([]byte, stride*r.Dy())
+			YCbCrToRGBA(m, r, q, stride)
+			for y := r.Min.Y; y < r.Max.Y; y++ {
+				for x := r.Min.X; x < r.Max.X; x++ {
+					c := m.YCbCrAt(x, y)
+					cr, cg, cb := color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+					want := color.RGBA{cr, cg, cb, 0xff}
+					i := (y-r.Min.Y)*stride + 4*(x-r.Min.X)
+					if got := (color.RGBA{q[i], q[i+1], q[i+2], q[i+3]}); got != want {
+						t.Fatalf("%v, %v: (%d, %d): got %v, want %v", ratio, r, x, y, got, want)
+					}
+				}
+				for i := (y-r.Min.Y)*stride + 4*r.Dx(); i < (y-r.Min.Y+1)*stride; i++ {
+					if q[i] != 0 {
+						t.Fatalf("%v, %v: row %d: wrote past its end", ratio, r, y)
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkKernel compares the vectorized and pure Go kernels on p, and checks
+// that neither writes past the end of its output.
+func checkKernel(t *testing.T, name string, k *kernel, p []byte) {
+	t.Helper()
+	// Each pixel of p is a whole number of bytes, which is the 4 bytes of an
+	// RGBA pixel over the scale.
+	n := len(p) - len(p)%(4/k.scale)
+	want := make([]byte, n*k.scale+64)
+	got := make([]byte, n*k.scale+64)
+	k.pure(p[:n], want[:n*k.scale])
+	k.run(p[:n], got[:n*k.scale])
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s: len=%d: got %v, want %v", name, n, got, want)
+	}
+}
+
+func TestKernelsRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	p := make([]byte, 1024)
+	for i := 0; i < 2000; i++ {
+		rng.Read(p)
+		o := rng.Intn(len(p))
+		n := rng.Intn(len(p) - o)
+		for name, k := range kernels {
+			checkKernel(t, name, k, p[o:o+n])
+		}
+	}
+}
+
+func TestYCbCrKernelsRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	y := make([]byte, 512)
+	cb := make([]byte, 512)
+	cr := make([]byte, 512)
+	for i := 0; i < 2000; i++ {
+		rng.Read(y)
+		rng.Read(cb)
+		rng.Read(cr)
+		n := rng.Intn(len(y))
+		for name, k := range ycbcrKernels {
+			c := (n + k.sub - 1) / k.sub
+			want := make([]byte, 4*n+64)
+			got := make([]byte, 4*n+64)
+			k.pure(y[:n], cb[:c], cr[:c], want[:4*n])
+			k.run(y[:n], cb[:c], cr[:c], got[:4*n])
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%s: len=%d: got %v, want %v", name, n, got, want)
+			}
+		}
+	}
+}
+
+func FuzzKernels(f *testing.F) {
+	f.Add([]byte("rgbargbargbargbargbargbargbargba"))
+	f.Add(bytes.Repeat([]byte{0x80, 0x40, 0xff, 0x00, 0x01}, 13))
+	f.Fuzz(func(t *testing.T, p []byte) {
+		for name, k := range kernels {
+			checkKernel(t, name, k, p)
+		}
+	})
+}
+
+func BenchmarkKernels(b *testing.B) {
+	const n = 64 << 10
+	p := make([]byte, n)
+	rand.New(rand.NewSource(1)).Read(p)
+	for name, k := range kernels {
+		for _, impl := range []string{"pure", "sd"} {
+			fn := k.fn
+			if impl == "pure" {
+				fn = k.pure
+			}
+			b.Run(fmt.Sprintf("%s/%s", name, impl), func(b *testing.B) {
+				q := make([]byte, n*k.scale)
+				b.SetBytes(n)
+				for i := 0; i < b.N; i++ {
+					fn(p, q)
+				}
+			})
+		}
+	}
+	for name, k := range ycbcrKernels {
+		for _, impl := range []string{"pure", "sd"} {
+			fn := k.fn
+			if impl == "pure" {
+				fn = k.pure
+			}
+			b.Run(fmt.Sprintf("%s/%s", name, impl), func(b *testing.B) {
+				q := make([]byte, 4*n)
+				b.SetBytes(4 * n)
+				for i := 0; i < b.N; i++ {
+					fn(p, p, p, q)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build swizzleneon
+
+package swizzle
+
+// NEON (ASIMD) is part of the arm64 baseline. Swizzle uses it only with the
+// swizzleneon tag until TestNEON has passed on arm64 hardware; the default
+// is pureBGRA.
+func init() {
+	swizzler = bgra64neon
+}
@@ -5,10 +5,11 @@ var (
 )
 
 func Swizzle(p, q []byte) {
+	p = p[:len(p)&^3]
 	if len(p) < 4 {
 		return
 	}
-	swizzler(p, q)
+	swizzler(p, q[:len(p)])
 }
 
 func pureBGRA(p, q []byte) {
@@ -39,20 +39,21 @@ TEXT ·haveAVX2(SB),NOSPLIT,$0
 	MOVB	BX, ret+0(FP)
 	RET
 
+// The swizzlers convert blocks of as many bytes as they can, then the rest
+// 4 bytes at a time. They never read or write past the end of p or q, and p
+// and q may be the same slice.
+
 // func bgra256sd(p, q []byte)
 TEXT ·bgra256sd(SB),NOSPLIT,$0
 	MOVQ	p+0(FP), SI
-	MOVQ	len+8(FP), CX
+	MOVQ	p_len+8(FP), CX
 	MOVQ	q+24(FP), DI
-	
+
 	VMOVDQU ·AVX2_swizzletab<>(SB), Y0
-	ADDQ SI, CX
-	ADDQ $256, SI
-	CMPQ CX, SI
-	JL prep32
-	SUBQ	$256, SI
-	
+
 loop256:
+	CMPQ	CX, $256
+	JB	loop32
 	VMOVDQU 	(0*32)(SI),Y1 
 	VMOVDQU 	(1*32)(SI),Y2 
 	VMOVDQU 	(2*32)(SI),Y3 
@@ -79,60 +80,47 @@ loop256:
 	VMOVDQU	Y8, (7*32)(DI)
 	ADDQ	$256, SI
 	ADDQ	$256, DI
-	CMPQ CX, SI
-	JGT loop256
-	JEQ done
-	
-	SUBQ	$256, DI
-prep32:
-	SUBQ	$256, SI
-	ADDQ $32, SI
-	CMPQ CX, SI
-	JL prep4
-	SUBQ	$32, SI
+	SUBQ	$256, CX
+	JMP	loop256
 
 loop32:
+	CMPQ	CX, $32
+	JB	loop4
 	VMOVDQU 	(0*32)(SI),Y1 
 	VPSHUFB Y0, Y1,  Y1
 	VMOVDQU	Y1, (0*32)(DI)
 	ADDQ	$32, SI
 	ADDQ	$32, DI
-	CMPQ CX, SI
-	JGT	loop32
-	JEQ done
-	
-	SUBQ	$32, DI
-prep4:
-	SUBQ	$32, SI
-	
+	SUBQ	$32, CX
+	JMP	loop32
+
 loop4:
-	MOVD	0(SI), AX	// r g b a
+	CMPQ	CX, $4
+	JB	done
+	MOVL	0(SI), AX	// r g b a
 	BSWAPL AX   // a b g r 
 	RORL	$8, AX 	// b g r a 
-	MOVD	AX, (DI)
-
+	MOVL	AX, (DI)
 	ADDQ	$4, SI
 	ADDQ	$4, DI
-	CMPQ CX, SI
-	JGT	loop4
+	SUBQ	$4, CX
+	JMP	loop4
 
 done:
+	VZEROUPPER
 	RET
 
 // func bgra128sd(p, q []byte)
 TEXT ·bgra128sd(SB),NOSPLIT,$0
 	MOVQ	p+0(FP), SI
-	MOVQ	len+8(FP), CX
+	MOVQ	p_len+8(FP), CX
 	MOVQ	q+24(FP), DI
-	
+
 	VMOVDQU ·AVX2_swizzletab<>(SB), X0
-	ADDQ SI, CX
-	ADDQ $128, SI
-	CMPQ CX, SI
-	JL prep16
-	SUBQ	$128, SI
-	
+
 loop128:
+	CMPQ	CX, $128
+	JB	loop16
 	VMOVDQU 	(0*16)(SI),X1 
 	VMOVDQU 	(1*16)(SI),X2 
 	VMOVDQU 	(2*16)(SI),X3 
@@ -159,42 +147,31 @@ loop128:
 	VMOVDQU	X8, (7*16)(DI)
 	ADDQ	$128, SI
 	ADDQ	$128, DI
-	CMPQ CX, SI
-	JGT loop128
-	JEQ done
-	
-	SUBQ	$128, DI
-prep16:
-	SUBQ	$128, SI
-	ADDQ $16, SI
-	CMPQ CX, SI
-	JL prep4
-	SUBQ	$16, SI
+	SUBQ	$128, CX
+	JMP	loop128
 
 loop16:
+	CMPQ	CX, $16
+	JB	loop4
 	VMOVDQU 	(0*16)(SI),X1 
 	VPSHUFB X0, X1,  X1
 	VMOVDQU	X1, (0*16)(DI)
 	ADDQ	$16, SI
 	ADDQ	$16, DI
-	CMPQ CX, SI
-	JGT	loop16
-	JEQ done
-	
-	SUBQ	$16, DI
-prep4:
-	SUBQ	$16, SI
-	
+	SUBQ	$16, CX
+	JMP	loop16
+
 loop4:
-	MOVD	0(SI), AX	// r g b a
+	CMPQ	CX, $4
+	JB	done
+	MOVL	0(SI), AX	// r g b a
 	BSWAPL AX   // a b g r 
 	RORL	$8, AX 	// b g r a 
-	MOVD	AX, (DI)
-
+	MOVL	AX, (DI)
 	ADDQ	$4, SI
 	ADDQ	$4, DI
-	CMPQ CX, SI
-	JGT	loop4
+	SUBQ	$4, CX
+	JMP	loop4
 
 done:
 	RET
@@ -202,15 +179,9 @@ done:
 // func bgra16sd(p, q []byte)
 TEXT ·bgra16sd(SB),NOSPLIT,$0
 	MOVQ	p+0(FP), SI
-	MOVQ	len+8(FP), CX
+	MOVQ	p_len+8(FP), CX
 	MOVQ	q+24(FP), DI
 
-	// Sanity check that len is a multiple of 16.
-	//	MOVQ	CX, AX
-	//	ANDQ	$15, AX
-	//	JNZ	done
-	ADDQ SI, CX
-
 	// Make the shuffle control mask (16-byte register X0) look like this,
 	// where the low order byte comes first:
 	//
@@ -225,50 +196,46 @@ TEXT ·bgra16sd(SB),NOSPLIT,$0
 	PUNPCKLQDQ	X1, X0
 
 loop16:
+	CMPQ	CX, $16
+	JB	loop4
 	MOVOU	(SI), X1
 	PSHUFB	X0, X1
 	MOVOU	X1, (DI)
-
 	ADDQ	$16, SI
 	ADDQ	$16, DI
-	CMPQ CX, SI
-	JGT	loop16
-	JEQ done
+	SUBQ	$16, CX
+	JMP	loop16
 
-prep4:
-	SUBQ	$16, DI
-	SUBQ	$16, SI
 loop4:
-	MOVD	0(SI), AX	// r g b a
+	CMPQ	CX, $4
+	JB	done
+	MOVL	0(SI), AX	// r g b a
 	BSWAPL AX   // a b g r 
 	RORL	$8, AX 	// b g r a 
-	MOVD	AX, (DI)
-
+	MOVL	AX, (DI)
 	ADDQ	$4, SI
 	ADDQ	$4, DI
-	CMPQ CX, SI
-	JGT	loop4
+	SUBQ	$4, CX
+	JMP	loop4
 done:
 	RET
 
 // func bgra4sd(p, q []byte)
 TEXT ·bgra4sd(SB),NOSPLIT,$0
 	MOVQ	p+0(FP), SI
-	MOVQ	len+8(FP), CX
+	MOVQ	p_len+8(FP), CX
 	MOVQ	q+24(FP), DI
 
-	ADDQ SI, CX
 loop:
-	CMPQ	SI, CX
-	JEQ	done
-
-	MOVD	0(SI), AX	// r g b a
+	CMPQ	CX, $4
+	JB	done
+	MOVL	0(SI), AX	// r g b a
 	BSWAPL AX   // a b g r 
 	RORL	$8, AX 	// b g r a 
-	MOVD	AX, (DI)
-
+	MOVL	AX, (DI)
 	ADDQ	$4, SI
 	ADDQ	$4, DI
+	SUBQ	$4, CX
 	JMP	loop
 done:
 	RET
@@ -0,0 +1,18 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+func init() {
+	supported["amd64.4"] = bgra4sd
+	if haveSSSE3() {
+		supported["ssse.16"] = bgra16sd
+	}
+	if haveAVX() {
+		supported["avx2.128"] = bgra128sd
+	}
+	if haveAVX2() {
+		supported["avx2.256"] = bgra256sd
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+// The NEON swizzlers are tested by TestNEON, but Swizzle only uses them when
+// built with the swizzleneon tag; see neon_arm64.go.
+
+func bgra64neon(p, q []byte) // swizzle_arm64.s:/bgra64neon/
+func bgra16neon(p, q []byte) // swizzle_arm64.s:/bgra16neon/
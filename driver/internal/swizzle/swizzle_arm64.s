@@ -0,0 +1,86 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// The VTBL indices that swap the R and B bytes of each of 4 pixels.
+DATA ·neon_swizzletab<>+0x00(SB)/8, $0x0704050603000102
+DATA ·neon_swizzletab<>+0x08(SB)/8, $0x0f0c0d0e0b08090a
+GLOBL ·neon_swizzletab<>(SB), (NOPTR+RODATA), $16
+
+// These convert blocks of as many bytes as they can, then the rest 4 bytes at
+// a time. They never read or write past the end of p or q, and p and q may be
+// the same slice.
+
+// func bgra64neon(p, q []byte)
+TEXT ·bgra64neon(SB),NOSPLIT,$0-48
+	MOVD	p+0(FP), R0
+	MOVD	p_len+8(FP), R2
+	MOVD	q+24(FP), R1
+	MOVD	$·neon_swizzletab<>(SB), R3
+	VLD1	(R3), [V31.B16]
+
+loop64:
+	CMP	$64, R2
+	BLT	loop16
+	VLD1.P	64(R0), [V0.B16, V1.B16, V2.B16, V3.B16]
+	VTBL	V31.B16, [V0.B16], V4.B16
+	VTBL	V31.B16, [V1.B16], V5.B16
+	VTBL	V31.B16, [V2.B16], V6.B16
+	VTBL	V31.B16, [V3.B16], V7.B16
+	VST1.P	[V4.B16, V5.B16, V6.B16, V7.B16], 64(R1)
+	SUB	$64, R2
+	B	loop64
+
+loop16:
+	CMP	$16, R2
+	BLT	loop4
+	VLD1.P	16(R0), [V0.B16]
+	VTBL	V31.B16, [V0.B16], V4.B16
+	VST1.P	[V4.B16], 16(R1)
+	SUB	$16, R2
+	B	loop16
+
+loop4:
+	CMP	$4, R2
+	BLT	done
+	MOVWU.P	4(R0), R4	// r g b a
+	REVW	R4, R4		// a b g r
+	RORW	$8, R4, R4	// b g r a
+	MOVWU.P	R4, 4(R1)
+	SUB	$4, R2
+	B	loop4
+
+done:
+	RET
+
+// func bgra16neon(p, q []byte)
+TEXT ·bgra16neon(SB),NOSPLIT,$0-48
+	MOVD	p+0(FP), R0
+	MOVD	p_len+8(FP), R2
+	MOVD	q+24(FP), R1
+	MOVD	$·neon_swizzletab<>(SB), R3
+	VLD1	(R3), [V31.B16]
+
+loop16:
+	CMP	$16, R2
+	BLT	loop4
+	VLD1.P	16(R0), [V0.B16]
+	VTBL	V31.B16, [V0.B16], V4.B16
+	VST1.P	[V4.B16], 16(R1)
+	SUB	$16, R2
+	B	loop16
+
+loop4:
+	CMP	$4, R2
+	BLT	done
+	MOVWU.P	4(R0), R4	// r g b a
+	REVW	R4, R4		// a b g r
+	RORW	$8, R4, R4	// b g r a
+	MOVWU.P	R4, 4(R1)
+	SUB	$4, R2
+	B	loop4
+
+done:
+	RET
@@ -0,0 +1,43 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package swizzle
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func init() {
+	supported["neon.16"] = bgra16neon
+	supported["neon.64"] = bgra64neon
+}
+
+// TestNEON tests that the NEON swizzlers match pureBGRA for random pixels,
+// lengths and offsets, both in place and not, and that they leave the bytes
+// past the end of p and q alone.
+func TestNEON(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for name, fn := range map[string]func(p, q []byte){"neon.16": bgra16neon, "neon.64": bgra64neon} {
+		for i := 0; i < 1000; i++ {
+			o := r.Intn(64)
+			n := r.Intn(1024) &^ 3
+			in := make([]byte, o+n+64)
+			r.Read(in)
+			want := append([]byte{}, in...)
+			pureBGRA(want[o:o+n], want[o:o+n])
+
+			q := append([]byte{}, in...)
+			fn(in[o:o+n], q[o:o+n])
+			if !bytes.Equal(q, want) {
+				t.Fatalf("%s: [%d:%d+%d]: have %x, want %x", name, o, o, n, q, want)
+			}
+			fn(in[o:o+n], in[o:o+n])
+			if !bytes.Equal(in, want) {
+				t.Fatalf("%s: [%d:%d+%d], in place: have %x, want %x", name, o, o, n, in, want)
+			}
+		}
+	}
+}
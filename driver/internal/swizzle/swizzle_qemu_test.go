@@ -0,0 +1,45 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build !arm64
+
+package swizzle
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestARM64 cross-compiles this package's tests for arm64, and runs them
+// under qemu-user, so that the NEON swizzlers are tested on other machines.
+// They are built with the swizzleneon tag, so that Swizzle uses them too.
+func TestARM64(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	qemu, err := exec.LookPath("qemu-aarch64")
+	if err != nil {
+		t.Skip("qemu-aarch64 not found")
+	}
+	dir, err := ioutil.TempDir("", "swizzle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bin := filepath.Join(dir, "swizzle.test")
+	build := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "test", "-c", "-tags", "swizzleneon", "-o", bin)
+	build.Env = append(os.Environ(), "GOARCH=arm64", "CGO_ENABLED=0")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go test -c: %v\n%s", err, out)
+	}
+	run := exec.Command(qemu, bin, "-test.run=Swizzle|BGRA|NEON|Kernels|Convert")
+	if out, err := run.CombinedOutput(); err != nil {
+		t.Fatalf("qemu-aarch64: %v\n%s", err, out)
+	}
+}
@@ -16,12 +16,10 @@ var (
 	rgbaslice = "abcdefghijklmnopqrstuvwxyz012345ABCDEFGHIJKLMNOPQRSTUVWXYZ6789@="
 	bgraslice = "cbadgfehkjilonmpsrqtwvux0zy14325CBADGFEHKJILONMPSRQTWVUX6ZY7@98="
 
+	// supported are the swizzlers that the CPU supports, by name. The
+	// per-architecture test files add to it.
 	supported = map[string]func(p, q []byte){
-		"pure":     pureBGRA,
-		"amd64.4":  bgra4sd,
-		"ssse.16":  bgra16sd,
-		"avx2.128": bgra128sd,
-		"avx2.256": bgra256sd,
+		"pure": pureBGRA,
 	}
 )
 
@@ -29,16 +27,6 @@ func TestMain(m *testing.M) {
 	const safe = 1024
 	rgbaslice = strings.Repeat(rgbaslice, safe)
 	bgraslice = strings.Repeat(bgraslice, safe)
-
-	if !haveAVX2() {
-		delete(supported, "avx2.256")
-	}
-	if !haveAVX() {
-		delete(supported, "avx2.128")
-	}
-	if !haveSSSE3() {
-		delete(supported, "ssse.16")
-	}
 	os.Exit(m.Run())
 }
 
@@ -59,15 +47,17 @@ func TestSwizzleOverlap(t *testing.T) {
 
 func testSwizzle1(t *testing.T, N int, distinct bool) {
 	t.Helper()
-	s := []byte(rgbaslice[:N])
-	d := s
-	if distinct {
-		d = make([]byte, N, N)
-	}
-	want := bgraslice[:N]
-	bgra256sd(s, d)
-	if string(d) != want {
-		t.Fatalf("have: %s\nwant: %s\n", d, want)
+	for name, fn := range supported {
+		s := []byte(rgbaslice[:N])
+		d := s
+		if distinct {
+			d = make([]byte, N, N)
+		}
+		want := bgraslice[:N]
+		fn(s, d)
+		if string(d) != want {
+			t.Fatalf("%s:\nhave: %s\nwant: %s\n", name, d, want)
+		}
 	}
 }
 
@@ -104,6 +94,40 @@ func TestBGRAShort(t *testing.T) {
 	}
 }
 
+func TestSwizzleTail(t *testing.T) {
+	// Lengths that are not whole blocks are swizzled up to, and not past,
+	// the end of p and q, by Swizzle and by every swizzler on its own.
+	fns := map[string]func(p, q []byte){"Swizzle": Swizzle}
+	for name, fn := range supported {
+		fns[name] = fn
+	}
+	for name, fn := range fns {
+		for n := 4; n <= 600; n += 4 {
+			in := rgbaslice[:n]
+			want := make([]byte, n)
+			pureBGRA([]byte(in), want)
+
+			p := []byte(in + strings.Repeat("-", 256))
+			q := make([]byte, n+256)
+			fn(p[:n], q[:n])
+			if string(q[:n]) != string(want) {
+				t.Fatalf("%s: len=%d: have %q, want %q", name, n, q[:n], want)
+			}
+			for i, c := range q[n:] {
+				if c != 0 {
+					t.Fatalf("%s: len=%d: wrote past the end, at %d", name, n, n+i)
+				}
+			}
+
+			// In place.
+			fn(p[:n], p[:n])
+			if string(p[:n]) != string(want) || string(p[n:]) != strings.Repeat("-", 256) {
+				t.Fatalf("%s: len=%d, in place: have %q, want %q", name, n, p[:n], want)
+			}
+		}
+	}
+}
+
 func TestBGRARandom(t *testing.T) {
 	r := rand.New(rand.NewSource(1))
 	var (
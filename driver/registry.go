@@ -0,0 +1,140 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/as/shiny/screen"
+)
+
+type registration struct {
+	name     string
+	priority int
+	probe    func() error
+	main     func(f func(screen.Screen))
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []registration
+)
+
+// Register makes a driver available to Main and MainWith under the given
+// name.
+//
+// The probe function reports whether the driver can be used on the running
+// system, for example whether a display server is reachable. It should be
+// cheap and must not leave resources behind. The main function runs the
+// application, as per the Main function of this package.
+//
+// When more than one driver probes successfully, Main prefers the one with
+// the highest priority. Register panics if the name is empty or is already
+// registered. It is typically called from an init function.
+func Register(name string, priority int, probe func() error, main func(f func(screen.Screen))) {
+	if name == "" {
+		panic("driver: Register called with an empty name")
+	}
+	if probe == nil || main == nil {
+		panic("driver: Register called with a nil function for " + name)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, r := range registry {
+		if r.name == name {
+			panic("driver: Register called twice for " + name)
+		}
+	}
+	registry = append(registry, registration{
+		name:     name,
+		priority: priority,
+		probe:    probe,
+		main:     main,
+	})
+}
+
+// Drivers returns the names of the registered drivers, in the order that
+// Main considers them.
+func Drivers() []string {
+	rs := registered()
+	names := make([]string, len(rs))
+	for i, r := range rs {
+		names[i] = r.name
+	}
+	return names
+}
+
+// registered returns a copy of the registry, sorted by decreasing priority.
+// Drivers with equal priority are kept in registration order.
+func registered() []registration {
+	registryMu.Lock()
+	rs := append([]registration(nil), registry...)
+	registryMu.Unlock()
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].priority > rs[j].priority
+	})
+	return rs
+}
+
+// choose returns the driver to run. If name is non-empty, only the driver
+// with that name is considered. Otherwise, it is the highest priority driver
+// whose probe succeeds.
+func choose(name string) (registration, error) {
+	rs := registered()
+	if len(rs) == 0 {
+		return registration{}, errors.New("driver: no driver for accessing a screen")
+	}
+
+	if name != "" {
+		for _, r := range rs {
+			if r.name != name {
+				continue
+			}
+			if err := r.probe(); err != nil {
+				return registration{}, fmt.Errorf("driver: %s: %v", name, err)
+			}
+			return r, nil
+		}
+		return registration{}, fmt.Errorf("driver: unknown driver %q (have %s)", name, strings.Join(Drivers(), ", "))
+	}
+
+	var errs probeErrors
+	for _, r := range rs {
+		err := r.probe()
+		if err == nil {
+			return r, nil
+		}
+		errs = append(errs, probeError{r.name, err})
+	}
+	return registration{}, errs
+}
+
+type probeError struct {
+	name string
+	err  error
+}
+
+// probeErrors is the error returned when every registered driver fails to
+// probe. It reports all of the failures, not just the first.
+type probeErrors []probeError
+
+func (e probeErrors) Error() string {
+	buf := []byte("driver: no usable driver")
+	for i, pe := range e {
+		if i == 0 {
+			buf = append(buf, ": "...)
+		} else {
+			buf = append(buf, "; "...)
+		}
+		buf = append(buf, pe.name...)
+		buf = append(buf, ": "...)
+		buf = append(buf, pe.err.Error()...)
+	}
+	return string(buf)
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package driver
+
+import (
+	"errors"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/as/shiny/screen"
+)
+
+func withRegistry(t *testing.T, f func()) {
+	t.Helper()
+	registryMu.Lock()
+	saved := registry
+	registry = nil
+	registryMu.Unlock()
+	defer func() {
+		registryMu.Lock()
+		registry = saved
+		registryMu.Unlock()
+	}()
+	f()
+}
+
+func TestChoose(t *testing.T) {
+	withRegistry(t, func() {
+		var ran string
+		reg := func(name string, priority int, probeErr error) {
+			Register(name, priority, func() error { return probeErr }, func(func(screen.Screen)) { ran = name })
+		}
+		reg("low", 1, nil)
+		reg("broken", 30, errors.New("no display"))
+		reg("high", 20, nil)
+
+		if got, want := strings.Join(Drivers(), ","), "broken,high,low"; got != want {
+			t.Errorf("Drivers: got %q, want %q", got, want)
+		}
+
+		testCases := []struct {
+			name, want, wantErr string
+		}{
+			{"", "high", ""},
+			{"low", "low", ""},
+			{"broken", "", "driver: broken: no display"},
+			{"missing", "", `driver: unknown driver "missing" (have broken, high, low)`},
+		}
+		for _, tc := range testCases {
+			ran = ""
+			d, err := choose(tc.name)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Errorf("choose(%q): got error %v, want %q", tc.name, err, tc.wantErr)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("choose(%q): %v", tc.name, err)
+				continue
+			}
+			d.main(nil)
+			if ran != tc.want {
+				t.Errorf("choose(%q): ran %q, want %q", tc.name, ran, tc.want)
+			}
+		}
+	})
+}
+
+func TestChooseAllFail(t *testing.T) {
+	withRegistry(t, func() {
+		Register("a", 2, func() error { return errors.New("error a") }, func(func(screen.Screen)) {})
+		Register("b", 1, func() error { return errors.New("error b") }, func(func(screen.Screen)) {})
+
+		_, err := choose("")
+		want := "driver: no usable driver: a: error a; b: error b"
+		if err == nil || err.Error() != want {
+			t.Fatalf("got %v, want %q", err, want)
+		}
+
+		var s screen.Screen
+		MainWith("", func(x screen.Screen) { s = x })
+		if _, err := s.NewTexture(image.Point{1, 1}, nil); err == nil || err.Error() != want {
+			t.Fatalf("errscreen: got %v, want %q", err, want)
+		}
+	})
+}
+
+func TestChooseEmpty(t *testing.T) {
+	withRegistry(t, func() {
+		if _, err := choose(""); err == nil {
+			t.Fatal("got nil error, want non-nil")
+		}
+	})
+}
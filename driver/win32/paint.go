@@ -3,9 +3,11 @@
 package win32
 
 import (
+	"image"
 	"syscall"
 
 	"github.com/as/shiny/event/paint"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/screen"
 )
 
@@ -14,6 +16,16 @@ type Paint = paint.Event
 var PaintEvent func(hwnd syscall.Handle, e paint.Event)
 
 func sendPaint(hwnd syscall.Handle, uMsg uint32, wParam, lParam uintptr) (lResult uintptr) {
-	screen.SendPaint(Paint{})
+	// The update rectangle must be read before DefWindowProc validates it.
+	var r Rectangle
+	e := Paint{External: true}
+	if GetUpdateRect(hwnd, &r, false) {
+		e.Damage = imageutil.NewRegion(image.Rect(int(r.Min.X), int(r.Min.Y), int(r.Max.X), int(r.Max.Y)))
+	}
+	if PaintEvent != nil {
+		PaintEvent(hwnd, e)
+	} else {
+		screen.SendPaint(e)
+	}
 	return DefWindowProc(hwnd, uMsg, wParam, lParam)
 }
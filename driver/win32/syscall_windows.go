@@ -26,6 +26,7 @@ package win32
 //sys	DispatchMessage(msg *_MSG) (ret int32) = user32.DispatchMessageW
 //sys	GetClientRect(hwnd syscall.Handle, rect *_RECT) (err error) = user32.GetClientRect
 //sys	GetWindowRect(hwnd syscall.Handle, rect *_RECT) (err error) = user32.GetWindowRect
+//sys	GetUpdateRect(hwnd syscall.Handle, rect *_RECT, erase bool) (ok bool) = user32.GetUpdateRect
 //sys   GetKeyboardLayout(threadID uint32) (locale syscall.Handle) = user32.GetKeyboardLayout
 //sys   GetKeyboardState(lpKeyState *byte) (err error) = user32.GetKeyboardState
 //sys	GetKeyState(virtkey int32) (keystatus int16) = user32.GetKeyState
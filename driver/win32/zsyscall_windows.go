@@ -68,6 +68,7 @@ var (
 	procDispatchMessageW  = moduser32.NewProc("DispatchMessageW")
 	procGetClientRect     = moduser32.NewProc("GetClientRect")
 	procGetWindowRect     = moduser32.NewProc("GetWindowRect")
+	procGetUpdateRect     = moduser32.NewProc("GetUpdateRect")
 	procGetKeyboardLayout = moduser32.NewProc("GetKeyboardLayout")
 	procGetKeyboardState  = moduser32.NewProc("GetKeyboardState")
 	procGetKeyState       = moduser32.NewProc("GetKeyState")
@@ -227,6 +228,18 @@ func GetWindowRect(hwnd syscall.Handle, rect *Rectangle) (err error) {
 	return
 }
 
+func GetUpdateRect(hwnd syscall.Handle, rect *Rectangle, erase bool) (ok bool) {
+	var _p0 uint32
+	if erase {
+		_p0 = 1
+	} else {
+		_p0 = 0
+	}
+	r0, _, _ := syscall.Syscall(procGetUpdateRect.Addr(), 3, uintptr(hwnd), uintptr(unsafe.Pointer(rect)), uintptr(_p0))
+	ok = r0 != 0
+	return
+}
+
 func GetKeyboardLayout(threadID uint32) (locale syscall.Handle) {
 	r0, _, _ := syscall.Syscall(procGetKeyboardLayout.Addr(), 1, uintptr(threadID), 0, 0)
 	locale = syscall.Handle(r0)
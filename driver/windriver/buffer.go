@@ -11,14 +11,21 @@ import (
 	"image/draw"
 	"syscall"
 
+	"github.com/as/shiny/driver/internal/swizzle"
 	"github.com/as/shiny/driver/win32"
+	"github.com/as/shiny/screen"
 )
 
 type bufferImpl struct {
-	hbitmap   syscall.Handle
-	buf, buf2 []byte
-	rgba      image.RGBA
-	size      image.Point
+	hbitmap syscall.Handle
+	// buf is the DIB section's memory, in the BGRA layout that GDI uses. For
+	// FormatBGRA, it holds img's pixels, and for the other formats, img is
+	// converted to and from it.
+	buf    []byte
+	rgba   image.RGBA
+	img    draw.Image
+	format screen.Format
+	size   image.Point
 }
 
 //func (b *bufferImpl) Resize(size image.Point) *bufferImpl       {
@@ -35,18 +42,37 @@ type bufferImpl struct {
 //}
 func (b *bufferImpl) Size() image.Point       { return b.size }
 func (b *bufferImpl) Bounds() image.Rectangle { return image.Rectangle{Max: b.size} }
-func (b *bufferImpl) RGBA() *image.RGBA       { return &b.rgba }
+func (b *bufferImpl) Image() draw.Image       { return b.img }
+func (b *bufferImpl) Format() screen.Format   { return b.format }
+
+func (b *bufferImpl) RGBA() *image.RGBA {
+	if b.format != screen.FormatRGBA {
+		return nil
+	}
+	return &b.rgba
+}
+
 func (b *bufferImpl) Release() {
 	go b.cleanUp()
 }
 
 func (b *bufferImpl) cleanUp() {
-	if b.rgba.Pix != nil {
-		b.rgba.Pix = nil
+	if b.buf != nil {
+		b.buf = nil
 		win32.DeleteObject(b.hbitmap)
 	}
 }
 
+// convert converts the pixels in sr of b's image to the BGRA layout of
+// b.buf, unless they are already in it.
+func (b *bufferImpl) convert(sr image.Rectangle) {
+	sr = sr.Intersect(b.Bounds())
+	if b.format == screen.FormatBGRA || sr.Empty() {
+		return
+	}
+	swizzle.Convert(b.buf, 4*b.size.X, sr.Min, b.img, sr, true)
+}
+
 func (b *bufferImpl) blitToDC(dc syscall.Handle, dp image.Point, sr image.Rectangle) error {
 	return copyBitmapToDC(dc, sr.Add(dp.Sub(sr.Min)), b.hbitmap, sr, draw.Src)
 }
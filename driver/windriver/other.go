@@ -21,5 +21,11 @@ import (
 // specific libraries require being on 'the main thread'. It returns when f
 // returns.
 func Main(f func(screen.Screen)) {
-	f(errscreen.Stub(fmt.Errorf("windriver: unsupported GOOS/GOARCH %s/%s", runtime.GOOS, runtime.GOARCH)))
+	f(errscreen.Stub(Probe()))
+}
+
+// Probe reports whether this driver can be used. It always fails on
+// non-Windows systems.
+func Probe() error {
+	return fmt.Errorf("windriver: unsupported GOOS/GOARCH %s/%s", runtime.GOOS, runtime.GOARCH)
 }
@@ -12,6 +12,7 @@ import (
 	"unsafe"
 
 	"github.com/as/shiny/driver/win32"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/screen"
 )
 
@@ -21,7 +22,7 @@ type screenImpl struct {
 	windows *windowImpl
 }
 
-func (*screenImpl) NewBuffer(size image.Point) (screen.Buffer, error) {
+func (*screenImpl) NewBuffer(size image.Point, opts *screen.NewBufferOptions) (screen.Buffer, error) {
 	const (
 		maxInt32  = 0x7fffffff
 		maxBufLen = maxInt32
@@ -29,6 +30,10 @@ func (*screenImpl) NewBuffer(size image.Point) (screen.Buffer, error) {
 	if size.X < 0 || size.X > maxInt32 || size.Y < 0 || size.Y > maxInt32 || int64(size.X)*int64(size.Y)*4 > maxBufLen {
 		return nil, fmt.Errorf("windriver: invalid buffer size %v", size)
 	}
+	format := opts.GetFormat()
+	if !format.Valid() {
+		return nil, fmt.Errorf("windriver: invalid buffer format %v", format)
+	}
 
 	hbitmap, bitvalues, err := mkbitmap(size)
 	if err != nil {
@@ -37,21 +42,29 @@ func (*screenImpl) NewBuffer(size image.Point) (screen.Buffer, error) {
 	bufLen := 4 * size.X * size.Y
 	array := (*[maxBufLen]byte)(unsafe.Pointer(bitvalues))
 	buf := (*array)[:bufLen:bufLen]
-	buf2 := make([]byte, bufLen, bufLen)
-	return &bufferImpl{
+	b := &bufferImpl{
 		hbitmap: hbitmap,
 		buf:     buf,
-		buf2:    buf2,
-		rgba: image.RGBA{
-			Pix:    buf2,
+		format:  format,
+		size:    size,
+	}
+	switch format {
+	case screen.FormatBGRA:
+		b.img = &imageutil.BGRA{
+			Pix:    buf,
 			Stride: 4 * size.X,
 			Rect:   image.Rectangle{Max: size},
-		},
-		size: size,
-	}, nil
+		}
+	case screen.FormatRGBA:
+		b.rgba = *image.NewRGBA(image.Rectangle{Max: size})
+		b.img = &b.rgba
+	default:
+		b.img = format.NewImage(image.Rectangle{Max: size})
+	}
+	return b, nil
 }
 
-func (*screenImpl) NewTexture(size image.Point) (screen.Texture, error) {
+func (*screenImpl) NewTexture(size image.Point, opts *screen.NewTextureOptions) (screen.Texture, error) {
 	return newTexture(size)
 }
 
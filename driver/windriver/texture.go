@@ -7,8 +7,9 @@
 package windriver
 
 import (
-	"github.com/as/shiny/driver/internal/swizzle"
+	"github.com/as/shiny/driver/internal/drawer"
 	"github.com/as/shiny/driver/win32"
+	"github.com/as/shiny/math/f64"
 	"github.com/as/shiny/screen"
 	"image"
 	"image/color"
@@ -97,6 +98,40 @@ func (t *textureImpl) Fill(r image.Rectangle, c color.Color, op draw.Op) {
 	}
 }
 
+func (t *textureImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	if op != draw.Src && op != draw.Over {
+		// TODO:
+		return
+	}
+	err := t.update(func(dc syscall.Handle) error {
+		return drawWindow(dc, src2dst, src.(*textureImpl).bitmap, sr, op)
+	})
+	if err != nil {
+		panic(err) // TODO handle error
+	}
+}
+
+func (t *textureImpl) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	if op != draw.Src && op != draw.Over {
+		// TODO:
+		return
+	}
+	err := t.update(func(dc syscall.Handle) error {
+		return drawWindow(dc, src2dst, src, sr, op)
+	})
+	if err != nil {
+		panic(err) // TODO handle error
+	}
+}
+
+func (t *textureImpl) Copy(dp image.Point, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	drawer.Copy(t, dp, src, sr, op, opts)
+}
+
+func (t *textureImpl) Scale(dr image.Rectangle, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	drawer.Scale(t, dr, src, sr, op, opts)
+}
+
 func (t *textureImpl) Release() {
 	if err := t.release(); err != nil {
 		panic(err) // TODO handle error
@@ -124,12 +159,23 @@ func (t *textureImpl) Size() image.Point {
 }
 
 func (t *textureImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
-	b := src.(*bufferImpl).buf
-	b2 := src.(*bufferImpl).buf2
-	swizzle.Swizzle(b2, b)
+	src.(*bufferImpl).convert(sr)
 	src.(*bufferImpl).blitToDC(t.dc, dp, sr)
 }
 
+// GenerateMipmaps does nothing, as GDI has no mipmaps, and NewTexture ignores
+// NewTextureOptions.Mipmaps.
+func (t *textureImpl) GenerateMipmaps() {}
+
+func (t *textureImpl) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
+	err := t.update(func(dc syscall.Handle) error {
+		return copyDCToBuffer(dst.(*bufferImpl), dp, dc, t.Bounds(), sr, false)
+	})
+	if err != nil {
+		panic(err) // TODO handle error
+	}
+}
+
 // update prepares texture t for update and executes f over texture device
 // context dc in a safe manner.
 func (t *textureImpl) update(f func(dc syscall.Handle) error) (retErr error) {
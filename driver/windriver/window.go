@@ -11,18 +11,19 @@ import (
 
 	"github.com/as/shiny/driver/internal/drawer"
 
-	"github.com/as/shiny/driver/internal/swizzle"
 	"github.com/as/shiny/driver/win32"
 	"github.com/as/shiny/math/f64"
 	"github.com/as/shiny/screen"
 
 	"github.com/as/shiny/event/lifecycle"
+	"github.com/as/shiny/event/paint"
 
 	"image"
 	"image/color"
 	"image/draw"
 	"math"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"github.com/as/shiny/event/size"
@@ -34,6 +35,9 @@ type windowImpl struct {
 	//TODO(as): device should be here
 	sz             size.Event
 	lifecycleStage lifecycle.Stage
+
+	// clock numbers the window's frames.
+	clock screen.FrameClock
 }
 
 func (w *windowImpl) Device() *screen.Device {
@@ -45,9 +49,7 @@ func (w *windowImpl) Release() {
 }
 
 func (w *windowImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
-	b := src.(*bufferImpl).buf
-	b2 := src.(*bufferImpl).buf2
-	swizzle.Swizzle(b2, b)
+	src.(*bufferImpl).convert(sr)
 	w.execCmd(&cmd{
 		id:     cmdUpload,
 		dp:     dp,
@@ -56,6 +58,15 @@ func (w *windowImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangl
 	})
 }
 
+func (w *windowImpl) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
+	w.execCmd(&cmd{
+		id:     cmdDownload,
+		dp:     dp,
+		buffer: dst.(*bufferImpl),
+		sr:     sr,
+	})
+}
+
 func (w *windowImpl) Fill(dr image.Rectangle, src color.Color, op draw.Op) {
 	w.execCmd(&cmd{
 		id:    cmdFill,
@@ -157,12 +168,14 @@ func (w *windowImpl) Scale(dr image.Rectangle, src screen.Texture, sr image.Rect
 }
 
 func (w *windowImpl) Publish() screen.PublishResult {
+	w.clock.Published(time.Now())
 	return screen.PublishResult{}
 }
 
 func init() {
 	win32.LifecycleEvent = lifecycleEvent
 	win32.SizeEvent = sizeEvent
+	win32.PaintEvent = paintEvent
 }
 
 func lifecycleEvent(hwnd syscall.Handle, to lifecycle.Stage) {
@@ -181,6 +194,16 @@ func lifecycleEvent(hwnd syscall.Handle, to lifecycle.Stage) {
 	w.lifecycleStage = to
 }
 
+func paintEvent(hwnd syscall.Handle, e paint.Event) {
+	w := theScreen.windows
+	if w == nil {
+		// The window is painted while it is being created.
+		screen.SendPaint(e)
+		return
+	}
+	w.clock.SendPaint(e)
+}
+
 func sizeEvent(hwnd syscall.Handle, e size.Event) {
 	w := theScreen.windows
 	w.Device().Size <- e
@@ -210,6 +233,7 @@ const (
 	cmdFill
 	cmdUpload
 	cmdDrawUniform
+	cmdDownload
 )
 
 var msgCmd = win32.AddWindowMsg(handleCmd)
@@ -236,6 +260,13 @@ func handleCmd(hwnd syscall.Handle, uMsg uint32, wParam, lParam uintptr) {
 		// TODO: adjust if dp is outside dst bounds, or sr is outside buffer bounds.
 		dr := c.sr.Add(c.dp.Sub(c.sr.Min))
 		c.err = copyBitmapToDC(dc, dr, c.buffer.hbitmap, c.sr, draw.Src)
+	case cmdDownload:
+		var cr win32.Rectangle
+		if c.err = win32.GetClientRect(hwnd, &cr); c.err != nil {
+			return
+		}
+		bounds := image.Rect(0, 0, int(cr.Dx()), int(cr.Dy()))
+		c.err = copyDCToBuffer(c.buffer, c.dp, dc, bounds, c.sr, true)
 	default:
 		c.err = fmt.Errorf("unknown command id=%d", c.id)
 	}
@@ -14,7 +14,9 @@ import (
 	"syscall"
 	"unsafe"
 
+	"github.com/as/shiny/driver/internal/swizzle"
 	"github.com/as/shiny/driver/win32"
+	"github.com/as/shiny/screen"
 )
 
 func mkbitmap(size image.Point) (syscall.Handle, *byte, error) {
@@ -101,3 +103,50 @@ func fill(dc syscall.Handle, dr image.Rectangle, c color.Color, op draw.Op) erro
 
 	return copyBitmapToDC(dc, dr, bitmap, sr, draw.Over)
 }
+
+// copyDCToBuffer is the inverse of copyBitmapToDC with draw.Src. It copies
+// the pixels in sr of dc, whose bounds are given, to b such that sr.Min maps
+// to dp, and converts them to b's format. If opaque is true, dc has no
+// meaningful alpha channel and the copied pixels are made fully opaque.
+func copyDCToBuffer(b *bufferImpl, dp image.Point, dc syscall.Handle, bounds, sr image.Rectangle, opaque bool) error {
+	delta := dp.Sub(sr.Min)
+	sr = sr.Intersect(bounds).Intersect(b.Bounds().Sub(delta))
+	if sr.Empty() {
+		return nil
+	}
+	dp = sr.Min.Add(delta)
+
+	memdc, err := win32.CreateCompatibleDC(dc)
+	if err != nil {
+		return err
+	}
+	defer win32.DeleteDC(memdc)
+
+	_, err = win32.SelectObject(memdc, b.hbitmap)
+	if err != nil {
+		return err
+	}
+	err = win32.BitBlt(memdc, int32(dp.X), int32(dp.Y), int32(sr.Dx()), int32(sr.Dy()),
+		dc, int32(sr.Min.X), int32(sr.Min.Y), win32.SrcCopy)
+	if err != nil {
+		return err
+	}
+
+	width := 4 * sr.Dx()
+	if opaque {
+		for y := dp.Y; y < dp.Y+sr.Dy(); y++ {
+			i := 4 * (y*b.size.X + dp.X)
+			row := b.buf[i : i+width]
+			for j := 3; j < len(row); j += 4 {
+				row[j] = 0xff
+			}
+		}
+	}
+	// Only convert the rows that were written, so that the rest of b's
+	// image is left unchanged.
+	if b.format != screen.FormatBGRA {
+		dr := sr.Add(dp.Sub(sr.Min))
+		swizzle.Store(b.img, dr, b.buf[4*(dp.Y*b.size.X+dp.X):], 4*b.size.X, true)
+	}
+	return nil
+}
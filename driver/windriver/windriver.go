@@ -23,3 +23,9 @@ func Main(f func(screen.Screen)) {
 		f(errscreen.Stub(err))
 	}
 }
+
+// Probe reports whether this driver can be used. The Windows driver only
+// relies on GDI, which is always available.
+func Probe() error {
+	return nil
+}
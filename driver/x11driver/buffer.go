@@ -9,24 +9,38 @@ import (
 	"image/color"
 	"image/draw"
 	"log"
+	"sync"
 	"unsafe"
 
-	"github.com/BurntSushi/xgb"
 	"github.com/BurntSushi/xgb/render"
 	"github.com/BurntSushi/xgb/shm"
 	"github.com/BurntSushi/xgb/xproto"
-	//	"github.com/as/shiny/driver/internal/swizzle"
+	"github.com/as/shiny/driver/internal/swizzle"
+	"github.com/as/shiny/screen"
 )
 
 type bufferImpl struct {
 	s *screenImpl
 
-	addr unsafe.Pointer
-	buf  []byte
-	rgba image.RGBA
-	size image.Point
-	xs   shm.Seg
+	// buf is the shared memory that the server reads and writes, in the BGRA
+	// layout that it presumes. For FormatBGRA, it holds img's pixels, and
+	// for the other formats, img is converted to and from it.
+	addr   unsafe.Pointer
+	buf    []byte
+	rgba   image.RGBA
+	img    draw.Image
+	format screen.Format
+	size   image.Point
+	xs     shm.Seg
 
+	// staged bounds the pixels of buf that were converted from img by
+	// uploads that the server may not have read yet.
+	staged image.Rectangle
+
+	// mu protects nUpload and released. nUpload is the number of uploads
+	// that the server has not completed yet. The shared memory is only
+	// cleaned up once b is released and there are none.
+	mu       sync.Mutex
 	nUpload  uint32
 	released bool
 }
@@ -34,13 +48,25 @@ type bufferImpl struct {
 func (b *bufferImpl) degenerate() bool        { return b.size.X == 0 || b.size.Y == 0 }
 func (b *bufferImpl) Size() image.Point       { return b.size }
 func (b *bufferImpl) Bounds() image.Rectangle { return image.Rectangle{Max: b.size} }
-func (b *bufferImpl) RGBA() *image.RGBA       { return &b.rgba }
+func (b *bufferImpl) Image() draw.Image       { return b.img }
+func (b *bufferImpl) Format() screen.Format   { return b.format }
+
+func (b *bufferImpl) RGBA() *image.RGBA {
+	if b.format != screen.FormatRGBA {
+		return nil
+	}
+	return &b.rgba
+}
 
 func (b *bufferImpl) Release() {
-	if !b.released && b.nUpload == 0 {
+	b.mu.Lock()
+	cleanUp := !b.released && b.nUpload == 0
+	b.released = true
+	b.mu.Unlock()
+
+	if cleanUp {
 		go b.cleanUp()
 	}
-	b.released = true
 }
 
 func (b *bufferImpl) cleanUp() {
@@ -57,14 +83,38 @@ func (b *bufferImpl) cleanUp() {
 	}
 }
 
-func (b *bufferImpl) upload(xd xproto.Drawable, xg xproto.Gcontext, depth uint8, dp image.Point, sr image.Rectangle) {
+// upload copies the pixels in sr of b to xd, a drawable with the given depth,
+// such that sr.Min maps to dp. It returns a channel that is closed once the
+// server has read the pixels, as per its SHM completion event.
+func (b *bufferImpl) upload(xd xproto.Drawable, xg xproto.Gcontext, depth uint8, dp image.Point, sr image.Rectangle) <-chan struct{} {
 	originalSRMin := sr.Min
 	sr = sr.Intersect(b.Bounds())
 	if sr.Empty() {
-		return
+		return screen.Closed
 	}
 	dp = dp.Add(sr.Min.Sub(originalSRMin))
-	shm.PutImage(
+	if b.format != screen.FormatBGRA {
+		if sr.Overlaps(b.staged) {
+			// Wait for the server to read the previous uploads' pixels,
+			// before overwriting them.
+			b.s.xc.Sync()
+			b.staged = image.Rectangle{}
+		}
+		swizzle.Convert(b.buf, 4*b.size.X, sr.Min, b.img, sr, true)
+		b.staged = b.staged.Union(sr)
+	}
+
+	b.mu.Lock()
+	b.nUpload++
+	b.mu.Unlock()
+
+	// The completion event may be handled before the upload is recorded
+	// below, so handleCompletions waits for the pending uploads.
+	b.s.mu.Lock()
+	b.s.nPendingUploads++
+	b.s.mu.Unlock()
+
+	cookie := shm.PutImage(
 		b.s.xc, xd, xg,
 		uint16(b.size.X), uint16(b.size.Y), // TotalWidth, TotalHeight,
 		uint16(sr.Min.X), uint16(sr.Min.Y), // SrcX, SrcY,
@@ -73,9 +123,39 @@ func (b *bufferImpl) upload(xd xproto.Drawable, xg xproto.Gcontext, depth uint8,
 		depth, xproto.ImageFormatZPixmap,
 		1, b.xs, 0, // 1 means send a completion event, 0 means a zero offset.
 	)
+
+	done := make(chan struct{})
+	b.s.mu.Lock()
+	b.s.uploads[cookie.Sequence] = upload{b: b, done: done}
+	b.s.nPendingUploads--
+	b.s.handleCompletions()
+	b.s.mu.Unlock()
+	return done
+}
+
+// postUpload is called once the server has completed an upload of b. It
+// cleans up b if it was released during the upload.
+func (b *bufferImpl) postUpload() {
+	b.mu.Lock()
+	b.nUpload--
+	cleanUp := b.released && b.nUpload == 0
+	b.mu.Unlock()
+
+	if cleanUp {
+		go b.cleanUp()
+	}
+}
+
+// wait waits for an upload of b to complete, if the server reads b's pixels
+// directly, as for FormatBGRA. The other formats are converted by upload, so
+// that their pixels may be modified as soon as it returns.
+func (b *bufferImpl) wait(done <-chan struct{}) {
+	if b.format == screen.FormatBGRA {
+		<-done
+	}
 }
 
-func fill(xc *xgb.Conn, xp render.Picture, dr image.Rectangle, src color.Color, op draw.Op) {
+func fill(s *screenImpl, xp render.Picture, dr image.Rectangle, src color.Color, op draw.Op) {
 	r, g, b, a := src.RGBA()
 	c := render.Color{
 		Red:   uint16(r),
@@ -91,10 +171,64 @@ func fill(xc *xgb.Conn, xp render.Picture, dr image.Rectangle, src color.Color,
 	if dx < 0 || 0xffff < dx || dy < 0 || 0xffff < dy {
 		return
 	}
-	render.FillRectangles(xc, renderOp(op), xp, c, []xproto.Rectangle{{
+	render.FillRectangles(s.xc, s.renderOp(op), xp, c, []xproto.Rectangle{{
 		X:      int16(x),
 		Y:      int16(y),
 		Width:  uint16(dx),
 		Height: uint16(dy),
 	}})
 }
+
+// download is the inverse of upload. It copies the pixels in sr of xd, a
+// drawable with the given bounds and depth, to b such that sr.Min maps to dp.
+// The server's bytes are converted from BGRA to b's format, if it differs.
+func (b *bufferImpl) download(xd xproto.Drawable, bounds image.Rectangle, depth uint8, sr image.Rectangle, dp image.Point) {
+	delta := dp.Sub(sr.Min)
+	sr = sr.Intersect(bounds).Intersect(b.Bounds().Sub(delta))
+	if sr.Empty() {
+		return
+	}
+	dp = sr.Min.Add(delta)
+
+	// The server writes the image contiguously, starting at the given offset
+	// into the shared memory segment. Unless sr spans the full width of b,
+	// that doesn't match b's stride, so each row is a separate request.
+	stride := 4 * b.size.X
+	rows, height := sr.Dy(), 1
+	if sr.Dx() == b.size.X {
+		rows, height = 1, sr.Dy()
+	}
+	cookies := make([]shm.GetImageCookie, rows)
+	for i := range cookies {
+		cookies[i] = shm.GetImage(
+			b.s.xc, xd,
+			int16(sr.Min.X), int16(sr.Min.Y+i), // X, Y,
+			uint16(sr.Dx()), uint16(height), // Width, Height,
+			0xffffffff, xproto.ImageFormatZPixmap, // PlaneMask, Format,
+			b.xs, uint32((dp.Y+i)*stride+4*dp.X), // Shmseg, Offset.
+		)
+	}
+	for _, c := range cookies {
+		if _, err := c.Reply(); err != nil {
+			log.Printf("x11driver: shm.GetImage: %v", err)
+			return
+		}
+	}
+
+	b.staged = image.Rectangle{}
+
+	if depth != textureDepth {
+		// A drawable without an alpha channel leaves the fourth byte of each
+		// pixel undefined. Such pixels are opaque.
+		for y := dp.Y; y < dp.Y+sr.Dy(); y++ {
+			i := y*stride + 4*dp.X
+			for x := 0; x < sr.Dx(); x, i = x+1, i+4 {
+				b.buf[i+3] = 0xff
+			}
+		}
+	}
+	if b.format != screen.FormatBGRA {
+		dr := sr.Add(dp.Sub(sr.Min))
+		swizzle.Store(b.img, dr, b.buf[dp.Y*stride+4*dp.X:], stride, true)
+	}
+}
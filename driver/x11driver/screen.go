@@ -21,7 +21,9 @@ import (
 	"github.com/as/shiny/event/key"
 	"github.com/as/shiny/event/lifecycle"
 	"github.com/as/shiny/event/mouse"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/math/fixed"
 	"github.com/as/shiny/screen"
 	//	"github.com/as/shiny/event/paint"
 )
@@ -42,9 +44,14 @@ type screenImpl struct {
 	atomWMTakeFocus    xproto.Atom
 
 	pixelsPerPt  float32
+	pictformat8  render.Pictformat
 	pictformat24 render.Pictformat
 	pictformat32 render.Pictformat
 
+	// blendModes is whether the server's Render extension has the
+	// separable blend modes, such as PictOpMultiply, added in version 0.11.
+	blendModes bool
+
 	// window32 and its related X11 resources is an unmapped window so that we
 	// have a depth-32 window to create depth-32 pixmaps from, i.e. pixmaps
 	// with an alpha channel. The root window isn't guaranteed to be depth-32.
@@ -60,7 +67,7 @@ type screenImpl struct {
 
 	mu              sync.Mutex
 	buffers         map[shm.Seg]*bufferImpl
-	uploads         map[uint16]chan struct{}
+	uploads         map[uint16]upload
 	windowX         xproto.Window
 	windowImp       *windowImpl
 	nPendingUploads int
@@ -72,7 +79,7 @@ func newScreenImpl(xc *xgb.Conn) (*screenImpl, error) {
 		xc:      xc,
 		xsi:     xproto.Setup(xc).DefaultScreen(xc),
 		buffers: map[shm.Seg]*bufferImpl{},
-		uploads: map[uint16]chan struct{}{},
+		uploads: map[uint16]upload{},
 		//		windows: map[xproto.Window]*windowImpl{},
 	}
 	if err := s.initAtoms(); err != nil {
@@ -90,6 +97,9 @@ func newScreenImpl(xc *xgb.Conn) (*screenImpl, error) {
 	if err := s.initPictformats(); err != nil {
 		return nil, err
 	}
+	if err := s.initRenderVersion(); err != nil {
+		return nil, err
+	}
 	if err := s.initWindow32(); err != nil {
 		return nil, err
 	}
@@ -145,9 +155,7 @@ func (s *screenImpl) run() {
 		case xproto.ConfigureNotifyEvent:
 			w.handleConfigureNotify(ev)
 		case xproto.ExposeEvent:
-			if ev.Count == 0 { // TODO(as)
-				w.handleExpose()
-			}
+			w.handleExpose(ev)
 		case xproto.FocusInEvent:
 			screen.SendLifecycle(lifecycle.Event{To: lifecycle.StageFocused}) // TODO(as)
 		case xproto.FocusOutEvent:
@@ -170,20 +178,27 @@ func (s *screenImpl) findWindow(key xproto.Window) *windowImpl {
 	return s.windowImp
 }
 
+// upload is an upload of a Buffer that the server has not completed, by the
+// sequence number of its request.
+type upload struct {
+	b    *bufferImpl
+	done chan struct{}
+}
+
 // handleCompletions must only be called while holding s.mu.
 func (s *screenImpl) handleCompletions() {
-	return
 	if s.nPendingUploads != 0 {
 		return
 	}
 	for _, ck := range s.completionKeys {
-		completion, ok := s.uploads[ck]
+		u, ok := s.uploads[ck]
 		if !ok {
 			log.Printf("x11driver: no matching upload for a SHM completion event")
 			continue
 		}
 		delete(s.uploads, ck)
-		close(completion)
+		u.b.postUpload()
+		close(u.done)
 	}
 	s.completionKeys = s.completionKeys[:0]
 }
@@ -193,7 +208,7 @@ const (
 	maxShmSize = 0x10000000 // 268,435,456 bytes.
 )
 
-func (s *screenImpl) NewBuffer(size image.Point) (retBuf screen.Buffer, retErr error) {
+func (s *screenImpl) NewBuffer(size image.Point, opts *screen.NewBufferOptions) (retBuf screen.Buffer, retErr error) {
 	// TODO: detect if the X11 server or connection cannot support SHM pixmaps,
 	// and fall back to regular pixmaps.
 
@@ -201,14 +216,15 @@ func (s *screenImpl) NewBuffer(size image.Point) (retBuf screen.Buffer, retErr e
 	if w < 0 || maxShmSide < w || h < 0 || maxShmSide < h || maxShmSize < 4*w*h {
 		return nil, fmt.Errorf("x11driver: invalid buffer size %v", size)
 	}
+	format := opts.GetFormat()
+	if !format.Valid() {
+		return nil, fmt.Errorf("x11driver: invalid buffer format %v", format)
+	}
 
 	b := &bufferImpl{
-		s: s,
-		rgba: image.RGBA{
-			Stride: 4 * size.X,
-			Rect:   image.Rectangle{Max: size},
-		},
-		size: size,
+		s:      s,
+		format: format,
+		size:   size,
 	}
 
 	if size.X == 0 || size.Y == 0 {
@@ -232,7 +248,6 @@ func (s *screenImpl) NewBuffer(size image.Point) (retBuf screen.Buffer, retErr e
 		}()
 		a := (*[maxShmSize]byte)(addr)
 		b.buf = (*a)[:bufLen:bufLen]
-		b.rgba.Pix = b.buf
 		b.addr = addr
 
 		// readOnly is whether the shared memory is read-only from the X11 server's
@@ -242,6 +257,22 @@ func (s *screenImpl) NewBuffer(size image.Point) (retBuf screen.Buffer, retErr e
 		b.xs = xs
 	}
 
+	// Only FormatBGRA is what the server presumes, so the other formats
+	// have their own memory, converted to and from b.buf.
+	switch format {
+	case screen.FormatBGRA:
+		b.img = &imageutil.BGRA{
+			Pix:    b.buf,
+			Stride: 4 * size.X,
+			Rect:   image.Rectangle{Max: size},
+		}
+	case screen.FormatRGBA:
+		b.rgba = *image.NewRGBA(image.Rectangle{Max: size})
+		b.img = &b.rgba
+	default:
+		b.img = format.NewImage(image.Rectangle{Max: size})
+	}
+
 	s.mu.Lock()
 	s.buffers[b.xs] = b
 	s.mu.Unlock()
@@ -249,7 +280,7 @@ func (s *screenImpl) NewBuffer(size image.Point) (retBuf screen.Buffer, retErr e
 	return b, nil
 }
 
-func (s *screenImpl) NewTexture(size image.Point) (screen.Texture, error) {
+func (s *screenImpl) NewTexture(size image.Point, opts *screen.NewTextureOptions) (screen.Texture, error) {
 	w, h := int64(size.X), int64(size.Y)
 	if w < 0 || maxShmSide < w || h < 0 || maxShmSide < h || maxShmSize < 4*w*h {
 		return nil, fmt.Errorf("x11driver: invalid texture size %v", size)
@@ -278,12 +309,40 @@ func (s *screenImpl) NewTexture(size image.Point) (screen.Texture, error) {
 		Height: uint16(h),
 	}})
 
-	return &textureImpl{
-		s:    s,
-		size: size,
-		xm:   xm,
-		xp:   xp,
-	}, nil
+	t := &textureImpl{
+		s:      s,
+		size:   size,
+		xm:     xm,
+		xp:     xp,
+		filter: "bilinear",
+	}
+	if opts.GetMipmaps() {
+		for _, sz := range mipmapSizes(size) {
+			lvl, err := s.NewTexture(sz, nil)
+			if err != nil {
+				t.Release()
+				return nil, err
+			}
+			t.mipmaps = append(t.mipmaps, lvl.(*textureImpl))
+		}
+	}
+	return t, nil
+}
+
+// mipmapSizes returns the sizes of the levels of the mipmaps of a texture of
+// the given size, after the texture itself. Each level is half the size of
+// the previous one, rounded up, down to 1×1. A texture with no pixels has no
+// mipmaps.
+func mipmapSizes(size image.Point) []image.Point {
+	if size.X <= 0 || size.Y <= 0 {
+		return nil
+	}
+	var sizes []image.Point
+	for sz := size; sz.X > 1 || sz.Y > 1; {
+		sz = image.Point{(sz.X + 1) / 2, (sz.Y + 1) / 2}
+		sizes = append(sizes, sz)
+	}
+	return sizes
 }
 
 func (s *screenImpl) NewWindow(opts *screen.NewWindowOptions) (screen.Window, error) {
@@ -412,11 +471,26 @@ func (s *screenImpl) initKeyboardMapping() error {
 	return nil
 }
 
+// initRenderVersion records whether the server's Render extension has the
+// blend modes.
+func (s *screenImpl) initRenderVersion() error {
+	v, err := render.QueryVersion(s.xc, 0, 11).Reply()
+	if err != nil {
+		return fmt.Errorf("x11driver: render.QueryVersion failed: %v", err)
+	}
+	s.blendModes = v.MajorVersion > 0 || v.MinorVersion >= 11
+	return nil
+}
+
 func (s *screenImpl) initPictformats() error {
 	pformats, err := render.QueryPictFormats(s.xc).Reply()
 	if err != nil {
 		return fmt.Errorf("x11driver: render.QueryPictFormats failed: %v", err)
 	}
+	s.pictformat8, err = findPictformat(pformats.Formats, 8)
+	if err != nil {
+		return err
+	}
 	s.pictformat24, err = findPictformat(pformats.Formats, 24)
 	if err != nil {
 		return err
@@ -440,7 +514,11 @@ func findPictformat(fs []render.Pictforminfo, depth byte) (render.Pictformat, er
 		AlphaShift: 24,
 		AlphaMask:  0xff,
 	}
-	if depth == 24 {
+	switch depth {
+	case 8:
+		// An alpha-only format, used for the masks of trapezoids.
+		want = render.Directformat{AlphaMask: 0xff}
+	case 24:
 		want.AlphaShift = 0
 		want.AlphaMask = 0x00
 	}
@@ -515,20 +593,22 @@ func (s *screenImpl) drawUniform(xp render.Picture, src2dst *f64.Aff3, src color
 	if sr.Empty() {
 		return
 	}
+	if clip := opts.GetClip(); !clip.Empty() {
+		defer setClip(s.xc, xp, clip)()
+	}
 
-	if opts == nil && *src2dst == (f64.Aff3{1, 0, 0, 0, 1, 0}) {
-		fill(s.xc, xp, sr, src, op)
+	c := renderColor(src, opts.GetOpacity())
+	if mask, _ := opts.GetMask().(*textureImpl); mask != nil {
+		if !mask.degenerate() {
+			s.drawUniformMasked(xp, src2dst, c, sr, op, mask, opts.GetFilter())
+		}
 		return
 	}
 
-	r, g, b, a := src.RGBA()
-	c := render.Color{
-		Red:   uint16(r),
-		Green: uint16(g),
-		Blue:  uint16(b),
-		Alpha: uint16(a),
+	if *src2dst == (f64.Aff3{1, 0, 0, 0, 1, 0}) {
+		fill(s, xp, sr, color.RGBA64{c.Red, c.Green, c.Blue, c.Alpha}, op)
+		return
 	}
-	points := trifanPoints(src2dst, sr)
 
 	s.uniformMu.Lock()
 	defer s.uniformMu.Unlock()
@@ -539,10 +619,220 @@ func (s *screenImpl) drawUniform(xp render.Picture, src2dst *f64.Aff3, src color
 		render.CreateSolidFill(s.xc, s.uniformP, c)
 	}
 
-	if op == draw.Src {
-		// We implement draw.Src as render.PictOpOutReverse followed by
-		// render.PictOpOver, for the same reason as in textureImpl.draw.
-		render.TriFan(s.xc, render.PictOpOutReverse, s.opaqueP, xp, 0, 0, 0, points[:])
+	s.trifan(op, s.uniformP, xp, trifanPoints(src2dst, sr))
+}
+
+// renderColor returns src, with its alpha scaled by opacity, as a
+// render.Color.
+func renderColor(src color.Color, opacity float64) render.Color {
+	r, g, b, a := src.RGBA()
+	if opacity != 1 {
+		// The color is alpha-premultiplied, so all four channels scale.
+		r = uint32(float64(r) * opacity)
+		g = uint32(float64(g) * opacity)
+		b = uint32(float64(b) * opacity)
+		a = uint32(float64(a) * opacity)
+	}
+	return render.Color{
+		Red:   uint16(r),
+		Green: uint16(g),
+		Blue:  uint16(b),
+		Alpha: uint16(a),
 	}
-	render.TriFan(s.xc, render.PictOpOver, s.uniformP, xp, 0, 0, 0, points[:])
+}
+
+// maxTrapezoids is the most trapezoids sent in one request, which keeps the
+// request well within the X11 maximum request length.
+const maxTrapezoids = 4096
+
+// drawTrapezoids fills the trapezoids, in the space of xp, with the uniform
+// color src. The X server rasterizes them, with anti-aliasing, to an alpha
+// mask.
+//
+// TODO: for operators that affect dst pixels outside of the src, such as
+// Src and Clear, a large number of trapezoids is split over requests whose
+// bounding boxes may overlap, and a later request may undo an earlier one.
+func (s *screenImpl) drawTrapezoids(xp render.Picture, src color.Color, traps []screen.Trapezoid, op draw.Op, opts *screen.DrawOptions) {
+	if len(traps) == 0 {
+		return
+	}
+	if clip := opts.GetClip(); !clip.Empty() {
+		defer setClip(s.xc, xp, clip)()
+	}
+	cp, err := solidFill(s.xc, renderColor(src, opts.GetOpacity()))
+	if err != nil {
+		log.Printf("x11driver: %v", err)
+		return
+	}
+	defer render.FreePicture(s.xc, cp)
+
+	// Render's Fixed is 16.16, and the screen's is 26.6.
+	fx := func(x fixed.Int26_6) render.Fixed { return render.Fixed(x.Int16_16()) }
+	line := func(l [2]fixed.Point26_6) render.Linefix {
+		return render.Linefix{
+			P1: render.Pointfix{X: fx(l[0].X), Y: fx(l[0].Y)},
+			P2: render.Pointfix{X: fx(l[1].X), Y: fx(l[1].Y)},
+		}
+	}
+	rt := make([]render.Trapezoid, 0, len(traps))
+	for _, t := range traps {
+		rt = append(rt, render.Trapezoid{
+			Top:    fx(t.Top),
+			Bottom: fx(t.Bottom),
+			Left:   line(t.Left),
+			Right:  line(t.Right),
+		})
+	}
+	for len(rt) > 0 {
+		n := len(rt)
+		if n > maxTrapezoids {
+			n = maxTrapezoids
+		}
+		render.Trapezoids(s.xc, s.renderOp(op), cp, xp, s.pictformat8, 0, 0, rt[:n])
+		rt = rt[n:]
+	}
+}
+
+// maxRectangles is the most rectangles sent in one FillRectangles request.
+const maxRectangles = 8192
+
+// drawBatch draws the ops onto dst, whose picture is xp. Consecutive fills of
+// the same color, and consecutive copies from the same texture, share one
+// request, or one round of picture state changes. Other ops are drawn one by
+// one.
+func (s *screenImpl) drawBatch(dst screen.Drawer, xp render.Picture, ops []screen.BatchOp) {
+	for len(ops) > 0 {
+		n := 1
+		if r, ok := batchFill(&ops[0]); ok {
+			c := renderColor(ops[0].Color, 1)
+			rects := []xproto.Rectangle{r}
+			for ; n < len(ops) && len(rects) < maxRectangles; n++ {
+				r, ok := batchFill(&ops[n])
+				if !ok || ops[n].Op != ops[0].Op || renderColor(ops[n].Color, 1) != c {
+					break
+				}
+				rects = append(rects, r)
+			}
+			render.FillRectangles(s.xc, s.renderOp(ops[0].Op), xp, c, rects)
+		} else if t := batchCopy(&ops[0]); t != nil {
+			for n < len(ops) && batchCopy(&ops[n]) == t {
+				n++
+			}
+			t.copies(xp, ops[:n])
+		} else {
+			ops[0].DrawTo(dst)
+		}
+		ops = ops[n:]
+	}
+}
+
+// batchFill returns the rectangle for op, and whether op is a plain fill of
+// a rectangle.
+func batchFill(op *screen.BatchOp) (xproto.Rectangle, bool) {
+	if op.Src != nil || !plainOpts(&op.Opts) {
+		return xproto.Rectangle{}, false
+	}
+	dp, ok := intTranslation(&op.Src2dst)
+	if !ok {
+		return xproto.Rectangle{}, false
+	}
+	r := op.SR.Add(dp)
+	if r.Min.X < -0x8000 || 0x7fff < r.Min.X || r.Min.Y < -0x8000 || 0x7fff < r.Min.Y ||
+		r.Dx() < 0 || 0xffff < r.Dx() || r.Dy() < 0 || 0xffff < r.Dy() {
+		return xproto.Rectangle{}, false
+	}
+	return xproto.Rectangle{
+		X:      int16(r.Min.X),
+		Y:      int16(r.Min.Y),
+		Width:  uint16(r.Dx()),
+		Height: uint16(r.Dy()),
+	}, true
+}
+
+// batchCopy returns op's texture if op is a plain copy of it, translated by
+// whole pixels, and nil otherwise.
+func batchCopy(op *screen.BatchOp) *textureImpl {
+	t, _ := op.Src.(*textureImpl)
+	if t == nil || t.degenerate() || !plainOpts(&op.Opts) {
+		return nil
+	}
+	if _, ok := intTranslation(&op.Src2dst); !ok {
+		return nil
+	}
+	return t
+}
+
+// plainOpts returns whether o has no clip, mask or opacity.
+func plainOpts(o *screen.DrawOptions) bool {
+	return o.Clip.Empty() && o.Mask == nil && o.GetOpacity() == 1
+}
+
+// intTranslation returns the translation of src2dst, and whether src2dst is
+// a translation by whole pixels.
+func intTranslation(src2dst *f64.Aff3) (image.Point, bool) {
+	if src2dst[0] != 1 || src2dst[1] != 0 || src2dst[3] != 0 || src2dst[4] != 1 {
+		return image.Point{}, false
+	}
+	dx, dy := int(src2dst[2]), int(src2dst[5])
+	if float64(dx) != src2dst[2] || float64(dy) != src2dst[5] {
+		return image.Point{}, false
+	}
+	return image.Point{dx, dy}, true
+}
+
+// drawUniformMasked is like drawUniform, but with the uniform color c masked
+// by the alpha of mask, sampled in the space of sr. Unless src2dst is a
+// translation by whole pixels, it goes through a temporary texture holding
+// the masked color, like textureImpl.draw.
+func (s *screenImpl) drawUniformMasked(xp render.Picture, src2dst *f64.Aff3, c render.Color, sr image.Rectangle, op draw.Op, mask *textureImpl, filter screen.Filter) {
+	if dp, ok := intTranslation(src2dst); ok {
+		// The mask needs no resampling, so it can mask the color directly,
+		// without a temporary texture. This is the common case of drawing
+		// glyphs from an atlas.
+		cp, err := solidFill(s.xc, c)
+		if err != nil {
+			log.Printf("x11driver: %v", err)
+			return
+		}
+		defer render.FreePicture(s.xc, cp)
+
+		mask.renderMu.Lock()
+		defer mask.renderMu.Unlock()
+		render.SetPictureTransform(s.xc, mask.xp, identityTransform)
+		render.Composite(s.xc, s.renderOp(op), cp, mask.xp, xp,
+			0, 0, // SrcX, SrcY,
+			int16(sr.Min.X), int16(sr.Min.Y), // MaskX, MaskY,
+			int16(sr.Min.X+dp.X), int16(sr.Min.Y+dp.Y), // DstX, DstY,
+			uint16(sr.Dx()), uint16(sr.Dy()), // Width, Height,
+		)
+		return
+	}
+
+	tex, err := s.NewTexture(sr.Size(), nil)
+	if err != nil {
+		log.Printf("x11driver: %v", err)
+		return
+	}
+	defer tex.Release()
+	tmp := tex.(*textureImpl)
+
+	cp, err := solidFill(s.xc, c)
+	if err != nil {
+		log.Printf("x11driver: %v", err)
+		return
+	}
+	defer render.FreePicture(s.xc, cp)
+
+	mask.renderMu.Lock()
+	render.SetPictureTransform(s.xc, mask.xp, identityTransform)
+	render.Composite(s.xc, render.PictOpSrc, cp, mask.xp, tmp.xp,
+		0, 0, // SrcX, SrcY,
+		int16(sr.Min.X), int16(sr.Min.Y), // MaskX, MaskY,
+		0, 0, // DstX, DstY,
+		uint16(sr.Dx()), uint16(sr.Dy()), // Width, Height,
+	)
+	mask.renderMu.Unlock()
+
+	m := translate(src2dst, sr.Min)
+	tmp.drawPicture(xp, 0, &m, tmp.Bounds(), op, filter, 1)
 }
@@ -5,16 +5,22 @@
 package x11driver
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
+	"log"
 	"math"
 	"sync"
 
+	"github.com/BurntSushi/xgb"
 	"github.com/BurntSushi/xgb/render"
 	"github.com/BurntSushi/xgb/xproto"
 
+	"github.com/as/shiny/driver/internal/drawer"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/math/fixed"
 	"github.com/as/shiny/screen"
 )
 
@@ -33,6 +39,13 @@ type textureImpl struct {
 	// X11/Render calls from separate higher-level operations causes
 	// inconsistencies.
 	renderMu sync.Mutex
+	// filter is the name of the picture filter last set on xp. It is
+	// protected by renderMu.
+	filter string
+
+	// mipmaps are the levels of t's mipmaps after t itself, if it was
+	// created with screen.NewTextureOptions.Mipmaps.
+	mipmaps []*textureImpl
 
 	releasedMu sync.Mutex
 	released   bool
@@ -53,123 +66,442 @@ func (t *textureImpl) Release() {
 	}
 	render.FreePicture(t.s.xc, t.xp)
 	xproto.FreePixmap(t.s.xc, t.xm)
+	for _, lvl := range t.mipmaps {
+		lvl.Release()
+	}
+}
+
+// GenerateMipmaps builds the mipmaps on the CPU: it downloads t, and box
+// filters each level from the previous one, before uploading it.
+func (t *textureImpl) GenerateMipmaps() {
+	if len(t.mipmaps) == 0 {
+		return
+	}
+	prev, err := t.s.NewBuffer(t.size, nil)
+	if err != nil {
+		log.Printf("x11driver: %v", err)
+		return
+	}
+	t.Download(t.Bounds(), prev, image.Point{})
+	for _, lvl := range t.mipmaps {
+		b, err := t.s.NewBuffer(lvl.size, nil)
+		if err != nil {
+			log.Printf("x11driver: %v", err)
+			break
+		}
+		imageutil.Resize(b.RGBA(), b.Bounds(), prev.RGBA(), prev.Bounds(), imageutil.Box)
+		prev.Release()
+		lvl.Upload(image.Point{}, b, b.Bounds())
+		prev = b
+	}
+	prev.Release()
 }
 
 func (t *textureImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
+	src.(*bufferImpl).wait(t.UploadAsync(dp, src, sr))
+}
+
+func (t *textureImpl) UploadAsync(dp image.Point, src screen.Buffer, sr image.Rectangle) <-chan struct{} {
+	if t.degenerate() {
+		return screen.Closed
+	}
+	return src.(*bufferImpl).upload(xproto.Drawable(t.xm), t.s.gcontext32, textureDepth, dp, sr)
+}
+
+func (t *textureImpl) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
 	if t.degenerate() {
 		return
 	}
-	src.(*bufferImpl).upload(xproto.Drawable(t.xm), t.s.gcontext32, textureDepth, dp, sr)
+	dst.(*bufferImpl).download(xproto.Drawable(t.xm), t.Bounds(), textureDepth, sr, dp)
 }
 
 func (t *textureImpl) Fill(dr image.Rectangle, src color.Color, op draw.Op) {
 	if t.degenerate() {
 		return
 	}
-	fill(t.s.xc, t.xp, dr, src, op)
+	fill(t.s, t.xp, dr, src, op)
 }
 
-// f64ToFixed converts from float64 to X11/Render's 16.16 fixed point.
-func f64ToFixed(x float64) render.Fixed {
-	return render.Fixed(x * 65536)
+func (t *textureImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	if t.degenerate() {
+		return
+	}
+	src.(*textureImpl).draw(t.xp, &src2dst, sr, op, opts)
 }
 
-func inv(x *f64.Aff3) f64.Aff3 {
-	invDet := 1 / (x[0]*x[4] - x[1]*x[3])
-	return f64.Aff3{
-		+x[4] * invDet,
-		-x[1] * invDet,
-		(x[1]*x[5] - x[2]*x[4]) * invDet,
-		-x[3] * invDet,
-		+x[0] * invDet,
-		(x[2]*x[3] - x[0]*x[5]) * invDet,
+func (t *textureImpl) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	if t.degenerate() {
+		return
 	}
+	t.s.drawUniform(t.xp, &src2dst, src, sr, op, opts)
+}
+
+func (t *textureImpl) DrawTrapezoids(src color.Color, traps []screen.Trapezoid, op draw.Op, opts *screen.DrawOptions) {
+	if t.degenerate() {
+		return
+	}
+	t.s.drawTrapezoids(t.xp, src, traps, op, opts)
+}
+
+func (t *textureImpl) DrawBatch(ops []screen.BatchOp) {
+	if t.degenerate() {
+		return
+	}
+	t.s.drawBatch(t, t.xp, ops)
+}
+
+func (t *textureImpl) Copy(dp image.Point, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	drawer.Copy(t, dp, src, sr, op, opts)
+}
+
+func (t *textureImpl) Scale(dr image.Rectangle, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	drawer.Scale(t, dr, src, sr, op, opts)
 }
 
+// f64ToFixed converts from float64 to X11/Render's 16.16 fixed point.
+func f64ToFixed(x float64) render.Fixed {
+	return render.Fixed(fixed.F16_16(x))
+}
+
+// draw composites t onto the dst picture xp, which belongs to either a window
+// or another texture.
 func (t *textureImpl) draw(xp render.Picture, src2dst *f64.Aff3, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	if t.degenerate() {
+		return
+	}
 	sr = sr.Intersect(t.Bounds())
 	if sr.Empty() {
 		return
 	}
+	if clip := opts.GetClip(); !clip.Empty() {
+		defer setClip(t.s.xc, xp, clip)()
+	}
+
+	mask, _ := opts.GetMask().(*textureImpl)
+	if mask != nil && mask.degenerate() {
+		// An empty mask has no pixels to extend, so it masks everything.
+		return
+	}
+	opacity := opts.GetOpacity()
+	if mask != nil || (opacity != 1 && (src2dst[1] != 0 || src2dst[3] != 0)) {
+		// render.TriFan, used for general transformations, takes no mask
+		// picture, and render.Composite takes only one. Instead, apply the
+		// mask and opacity to a temporary copy of sr, and draw that. The
+		// mask is sampled at the same coordinates as t, so such a copy is
+		// never taken from t's mipmaps.
+		tmp, err := t.masked(sr, mask, opacity)
+		if err != nil {
+			log.Printf("x11driver: %v", err)
+			return
+		}
+		defer tmp.Release()
+		m := translate(src2dst, sr.Min)
+		tmp.drawPicture(xp, 0, &m, tmp.Bounds(), op, opts.GetFilter(), 1)
+		return
+	}
+	t.drawPicture(xp, t.level(src2dst, opts.GetFilter()), src2dst, sr, op, opts.GetFilter(), opacity)
+}
+
+// level returns the level of t's mipmaps to draw t from with src2dst and
+// the filter. Level 0 is t itself.
+//
+// The level is the one nearest to the scale of the axis that is shrunk the
+// most, and is sampled bilinearly, like GL's LINEAR_MIPMAP_NEAREST, as
+// X11/Render can not interpolate between two levels.
+func (t *textureImpl) level(src2dst *f64.Aff3, f screen.Filter) int {
+	if len(t.mipmaps) == 0 || (f != screen.FilterDefault && f != screen.FilterBest) {
+		return 0
+	}
+	scale := math.Min(math.Hypot(src2dst[0], src2dst[3]), math.Hypot(src2dst[1], src2dst[4]))
+	if scale == 0 {
+		return 0
+	}
+	n := int(math.Floor(math.Log2(1/scale) + 0.5))
+	if n <= 0 {
+		return 0
+	}
+	if n > len(t.mipmaps) {
+		n = len(t.mipmaps)
+	}
+	return n
+}
+
+// copies composites parts of t onto the dst picture xp, as per the ops, which
+// must all be copies of t, as per batchCopy. The picture transform is set
+// once for all of them.
+func (t *textureImpl) copies(xp render.Picture, ops []screen.BatchOp) {
+	t.renderMu.Lock()
+	defer t.renderMu.Unlock()
+	render.SetPictureTransform(t.s.xc, t.xp, identityTransform)
+	for i := range ops {
+		dp, _ := intTranslation(&ops[i].Src2dst)
+		sr := ops[i].SR.Intersect(t.Bounds())
+		if sr.Empty() {
+			continue
+		}
+		render.Composite(t.s.xc, t.s.renderOp(ops[i].Op), t.xp, 0, xp,
+			int16(sr.Min.X), int16(sr.Min.Y), // SrcX, SrcY,
+			0, 0, // MaskX, MaskY,
+			int16(sr.Min.X+dp.X), int16(sr.Min.Y+dp.Y), // DstX, DstY,
+			uint16(sr.Dx()), uint16(sr.Dy()), // Width, Height,
+		)
+	}
+}
+
+// masked returns a new texture holding sr of t, multiplied by the alpha of
+// the mask, if non-nil, and by opacity. The mask is sampled at the same
+// coordinates as t.
+func (t *textureImpl) masked(sr image.Rectangle, mask *textureImpl, opacity float64) (*textureImpl, error) {
+	tex, err := t.s.NewTexture(sr.Size(), nil)
+	if err != nil {
+		return nil, err
+	}
+	tmp := tex.(*textureImpl)
+	w, h := uint16(sr.Dx()), uint16(sr.Dy())
+
+	var opacityP render.Picture
+	if opacity != 1 {
+		opacityP, err = solidFill(t.s.xc, render.Color{Alpha: uint16(opacity * 0xffff)})
+		if err != nil {
+			tmp.Release()
+			return nil, err
+		}
+		defer render.FreePicture(t.s.xc, opacityP)
+	}
 
 	t.renderMu.Lock()
 	defer t.renderMu.Unlock()
+	render.SetPictureTransform(t.s.xc, t.xp, identityTransform)
+
+	maskP := opacityP
+	if mask != nil {
+		if mask != t {
+			mask.renderMu.Lock()
+			defer mask.renderMu.Unlock()
+		}
+		render.SetPictureTransform(t.s.xc, mask.xp, identityTransform)
+		maskP = mask.xp
+	}
+	render.Composite(t.s.xc, render.PictOpSrc, t.xp, maskP, tmp.xp,
+		int16(sr.Min.X), int16(sr.Min.Y), // SrcX, SrcY,
+		int16(sr.Min.X), int16(sr.Min.Y), // MaskX, MaskY,
+		0, 0, w, h, // DstX, DstY, Width, Height,
+	)
+	if mask != nil && opacity != 1 {
+		// What X11/Render calls PictOpInReverse is also known as dst-in. It
+		// scales the dst by the src's alpha.
+		render.Composite(t.s.xc, render.PictOpInReverse, opacityP, 0, tmp.xp, 0, 0, 0, 0, 0, 0, w, h)
+	}
+	return tmp, nil
+}
+
+// drawPicture is like draw, but without clipping or masking. An opacity
+// other than 1 is only supported for axis-aligned src2dst transformations.
+//
+// The pixels are sampled from level n of t's mipmaps, but src2dst and sr are
+// in t's coordinates, so that exactly sr is drawn, whatever the level.
+func (t *textureImpl) drawPicture(xp render.Picture, n int, src2dst *f64.Aff3, sr image.Rectangle, op draw.Op, filter screen.Filter, opacity float64) {
+	src := t
+	if n > 0 {
+		src = t.mipmaps[n-1]
+	}
+	src.renderMu.Lock()
+	defer src.renderMu.Unlock()
+	src.setFilter(filter)
+	k := float64(int(1) << uint(n))
 
 	// For simple copies and scales, the inverse matrix is trivial to compute,
 	// and we do not need the "Src becomes OutReverse plus Over" dance (see
 	// below). Thus, draw can be one render.SetPictureTransform call and then
 	// one render.Composite call, regardless of whether or not op is Src.
 	if src2dst[1] == 0 && src2dst[3] == 0 {
-		dstXMin := float64(sr.Min.X)*src2dst[0] + src2dst[2]
-		dstXMax := float64(sr.Max.X)*src2dst[0] + src2dst[2]
-		if dstXMin > dstXMax {
-			// TODO: check if this (and below) works when src2dst[0] < 0.
-			dstXMin, dstXMax = dstXMax, dstXMin
-		}
-		dXMin := int(math.Floor(dstXMin))
-		dXMax := int(math.Ceil(dstXMax))
-
-		dstYMin := float64(sr.Min.Y)*src2dst[4] + src2dst[5]
-		dstYMax := float64(sr.Max.Y)*src2dst[4] + src2dst[5]
-		if dstYMin > dstYMax {
-			// TODO: check if this (and below) works when src2dst[4] < 0.
-			dstYMin, dstYMax = dstYMax, dstYMin
+		transform, dr := scaleTransform(src2dst, sr, k)
+		render.SetPictureTransform(t.s.xc, src.xp, transform)
+		var maskP render.Picture
+		if opacity != 1 {
+			var err error
+			maskP, err = solidFill(t.s.xc, render.Color{Alpha: uint16(opacity * 0xffff)})
+			if err != nil {
+				log.Printf("x11driver: %v", err)
+				return
+			}
+			defer render.FreePicture(t.s.xc, maskP)
 		}
-		dYMin := int(math.Floor(dstYMin))
-		dYMax := int(math.Ceil(dstYMax))
-
-		render.SetPictureTransform(t.s.xc, t.xp, render.Transform{
-			f64ToFixed(1 / src2dst[0]), 0, 0,
-			0, f64ToFixed(1 / src2dst[4]), 0,
-			0, 0, 1 << 16,
-		})
-		render.Composite(t.s.xc, renderOp(op), t.xp, 0, xp,
+		render.Composite(t.s.xc, t.s.renderOp(op), src.xp, maskP, xp,
 			int16(sr.Min.X), int16(sr.Min.Y), // SrcX, SrcY,
 			0, 0, // MaskX, MaskY,
-			int16(dXMin), int16(dYMin), // DstX, DstY,
-			uint16(dXMax-dXMin), uint16(dYMax-dYMin), // Width, Height,
+			int16(dr.Min.X), int16(dr.Min.Y), // DstX, DstY,
+			uint16(dr.Dx()), uint16(dr.Dy()), // Width, Height,
 		)
 		return
 	}
 
+	transform, ok := generalTransform(src2dst, sr, k)
+	if !ok {
+		return
+	}
+	render.SetPictureTransform(t.s.xc, src.xp, transform)
+	t.s.trifan(op, src.xp, xp, trifanPoints(src2dst, sr))
+}
+
+// scaleTransform returns the picture transform and the destination
+// rectangle for drawing sr with the axis-aligned src2dst, from a level whose
+// pixels each cover k×k pixels of sr's texture.
+func scaleTransform(src2dst *f64.Aff3, sr image.Rectangle, k float64) (render.Transform, image.Rectangle) {
+	dstXMin := float64(sr.Min.X)*src2dst[0] + src2dst[2]
+	dstXMax := float64(sr.Max.X)*src2dst[0] + src2dst[2]
+	if dstXMin > dstXMax {
+		// TODO: check if this (and below) works when src2dst[0] < 0.
+		dstXMin, dstXMax = dstXMax, dstXMin
+	}
+	dstYMin := float64(sr.Min.Y)*src2dst[4] + src2dst[5]
+	dstYMax := float64(sr.Max.Y)*src2dst[4] + src2dst[5]
+	if dstYMin > dstYMax {
+		// TODO: check if this (and below) works when src2dst[4] < 0.
+		dstYMin, dstYMax = dstYMax, dstYMin
+	}
+	dr := image.Rect(
+		int(math.Floor(dstXMin)), int(math.Floor(dstYMin)),
+		int(math.Ceil(dstXMax)), int(math.Ceil(dstYMax)),
+	)
+	return render.Transform{
+		f64ToFixed(1 / (src2dst[0] * k)), 0, 0,
+		0, f64ToFixed(1 / (src2dst[4] * k)), 0,
+		0, 0, 1 << 16,
+	}, dr
+}
+
+// generalTransform is like scaleTransform, for any invertible src2dst. The
+// destination is the quad given by trifanPoints. It returns false if src2dst
+// is singular, which squashes the source to a line or a point that covers no
+// pixels.
+func generalTransform(src2dst *f64.Aff3, sr image.Rectangle, k float64) (render.Transform, bool) {
 	// The X11/Render transform matrix maps from destination pixels to source
 	// pixels, so we invert src2dst.
-	dst2src := inv(src2dst)
-	render.SetPictureTransform(t.s.xc, t.xp, render.Transform{
-		f64ToFixed(dst2src[0]), f64ToFixed(dst2src[1]), render.Fixed(sr.Min.X << 16),
-		f64ToFixed(dst2src[3]), f64ToFixed(dst2src[4]), render.Fixed(sr.Min.Y << 16),
+	dst2src, ok := src2dst.Inverse()
+	if !ok {
+		return render.Transform{}, false
+	}
+	return render.Transform{
+		f64ToFixed(dst2src[0] / k), f64ToFixed(dst2src[1] / k), f64ToFixed(float64(sr.Min.X) / k),
+		f64ToFixed(dst2src[3] / k), f64ToFixed(dst2src[4] / k), f64ToFixed(float64(sr.Min.Y) / k),
 		0, 0, 1 << 16,
-	})
-
-	points := trifanPoints(src2dst, sr)
-	if op == draw.Src {
-		// render.TriFan visits every dst-space pixel in the axis-aligned
-		// bounding box (AABB) containing the transformation of the sr
-		// rectangle in src-space to a quad in dst-space.
-		//
-		// render.TriFan is like render.Composite, except that the AABB is
-		// defined implicitly by the transformed triangle vertices instead of
-		// being passed explicitly as arguments. It implies the minimal AABB.
-		//
-		// In any case, for arbitrary src2dst affine transformations, which
-		// include rotations, this means that a naive render.TriFan call will
-		// affect those pixels inside the AABB but outside the quad. For the
-		// draw.Src operator, this means that pixels in that AABB can be
-		// incorrectly set to zero.
-		//
-		// Instead, we implement the draw.Src operator as two render.TriFan
-		// calls. The first one (using the PictOpOutReverse operator and a
-		// fully opaque source) clears the dst-space quad but leaves pixels
-		// outside that quad (but inside the AABB) untouched. The second one
-		// (using the PictOpOver operator and the texture t as source) fills in
-		// the quad and again does not touch the pixels outside.
-		//
-		// What X11/Render calls PictOpOutReverse is also known as dst-out. See
-		// http://www.w3.org/TR/SVGCompositing/examples/compop-porterduff-examples.png
-		// for a visualization.
-		render.TriFan(t.s.xc, render.PictOpOutReverse, t.s.opaqueP, xp, 0, 0, 0, points[:])
-	}
-	render.TriFan(t.s.xc, render.PictOpOver, t.xp, xp, 0, 0, 0, points[:])
+	}, true
+}
+
+// trifan composites the src picture onto the quad points of the dst picture
+// with the given op.
+func (s *screenImpl) trifan(op draw.Op, src, dst render.Picture, points [4]render.Pointfix) {
+	// render.TriFan visits every dst-space pixel in the axis-aligned bounding
+	// box (AABB) containing the quad.
+	//
+	// render.TriFan is like render.Composite, except that the AABB is defined
+	// implicitly by the transformed triangle vertices instead of being passed
+	// explicitly as arguments. It implies the minimal AABB. Pixels inside the
+	// AABB but outside the quad are composited as if the src there was fully
+	// transparent.
+	//
+	// For arbitrary src2dst affine transformations, which include rotations,
+	// this means that a naive render.TriFan call will affect those pixels
+	// inside the AABB but outside the quad, unless op leaves dst unchanged
+	// where the src is transparent. Over, Add and the blend modes, amongst
+	// others, are such ops. For the draw.Src operator, though, pixels in that
+	// AABB would be incorrectly set to zero.
+	//
+	// Instead, we implement the draw.Src operator as two render.TriFan calls.
+	// The first one (using the PictOpOutReverse operator and a fully opaque
+	// source) clears the dst-space quad but leaves pixels outside that quad
+	// (but inside the AABB) untouched. The second one (using the PictOpOver
+	// operator and the src) fills in the quad and again does not touch the
+	// pixels outside. Clear is just the first of those.
+	//
+	// What X11/Render calls PictOpOutReverse is also known as dst-out. See
+	// http://www.w3.org/TR/SVGCompositing/examples/compop-porterduff-examples.png
+	// for a visualization.
+	switch op {
+	case screen.Src, screen.Clear:
+		render.TriFan(s.xc, render.PictOpOutReverse, s.opaqueP, dst, 0, 0, 0, points[:])
+		if op == screen.Clear {
+			return
+		}
+		op = screen.Over
+	case screen.In, screen.DstIn, screen.Out, screen.DstAtop:
+		// These ops also change the dst where the src is transparent, but
+		// can not be split like Src can.
+		s.trifanCopy(op, src, dst, points)
+		return
+	}
+	render.TriFan(s.xc, s.renderOp(op), src, dst, 0, 0, 0, points[:])
+}
+
+// trifanCopy is like trifan, for any op. It composites the src onto a copy
+// of the quad's AABB, and then copies the quad back onto the dst. The copy is
+// masked by the quad's coverage, so that its anti-aliased edges are blended
+// with the dst's pixels outside the quad.
+func (s *screenImpl) trifanCopy(op draw.Op, src, dst render.Picture, points [4]render.Pointfix) {
+	r := quadBounds(points)
+	if r.Empty() {
+		return
+	}
+	tex, err := s.NewTexture(r.Size(), nil)
+	if err != nil {
+		log.Printf("x11driver: %v", err)
+		return
+	}
+	defer tex.Release()
+	cov, err := s.NewTexture(r.Size(), nil)
+	if err != nil {
+		log.Printf("x11driver: %v", err)
+		return
+	}
+	defer cov.Release()
+	tmp, mask := tex.(*textureImpl), cov.(*textureImpl)
+
+	// The quad's points, relative to the AABB. render.TriFan samples the src
+	// relative to the first point, so translating the points and the dst by
+	// the same whole number of pixels samples the same src pixels.
+	var local [4]render.Pointfix
+	for i, p := range points {
+		local[i] = render.Pointfix{
+			X: p.X - render.Fixed(r.Min.X<<16),
+			Y: p.Y - render.Fixed(r.Min.Y<<16),
+		}
+	}
+	x, y, w, h := int16(r.Min.X), int16(r.Min.Y), uint16(r.Dx()), uint16(r.Dy())
+	render.Composite(s.xc, render.PictOpSrc, dst, 0, tmp.xp, x, y, 0, 0, 0, 0, w, h)
+	render.TriFan(s.xc, s.renderOp(op), src, tmp.xp, 0, 0, 0, local[:])
+	render.TriFan(s.xc, render.PictOpOver, s.opaqueP, mask.xp, 0, 0, 0, local[:])
+
+	// Interpolate between the dst and tmp by the coverage: the first call
+	// scales the dst by one minus the coverage, and the second one adds tmp
+	// scaled by the coverage.
+	render.Composite(s.xc, render.PictOpOutReverse, mask.xp, 0, dst, 0, 0, 0, 0, x, y, w, h)
+	render.Composite(s.xc, render.PictOpAdd, tmp.xp, mask.xp, dst, 0, 0, 0, 0, x, y, w, h)
+}
+
+// quadBounds returns the smallest rectangle of whole pixels that contains the
+// quad points, within the range of X11 coordinates.
+func quadBounds(points [4]render.Pointfix) image.Rectangle {
+	r := image.Rectangle{Min: image.Point{math.MaxInt32, math.MaxInt32}, Max: image.Point{math.MinInt32, math.MinInt32}}
+	for _, p := range points {
+		// An arithmetic right shift of a 16.16 fixed point number is its
+		// floor.
+		x0, y0 := int(p.X>>16), int(p.Y>>16)
+		x1, y1 := int((p.X+0xffff)>>16), int((p.Y+0xffff)>>16)
+		if r.Min.X > x0 {
+			r.Min.X = x0
+		}
+		if r.Min.Y > y0 {
+			r.Min.Y = y0
+		}
+		if r.Max.X < x1 {
+			r.Max.X = x1
+		}
+		if r.Max.Y < y1 {
+			r.Max.Y = y1
+		}
+	}
+	return r.Intersect(image.Rect(-0x8000, -0x8000, 0x7fff, 0x7fff))
 }
 
 func trifanPoints(src2dst *f64.Aff3, sr image.Rectangle) [4]render.Pointfix {
@@ -192,9 +524,127 @@ func trifanPoints(src2dst *f64.Aff3, sr image.Rectangle) [4]render.Pointfix {
 	}}
 }
 
-func renderOp(op draw.Op) byte {
-	if op == draw.Src {
+var identityTransform = render.Transform{
+	1 << 16, 0, 0,
+	0, 1 << 16, 0,
+	0, 0, 1 << 16,
+}
+
+// translate returns the transformation that first translates by p and then
+// applies src2dst.
+func translate(src2dst *f64.Aff3, p image.Point) f64.Aff3 {
+	x, y := float64(p.X), float64(p.Y)
+	return f64.Aff3{
+		src2dst[0], src2dst[1], src2dst[0]*x + src2dst[1]*y + src2dst[2],
+		src2dst[3], src2dst[4], src2dst[3]*x + src2dst[4]*y + src2dst[5],
+	}
+}
+
+// renderFilter returns the name of the X11/Render filter for f.
+func renderFilter(f screen.Filter) string {
+	switch f {
+	case screen.FilterNearest:
+		return "nearest"
+	case screen.FilterBest:
+		return "best"
+	}
+	return "bilinear"
+}
+
+// setFilter sets the filter used when t is the source of a transformed
+// composite, if it differs from the one last set.
+//
+// setFilter must only be called while holding t.renderMu.
+func (t *textureImpl) setFilter(f screen.Filter) {
+	name := renderFilter(f)
+	if t.filter == name {
+		return
+	}
+	t.filter = name
+	render.SetPictureFilter(t.s.xc, t.xp, uint16(len(name)), name, nil)
+}
+
+// solidFill returns a new picture of the uniform color c. The caller is
+// responsible for freeing it.
+func solidFill(xc *xgb.Conn, c render.Color) (render.Picture, error) {
+	xp, err := render.NewPictureId(xc)
+	if err != nil {
+		return 0, fmt.Errorf("render.NewPictureId failed: %v", err)
+	}
+	render.CreateSolidFill(xc, xp, c)
+	return xp, nil
+}
+
+// setClip restricts rendering to the picture xp to the clip rectangle, and
+// returns a function that lifts that restriction again.
+func setClip(xc *xgb.Conn, xp render.Picture, clip image.Rectangle) (unset func()) {
+	// An empty list of rectangles, if clip is entirely outside of the range
+	// of X11 coordinates, clips everything.
+	var rects []xproto.Rectangle
+	clip = clip.Intersect(image.Rect(-0x8000, -0x8000, 0x7fff, 0x7fff))
+	if !clip.Empty() {
+		rects = append(rects, xproto.Rectangle{
+			X:      int16(clip.Min.X),
+			Y:      int16(clip.Min.Y),
+			Width:  uint16(clip.Dx()),
+			Height: uint16(clip.Dy()),
+		})
+	}
+	render.SetPictureClipRectangles(xc, xp, 0, 0, rects)
+	return func() {
+		render.ChangePicture(xc, xp, render.CpClipMask, []uint32{0}) // 0 means None.
+	}
+}
+
+// renderOp returns the X11/Render operator for op. The blend modes require
+// version 0.11 of the Render extension: if s's server is older, they have no
+// effect, like unknown ops.
+func (s *screenImpl) renderOp(op draw.Op) byte {
+	switch op {
+	case screen.Over:
+		return render.PictOpOver
+	case screen.Clear:
+		return render.PictOpClear
+	case screen.Src:
 		return render.PictOpSrc
+	case screen.Dst:
+		return render.PictOpDst
+	case screen.DstOver:
+		return render.PictOpOverReverse
+	case screen.In:
+		return render.PictOpIn
+	case screen.DstIn:
+		return render.PictOpInReverse
+	case screen.Out:
+		return render.PictOpOut
+	case screen.DstOut:
+		return render.PictOpOutReverse
+	case screen.Atop:
+		return render.PictOpAtop
+	case screen.DstAtop:
+		return render.PictOpAtopReverse
+	case screen.Xor:
+		return render.PictOpXor
+	case screen.Add:
+		return render.PictOpAdd
+	}
+	if !s.blendModes {
+		return render.PictOpDst
+	}
+	switch op {
+	case screen.BlendMultiply:
+		return render.PictOpMultiply
+	case screen.BlendScreen:
+		return render.PictOpScreen
+	case screen.BlendOverlay:
+		return render.PictOpOverlay
+	case screen.BlendDarken:
+		return render.PictOpDarken
+	case screen.BlendLighten:
+		return render.PictOpLighten
+	case screen.BlendDifference:
+		return render.PictOpDifference
 	}
-	return render.PictOpOver
+	// Unknown ops have no effect.
+	return render.PictOpDst
 }
@@ -0,0 +1,154 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package x11driver
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/BurntSushi/xgb/render"
+
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+func TestMipmapSizes(t *testing.T) {
+	testCases := []struct {
+		size image.Point
+		want []image.Point
+	}{
+		{image.Pt(0, 0), nil},
+		{image.Pt(0, 5), nil},
+		{image.Pt(7, 0), nil},
+		{image.Pt(1, 1), nil},
+		{image.Pt(1, 3), []image.Point{{1, 2}, {1, 1}}},
+		{image.Pt(5, 2), []image.Point{{3, 1}, {2, 1}, {1, 1}}},
+		{image.Pt(8, 8), []image.Point{{4, 4}, {2, 2}, {1, 1}}},
+	}
+	for _, tc := range testCases {
+		if got := mipmapSizes(tc.size); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("size %v: got %v, want %v", tc.size, got, tc.want)
+		}
+	}
+}
+
+func TestNewTextureZeroSizeMipmaps(t *testing.T) {
+	// A texture with no pixels makes no X11 requests, so it needs no
+	// connection.
+	s := &screenImpl{}
+	for _, size := range []image.Point{{0, 0}, {0, 9}, {9, 0}} {
+		tex, err := s.NewTexture(size, &screen.NewTextureOptions{Mipmaps: true})
+		if err != nil {
+			t.Fatalf("size %v: %v", size, err)
+		}
+		if n := len(tex.(*textureImpl).mipmaps); n != 0 {
+			t.Errorf("size %v: got %d levels, want 0", size, n)
+		}
+		tex.GenerateMipmaps()
+		tex.Release()
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tex := &textureImpl{mipmaps: make([]*textureImpl, 3)}
+	testCases := []struct {
+		scale  float64
+		filter screen.Filter
+		want   int
+	}{
+		{1, screen.FilterDefault, 0},
+		{0.75, screen.FilterDefault, 0},
+		{0.5, screen.FilterDefault, 1},
+		{0.25, screen.FilterBest, 2},
+		{0.01, screen.FilterDefault, 3},
+		{0.25, screen.FilterNearest, 0},
+		{0.25, screen.FilterBilinear, 0},
+	}
+	for _, tc := range testCases {
+		m := &f64.Aff3{tc.scale, 0, 0, 0, tc.scale, 0}
+		if got := tex.level(m, tc.filter); got != tc.want {
+			t.Errorf("scale %v, filter %v: got %d, want %d", tc.scale, tc.filter, got, tc.want)
+		}
+	}
+}
+
+// TestDrawSubRectangleFromMipmap tests that drawing a sub-rectangle of a
+// texture from one of its mipmaps covers the same destination pixels as
+// drawing it from the texture itself, and samples the level at the exact,
+// possibly fractional, coordinates of that sub-rectangle.
+func TestDrawSubRectangleFromMipmap(t *testing.T) {
+	sr := image.Rect(1, 1, 3, 3)
+	src2dst := &f64.Aff3{0.5, 0, 0.5, 0, 0.5, 0.5}
+	const k = 2
+
+	t0, dr0 := scaleTransform(src2dst, sr, 1)
+	t1, dr1 := scaleTransform(src2dst, sr, k)
+	if want := image.Rect(1, 1, 2, 2); dr0 != want {
+		t.Errorf("level 0: dr: got %v, want %v", dr0, want)
+	}
+	if dr1 != dr0 {
+		t.Errorf("level 1: dr: got %v, want %v", dr1, dr0)
+	}
+	if t1.Matrix11 != t0.Matrix11/k || t1.Matrix22 != t0.Matrix22/k {
+		t.Errorf("level 1: transform: got %v, want the level 0 transform %v scaled by 1/%d", t1, t0, k)
+	}
+
+	rot := &f64.Aff3{0, -0.5, 10, 0.5, 0, 0}
+	g0, ok0 := generalTransform(rot, sr, 1)
+	g1, ok1 := generalTransform(rot, sr, k)
+	if !ok0 || !ok1 {
+		t.Fatalf("generalTransform: got ok %t, %t, want true, true", ok0, ok1)
+	}
+	got := []render.Fixed{g1.Matrix11, g1.Matrix12, g1.Matrix21, g1.Matrix22}
+	want := []render.Fixed{g0.Matrix11 / k, g0.Matrix12 / k, g0.Matrix21 / k, g0.Matrix22 / k}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("level 1: transform: got %v, want %v", got, want)
+	}
+	if want := render.Fixed(1 << 15); g1.Matrix13 != want || g1.Matrix23 != want {
+		t.Errorf("level 1: origin: got (%v, %v), want (%v, %v)", g1.Matrix13, g1.Matrix23, want, want)
+	}
+}
+
+func TestQuadBounds(t *testing.T) {
+	fix := func(x, y float64) render.Pointfix {
+		return render.Pointfix{X: f64ToFixed(x), Y: f64ToFixed(y)}
+	}
+	testCases := []struct {
+		points [4]render.Pointfix
+		want   image.Rectangle
+	}{
+		{[4]render.Pointfix{fix(1, 1), fix(3, 1), fix(3, 4), fix(1, 4)}, image.Rect(1, 1, 3, 4)},
+		{[4]render.Pointfix{fix(5, 0.5), fix(9.5, 5), fix(5, 9.5), fix(0.5, 5)}, image.Rect(0, 0, 10, 10)},
+		{[4]render.Pointfix{fix(-2.25, -1), fix(0, -3.5), fix(2, -1), fix(0, 1.75)}, image.Rect(-3, -4, 2, 2)},
+		{[4]render.Pointfix{fix(-40000, 0), fix(0, 0), fix(0, 1), fix(-40000, 1)}, image.Rect(-0x8000, 0, 0, 1)},
+	}
+	for _, tc := range testCases {
+		if got := quadBounds(tc.points); got != tc.want {
+			t.Errorf("%v: got %v, want %v", tc.points, got, tc.want)
+		}
+	}
+}
+
+func TestRenderOp(t *testing.T) {
+	old, s := &screenImpl{}, &screenImpl{blendModes: true}
+	for _, tc := range []struct {
+		op      screen.Op
+		want    byte
+		wantOld byte
+	}{
+		{screen.Over, render.PictOpOver, render.PictOpOver},
+		{screen.Xor, render.PictOpXor, render.PictOpXor},
+		{screen.BlendMultiply, render.PictOpMultiply, render.PictOpDst},
+		{screen.BlendDifference, render.PictOpDifference, render.PictOpDst},
+	} {
+		if got := s.renderOp(tc.op); got != tc.want {
+			t.Errorf("op %v: got %d, want %d", tc.op, got, tc.want)
+		}
+		if got := old.renderOp(tc.op); got != tc.wantOld {
+			t.Errorf("op %v, before Render 0.11: got %d, want %d", tc.op, got, tc.wantOld)
+		}
+	}
+}
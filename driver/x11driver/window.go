@@ -10,7 +10,9 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"log"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/xgb"
 	"github.com/BurntSushi/xgb/render"
@@ -23,6 +25,7 @@ import (
 	"github.com/as/shiny/event/paint"
 	"github.com/as/shiny/event/size"
 	"github.com/as/shiny/geom"
+	"github.com/as/shiny/imageutil"
 	"github.com/as/shiny/math/f64"
 	"github.com/as/shiny/screen"
 )
@@ -39,6 +42,12 @@ type windowImpl struct {
 	// This next group of variables are mutable, but are only modified in the
 	// screenImpl.run goroutine.
 	width, height int
+	// damage is the union of the rectangles of a run of Expose events, which
+	// is sent as one paint event after the run's last event.
+	damage imageutil.Region
+
+	// clock numbers the window's frames.
+	clock screen.FrameClock
 
 	mu       sync.Mutex
 	released bool
@@ -55,17 +64,41 @@ func (w *windowImpl) Release() {
 }
 
 func (w *windowImpl) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
-	src.(*bufferImpl).upload(xproto.Drawable(w.xw), w.xg, w.s.xsi.RootDepth, dp, sr)
+	src.(*bufferImpl).wait(w.UploadAsync(dp, src, sr))
+}
+
+func (w *windowImpl) UploadAsync(dp image.Point, src screen.Buffer, sr image.Rectangle) <-chan struct{} {
+	return src.(*bufferImpl).upload(xproto.Drawable(w.xw), w.xg, w.s.xsi.RootDepth, dp, sr)
+}
+
+func (w *windowImpl) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
+	// The window's size is only tracked by the screenImpl.run goroutine, so
+	// ask the server for it instead.
+	g, err := xproto.GetGeometry(w.s.xc, xproto.Drawable(w.xw)).Reply()
+	if err != nil {
+		log.Printf("x11driver: xproto.GetGeometry: %v", err)
+		return
+	}
+	bounds := image.Rect(0, 0, int(g.Width), int(g.Height))
+	dst.(*bufferImpl).download(xproto.Drawable(w.xw), bounds, w.s.xsi.RootDepth, sr, dp)
 }
 
 func (w *windowImpl) Fill(dr image.Rectangle, src color.Color, op draw.Op) {
-	fill(w.s.xc, w.xp, dr, src, op)
+	fill(w.s, w.xp, dr, src, op)
 }
 
 func (w *windowImpl) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
 	w.s.drawUniform(w.xp, &src2dst, src, sr, op, opts)
 }
 
+func (w *windowImpl) DrawTrapezoids(src color.Color, traps []screen.Trapezoid, op draw.Op, opts *screen.DrawOptions) {
+	w.s.drawTrapezoids(w.xp, src, traps, op, opts)
+}
+
+func (w *windowImpl) DrawBatch(ops []screen.BatchOp) {
+	w.s.drawBatch(w, w.xp, ops)
+}
+
 func (w *windowImpl) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
 	src.(*textureImpl).draw(w.xp, &src2dst, sr, op, opts)
 }
@@ -91,6 +124,7 @@ func (w *windowImpl) Publish() screen.PublishResult {
 	// server can serve.
 
 	w.s.xc.Sync()
+	w.clock.Published(time.Now())
 	return screen.PublishResult{}
 }
 
@@ -113,8 +147,17 @@ func (w *windowImpl) handleConfigureNotify(ev xproto.ConfigureNotifyEvent) {
 	})
 }
 
-func (w *windowImpl) handleExpose() {
-	screen.SendPaint(paint.Event{External: true})
+func (w *windowImpl) handleExpose(ev xproto.ExposeEvent) {
+	w.damage = w.damage.Union(imageutil.NewRegion(image.Rect(
+		int(ev.X), int(ev.Y), int(ev.X)+int(ev.Width), int(ev.Y)+int(ev.Height),
+	)))
+	// A non-zero Count means that more Expose events follow, whose
+	// rectangles are part of the same damage.
+	if ev.Count != 0 {
+		return
+	}
+	w.clock.SendPaint(paint.Event{External: true, Damage: w.damage})
+	w.damage = imageutil.Region{}
 }
 
 func (w *windowImpl) handleKey(detail xproto.Keycode, state uint16, dir key.Direction) {
@@ -33,6 +33,25 @@ func Main(f func(screen.Screen)) {
 	}
 }
 
+// Probe reports whether an X11 server with the Render and MIT-SHM
+// extensions, both required by this driver, is reachable. It does not keep
+// the connection open.
+func Probe() error {
+	xc, err := xgb.NewConn()
+	if err != nil {
+		return fmt.Errorf("x11driver: xgb.NewConn failed: %v", err)
+	}
+	defer xc.Close()
+
+	if err := render.Init(xc); err != nil {
+		return fmt.Errorf("x11driver: render.Init failed: %v", err)
+	}
+	if err := shm.Init(xc); err != nil {
+		return fmt.Errorf("x11driver: shm.Init failed: %v", err)
+	}
+	return nil
+}
+
 func main(f func(screen.Screen)) (retErr error) {
 	xc, err := xgb.NewConn()
 	if err != nil {
@@ -0,0 +1,173 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package effect provides built-in screen.Effects, such as blurs and color
+// matrices, and draws screen.Effects onto a screen.Drawer.
+//
+// An Effect is run by the driver itself if the dst implements
+// screen.EffectDrawer, such as on a GPU. Otherwise, its Func computes it in
+// software, from the src pixels that are downloaded from the src Texture.
+package effect // import "github.com/as/shiny/effect"
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+var (
+	errMask = errors.New("effect: DrawOptions.Mask is not supported")
+	errFunc = errors.New("effect: the Drawer can not run shaders, and the Effect has no Func")
+)
+
+// Draw draws the src rectangle sr of src, inset by -e.Margin, through e onto
+// dst. src2dst maps src space to dst space, as for screen.Drawer.Draw.
+//
+// If dst is a screen.EffectDrawer, the driver runs e's Source. Otherwise, e's
+// Func computes the effect in software, into a Texture, created with s, that
+// is then drawn with dst.Draw.
+//
+// The Clip, Opacity and Filter in opts are honored. A Mask is not supported.
+func Draw(s screen.Screen, dst screen.Drawer, src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, e *screen.Effect, op screen.Op, opts *screen.DrawOptions) error {
+	if opts.GetMask() != nil {
+		return errMask
+	}
+	if ed, ok := dst.(screen.EffectDrawer); ok {
+		return ed.DrawEffect(src2dst, src, sr, e, op, opts)
+	}
+	if e.Func == nil {
+		return errFunc
+	}
+	sr = sr.Intersect(src.Bounds())
+	if sr.Empty() {
+		return nil
+	}
+
+	in, err := s.NewBuffer(sr.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer in.Release()
+	src.Download(sr, in, image.Point{})
+	m := *in.RGBA()
+	m.Rect = m.Rect.Add(sr.Min)
+
+	r := sr.Inset(-e.Margin)
+	out, err := s.NewBuffer(r.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer out.Release()
+	res := *out.RGBA()
+	res.Rect = res.Rect.Add(r.Min)
+	Apply(&res, &m, e, opts.GetFilter())
+
+	t, err := s.NewTexture(r.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer t.Release()
+	t.Upload(image.Point{}, out, out.Bounds())
+
+	// opts has no Mask. Its Opacity is copied as it is: a negative one, for
+	// a transparent draw, must not become the zero value, which is opaque.
+	var dopts screen.DrawOptions
+	if opts != nil {
+		dopts = *opts
+	}
+	// t's origin is r.Min in src space.
+	o := src2dst.Transform(f64.Vec2{float64(r.Min.X), float64(r.Min.Y)})
+	dst.Draw(f64.Aff3{
+		src2dst[0], src2dst[1], o[0],
+		src2dst[3], src2dst[4], o[1],
+	}, t, t.Bounds(), op, &dopts)
+	return nil
+}
+
+// Apply sets each pixel of dst to the color that e's Func computes at its
+// center, where src holds the pixels of the src rectangle, src.Bounds(). It
+// samples src with the given filter. It does nothing if e has no Func.
+//
+// It is the software implementation of an Effect that Draw falls back to.
+func Apply(dst, src *image.RGBA, e *screen.Effect, filter screen.Filter) {
+	if e.Func == nil {
+		return
+	}
+	a := &screen.EffectArgs{
+		Src:      sampler(src, filter),
+		SR:       src.Bounds(),
+		Uniforms: e.Uniforms,
+	}
+	r := dst.Bounds()
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := e.Func(a, float32(x)+0.5, float32(y)+0.5)
+			// Clamp to a valid alpha-premultiplied color.
+			alpha := clamp(c[3])
+			p := dst.Pix[dst.PixOffset(x, y):]
+			p[0] = to8(math.Min(clamp(c[0]), alpha))
+			p[1] = to8(math.Min(clamp(c[1]), alpha))
+			p[2] = to8(math.Min(clamp(c[2]), alpha))
+			p[3] = to8(alpha)
+		}
+	}
+}
+
+func clamp(v float32) float64 {
+	return math.Max(0, math.Min(1, float64(v)))
+}
+
+func to8(v float64) uint8 {
+	return uint8(v*255 + 0.5)
+}
+
+// sampler returns the src function of an EffectArgs for m, which is
+// transparent outside of m's bounds, like src in an Effect's Source.
+func sampler(m *image.RGBA, filter screen.Filter) func(x, y float32) [4]float32 {
+	r := m.Bounds()
+	at := func(x, y int) [4]float32 {
+		// Clamp to the edge, like the drivers' textures.
+		x = min(max(x, r.Min.X), r.Max.X-1)
+		y = min(max(y, r.Min.Y), r.Max.Y-1)
+		p := m.Pix[m.PixOffset(x, y):]
+		return [4]float32{
+			float32(p[0]) / 255,
+			float32(p[1]) / 255,
+			float32(p[2]) / 255,
+			float32(p[3]) / 255,
+		}
+	}
+	inside := func(x, y float32) bool {
+		return x >= float32(r.Min.X) && y >= float32(r.Min.Y) && x <= float32(r.Max.X) && y <= float32(r.Max.Y)
+	}
+	if filter == screen.FilterNearest {
+		return func(x, y float32) [4]float32 {
+			if !inside(x, y) {
+				return [4]float32{}
+			}
+			return at(int(math.Floor(float64(x))), int(math.Floor(float64(y))))
+		}
+	}
+	return func(x, y float32) [4]float32 {
+		if !inside(x, y) {
+			return [4]float32{}
+		}
+		// Interpolate between the four nearest pixel centers.
+		fx, fy := math.Floor(float64(x)-0.5), math.Floor(float64(y)-0.5)
+		tx, ty := float32(float64(x)-0.5-fx), float32(float64(y)-0.5-fy)
+		x0, y0 := int(fx), int(fy)
+		c00, c10 := at(x0, y0), at(x0+1, y0)
+		c01, c11 := at(x0, y0+1), at(x0+1, y0+1)
+		var c [4]float32
+		for i := range c {
+			top := c00[i] + (c10[i]-c00[i])*tx
+			bot := c01[i] + (c11[i]-c01[i])*tx
+			c[i] = top + (bot-top)*ty
+		}
+		return c
+	}
+}
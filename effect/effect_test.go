@@ -0,0 +1,236 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package effect
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+// testScreen implements the parts of a screen.Screen that Draw's software
+// path uses: NewBuffer, NewTexture, and the Textures' Upload, Download and
+// Draw. It counts the live Textures.
+type testScreen struct {
+	screen.Screen
+	textures int
+}
+
+type testBuffer struct {
+	screen.Buffer
+	m *image.RGBA
+}
+
+func (b *testBuffer) Release()                {}
+func (b *testBuffer) Bounds() image.Rectangle { return b.m.Bounds() }
+func (b *testBuffer) RGBA() *image.RGBA       { return b.m }
+
+type testTexture struct {
+	screen.Texture
+	s *testScreen
+	m *image.RGBA
+	// opts are the DrawOptions of the last Draw onto the texture.
+	opts screen.DrawOptions
+}
+
+func (s *testScreen) NewBuffer(size image.Point, opts *screen.NewBufferOptions) (screen.Buffer, error) {
+	return &testBuffer{m: image.NewRGBA(image.Rectangle{Max: size})}, nil
+}
+
+func (s *testScreen) NewTexture(size image.Point, opts *screen.NewTextureOptions) (screen.Texture, error) {
+	s.textures++
+	return &testTexture{s: s, m: image.NewRGBA(image.Rectangle{Max: size})}, nil
+}
+
+func (t *testTexture) Release()                { t.s.textures-- }
+func (t *testTexture) Bounds() image.Rectangle { return t.m.Bounds() }
+
+func (t *testTexture) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
+	draw.Draw(t.m, sr.Sub(sr.Min).Add(dp), src.RGBA(), sr.Min, draw.Src)
+}
+
+func (t *testTexture) Download(sr image.Rectangle, dst screen.Buffer, dp image.Point) {
+	draw.Draw(dst.RGBA(), sr.Sub(sr.Min).Add(dp), t.m, sr.Min, draw.Src)
+}
+
+// Draw only supports translations, which is all that Draw uses here.
+func (t *testTexture) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	dp := image.Point{int(src2dst[2]), int(src2dst[5])}
+	draw.Draw(t.m, sr.Add(dp), src.(*testTexture).m, sr.Min, op)
+	if opts != nil {
+		t.opts = *opts
+	}
+}
+
+// effectDrawer is a screen.EffectDrawer that records its last call.
+type effectDrawer struct {
+	screen.Drawer
+	sr image.Rectangle
+	e  *screen.Effect
+}
+
+func (d *effectDrawer) DrawEffect(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, e *screen.Effect, op screen.Op, opts *screen.DrawOptions) error {
+	d.sr, d.e = sr, e
+	return nil
+}
+
+func fill(r image.Rectangle, c color.RGBA) *image.RGBA {
+	m := image.NewRGBA(r)
+	draw.Draw(m, r, image.NewUniform(c), image.Point{}, draw.Src)
+	return m
+}
+
+func TestApply(t *testing.T) {
+	white := color.RGBA{0xff, 0xff, 0xff, 0xff}
+	invert := ColorMatrix([20]float32{
+		-1, 0, 0, 0, 1,
+		0, -1, 0, 0, 1,
+		0, 0, -1, 0, 1,
+		0, 0, 0, 1, 0,
+	})
+	testCases := []struct {
+		desc string
+		e    *screen.Effect
+		src  *image.RGBA
+		want map[image.Point]color.RGBA
+	}{{
+		desc: "gamma",
+		e:    Gamma(2),
+		src:  fill(image.Rect(0, 0, 4, 4), color.RGBA{0x40, 0x40, 0x40, 0xff}),
+		want: map[image.Point]color.RGBA{
+			{1, 1}: {0x80, 0x80, 0x80, 0xff},
+		},
+	}, {
+		desc: "invert",
+		e:    invert,
+		src:  fill(image.Rect(0, 0, 4, 4), color.RGBA{0xff, 0, 0, 0xff}),
+		want: map[image.Point]color.RGBA{
+			{1, 1}: {0, 0xff, 0xff, 0xff},
+		},
+	}, {
+		desc: "invert translucent",
+		e:    invert,
+		src:  fill(image.Rect(0, 0, 4, 4), color.RGBA{0x80, 0, 0, 0x80}),
+		want: map[image.Point]color.RGBA{
+			{1, 1}: {0, 0x80, 0x80, 0x80},
+		},
+	}, {
+		desc: "rounded corners",
+		e:    RoundedCorners(4),
+		src:  fill(image.Rect(10, 10, 26, 26), white),
+		want: map[image.Point]color.RGBA{
+			{10, 10}: {},
+			{25, 25}: {},
+			{10, 18}: white,
+			{18, 18}: white,
+		},
+	}, {
+		desc: "blur",
+		e:    Blur(2),
+		src:  fill(image.Rect(0, 0, 16, 16), white),
+		want: map[image.Point]color.RGBA{
+			{8, 8}: white,
+		},
+	}, {
+		desc: "drop shadow",
+		e:    DropShadow(4, 4, 0, color.Black),
+		src:  fill(image.Rect(0, 0, 8, 8), white),
+		want: map[image.Point]color.RGBA{
+			{2, 2}:   white,
+			{10, 10}: {0, 0, 0, 0xff},
+			{-2, -2}: {},
+			{10, 2}:  {},
+		},
+	}}
+	for _, tc := range testCases {
+		dst := image.NewRGBA(tc.src.Bounds().Inset(-tc.e.Margin))
+		Apply(dst, tc.src, tc.e, screen.FilterDefault)
+		for p, want := range tc.want {
+			if got := dst.RGBAAt(p.X, p.Y); got != want {
+				t.Errorf("%s: at %v: got %v, want %v", tc.desc, p, got, want)
+			}
+		}
+	}
+}
+
+func TestBlurEdge(t *testing.T) {
+	src := fill(image.Rect(0, 0, 16, 16), color.RGBA{0xff, 0xff, 0xff, 0xff})
+	e := Blur(2)
+	dst := image.NewRGBA(src.Bounds().Inset(-e.Margin))
+	Apply(dst, src, e, screen.FilterDefault)
+
+	// The alpha falls off across the edge.
+	prev := uint8(0xff)
+	for x := 8; x >= -e.Margin; x-- {
+		a := dst.RGBAAt(x, 8).A
+		if a > prev {
+			t.Errorf("alpha at x=%d is %#x, more than %#x at x=%d", x, a, prev, x+1)
+		}
+		prev = a
+	}
+	if a := dst.RGBAAt(-1, 8).A; a == 0 || a == 0xff {
+		t.Errorf("alpha just outside the edge: got %#x, want partial", a)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	s := &testScreen{}
+	src, _ := s.NewTexture(image.Point{8, 8}, nil)
+	draw.Draw(src.(*testTexture).m, image.Rect(0, 0, 8, 8), image.White, image.Point{}, draw.Src)
+	dst, _ := s.NewTexture(image.Point{32, 32}, nil)
+	e := DropShadow(4, 4, 0, color.Black)
+
+	err := Draw(s, dst, f64.Aff3{1, 0, 10, 0, 1, 10}, src, src.Bounds(), e, screen.Over, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.textures != 2 {
+		t.Errorf("leaked %d textures", s.textures-2)
+	}
+	m := dst.(*testTexture).m
+	for _, tc := range []struct {
+		p    image.Point
+		want color.RGBA
+	}{
+		{image.Point{12, 12}, color.RGBA{0xff, 0xff, 0xff, 0xff}},
+		{image.Point{20, 20}, color.RGBA{0, 0, 0, 0xff}},
+		{image.Point{8, 8}, color.RGBA{}},
+	} {
+		if got := m.RGBAAt(tc.p.X, tc.p.Y); got != tc.want {
+			t.Errorf("at %v: got %v, want %v", tc.p, got, tc.want)
+		}
+	}
+
+	// A transparent Opacity stays transparent.
+	opts := &screen.DrawOptions{Opacity: -1, Clip: image.Rect(0, 0, 30, 30)}
+	if err := Draw(s, dst, f64.Aff3{1, 0, 0, 0, 1, 0}, src, src.Bounds(), e, screen.Src, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := dst.(*testTexture).opts; got != *opts {
+		t.Errorf("DrawOptions: got %+v, want %+v", got, *opts)
+	}
+
+	ed := &effectDrawer{}
+	sr := image.Rect(1, 2, 3, 4)
+	if err := Draw(s, ed, f64.Aff3{1, 0, 0, 0, 1, 0}, src, sr, e, screen.Over, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ed.sr != sr || ed.e != e {
+		t.Errorf("EffectDrawer: got %v, %p, want %v, %p", ed.sr, ed.e, sr, e)
+	}
+
+	opts = &screen.DrawOptions{Mask: src}
+	if err := Draw(s, ed, f64.Aff3{1, 0, 0, 0, 1, 0}, src, sr, e, screen.Over, opts); err != errMask {
+		t.Errorf("with a Mask: got %v, want %v", err, errMask)
+	}
+	custom := &screen.Effect{Source: "vec4 effect(vec2 p) { return src(p); }"}
+	if err := Draw(s, dst, f64.Aff3{1, 0, 0, 0, 1, 0}, src, sr, custom, screen.Over, nil); err != errFunc {
+		t.Errorf("without a Func: got %v, want %v", err, errFunc)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package effect
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/as/shiny/screen"
+)
+
+// blurSrc defines blur, which approximates a Gaussian blur of src, with the
+// standard deviation of the sigma uniform, by sampling src on a 9×9 grid
+// spanning 3 standard deviations in each direction. Its weights are those of
+// blurWeights.
+const blurSrc = `
+uniform float sigma;
+vec4 blur(vec2 p) {
+	vec4 sum = vec4(0.0);
+	float total = 0.0;
+	for (int i = -4; i <= 4; i++) {
+		for (int j = -4; j <= 4; j++) {
+			vec2 d = vec2(float(i), float(j));
+			float w = exp(-0.28125 * dot(d, d));
+			sum += w * src(p + d * (0.75 * sigma));
+			total += w;
+		}
+	}
+	return sum / total;
+}
+`
+
+// blurWeights are the normalized weights of blurSrc's grid.
+var blurWeights = func() (w [9][9]float32) {
+	total := 0.0
+	for i := range w {
+		for j := range w[i] {
+			d := float64((i-4)*(i-4) + (j-4)*(j-4))
+			total += math.Exp(-0.28125 * d)
+		}
+	}
+	for i := range w {
+		for j := range w[i] {
+			d := float64((i-4)*(i-4) + (j-4)*(j-4))
+			w[i][j] = float32(math.Exp(-0.28125*d) / total)
+		}
+	}
+	return w
+}()
+
+// blur is blurSrc's blur, in Go.
+func blur(a *screen.EffectArgs, x, y float32) [4]float32 {
+	k := 0.75 * a.Uniforms["sigma"][0]
+	var sum [4]float32
+	for i := range blurWeights {
+		for j, w := range blurWeights[i] {
+			c := a.Src(x+float32(i-4)*k, y+float32(j-4)*k)
+			for n := range sum {
+				sum[n] += w * c[n]
+			}
+		}
+	}
+	return sum
+}
+
+func blurMargin(sigma float32) int {
+	return int(math.Ceil(3 * math.Abs(float64(sigma))))
+}
+
+// Blur returns an Effect that blurs the src with approximately a Gaussian of
+// standard deviation sigma, in src pixels. Large blurs are coarse, as the src
+// is sampled at 81 points for each pixel.
+func Blur(sigma float32) *screen.Effect {
+	return &screen.Effect{
+		Source: blurSrc + `
+vec4 effect(vec2 p) {
+	return blur(p);
+}
+`,
+		Uniforms: map[string][]float32{"sigma": {sigma}},
+		Margin:   blurMargin(sigma),
+		Func:     blur,
+	}
+}
+
+// DropShadow returns an Effect that draws the src over its shadow: the
+// src's alpha, offset by (dx, dy) and blurred as per Blur(sigma), in the
+// color c.
+func DropShadow(dx, dy, sigma float32, c color.Color) *screen.Effect {
+	r, g, b, a := c.RGBA()
+	off := math.Max(math.Abs(float64(dx)), math.Abs(float64(dy)))
+	return &screen.Effect{
+		Source: blurSrc + `
+uniform vec2 offset;
+uniform vec4 color;
+vec4 effect(vec2 p) {
+	vec4 s = src(p);
+	return s + color * (blur(p - offset).a * (1.0 - s.a));
+}
+`,
+		Uniforms: map[string][]float32{
+			"sigma":  {sigma},
+			"offset": {dx, dy},
+			"color":  {float32(r) / 0xffff, float32(g) / 0xffff, float32(b) / 0xffff, float32(a) / 0xffff},
+		},
+		Margin: blurMargin(sigma) + int(math.Ceil(off)),
+		Func:   dropShadow,
+	}
+}
+
+func dropShadow(a *screen.EffectArgs, x, y float32) [4]float32 {
+	off, color := a.Uniforms["offset"], a.Uniforms["color"]
+	s := a.Src(x, y)
+	k := blur(a, x-off[0], y-off[1])[3] * (1 - s[3])
+	for i := range s {
+		s[i] += color[i] * k
+	}
+	return s
+}
+
+// ColorMatrix returns an Effect that transforms each non-premultiplied src
+// color, with channels from 0 to 1, by the 4×5 matrix m, in row major order:
+//
+//	R' = m[0]*R + m[1]*G + m[2]*B + m[3]*A + m[4]
+//	G' = m[5]*R + ...
+//
+// and so on for B' and A'. The results are clamped to [0, 1].
+func ColorMatrix(m [20]float32) *screen.Effect {
+	// GLSL takes the 4×4 part in column major order, and the last column
+	// as a separate vector.
+	mat := make([]float32, 16)
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			mat[4*col+row] = m[5*row+col]
+		}
+	}
+	return &screen.Effect{
+		Source: `
+uniform mat4 matrix;
+uniform vec4 offset;
+vec4 effect(vec2 p) {
+	vec4 c = src(p);
+	if (c.a > 0.0) {
+		c.rgb /= c.a;
+	}
+	c = clamp(matrix * c + offset, 0.0, 1.0);
+	return vec4(c.rgb * c.a, c.a);
+}
+`,
+		Uniforms: map[string][]float32{
+			"matrix": mat,
+			"offset": {m[4], m[9], m[14], m[19]},
+		},
+		Func: colorMatrix,
+	}
+}
+
+func colorMatrix(a *screen.EffectArgs, x, y float32) [4]float32 {
+	mat, off := a.Uniforms["matrix"], a.Uniforms["offset"]
+	c := unpremultiply(a.Src(x, y))
+	var d [4]float32
+	for row := range d {
+		v := off[row]
+		for col := range c {
+			v += mat[4*col+row] * c[col]
+		}
+		d[row] = float32(clamp(v))
+	}
+	return premultiply(d)
+}
+
+// Gamma returns an Effect that gamma corrects the src: each non-premultiplied
+// color channel c becomes c^(1/g). g must be positive.
+func Gamma(g float32) *screen.Effect {
+	return &screen.Effect{
+		Source: `
+uniform float exponent;
+vec4 effect(vec2 p) {
+	vec4 c = src(p);
+	if (c.a <= 0.0) {
+		return vec4(0.0);
+	}
+	return vec4(pow(c.rgb / c.a, vec3(exponent)) * c.a, c.a);
+}
+`,
+		Uniforms: map[string][]float32{"exponent": {1 / g}},
+		Func:     gamma,
+	}
+}
+
+func gamma(a *screen.EffectArgs, x, y float32) [4]float32 {
+	e := float64(a.Uniforms["exponent"][0])
+	c := unpremultiply(a.Src(x, y))
+	for i := 0; i < 3; i++ {
+		c[i] = float32(math.Pow(float64(c[i]), e))
+	}
+	return premultiply(c)
+}
+
+// RoundedCorners returns an Effect that rounds the corners of the src
+// rectangle, with an anti-aliased edge, to quarter circles of the given
+// radius, in src pixels. The radius is at most half of the src rectangle's
+// shorter side.
+func RoundedCorners(radius float32) *screen.Effect {
+	// A radius under half a pixel makes no difference, and keeps the
+	// coverage of pixels away from the corners below 1.
+	if radius < 0.5 {
+		radius = 0.5
+	}
+	return &screen.Effect{
+		Source: `
+uniform float radius;
+vec4 effect(vec2 p) {
+	vec2 c = (srcRect.xy + srcRect.zw) * 0.5;
+	vec2 h = (srcRect.zw - srcRect.xy) * 0.5;
+	float r = min(radius, min(h.x, h.y));
+	vec2 q = clamp(p, c - h + r, c + h - r);
+	return src(p) * clamp(r + 0.5 - length(p - q), 0.0, 1.0);
+}
+`,
+		Uniforms: map[string][]float32{"radius": {radius}},
+		Func:     roundedCorners,
+	}
+}
+
+func roundedCorners(a *screen.EffectArgs, x, y float32) [4]float32 {
+	cx := float64(a.SR.Min.X+a.SR.Max.X) / 2
+	cy := float64(a.SR.Min.Y+a.SR.Max.Y) / 2
+	hx := float64(a.SR.Dx()) / 2
+	hy := float64(a.SR.Dy()) / 2
+	r := math.Min(float64(a.Uniforms["radius"][0]), math.Min(hx, hy))
+	qx := math.Max(cx-hx+r, math.Min(cx+hx-r, float64(x)))
+	qy := math.Max(cy-hy+r, math.Min(cy+hy-r, float64(y)))
+	k := float32(clamp(float32(r + 0.5 - math.Hypot(float64(x)-qx, float64(y)-qy))))
+	c := a.Src(x, y)
+	for i := range c {
+		c[i] *= k
+	}
+	return c
+}
+
+func unpremultiply(c [4]float32) [4]float32 {
+	if c[3] > 0 {
+		c[0] /= c[3]
+		c[1] /= c[3]
+		c[2] /= c[3]
+	}
+	return c
+}
+
+func premultiply(c [4]float32) [4]float32 {
+	c[0] *= c[3]
+	c[1] *= c[3]
+	c[2] *= c[3]
+	return c
+}
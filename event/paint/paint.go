@@ -4,6 +4,19 @@
 
 package paint
 
+import (
+	"time"
+
+	"github.com/as/shiny/imageutil"
+)
+
+// Event is sent when a window needs to be painted.
+//
+// Events do not say which window they are for, and every window sends them
+// on the same channel, where a pending event is merged with the next one.
+// Damage, Frame and Target are therefore only meaningful for a program with
+// a single window. With more, the Damage of one window may include that of
+// another, and Frame and Target may be those of either window.
 type Event struct {
 	// External is true for paint events sent by the screen driver.
 	//
@@ -15,4 +28,39 @@ type Event struct {
 	// should ignore external paint events to avoid a backlog of paint
 	// events building up.
 	External bool
+
+	// Damage is the part of the window, in pixels, whose contents were lost
+	// and need to be painted again. It is empty when the whole window needs
+	// to be painted, such as when the driver does not know which part does.
+	Damage imageutil.Region
+
+	// Frame is the number of the frame that the window is drawing, which
+	// increases by one each time the window is published.
+	Frame uint64
+
+	// Target is an estimate of when the frame will be presented, if it is
+	// published in time. It is the zero time if it is not known.
+	Target time.Time
+}
+
+// Whole reports whether the whole window needs to be painted, rather than
+// only its Damage.
+func (e Event) Whole() bool {
+	return e.Damage.Empty()
+}
+
+// Frame is sent when a window is ready for its next frame, after it was
+// published. Programs that animate can draw a frame for each Frame event,
+// to draw no faster than the screen presents them.
+//
+// Like an Event, a Frame does not say which window it is for, and only the
+// latest pending one is kept, so it is only meaningful for a program with a
+// single window.
+type Frame struct {
+	// Frame is the number of the frame that is ready to be drawn.
+	Frame uint64
+
+	// Target is an estimate of when the frame will be presented, if it is
+	// published in time. It is the zero time if it is not known.
+	Target time.Time
 }
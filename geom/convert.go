@@ -0,0 +1,137 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geom
+
+import (
+	"image"
+	"math"
+
+	"github.com/as/shiny/math/fixed"
+)
+
+// Common lengths. To count the number of units in a Pt, use Pt.Units:
+//
+//	margin := 5 * geom.Millimeter
+//	fmt.Println(margin.Units(geom.Inch)) // 0.19685
+const (
+	Inch       Pt = 72
+	Millimeter Pt = Inch / 25.4
+	Centimeter Pt = 10 * Millimeter
+
+	// Dp is a density-independent pixel, 1/160 of an inch, as on Android.
+	Dp Pt = Inch / 160
+)
+
+// Ems returns the length of n ems, for a font of the given size.
+func Ems(n float32, fontSize Pt) Pt { return Pt(n) * fontSize }
+
+// Units returns the number of u in p, for example p.Units(geom.Millimeter)
+// is p's length in millimeters.
+func (p Pt) Units(u Pt) float32 { return float32(p / u) }
+
+// Rounding is how lengths in Pt are rounded to whole pixels.
+type Rounding int
+
+const (
+	// RoundNearest rounds to the nearest pixel, and halves away from zero.
+	RoundNearest Rounding = iota
+	// RoundDown rounds toward negative infinity.
+	RoundDown
+	// RoundUp rounds toward positive infinity.
+	RoundUp
+	// RoundOut rounds a Rectangle to the smallest pixel rectangle that
+	// contains it, rounding its Min down and its Max up. For Pts and Points
+	// it is the same as RoundUp.
+	RoundOut
+	// RoundIn rounds a Rectangle to the largest pixel rectangle that it
+	// contains, rounding its Min up and its Max down. For Pts and Points it
+	// is the same as RoundDown.
+	RoundIn
+)
+
+func (r Rounding) round(x float32) int {
+	switch r {
+	case RoundDown, RoundIn:
+		return int(math.Floor(float64(x)))
+	case RoundUp, RoundOut:
+		return int(math.Ceil(float64(x)))
+	}
+	return int(math.Round(float64(x)))
+}
+
+// PxInt converts the length to a whole number of device pixels, rounded by
+// r.
+func (p Pt) PxInt(pixelsPerPt float32, r Rounding) int { return r.round(p.Px(pixelsPerPt)) }
+
+// FromPx converts a length in device pixels to a Pt. The pixelsPerPt is
+// usually that of a size.Event.
+func FromPx(px, pixelsPerPt float32) Pt { return Pt(px / pixelsPerPt) }
+
+// Fixed converts the length to device pixels, as a 26.6 fixed-point number,
+// rounded to the nearest 1/64 of a pixel.
+func (p Pt) Fixed(pixelsPerPt float32) fixed.Int26_6 {
+	return fixed.Int26_6(math.Round(float64(p.Px(pixelsPerPt)) * 64))
+}
+
+// FromFixed converts a length in device pixels, as a 26.6 fixed-point number,
+// to a Pt.
+func FromFixed(x fixed.Int26_6, pixelsPerPt float32) Pt {
+	return FromPx(float32(x)/64, pixelsPerPt)
+}
+
+// Px converts the point to device pixels, rounded by r.
+func (p Point) Px(pixelsPerPt float32, r Rounding) image.Point {
+	return image.Point{p.X.PxInt(pixelsPerPt, r), p.Y.PxInt(pixelsPerPt, r)}
+}
+
+// PointFromPx converts a point in device pixels to a Point.
+func PointFromPx(p image.Point, pixelsPerPt float32) Point {
+	return Point{FromPx(float32(p.X), pixelsPerPt), FromPx(float32(p.Y), pixelsPerPt)}
+}
+
+// Fixed converts the point to device pixels, as 26.6 fixed-point numbers.
+func (p Point) Fixed(pixelsPerPt float32) fixed.Point26_6 {
+	return fixed.Point26_6{X: p.X.Fixed(pixelsPerPt), Y: p.Y.Fixed(pixelsPerPt)}
+}
+
+// PointFromFixed converts a point in device pixels, as 26.6 fixed-point
+// numbers, to a Point.
+func PointFromFixed(p fixed.Point26_6, pixelsPerPt float32) Point {
+	return Point{FromFixed(p.X, pixelsPerPt), FromFixed(p.Y, pixelsPerPt)}
+}
+
+// Px converts the rectangle to device pixels, rounded by mode.
+func (r Rectangle) Px(pixelsPerPt float32, mode Rounding) image.Rectangle {
+	lo, hi := mode, mode
+	switch mode {
+	case RoundOut:
+		lo, hi = RoundDown, RoundUp
+	case RoundIn:
+		lo, hi = RoundUp, RoundDown
+	}
+	s := image.Rectangle{r.Min.Px(pixelsPerPt, lo), r.Max.Px(pixelsPerPt, hi)}
+	if s.Empty() {
+		// RoundIn can round a thin rectangle to an inside out one.
+		return image.Rectangle{}
+	}
+	return s
+}
+
+// RectFromPx converts a rectangle in device pixels to a Rectangle.
+func RectFromPx(r image.Rectangle, pixelsPerPt float32) Rectangle {
+	return Rectangle{PointFromPx(r.Min, pixelsPerPt), PointFromPx(r.Max, pixelsPerPt)}
+}
+
+// Fixed converts the rectangle to device pixels, as 26.6 fixed-point
+// numbers.
+func (r Rectangle) Fixed(pixelsPerPt float32) fixed.Rectangle26_6 {
+	return fixed.Rectangle26_6{Min: r.Min.Fixed(pixelsPerPt), Max: r.Max.Fixed(pixelsPerPt)}
+}
+
+// RectFromFixed converts a rectangle in device pixels, as 26.6 fixed-point
+// numbers, to a Rectangle.
+func RectFromFixed(r fixed.Rectangle26_6, pixelsPerPt float32) Rectangle {
+	return Rectangle{PointFromFixed(r.Min, pixelsPerPt), PointFromFixed(r.Max, pixelsPerPt)}
+}
@@ -4,7 +4,10 @@
 
 package geom
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // Pt is a length.
 //
@@ -20,6 +23,13 @@ func (p Pt) Px(pixelsPerPt float32) float32 { return float32(p) * pixelsPerPt }
 // String returns a string representation of p like "3.2pt".
 func (p Pt) String() string { return fmt.Sprintf("%.2fpt", p) }
 
+// Eq reports whether p and q differ by at most eps.
+func (p Pt) Eq(q, eps Pt) bool { return abs(p-q) <= eps }
+
+func abs(p Pt) Pt {
+	return Pt(math.Abs(float64(p)))
+}
+
 // Point is a point in a two-dimensional plane.
 type Point struct {
 	X, Y Pt
@@ -28,15 +38,162 @@ type Point struct {
 // String returns a string representation of p like "(1.2,3.4)".
 func (p Point) String() string { return fmt.Sprintf("(%.2f,%.2f)", p.X, p.Y) }
 
+// Add returns the vector p+q.
+func (p Point) Add(q Point) Point { return Point{p.X + q.X, p.Y + q.Y} }
+
+// Sub returns the vector p-q.
+func (p Point) Sub(q Point) Point { return Point{p.X - q.X, p.Y - q.Y} }
+
+// Mul returns the vector p*k.
+func (p Point) Mul(k float32) Point { return Point{p.X * Pt(k), p.Y * Pt(k)} }
+
+// Div returns the vector p/k.
+func (p Point) Div(k float32) Point { return Point{p.X / Pt(k), p.Y / Pt(k)} }
+
+// In reports whether p is in r.
+func (p Point) In(r Rectangle) bool {
+	return r.Min.X <= p.X && p.X < r.Max.X &&
+		r.Min.Y <= p.Y && p.Y < r.Max.Y
+}
+
+// Eq reports whether each of p's coordinates differs from q's by at most
+// eps. Computed points are rarely exactly equal, so a small eps, such as
+// 1/64 of a Pt, is usually wanted.
+func (p Point) Eq(q Point, eps Pt) bool {
+	return p.X.Eq(q.X, eps) && p.Y.Eq(q.Y, eps)
+}
+
 // A Rectangle is region of points.
 // The top-left point is Min, and the bottom-right point is Max.
+//
+// Like image.Rectangle, it contains the points with Min.X <= X < Max.X and
+// Min.Y <= Y < Max.Y, and it is well-formed if Min.X <= Max.X and likewise
+// for Y.
 type Rectangle struct {
 	Min, Max Point
 }
 
+// Rect is shorthand for Rectangle{Point{x0, y0}, Point{x1, y1}}. The returned
+// rectangle has minimum and maximum coordinates swapped if necessary so that
+// it is well-formed.
+func Rect(x0, y0, x1, y1 Pt) Rectangle {
+	return Rectangle{Point{x0, y0}, Point{x1, y1}}.Canon()
+}
+
 // String returns a string representation of r like "(3,4)-(6,5)".
 func (r Rectangle) String() string { return r.Min.String() + "-" + r.Max.String() }
 
+// Dx returns r's width.
+func (r Rectangle) Dx() Pt { return r.Max.X - r.Min.X }
+
+// Dy returns r's height.
+func (r Rectangle) Dy() Pt { return r.Max.Y - r.Min.Y }
+
+// Size returns r's width and height.
+func (r Rectangle) Size() Point { return r.Max.Sub(r.Min) }
+
+// Center returns the point in the middle of r.
+func (r Rectangle) Center() Point { return r.Min.Add(r.Max).Div(2) }
+
+// Add returns the rectangle r translated by p.
+func (r Rectangle) Add(p Point) Rectangle { return Rectangle{r.Min.Add(p), r.Max.Add(p)} }
+
+// Sub returns the rectangle r translated by -p.
+func (r Rectangle) Sub(p Point) Rectangle { return Rectangle{r.Min.Sub(p), r.Max.Sub(p)} }
+
+// Inset returns the rectangle r inset by n, which may be negative. If either
+// of r's dimensions is less than 2*n then an empty rectangle near the center
+// of r will be returned.
+func (r Rectangle) Inset(n Pt) Rectangle {
+	if r.Dx() < 2*n {
+		r.Min.X = (r.Min.X + r.Max.X) / 2
+		r.Max.X = r.Min.X
+	} else {
+		r.Min.X += n
+		r.Max.X -= n
+	}
+	if r.Dy() < 2*n {
+		r.Min.Y = (r.Min.Y + r.Max.Y) / 2
+		r.Max.Y = r.Min.Y
+	} else {
+		r.Min.Y += n
+		r.Max.Y -= n
+	}
+	return r
+}
+
+// Intersect returns the largest rectangle contained by both r and s. If the
+// two rectangles do not overlap then the zero rectangle will be returned.
+func (r Rectangle) Intersect(s Rectangle) Rectangle {
+	r = Rectangle{
+		Point{max(r.Min.X, s.Min.X), max(r.Min.Y, s.Min.Y)},
+		Point{min(r.Max.X, s.Max.X), min(r.Max.Y, s.Max.Y)},
+	}
+	if r.Empty() {
+		return Rectangle{}
+	}
+	return r
+}
+
+// Union returns the smallest rectangle that contains both r and s.
+func (r Rectangle) Union(s Rectangle) Rectangle {
+	if r.Empty() {
+		return s
+	}
+	if s.Empty() {
+		return r
+	}
+	return Rectangle{
+		Point{min(r.Min.X, s.Min.X), min(r.Min.Y, s.Min.Y)},
+		Point{max(r.Max.X, s.Max.X), max(r.Max.Y, s.Max.Y)},
+	}
+}
+
+// Empty reports whether the rectangle contains no points.
+func (r Rectangle) Empty() bool {
+	return r.Min.X >= r.Max.X || r.Min.Y >= r.Max.Y
+}
+
+// Eq reports whether r and s contain the same points, to within eps: either
+// both are empty, or their corners differ by at most eps.
+func (r Rectangle) Eq(s Rectangle, eps Pt) bool {
+	if r.Empty() && s.Empty() {
+		return true
+	}
+	return r.Min.Eq(s.Min, eps) && r.Max.Eq(s.Max, eps)
+}
+
+// Overlaps reports whether r and s have a non-empty intersection.
+func (r Rectangle) Overlaps(s Rectangle) bool {
+	return !r.Empty() && !s.Empty() &&
+		r.Min.X < s.Max.X && s.Min.X < r.Max.X &&
+		r.Min.Y < s.Max.Y && s.Min.Y < r.Max.Y
+}
+
+// In reports whether every point in r is in s.
+func (r Rectangle) In(s Rectangle) bool {
+	if r.Empty() {
+		return true
+	}
+	// Note that r.Max is an exclusive bound for r, so that r.In(s)
+	// does not require that r.Max.In(s).
+	return s.Min.X <= r.Min.X && r.Max.X <= s.Max.X &&
+		s.Min.Y <= r.Min.Y && r.Max.Y <= s.Max.Y
+}
+
+// Canon returns the canonical version of r. The returned rectangle has
+// minimum and maximum coordinates swapped if necessary so that it is
+// well-formed.
+func (r Rectangle) Canon() Rectangle {
+	if r.Max.X < r.Min.X {
+		r.Min.X, r.Max.X = r.Max.X, r.Min.X
+	}
+	if r.Max.Y < r.Min.Y {
+		r.Min.Y, r.Max.Y = r.Max.Y, r.Min.Y
+	}
+	return r
+}
+
 /*
 The coordinate system is based on an left-handed Cartesian plane.
 That is, X increases to the right and Y increases down. For (x,y),
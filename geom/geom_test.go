@@ -0,0 +1,195 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geom
+
+import (
+	"image"
+	"testing"
+
+	"github.com/as/shiny/math/fixed"
+)
+
+const eps = 1.0 / 1024
+
+func TestPoint(t *testing.T) {
+	p, q := Point{1.5, 2}, Point{0.25, -1}
+	if got, want := p.Add(q), (Point{1.75, 1}); got != want {
+		t.Errorf("Add: got %v, want %v", got, want)
+	}
+	if got, want := p.Sub(q), (Point{1.25, 3}); got != want {
+		t.Errorf("Sub: got %v, want %v", got, want)
+	}
+	if got, want := p.Mul(2), (Point{3, 4}); got != want {
+		t.Errorf("Mul: got %v, want %v", got, want)
+	}
+	if got, want := p.Div(2), (Point{0.75, 1}); got != want {
+		t.Errorf("Div: got %v, want %v", got, want)
+	}
+	if !p.Eq(Point{1.5 + eps/2, 2 - eps/2}, eps) || p.Eq(Point{1.5 + 2*eps, 2}, eps) {
+		t.Errorf("Eq: wrong for eps %v", eps)
+	}
+	r := Rect(0, 0, 2, 2)
+	for _, tc := range []struct {
+		p    Point
+		want bool
+	}{
+		{Point{0, 0}, true},
+		{Point{1.5, 2}, false},
+		{Point{1.99, 1.99}, true},
+		{Point{-0.01, 1}, false},
+	} {
+		if got := tc.p.In(r); got != tc.want {
+			t.Errorf("%v.In(%v): got %t, want %t", tc.p, r, got, tc.want)
+		}
+	}
+}
+
+func TestRectangle(t *testing.T) {
+	r := Rect(4, 3, 0, 1)
+	if want := (Rectangle{Point{0, 1}, Point{4, 3}}); r != want {
+		t.Fatalf("Rect: got %v, want %v", r, want)
+	}
+	if r.Dx() != 4 || r.Dy() != 2 || r.Size() != (Point{4, 2}) {
+		t.Errorf("size: got %v, %v, %v", r.Dx(), r.Dy(), r.Size())
+	}
+	if got, want := r.Center(), (Point{2, 2}); got != want {
+		t.Errorf("Center: got %v, want %v", got, want)
+	}
+	if got, want := r.Add(Point{1, 1}).Sub(Point{0.5, 0.5}), Rect(0.5, 1.5, 4.5, 3.5); got != want {
+		t.Errorf("Add, Sub: got %v, want %v", got, want)
+	}
+	if got, want := r.Inset(0.5), Rect(0.5, 1.5, 3.5, 2.5); got != want {
+		t.Errorf("Inset: got %v, want %v", got, want)
+	}
+	if got := r.Inset(1.5); !got.Empty() || got.Min != (Point{1.5, 2}) {
+		t.Errorf("Inset past the center: got %v", got)
+	}
+	if got, want := r.Inset(-1), Rect(-1, 0, 5, 4); got != want {
+		t.Errorf("Inset outward: got %v, want %v", got, want)
+	}
+
+	s := Rect(3, 2, 6, 6)
+	if got, want := r.Intersect(s), Rect(3, 2, 4, 3); got != want {
+		t.Errorf("Intersect: got %v, want %v", got, want)
+	}
+	if got := r.Intersect(Rect(5, 5, 6, 6)); got != (Rectangle{}) {
+		t.Errorf("Intersect disjoint: got %v, want the zero Rectangle", got)
+	}
+	if got, want := r.Union(s), Rect(0, 1, 6, 6); got != want {
+		t.Errorf("Union: got %v, want %v", got, want)
+	}
+	if got := r.Union(Rectangle{}); got != r {
+		t.Errorf("Union empty: got %v, want %v", got, r)
+	}
+	if !r.Overlaps(s) || r.Overlaps(Rect(4, 0, 5, 5)) {
+		t.Errorf("Overlaps: wrong for touching or overlapping rectangles")
+	}
+	if !r.Intersect(s).In(r) || !r.Intersect(s).In(s) || r.In(s) {
+		t.Errorf("In: wrong")
+	}
+	if !r.Eq(r.Add(Point{eps / 2, 0}), eps) || r.Eq(r.Inset(1), eps) {
+		t.Errorf("Eq: wrong for eps %v", eps)
+	}
+	if !Rect(1, 1, 1, 5).Eq(Rect(3, 3, 4, 3), 0) {
+		t.Errorf("Eq: empty rectangles are not equal")
+	}
+}
+
+func TestUnits(t *testing.T) {
+	testCases := []struct {
+		p    Pt
+		u    Pt
+		want float32
+	}{
+		{72, Inch, 1},
+		{Inch, Millimeter, 25.4},
+		{2 * Centimeter, Millimeter, 20},
+		{Inch, Dp, 160},
+		{Ems(1.5, 12), 1, 18},
+	}
+	for _, tc := range testCases {
+		if got := tc.p.Units(tc.u); !Pt(got).Eq(Pt(tc.want), eps) {
+			t.Errorf("%v.Units(%v): got %v, want %v", tc.p, tc.u, got, tc.want)
+		}
+	}
+}
+
+func TestPx(t *testing.T) {
+	const ppp = 1.5
+	testCases := []struct {
+		p                 Pt
+		nearest, down, up int
+	}{
+		{0, 0, 0, 0},
+		{1, 2, 1, 2},
+		{2, 3, 3, 3},
+		{1.1, 2, 1, 2},
+		{-1, -2, -2, -1},
+		{-1.1, -2, -2, -1},
+	}
+	for _, tc := range testCases {
+		if got := tc.p.PxInt(ppp, RoundNearest); got != tc.nearest {
+			t.Errorf("%v: RoundNearest: got %d, want %d", tc.p, got, tc.nearest)
+		}
+		if got := tc.p.PxInt(ppp, RoundDown); got != tc.down {
+			t.Errorf("%v: RoundDown: got %d, want %d", tc.p, got, tc.down)
+		}
+		if got := tc.p.PxInt(ppp, RoundUp); got != tc.up {
+			t.Errorf("%v: RoundUp: got %d, want %d", tc.p, got, tc.up)
+		}
+	}
+	if got, want := FromPx(3, ppp), Pt(2); got != want {
+		t.Errorf("FromPx: got %v, want %v", got, want)
+	}
+
+	r := Rect(0.5, 0.5, 3.5, 1.1)
+	for _, tc := range []struct {
+		mode Rounding
+		want image.Rectangle
+	}{
+		{RoundNearest, image.Rect(1, 1, 5, 2)},
+		{RoundOut, image.Rect(0, 0, 6, 2)},
+		{RoundIn, image.Rectangle{}},
+		{RoundDown, image.Rect(0, 0, 5, 1)},
+	} {
+		if got := r.Px(ppp, tc.mode); got != tc.want {
+			t.Errorf("%v.Px(%v): got %v, want %v", r, tc.mode, got, tc.want)
+		}
+	}
+	if got, want := Rect(0.5, 0.5, 4, 4).Px(ppp, RoundIn), image.Rect(1, 1, 6, 6); got != want {
+		t.Errorf("RoundIn: got %v, want %v", got, want)
+	}
+
+	// Whole pixels convert exactly, both ways.
+	ir := image.Rect(-3, 6, 9, 12)
+	if got := RectFromPx(ir, ppp).Px(ppp, RoundNearest); got != ir {
+		t.Errorf("RectFromPx: round trip: got %v, want %v", got, ir)
+	}
+	if got := PointFromPx(ir.Min, ppp).Px(ppp, RoundDown); got != ir.Min {
+		t.Errorf("PointFromPx: round trip: got %v, want %v", got, ir.Min)
+	}
+}
+
+func TestFixed(t *testing.T) {
+	const ppp = 2
+	if got, want := Pt(1.25).Fixed(ppp), fixed.Int26_6(2*64+32); got != want {
+		t.Errorf("Fixed: got %v, want %v", got, want)
+	}
+	if got, want := FromFixed(fixed.I(5), ppp), Pt(2.5); got != want {
+		t.Errorf("FromFixed: got %v, want %v", got, want)
+	}
+	r := Rect(0.25, -1, 3, 7.5)
+	fr := r.Fixed(ppp)
+	if want := (fixed.Rectangle26_6{Min: fixed.Point26_6{X: 32, Y: -128}, Max: fixed.Point26_6{X: 384, Y: 960}}); fr != want {
+		t.Errorf("Rectangle.Fixed: got %v, want %v", fr, want)
+	}
+	if got := RectFromFixed(fr, ppp); got != r {
+		t.Errorf("RectFromFixed: round trip: got %v, want %v", got, r)
+	}
+	p := Point{1, 2}
+	if got := PointFromFixed(p.Fixed(ppp), ppp); got != p {
+		t.Errorf("PointFromFixed: round trip: got %v, want %v", got, p)
+	}
+}
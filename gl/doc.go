@@ -11,7 +11,13 @@ github.com/as/shiny/screen) will call NewContext and provide
 a gl.Context for a user application.
 
 If the gl package is compiled on a platform capable of supporting ES 3.0,
-the gl.Context object also implements gl.Context3.
+the gl.Context object also implements gl.Context3, which adds the ES 3.0
+functions for instanced drawing, uniform buffers, multiple render targets,
+pixel buffers, samplers, sync objects, transform feedback, integer
+textures and immutable texture storage. Version reports the version of
+the headers the package was compiled against, up to ES 3.2, and
+ContextVersion the version that a live context provides, up to that of the
+headers. Context3 is not available on Windows.
 
 The bindings are deliberately minimal, staying as close the C API as
 possible. The semantics of each function maps onto functions
@@ -41,6 +47,26 @@ error messages. For example,
 
 The gldebug tracing has very high overhead, so make sure to remove
 the build tag before deploying any binaries.
+
+Tracing
+
+NewTracer wraps a Context in one that can record its calls while the
+program runs. The calls, with their arguments, results and the data of the
+slices they take, are written to a compact binary trace:
+
+	glctx, tracer := gl.NewTracer(glctx)
+	...
+	tracer.Start(f)
+	// ... calls on glctx are recorded.
+	err := tracer.Stop()
+
+While it is stopped, a Tracer costs little more than an atomic load per
+call. A TraceReader reads the calls of a trace, and a Replayer makes them on
+another Context. The gltrace command, in github.com/as/shiny/cmd/gltrace,
+dumps, compares and replays traces.
+
+The tracing Context is generated from interface.go by gendebug.go, so it
+covers every method of Context and Context3.
 */
 package gl // import "github.com/as/shiny/gl"
 
@@ -48,19 +74,22 @@ package gl // import "github.com/as/shiny/gl"
 Implementation details.
 
 All GL function calls fill out a C.struct_fnargs and drop it on the work
-queue. The Start function drains the work queue and hands over a batch
-of calls to C.process which runs them. This allows multiple GL calls to
-be executed in a single cgo call.
+queue. The Start function drains the work queue and hands over batches
+of up to workbufLen calls to C.process which runs them. This allows
+multiple GL calls to be executed in a single cgo call.
 
 A GL call is marked as blocking if it returns a value, or if it takes a
-Go pointer. In this case the call will not return until C.process sends a
-value on the retvalue channel.
-
-This implementation ensures any goroutine can make GL calls, but it does
-not make the GL interface safe for simultaneous use by multiple goroutines.
-For the purpose of analyzing this code for race conditions, picture two
-separate goroutines: one blocked on gl.Start, and another making calls to
-the gl package exported functions.
+Go pointer. A blocking call carries its own reply channel, taken from a
+pool, and will not return until DoWork sends its return value on that
+channel once the batch holding it has run. Because every caller waits on
+its own channel, any number of goroutines can make calls, blocking or
+not, on the same context at once. The calls of each goroutine run in the
+order it made them, but calls of different goroutines interleave.
+
+For the purpose of analyzing this code for race conditions, picture
+several goroutines making calls to the gl package exported functions,
+and one goroutine blocked on gl.Start, or calling DoWork.
 */
 
 //go:generate go run gendebug.go -o gldebug.go
+//go:generate go run gendebug.go -trace -o gltrace.go
@@ -10,6 +10,9 @@ type call struct {
 	args     fnargs
 	parg     unsafe.Pointer
 	blocking bool
+
+	// ret receives the return value of a blocking call.
+	ret chan uintptr
 }
 
 type fnargs struct {
@@ -191,6 +194,46 @@ const (
 	glfnUniform2uiv
 	glfnUniform3uiv
 	glfnUniform4uiv
+	glfnBeginTransformFeedback
+	glfnBindBufferBase
+	glfnBindBufferRange
+	glfnBindSampler
+	glfnBindTransformFeedback
+	glfnClearBufferfi
+	glfnClearBufferfv
+	glfnClearBufferiv
+	glfnClearBufferuiv
+	glfnClientWaitSync
+	glfnCopyBufferSubData
+	glfnDeleteSampler
+	glfnDeleteSync
+	glfnDeleteTransformFeedback
+	glfnDrawArraysInstanced
+	glfnDrawBuffers
+	glfnDrawElementsInstanced
+	glfnEndTransformFeedback
+	glfnFenceSync
+	glfnGenSampler
+	glfnGenTransformFeedback
+	glfnGetActiveUniformBlockiv
+	glfnGetActiveUniformBlockName
+	glfnGetSynciv
+	glfnGetUniformBlockIndex
+	glfnGetUniformuiv
+	glfnPauseTransformFeedback
+	glfnReadBuffer
+	glfnReadPixelsOffset
+	glfnRenderbufferStorageMultisample
+	glfnResumeTransformFeedback
+	glfnSamplerParameterf
+	glfnSamplerParameteri
+	glfnTexStorage2D
+	glfnTexSubImage2DOffset
+	glfnTransformFeedbackVaryings
+	glfnUniformBlockBinding
+	glfnVertexAttribDivisor
+	glfnVertexAttribIPointer
+	glfnWaitSync
 )
 
 func goString(buf []byte) string {
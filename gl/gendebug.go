@@ -7,6 +7,11 @@
 // The gendebug program takes gl.go and generates a version of it
 // where each function includes tracing code that writes its arguments
 // to the standard log.
+//
+// With the -trace flag, it instead takes the Context and Context3
+// interfaces of interface.go and generates the methods of the Context
+// returned by NewTracer, which record each call to a binary trace, and the
+// tables that read and replay such traces.
 package main
 
 import (
@@ -22,6 +27,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 var enumWhitelist = []string{
@@ -633,6 +639,7 @@ var enumWhitelist = []string{
 }
 
 var outfile = flag.String("o", "", "result will be written to the file instead of stdout.")
+var trace = flag.Bool("trace", false, "generate the tracer from interface.go instead.")
 
 var fset = new(token.FileSet)
 
@@ -672,6 +679,15 @@ func main() {
 	}
 	entries := enum(f)
 
+	if *trace {
+		f, err = parser.ParseFile(fset, "interface.go", nil, parser.ParseComments)
+		if err != nil {
+			die(err)
+		}
+		output(genTrace(f, entries))
+		return
+	}
+
 	f, err = parser.ParseFile(fset, "gl.go", nil, parser.ParseComments)
 	if err != nil {
 		die(err)
@@ -825,6 +841,11 @@ func main() {
 		fmt.Fprintf(buf, "}\n\n")
 	}
 
+	output(buf)
+}
+
+// output formats the source in buf and writes it to the output file.
+func output(buf *bytes.Buffer) {
 	b, err := format.Source(buf.Bytes())
 	if err != nil {
 		os.Stdout.Write(buf.Bytes())
@@ -994,3 +1015,200 @@ func dedup(entries []entry) []entry {
 	}
 	return dedup
 }
+
+// method is a method of an interface, with its parameter and result types.
+type method struct {
+	name        string
+	context3    bool // Whether it is only in Context3.
+	params      []string
+	paramTypes  []string
+	resultTypes []string
+}
+
+// methods returns the methods declared in the interface named name in f.
+func methods(f *ast.File, name string) []method {
+	var ms []method
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok || spec.Name.Name != name {
+			return true
+		}
+		for _, field := range spec.Type.(*ast.InterfaceType).Methods.List {
+			fn, ok := field.Type.(*ast.FuncType)
+			if !ok {
+				continue // An embedded interface.
+			}
+			m := method{name: field.Names[0].Name, context3: name == "Context3"}
+			for _, p := range fn.Params.List {
+				for _, n := range p.Names {
+					m.params = append(m.params, n.Name)
+					m.paramTypes = append(m.paramTypes, typeString(p.Type))
+				}
+			}
+			if fn.Results != nil {
+				for _, r := range fn.Results.List {
+					n := len(r.Names)
+					if n == 0 {
+						n = 1
+					}
+					for i := 0; i < n; i++ {
+						m.resultTypes = append(m.resultTypes, typeString(r.Type))
+					}
+				}
+			}
+			ms = append(ms, m)
+		}
+		return false
+	})
+	return ms
+}
+
+// traceEncoder returns the name of the traceEncoder method that writes
+// values of type t: "texture" for Texture, or "float32s" for []float32.
+func traceEncoder(t string) string {
+	s := strings.TrimPrefix(t, "[]")
+	s = strings.ToLower(s[:1]) + s[1:]
+	if strings.HasPrefix(t, "[]") {
+		s += "s"
+	}
+	return s
+}
+
+// traceType returns the name of the traceType constant for t: traceTexture
+// for Texture, or traceFloat32s for []float32.
+func traceType(t string) string {
+	s := traceEncoder(t)
+	return "trace" + strings.ToUpper(s[:1]) + s[1:]
+}
+
+func genTrace(f *ast.File, entries []entry) *bytes.Buffer {
+	ms := append(methods(f, "Context"), methods(f, "Context3")...)
+	buf := new(bytes.Buffer)
+	fmt.Fprint(buf, tracePreamble)
+
+	fmt.Fprintf(buf, "var traceNames = []string{\n")
+	for _, m := range ms {
+		fmt.Fprintf(buf, "\t%q,\n", m.name)
+	}
+	fmt.Fprintf(buf, "}\n\n")
+
+	types := func(ts []string) string {
+		var s []string
+		for _, t := range ts {
+			s = append(s, traceType(t))
+		}
+		return "[]traceType{" + strings.Join(s, ", ") + "}"
+	}
+	fmt.Fprintf(buf, "var traceSigs = map[string]*traceSig{\n")
+	for _, m := range ms {
+		fmt.Fprintf(buf, "\t%q: {", m.name)
+		if len(m.paramTypes) > 0 {
+			fmt.Fprintf(buf, "args: %s", types(m.paramTypes))
+		}
+		if len(m.resultTypes) > 0 {
+			if len(m.paramTypes) > 0 {
+				fmt.Fprint(buf, ", ")
+			}
+			fmt.Fprintf(buf, "results: %s", types(m.resultTypes))
+		}
+		fmt.Fprintf(buf, "},\n")
+	}
+	fmt.Fprintf(buf, "}\n\n")
+
+	fmt.Fprintf(buf, "var traceEnumNames = map[Enum]string{\n")
+	for _, e := range dedup(entries) {
+		fmt.Fprintf(buf, "\t0x%x: %q,\n", e.value, e.name)
+	}
+	fmt.Fprintf(buf, "}\n\n")
+
+	for id, m := range ms {
+		// func (ctx *traceContext) CreateTexture() (r0 Texture) {
+		//	r0 = ctx.ctx.CreateTexture()
+		//	if e := ctx.t.begin(12); e != nil {
+		//		e.texture(r0)
+		//		e.end()
+		//	}
+		//	return
+		// }
+		recv, target := "*traceContext", "ctx.ctx"
+		if m.context3 {
+			recv, target = "*traceContext3", "ctx.ctx.(Context3)"
+		}
+		var params, results []string
+		for i, p := range m.params {
+			params = append(params, p+" "+m.paramTypes[i])
+		}
+		for i, r := range m.resultTypes {
+			results = append(results, fmt.Sprintf("r%d %s", i, r))
+		}
+		fmt.Fprintf(buf, "func (ctx %s) %s(%s) (%s) {\n", recv, m.name, strings.Join(params, ", "), strings.Join(results, ", "))
+		if len(results) > 0 {
+			for i := range results {
+				if i > 0 {
+					fmt.Fprint(buf, ", ")
+				}
+				fmt.Fprintf(buf, "r%d", i)
+			}
+			fmt.Fprint(buf, " = ")
+		}
+		fmt.Fprintf(buf, "%s.%s(%s)\n", target, m.name, strings.Join(m.params, ", "))
+		fmt.Fprintf(buf, "if e := ctx.t.begin(%d); e != nil {\n", id)
+		for i, p := range m.params {
+			fmt.Fprintf(buf, "e.%s(%s)\n", traceEncoder(m.paramTypes[i]), p)
+		}
+		for i, r := range m.resultTypes {
+			fmt.Fprintf(buf, "e.%s(r%d)\n", traceEncoder(r), i)
+		}
+		fmt.Fprintf(buf, "e.end()\n")
+		fmt.Fprintf(buf, "}\n")
+		if len(results) > 0 {
+			fmt.Fprintf(buf, "return\n")
+		}
+		fmt.Fprintf(buf, "}\n\n")
+	}
+
+	fmt.Fprintf(buf, "// replayCall calls the method name of ctx with the arguments a.\n")
+	fmt.Fprintf(buf, "func replayCall(ctx Context, name string, a []interface{}) ([]interface{}, error) {\n")
+	fmt.Fprintf(buf, "switch name {\n")
+	for _, m := range ms {
+		fmt.Fprintf(buf, "case %q:\n", m.name)
+		target := "ctx"
+		if m.context3 {
+			fmt.Fprintf(buf, "ctx3, ok := ctx.(Context3)\n")
+			fmt.Fprintf(buf, "if !ok {\n return nil, errNotContext3\n}\n")
+			target = "ctx3"
+		}
+		var args, results []string
+		for i, t := range m.paramTypes {
+			args = append(args, fmt.Sprintf("a[%d].(%s)", i, t))
+		}
+		for i := range m.resultTypes {
+			results = append(results, fmt.Sprintf("r%d", i))
+		}
+		call := fmt.Sprintf("%s.%s(%s)", target, m.name, strings.Join(args, ", "))
+		if len(results) == 0 {
+			fmt.Fprintf(buf, "%s\n", call)
+			fmt.Fprintf(buf, "return nil, nil\n")
+			continue
+		}
+		fmt.Fprintf(buf, "%s := %s\n", strings.Join(results, ", "), call)
+		fmt.Fprintf(buf, "return []interface{}{%s}, nil\n", strings.Join(results, ", "))
+	}
+	fmt.Fprintf(buf, "}\n")
+	fmt.Fprintf(buf, "return nil, fmt.Errorf(\"gl: replay of unknown call %%q\", name)\n")
+	fmt.Fprintf(buf, "}\n")
+	return buf
+}
+
+const tracePreamble = `// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated from interface.go using go generate. DO NOT EDIT.
+// See doc.go for details.
+
+package gl
+
+import "fmt"
+
+`
@@ -1123,7 +1123,6 @@ func (ctx *context) ReadPixels(dst []byte, x, y, width, height int, format, ty E
 	ctx.enqueue(call{
 		args: fnargs{
 			fn: glfnReadPixels,
-			// TODO(crawshaw): support PIXEL_PACK_BUFFER in GLES3, uses offset.
 			a0: uintptr(x),
 			a1: uintptr(y),
 			a2: uintptr(width),
@@ -1287,7 +1286,6 @@ func (ctx *context) TexSubImage2D(target Enum, level int, x, y, width, height in
 	ctx.enqueue(call{
 		args: fnargs{
 			fn: glfnTexSubImage2D,
-			// TODO(crawshaw): GLES3 offset for PIXEL_UNPACK_BUFFER and PIXEL_PACK_BUFFER.
 			a0: target.c(),
 			a1: uintptr(level),
 			a2: uintptr(x),
@@ -1818,7 +1816,7 @@ func (ctx context3) Uniform2ui(dst Uniform, v0, v1 uint32) {
 	})
 }
 
-func (ctx context3) Uniform3ui(dst Uniform, v0, v1, v2 uint) {
+func (ctx context3) Uniform3ui(dst Uniform, v0, v1, v2 uint32) {
 	ctx.enqueue(call{
 		args: fnargs{
 			fn: glfnUniform3ui,
@@ -1842,3 +1840,520 @@ func (ctx context3) Uniform4ui(dst Uniform, v0, v1, v2, v3 uint32) {
 		},
 	})
 }
+
+func (ctx context3) Uniform1uiv(dst Uniform, src []uint32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnUniform1uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src)),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) Uniform2uiv(dst Uniform, src []uint32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnUniform2uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src) / 2),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) Uniform3uiv(dst Uniform, src []uint32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnUniform3uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src) / 3),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) Uniform4uiv(dst Uniform, src []uint32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnUniform4uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src) / 4),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) GetUniformuiv(dst []uint32, src Uniform, p Program) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetUniformuiv,
+			a0: p.c(),
+			a1: src.c(),
+		},
+		parg:     unsafe.Pointer(&dst[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) VertexAttribIPointer(dst Attrib, size int, ty Enum, stride, offset int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnVertexAttribIPointer,
+			a0: dst.c(),
+			a1: uintptr(size),
+			a2: ty.c(),
+			a3: uintptr(stride),
+			a4: uintptr(offset),
+		},
+	})
+}
+
+func (ctx context3) VertexAttribDivisor(dst Attrib, divisor int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnVertexAttribDivisor,
+			a0: dst.c(),
+			a1: uintptr(divisor),
+		},
+	})
+}
+
+func (ctx context3) DrawArraysInstanced(mode Enum, first, count, instances int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnDrawArraysInstanced,
+			a0: mode.c(),
+			a1: uintptr(first),
+			a2: uintptr(count),
+			a3: uintptr(instances),
+		},
+	})
+}
+
+func (ctx context3) DrawElementsInstanced(mode Enum, count int, ty Enum, offset, instances int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnDrawElementsInstanced,
+			a0: mode.c(),
+			a1: uintptr(count),
+			a2: ty.c(),
+			a3: uintptr(offset),
+			a4: uintptr(instances),
+		},
+	})
+}
+
+func (ctx context3) BindBufferBase(target Enum, index uint32, b Buffer) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnBindBufferBase,
+			a0: target.c(),
+			a1: uintptr(index),
+			a2: b.c(),
+		},
+	})
+}
+
+func (ctx context3) BindBufferRange(target Enum, index uint32, b Buffer, offset, size int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnBindBufferRange,
+			a0: target.c(),
+			a1: uintptr(index),
+			a2: b.c(),
+			a3: uintptr(offset),
+			a4: uintptr(size),
+		},
+	})
+}
+
+func (ctx context3) GetUniformBlockIndex(p Program, name string) uint32 {
+	s, free := ctx.cString(name)
+	defer free()
+	return uint32(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetUniformBlockIndex,
+			a0: p.c(),
+			a1: s,
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) UniformBlockBinding(p Program, index, binding uint32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnUniformBlockBinding,
+			a0: p.c(),
+			a1: uintptr(index),
+			a2: uintptr(binding),
+		},
+	})
+}
+
+func (ctx context3) GetActiveUniformBlocki(p Program, index uint32, pname Enum) int {
+	return int(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetActiveUniformBlockiv,
+			a0: p.c(),
+			a1: uintptr(index),
+			a2: pname.c(),
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) GetActiveUniformBlockName(p Program, index uint32) string {
+	n := ctx.GetActiveUniformBlocki(p, index, UNIFORM_BLOCK_NAME_LENGTH)
+	if n == 0 {
+		return ""
+	}
+	buf := make([]byte, n)
+
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetActiveUniformBlockName,
+			a0: p.c(),
+			a1: uintptr(index),
+			a2: uintptr(n),
+		},
+		parg:     unsafe.Pointer(&buf[0]),
+		blocking: true,
+	})
+
+	return goString(buf)
+}
+
+func (ctx context3) DrawBuffers(bufs []Enum) {
+	parg := unsafe.Pointer(nil)
+	if len(bufs) > 0 {
+		parg = unsafe.Pointer(&bufs[0])
+	}
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnDrawBuffers,
+			a0: uintptr(len(bufs)),
+		},
+		parg:     parg,
+		blocking: true,
+	})
+}
+
+func (ctx context3) ReadBuffer(src Enum) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnReadBuffer,
+			a0: src.c(),
+		},
+	})
+}
+
+func (ctx context3) ClearBufferfv(buffer Enum, drawbuffer int, value []float32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnClearBufferfv,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+		},
+		parg:     unsafe.Pointer(&value[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) ClearBufferiv(buffer Enum, drawbuffer int, value []int32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnClearBufferiv,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+		},
+		parg:     unsafe.Pointer(&value[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) ClearBufferuiv(buffer Enum, drawbuffer int, value []uint32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnClearBufferuiv,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+		},
+		parg:     unsafe.Pointer(&value[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) ClearBufferfi(buffer Enum, drawbuffer int, depth float32, stencil int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnClearBufferfi,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+			a2: uintptr(math.Float32bits(depth)),
+			a3: uintptr(stencil),
+		},
+	})
+}
+
+func (ctx context3) CopyBufferSubData(readTarget, writeTarget Enum, readOffset, writeOffset, size int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnCopyBufferSubData,
+			a0: readTarget.c(),
+			a1: writeTarget.c(),
+			a2: uintptr(readOffset),
+			a3: uintptr(writeOffset),
+			a4: uintptr(size),
+		},
+	})
+}
+
+func (ctx context3) ReadPixelsOffset(x, y, width, height int, format, ty Enum, offset int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnReadPixelsOffset,
+			a0: uintptr(x),
+			a1: uintptr(y),
+			a2: uintptr(width),
+			a3: uintptr(height),
+			a4: format.c(),
+			a5: ty.c(),
+			a6: uintptr(offset),
+		},
+	})
+}
+
+func (ctx context3) TexSubImage2DOffset(target Enum, level int, x, y, width, height int, format, ty Enum, offset int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnTexSubImage2DOffset,
+			a0: target.c(),
+			a1: uintptr(level),
+			a2: uintptr(x),
+			a3: uintptr(y),
+			a4: uintptr(width),
+			a5: uintptr(height),
+			a6: format.c(),
+			a7: ty.c(),
+			a8: uintptr(offset),
+		},
+	})
+}
+
+func (ctx context3) TexStorage2D(target Enum, levels int, internalFormat Enum, width, height int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnTexStorage2D,
+			a0: target.c(),
+			a1: uintptr(levels),
+			a2: internalFormat.c(),
+			a3: uintptr(width),
+			a4: uintptr(height),
+		},
+	})
+}
+
+func (ctx context3) RenderbufferStorageMultisample(target Enum, samples int, internalFormat Enum, width, height int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnRenderbufferStorageMultisample,
+			a0: target.c(),
+			a1: uintptr(samples),
+			a2: internalFormat.c(),
+			a3: uintptr(width),
+			a4: uintptr(height),
+		},
+	})
+}
+
+func (ctx context3) CreateSampler() Sampler {
+	return Sampler{Value: uint32(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGenSampler,
+		},
+		blocking: true,
+	}))}
+}
+
+func (ctx context3) DeleteSampler(s Sampler) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnDeleteSampler,
+			a0: s.c(),
+		},
+	})
+}
+
+func (ctx context3) BindSampler(unit uint32, s Sampler) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnBindSampler,
+			a0: uintptr(unit),
+			a1: s.c(),
+		},
+	})
+}
+
+func (ctx context3) SamplerParameterf(s Sampler, pname Enum, param float32) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnSamplerParameterf,
+			a0: s.c(),
+			a1: pname.c(),
+			a2: uintptr(math.Float32bits(param)),
+		},
+	})
+}
+
+func (ctx context3) SamplerParameteri(s Sampler, pname Enum, param int) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnSamplerParameteri,
+			a0: s.c(),
+			a1: pname.c(),
+			a2: uintptr(param),
+		},
+	})
+}
+
+func (ctx context3) FenceSync(condition Enum, flags uint32) Sync {
+	return Sync{Value: ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnFenceSync,
+			a0: condition.c(),
+			a1: uintptr(flags),
+		},
+		blocking: true,
+	})}
+}
+
+func (ctx context3) DeleteSync(s Sync) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnDeleteSync,
+			a0: s.c(),
+		},
+	})
+}
+
+// The timeouts of ClientWaitSync and WaitSync are split between two
+// arguments, as a uintptr may not hold 64 bits.
+
+func (ctx context3) ClientWaitSync(s Sync, flags uint32, timeout uint64) Enum {
+	return Enum(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnClientWaitSync,
+			a0: s.c(),
+			a1: uintptr(flags),
+			a2: uintptr(timeout),
+			a3: uintptr(timeout >> 32),
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) WaitSync(s Sync, flags uint32, timeout uint64) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnWaitSync,
+			a0: s.c(),
+			a1: uintptr(flags),
+			a2: uintptr(timeout),
+			a3: uintptr(timeout >> 32),
+		},
+	})
+}
+
+func (ctx context3) GetSynci(s Sync, pname Enum) int {
+	return int(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetSynciv,
+			a0: s.c(),
+			a1: pname.c(),
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) CreateTransformFeedback() TransformFeedback {
+	return TransformFeedback{Value: uint32(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGenTransformFeedback,
+		},
+		blocking: true,
+	}))}
+}
+
+func (ctx context3) DeleteTransformFeedback(tf TransformFeedback) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnDeleteTransformFeedback,
+			a0: tf.c(),
+		},
+	})
+}
+
+func (ctx context3) BindTransformFeedback(target Enum, tf TransformFeedback) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnBindTransformFeedback,
+			a0: target.c(),
+			a1: tf.c(),
+		},
+	})
+}
+
+func (ctx context3) BeginTransformFeedback(mode Enum) {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnBeginTransformFeedback,
+			a0: mode.c(),
+		},
+	})
+}
+
+func (ctx context3) EndTransformFeedback() {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnEndTransformFeedback,
+		},
+	})
+}
+
+func (ctx context3) PauseTransformFeedback() {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnPauseTransformFeedback,
+		},
+	})
+}
+
+func (ctx context3) ResumeTransformFeedback() {
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnResumeTransformFeedback,
+		},
+	})
+}
+
+func (ctx context3) TransformFeedbackVaryings(p Program, varyings []string, mode Enum) {
+	strs, free := ctx.cStringArray(varyings)
+	defer free()
+	ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnTransformFeedbackVaryings,
+			a0: p.c(),
+			a1: uintptr(len(varyings)),
+			a2: strs,
+			a3: mode.c(),
+		},
+		blocking: true,
+	})
+}
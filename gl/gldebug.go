@@ -5,6 +5,7 @@
 // Code generated from gl.go using go generate. DO NOT EDIT.
 // See doc.go for details.
 
+//go:build (linux || darwin || windows || openbsd) && gldebug
 // +build linux darwin windows openbsd
 // +build gldebug
 
@@ -2720,7 +2721,6 @@ func (ctx *context) ReadPixels(dst []byte, x, y, width, height int, format, ty E
 	ctx.enqueueDebug(call{
 		args: fnargs{
 			fn: glfnReadPixels,
-
 			a0: uintptr(x),
 			a1: uintptr(y),
 			a2: uintptr(width),
@@ -2932,7 +2932,6 @@ func (ctx *context) TexSubImage2D(target Enum, level int, x, y, width, height in
 	ctx.enqueueDebug(call{
 		args: fnargs{
 			fn: glfnTexSubImage2D,
-
 			a0: target.c(),
 			a1: uintptr(level),
 			a2: uintptr(x),
@@ -3639,7 +3638,7 @@ func (ctx context3) Uniform2ui(dst Uniform, v0, v1 uint32) {
 		blocking: true})
 }
 
-func (ctx context3) Uniform3ui(dst Uniform, v0, v1, v2 uint) {
+func (ctx context3) Uniform3ui(dst Uniform, v0, v1, v2 uint32) {
 	defer func() {
 		errstr := ctx.errDrain()
 		log.Printf("gl.Uniform3ui(%v, %v, %v, %v) %v", dst, v0, v1, v2, errstr)
@@ -3671,3 +3670,691 @@ func (ctx context3) Uniform4ui(dst Uniform, v0, v1, v2, v3 uint32) {
 		},
 		blocking: true})
 }
+
+func (ctx context3) Uniform1uiv(dst Uniform, src []uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.Uniform1uiv(%v, %v) %v", dst, src, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnUniform1uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src)),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) Uniform2uiv(dst Uniform, src []uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.Uniform2uiv(%v, %v) %v", dst, src, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnUniform2uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src) / 2),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) Uniform3uiv(dst Uniform, src []uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.Uniform3uiv(%v, %v) %v", dst, src, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnUniform3uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src) / 3),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) Uniform4uiv(dst Uniform, src []uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.Uniform4uiv(%v, %v) %v", dst, src, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnUniform4uiv,
+			a0: dst.c(),
+			a1: uintptr(len(src) / 4),
+		},
+		parg:     unsafe.Pointer(&src[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) GetUniformuiv(dst []uint32, src Uniform, p Program) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.GetUniformuiv(%v, %v, %v) %v", dst, src, p, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnGetUniformuiv,
+			a0: p.c(),
+			a1: src.c(),
+		},
+		parg:     unsafe.Pointer(&dst[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) VertexAttribIPointer(dst Attrib, size int, ty Enum, stride, offset int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.VertexAttribIPointer(%v, %v, %v, %v, %v) %v", dst, size, ty, stride, offset, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnVertexAttribIPointer,
+			a0: dst.c(),
+			a1: uintptr(size),
+			a2: ty.c(),
+			a3: uintptr(stride),
+			a4: uintptr(offset),
+		},
+		blocking: true})
+}
+
+func (ctx context3) VertexAttribDivisor(dst Attrib, divisor int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.VertexAttribDivisor(%v, %v) %v", dst, divisor, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnVertexAttribDivisor,
+			a0: dst.c(),
+			a1: uintptr(divisor),
+		},
+		blocking: true})
+}
+
+func (ctx context3) DrawArraysInstanced(mode Enum, first, count, instances int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.DrawArraysInstanced(%v, %v, %v, %v) %v", mode, first, count, instances, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnDrawArraysInstanced,
+			a0: mode.c(),
+			a1: uintptr(first),
+			a2: uintptr(count),
+			a3: uintptr(instances),
+		},
+		blocking: true})
+}
+
+func (ctx context3) DrawElementsInstanced(mode Enum, count int, ty Enum, offset, instances int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.DrawElementsInstanced(%v, %v, %v, %v, %v) %v", mode, count, ty, offset, instances, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnDrawElementsInstanced,
+			a0: mode.c(),
+			a1: uintptr(count),
+			a2: ty.c(),
+			a3: uintptr(offset),
+			a4: uintptr(instances),
+		},
+		blocking: true})
+}
+
+func (ctx context3) BindBufferBase(target Enum, index uint32, b Buffer) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.BindBufferBase(%v, %v, %v) %v", target, index, b, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnBindBufferBase,
+			a0: target.c(),
+			a1: uintptr(index),
+			a2: b.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) BindBufferRange(target Enum, index uint32, b Buffer, offset, size int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.BindBufferRange(%v, %v, %v, %v, %v) %v", target, index, b, offset, size, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnBindBufferRange,
+			a0: target.c(),
+			a1: uintptr(index),
+			a2: b.c(),
+			a3: uintptr(offset),
+			a4: uintptr(size),
+		},
+		blocking: true})
+}
+
+func (ctx context3) GetUniformBlockIndex(p Program, name string) (r0 uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.GetUniformBlockIndex(%v, %v) %v%v", p, name, r0, errstr)
+	}()
+	s, free := ctx.cString(name)
+	defer free()
+	return uint32(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetUniformBlockIndex,
+			a0: p.c(),
+			a1: s,
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) UniformBlockBinding(p Program, index, binding uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.UniformBlockBinding(%v, %v, %v) %v", p, index, binding, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnUniformBlockBinding,
+			a0: p.c(),
+			a1: uintptr(index),
+			a2: uintptr(binding),
+		},
+		blocking: true})
+}
+
+func (ctx context3) GetActiveUniformBlocki(p Program, index uint32, pname Enum) (r0 int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.GetActiveUniformBlocki(%v, %v, %v) %v%v", p, index, pname, r0, errstr)
+	}()
+	return int(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetActiveUniformBlockiv,
+			a0: p.c(),
+			a1: uintptr(index),
+			a2: pname.c(),
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) GetActiveUniformBlockName(p Program, index uint32) (r0 string) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.GetActiveUniformBlockName(%v, %v) %v%v", p, index, r0, errstr)
+	}()
+	n := ctx.GetActiveUniformBlocki(p, index, UNIFORM_BLOCK_NAME_LENGTH)
+	if n == 0 {
+		return ""
+	}
+	buf := make([]byte, n)
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnGetActiveUniformBlockName,
+			a0: p.c(),
+			a1: uintptr(index),
+			a2: uintptr(n),
+		},
+		parg:     unsafe.Pointer(&buf[0]),
+		blocking: true,
+	})
+	return goString(buf)
+}
+
+func (ctx context3) DrawBuffers(bufs []Enum) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.DrawBuffers(%v) %v", bufs, errstr)
+	}()
+	parg := unsafe.Pointer(nil)
+	if len(bufs) > 0 {
+		parg = unsafe.Pointer(&bufs[0])
+	}
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnDrawBuffers,
+			a0: uintptr(len(bufs)),
+		},
+		parg:     parg,
+		blocking: true,
+	})
+}
+
+func (ctx context3) ReadBuffer(src Enum) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ReadBuffer(%v) %v", src, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnReadBuffer,
+			a0: src.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) ClearBufferfv(buffer Enum, drawbuffer int, value []float32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ClearBufferfv(%v, %v, len(%d)) %v", buffer, drawbuffer, len(value), errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnClearBufferfv,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+		},
+		parg:     unsafe.Pointer(&value[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) ClearBufferiv(buffer Enum, drawbuffer int, value []int32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ClearBufferiv(%v, %v, %v) %v", buffer, drawbuffer, value, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnClearBufferiv,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+		},
+		parg:     unsafe.Pointer(&value[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) ClearBufferuiv(buffer Enum, drawbuffer int, value []uint32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ClearBufferuiv(%v, %v, %v) %v", buffer, drawbuffer, value, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnClearBufferuiv,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+		},
+		parg:     unsafe.Pointer(&value[0]),
+		blocking: true,
+	})
+}
+
+func (ctx context3) ClearBufferfi(buffer Enum, drawbuffer int, depth float32, stencil int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ClearBufferfi(%v, %v, %v, %v) %v", buffer, drawbuffer, depth, stencil, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnClearBufferfi,
+			a0: buffer.c(),
+			a1: uintptr(drawbuffer),
+			a2: uintptr(math.Float32bits(depth)),
+			a3: uintptr(stencil),
+		},
+		blocking: true})
+}
+
+func (ctx context3) CopyBufferSubData(readTarget, writeTarget Enum, readOffset, writeOffset, size int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.CopyBufferSubData(%v, %v, %v, %v, %v) %v", readTarget, writeTarget, readOffset, writeOffset, size, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnCopyBufferSubData,
+			a0: readTarget.c(),
+			a1: writeTarget.c(),
+			a2: uintptr(readOffset),
+			a3: uintptr(writeOffset),
+			a4: uintptr(size),
+		},
+		blocking: true})
+}
+
+func (ctx context3) ReadPixelsOffset(x, y, width, height int, format, ty Enum, offset int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ReadPixelsOffset(%v, %v, %v, %v, %v, %v, %v) %v", x, y, width, height, format, ty, offset, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnReadPixelsOffset,
+			a0: uintptr(x),
+			a1: uintptr(y),
+			a2: uintptr(width),
+			a3: uintptr(height),
+			a4: format.c(),
+			a5: ty.c(),
+			a6: uintptr(offset),
+		},
+		blocking: true})
+}
+
+func (ctx context3) TexSubImage2DOffset(target Enum, level int, x, y, width, height int, format, ty Enum, offset int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.TexSubImage2DOffset(%v, %v, %v, %v, %v, %v, %v, %v, %v) %v", target, level, x, y, width, height, format, ty, offset, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnTexSubImage2DOffset,
+			a0: target.c(),
+			a1: uintptr(level),
+			a2: uintptr(x),
+			a3: uintptr(y),
+			a4: uintptr(width),
+			a5: uintptr(height),
+			a6: format.c(),
+			a7: ty.c(),
+			a8: uintptr(offset),
+		},
+		blocking: true})
+}
+
+func (ctx context3) TexStorage2D(target Enum, levels int, internalFormat Enum, width, height int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.TexStorage2D(%v, %v, %v, %v, %v) %v", target, levels, internalFormat, width, height, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnTexStorage2D,
+			a0: target.c(),
+			a1: uintptr(levels),
+			a2: internalFormat.c(),
+			a3: uintptr(width),
+			a4: uintptr(height),
+		},
+		blocking: true})
+}
+
+func (ctx context3) RenderbufferStorageMultisample(target Enum, samples int, internalFormat Enum, width, height int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.RenderbufferStorageMultisample(%v, %v, %v, %v, %v) %v", target, samples, internalFormat, width, height, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnRenderbufferStorageMultisample,
+			a0: target.c(),
+			a1: uintptr(samples),
+			a2: internalFormat.c(),
+			a3: uintptr(width),
+			a4: uintptr(height),
+		},
+		blocking: true})
+}
+
+func (ctx context3) CreateSampler() (r0 Sampler) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.CreateSampler() %v%v", r0, errstr)
+	}()
+	return Sampler{Value: uint32(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGenSampler,
+		},
+		blocking: true,
+	}))}
+}
+
+func (ctx context3) DeleteSampler(s Sampler) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.DeleteSampler(%v) %v", s, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnDeleteSampler,
+			a0: s.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) BindSampler(unit uint32, s Sampler) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.BindSampler(%v, %v) %v", unit, s, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnBindSampler,
+			a0: uintptr(unit),
+			a1: s.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) SamplerParameterf(s Sampler, pname Enum, param float32) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.SamplerParameterf(%v, %v, %v) %v", s, pname, param, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnSamplerParameterf,
+			a0: s.c(),
+			a1: pname.c(),
+			a2: uintptr(math.Float32bits(param)),
+		},
+		blocking: true})
+}
+
+func (ctx context3) SamplerParameteri(s Sampler, pname Enum, param int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.SamplerParameteri(%v, %v, %v) %v", s, pname, param, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnSamplerParameteri,
+			a0: s.c(),
+			a1: pname.c(),
+			a2: uintptr(param),
+		},
+		blocking: true})
+}
+
+func (ctx context3) FenceSync(condition Enum, flags uint32) (r0 Sync) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.FenceSync(%v, %v) %v%v", condition, flags, r0, errstr)
+	}()
+	return Sync{Value: ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnFenceSync,
+			a0: condition.c(),
+			a1: uintptr(flags),
+		},
+		blocking: true,
+	})}
+}
+
+func (ctx context3) DeleteSync(s Sync) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.DeleteSync(%v) %v", s, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnDeleteSync,
+			a0: s.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) ClientWaitSync(s Sync, flags uint32, timeout uint64) (r0 Enum) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ClientWaitSync(%v, %v, %v) %v%v", s, flags, timeout, r0, errstr)
+	}()
+	return Enum(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnClientWaitSync,
+			a0: s.c(),
+			a1: uintptr(flags),
+			a2: uintptr(timeout),
+			a3: uintptr(timeout >> 32),
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) WaitSync(s Sync, flags uint32, timeout uint64) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.WaitSync(%v, %v, %v) %v", s, flags, timeout, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnWaitSync,
+			a0: s.c(),
+			a1: uintptr(flags),
+			a2: uintptr(timeout),
+			a3: uintptr(timeout >> 32),
+		},
+		blocking: true})
+}
+
+func (ctx context3) GetSynci(s Sync, pname Enum) (r0 int) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.GetSynci(%v, %v) %v%v", s, pname, r0, errstr)
+	}()
+	return int(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGetSynciv,
+			a0: s.c(),
+			a1: pname.c(),
+		},
+		blocking: true,
+	}))
+}
+
+func (ctx context3) CreateTransformFeedback() (r0 TransformFeedback) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.CreateTransformFeedback() %v%v", r0, errstr)
+	}()
+	return TransformFeedback{Value: uint32(ctx.enqueue(call{
+		args: fnargs{
+			fn: glfnGenTransformFeedback,
+		},
+		blocking: true,
+	}))}
+}
+
+func (ctx context3) DeleteTransformFeedback(tf TransformFeedback) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.DeleteTransformFeedback(%v) %v", tf, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnDeleteTransformFeedback,
+			a0: tf.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) BindTransformFeedback(target Enum, tf TransformFeedback) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.BindTransformFeedback(%v, %v) %v", target, tf, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnBindTransformFeedback,
+			a0: target.c(),
+			a1: tf.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) BeginTransformFeedback(mode Enum) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.BeginTransformFeedback(%v) %v", mode, errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnBeginTransformFeedback,
+			a0: mode.c(),
+		},
+		blocking: true})
+}
+
+func (ctx context3) EndTransformFeedback() {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.EndTransformFeedback() %v", errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnEndTransformFeedback,
+		},
+		blocking: true})
+}
+
+func (ctx context3) PauseTransformFeedback() {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.PauseTransformFeedback() %v", errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnPauseTransformFeedback,
+		},
+		blocking: true})
+}
+
+func (ctx context3) ResumeTransformFeedback() {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.ResumeTransformFeedback() %v", errstr)
+	}()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnResumeTransformFeedback,
+		},
+		blocking: true})
+}
+
+func (ctx context3) TransformFeedbackVaryings(p Program, varyings []string, mode Enum) {
+	defer func() {
+		errstr := ctx.errDrain()
+		log.Printf("gl.TransformFeedbackVaryings(%v, %v, %v) %v", p, varyings, mode, errstr)
+	}()
+	strs, free := ctx.cStringArray(varyings)
+	defer free()
+	ctx.enqueueDebug(call{
+		args: fnargs{
+			fn: glfnTransformFeedbackVaryings,
+			a0: p.c(),
+			a1: uintptr(len(varyings)),
+			a2: strs,
+			a3: mode.c(),
+		},
+		blocking: true,
+	})
+}
@@ -0,0 +1,439 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import (
+	"github.com/as/shiny/gl"
+)
+
+// attrib is the state of a vertex attribute.
+type attrib struct {
+	enabled    bool
+	buffer     uint32
+	size       int
+	ty         gl.Enum
+	normalized bool
+	stride     int
+	offset     int
+	current    [4]float32 // The value when the array is disabled.
+}
+
+// typeSize returns the size of a value of type ty in a vertex attribute or
+// element array, or 0 if ty is not such a type.
+func typeSize(ty gl.Enum) int {
+	switch ty {
+	case gl.BYTE, gl.UNSIGNED_BYTE:
+		return 1
+	case gl.SHORT, gl.UNSIGNED_SHORT:
+		return 2
+	case gl.FLOAT, gl.FIXED:
+		return 4
+	}
+	return 0
+}
+
+// BufferData implements gl.Context.
+func (c *Context) BufferData(target gl.Enum, src []byte, usage gl.Enum) {
+	defer c.record("BufferData", target, src, usage)()
+	c.bufferData(target, append([]byte(nil), src...), usage)
+}
+
+// BufferInit implements gl.Context.
+func (c *Context) BufferInit(target gl.Enum, size int, usage gl.Enum) {
+	defer c.record("BufferInit", target, size, usage)()
+	if size < 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.bufferData(target, make([]byte, size), usage)
+}
+
+func (c *Context) bufferData(target gl.Enum, data []byte, usage gl.Enum) {
+	if usage != gl.STREAM_DRAW && usage != gl.STATIC_DRAW && usage != gl.DYNAMIC_DRAW {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	b := c.buffer(target)
+	if b == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	b.data, b.usage = data, usage
+}
+
+// BufferSubData implements gl.Context.
+func (c *Context) BufferSubData(target gl.Enum, offset int, data []byte) {
+	defer c.record("BufferSubData", target, offset, data)()
+	b := c.buffer(target)
+	if b == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	if offset < 0 || offset+len(data) > len(b.data) {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	copy(b.data[offset:], data)
+}
+
+// GetBufferParameteri implements gl.Context.
+func (c *Context) GetBufferParameteri(target, value gl.Enum) int {
+	defer c.record("GetBufferParameteri", target, value)()
+	var v int
+	b := c.buffer(target)
+	switch {
+	case b == nil:
+		c.fail(gl.INVALID_OPERATION)
+	case value == gl.BUFFER_SIZE:
+		v = len(b.data)
+	case value == gl.BUFFER_USAGE:
+		v = int(b.usage)
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(v)
+	return v
+}
+
+// attrib returns the vertex attribute a, or nil, having reported
+// INVALID_VALUE, if there is no such attribute.
+func (c *Context) attrib(a gl.Attrib) *attrib {
+	if a.Value >= maxVertexAttribs {
+		c.fail(gl.INVALID_VALUE)
+		return nil
+	}
+	return &c.attribs[a.Value]
+}
+
+// EnableVertexAttribArray implements gl.Context.
+func (c *Context) EnableVertexAttribArray(a gl.Attrib) {
+	defer c.record("EnableVertexAttribArray", a)()
+	if at := c.attrib(a); at != nil {
+		at.enabled = true
+	}
+}
+
+// DisableVertexAttribArray implements gl.Context.
+func (c *Context) DisableVertexAttribArray(a gl.Attrib) {
+	defer c.record("DisableVertexAttribArray", a)()
+	if at := c.attrib(a); at != nil {
+		at.enabled = false
+	}
+}
+
+// VertexAttribPointer implements gl.Context.
+func (c *Context) VertexAttribPointer(dst gl.Attrib, size int, ty gl.Enum, normalized bool, stride, offset int) {
+	defer c.record("VertexAttribPointer", dst, size, ty, normalized, stride, offset)()
+	at := c.attrib(dst)
+	switch {
+	case at == nil:
+		return
+	case typeSize(ty) == 0:
+		c.fail(gl.INVALID_ENUM)
+		return
+	case size < 1 || size > 4 || stride < 0 || offset < 0:
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	at.buffer, at.size, at.ty, at.normalized, at.stride, at.offset = c.arrayBuffer, size, ty, normalized, stride, offset
+}
+
+func (c *Context) vertexAttrib(dst gl.Attrib, v ...float32) {
+	at := c.attrib(dst)
+	if at == nil {
+		return
+	}
+	at.current = [4]float32{0, 0, 0, 1}
+	copy(at.current[:], v)
+}
+
+// vertexAttribv is vertexAttrib for the first n elements of src.
+// As an addition to the specification, it is INVALID_VALUE for src to be
+// shorter than n.
+func (c *Context) vertexAttribv(dst gl.Attrib, n int, src []float32) {
+	if len(src) < n {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.vertexAttrib(dst, src[:n]...)
+}
+
+// VertexAttrib1f implements gl.Context.
+func (c *Context) VertexAttrib1f(dst gl.Attrib, x float32) {
+	defer c.record("VertexAttrib1f", dst, x)()
+	c.vertexAttrib(dst, x)
+}
+
+// VertexAttrib1fv implements gl.Context.
+func (c *Context) VertexAttrib1fv(dst gl.Attrib, src []float32) {
+	defer c.record("VertexAttrib1fv", dst, src)()
+	c.vertexAttribv(dst, 1, src)
+}
+
+// VertexAttrib2f implements gl.Context.
+func (c *Context) VertexAttrib2f(dst gl.Attrib, x, y float32) {
+	defer c.record("VertexAttrib2f", dst, x, y)()
+	c.vertexAttrib(dst, x, y)
+}
+
+// VertexAttrib2fv implements gl.Context.
+func (c *Context) VertexAttrib2fv(dst gl.Attrib, src []float32) {
+	defer c.record("VertexAttrib2fv", dst, src)()
+	c.vertexAttribv(dst, 2, src)
+}
+
+// VertexAttrib3f implements gl.Context.
+func (c *Context) VertexAttrib3f(dst gl.Attrib, x, y, z float32) {
+	defer c.record("VertexAttrib3f", dst, x, y, z)()
+	c.vertexAttrib(dst, x, y, z)
+}
+
+// VertexAttrib3fv implements gl.Context.
+func (c *Context) VertexAttrib3fv(dst gl.Attrib, src []float32) {
+	defer c.record("VertexAttrib3fv", dst, src)()
+	c.vertexAttribv(dst, 3, src)
+}
+
+// VertexAttrib4f implements gl.Context.
+func (c *Context) VertexAttrib4f(dst gl.Attrib, x, y, z, w float32) {
+	defer c.record("VertexAttrib4f", dst, x, y, z, w)()
+	c.vertexAttrib(dst, x, y, z, w)
+}
+
+// VertexAttrib4fv implements gl.Context.
+func (c *Context) VertexAttrib4fv(dst gl.Attrib, src []float32) {
+	defer c.record("VertexAttrib4fv", dst, src)()
+	c.vertexAttribv(dst, 4, src)
+}
+
+// getVertexAttrib returns the value of pname of the vertex attribute a.
+func (c *Context) getVertexAttrib(a gl.Attrib, pname gl.Enum) []float32 {
+	at := c.attrib(a)
+	if at == nil {
+		return nil
+	}
+	switch pname {
+	case gl.VERTEX_ATTRIB_ARRAY_ENABLED:
+		return []float32{float32(boolInt(at.enabled))}
+	case gl.VERTEX_ATTRIB_ARRAY_SIZE:
+		return []float32{float32(at.size)}
+	case gl.VERTEX_ATTRIB_ARRAY_STRIDE:
+		return []float32{float32(at.stride)}
+	case gl.VERTEX_ATTRIB_ARRAY_TYPE:
+		return []float32{float32(at.ty)}
+	case gl.VERTEX_ATTRIB_ARRAY_NORMALIZED:
+		return []float32{float32(boolInt(at.normalized))}
+	case gl.VERTEX_ATTRIB_ARRAY_BUFFER_BINDING:
+		return []float32{float32(at.buffer)}
+	case gl.CURRENT_VERTEX_ATTRIB:
+		return at.current[:]
+	}
+	c.fail(gl.INVALID_ENUM)
+	return nil
+}
+
+// GetVertexAttribf implements gl.Context.
+func (c *Context) GetVertexAttribf(src gl.Attrib, pname gl.Enum) float32 {
+	defer c.record("GetVertexAttribf", src, pname)()
+	var x float32
+	if v := c.getVertexAttrib(src, pname); len(v) > 0 {
+		x = v[0]
+	}
+	c.result(x)
+	return x
+}
+
+// GetVertexAttribfv implements gl.Context.
+func (c *Context) GetVertexAttribfv(dst []float32, src gl.Attrib, pname gl.Enum) {
+	defer c.record("GetVertexAttribfv", dst, src, pname)()
+	copy(dst, c.getVertexAttrib(src, pname))
+}
+
+// GetVertexAttribi implements gl.Context.
+func (c *Context) GetVertexAttribi(src gl.Attrib, pname gl.Enum) int32 {
+	defer c.record("GetVertexAttribi", src, pname)()
+	var x int32
+	if v := c.getVertexAttrib(src, pname); len(v) > 0 {
+		x = int32(v[0])
+	}
+	c.result(x)
+	return x
+}
+
+// GetVertexAttribiv implements gl.Context.
+func (c *Context) GetVertexAttribiv(dst []int32, src gl.Attrib, pname gl.Enum) {
+	defer c.record("GetVertexAttribiv", dst, src, pname)()
+	for i, v := range c.getVertexAttrib(src, pname) {
+		if i < len(dst) {
+			dst[i] = int32(v)
+		}
+	}
+}
+
+// Clear implements gl.Context. It clears the color buffer of a framebuffer
+// with a texture of type UNSIGNED_BYTE attached, within the scissor box if
+// the scissor test is enabled, and only the channels that the color mask
+// allows.
+func (c *Context) Clear(mask gl.Enum) {
+	defer c.record("Clear", mask)()
+	if mask&^(gl.COLOR_BUFFER_BIT|gl.DEPTH_BUFFER_BIT|gl.STENCIL_BUFFER_BIT) != 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	if c.checkFramebuffer() || mask&gl.COLOR_BUFFER_BIT == 0 {
+		return
+	}
+	m := c.colorImage()
+	if m == nil {
+		return
+	}
+	x0, y0, x1, y1 := 0, 0, m.Width, m.Height
+	if c.enabled[gl.SCISSOR_TEST] {
+		s := c.scissor
+		x0, y0 = max(x0, int(s[0])), max(y0, int(s[1]))
+		x1, y1 = min(x1, int(s[0]+s[2])), min(y1, int(s[1]+s[3]))
+	}
+	var color [4]byte
+	for i, v := range c.clearColor {
+		color[i] = byte(v*255 + 0.5)
+	}
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			i := y*m.Width + x
+			p := pixel(m, i)
+			for j, on := range c.colorMask {
+				if on {
+					p[j] = color[j]
+				}
+			}
+			setPixel(m, i, p[:])
+		}
+	}
+}
+
+// ReadPixels implements gl.Context. Only the RGBA, UNSIGNED_BYTE format is
+// supported, which is also the implementation's color read format, so the
+// others report INVALID_OPERATION. Pixels of a framebuffer without a texture
+// image attached read as zero. As an addition to the specification, it is
+// INVALID_VALUE for dst to be too short for the pixels.
+func (c *Context) ReadPixels(dst []byte, x, y, width, height int, format, ty gl.Enum) {
+	defer c.record("ReadPixels", dst, x, y, width, height, format, ty)()
+	if _, e := pixelSize(format, ty); e == gl.INVALID_ENUM {
+		c.fail(e)
+		return
+	}
+	if format != gl.RGBA || ty != gl.UNSIGNED_BYTE {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	if width < 0 || height < 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	if c.checkFramebuffer() {
+		return
+	}
+	size, stride := imageSize(width, height, 4, c.pixelStore[gl.PACK_ALIGNMENT])
+	if len(dst) < size {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	src := c.readFramebuffer(x, y, width, height)
+	for j := 0; j < height; j++ {
+		copy(dst[j*stride:j*stride+4*width], src[j*4*width:])
+	}
+	// The record has the slice as it was passed, so update it.
+	c.call.Args[0] = append([]byte(nil), dst...)
+}
+
+func isMode(mode gl.Enum) bool {
+	switch mode {
+	case gl.POINTS, gl.LINE_STRIP, gl.LINE_LOOP, gl.LINES, gl.TRIANGLE_STRIP, gl.TRIANGLE_FAN, gl.TRIANGLES:
+		return true
+	}
+	return false
+}
+
+// checkDraw returns whether a draw of the vertices up to last can be made,
+// having reported the error if not.
+func (c *Context) checkDraw(last int) bool {
+	if c.checkFramebuffer() {
+		return false
+	}
+	prog := c.programs[c.program]
+	if prog == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return false
+	}
+	for _, a := range prog.attribs {
+		at := c.attribs[a.loc]
+		if !at.enabled {
+			continue
+		}
+		b := c.buffers[at.buffer]
+		if b == nil {
+			c.fail(gl.INVALID_OPERATION)
+			return false
+		}
+		stride := at.stride
+		if stride == 0 {
+			stride = at.size * typeSize(at.ty)
+		}
+		if last >= 0 && at.offset+last*stride+at.size*typeSize(at.ty) > len(b.data) {
+			c.fail(gl.INVALID_OPERATION)
+			return false
+		}
+	}
+	return true
+}
+
+// DrawArrays implements gl.Context. It draws nothing. As additions to the
+// specification, it is INVALID_OPERATION for there to be no current program,
+// or for an enabled array of an active attribute to be read beyond the end
+// of its buffer, or to have no buffer.
+func (c *Context) DrawArrays(mode gl.Enum, first, count int) {
+	defer c.record("DrawArrays", mode, first, count)()
+	if !isMode(mode) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if first < 0 || count < 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.checkDraw(first + count - 1)
+}
+
+// DrawElements implements gl.Context. It draws nothing, and makes the checks
+// that DrawArrays makes, and, as an addition to the specification, reports
+// INVALID_OPERATION for elements beyond the end of the element array buffer.
+func (c *Context) DrawElements(mode gl.Enum, count int, ty gl.Enum, offset int) {
+	defer c.record("DrawElements", mode, count, ty, offset)()
+	if !isMode(mode) || (ty != gl.UNSIGNED_BYTE && ty != gl.UNSIGNED_SHORT) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if count < 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	b := c.buffers[c.elementBuffer]
+	size := typeSize(ty)
+	if b == nil || offset < 0 || offset+count*size > len(b.data) {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	last := -1
+	for i := 0; i < count; i++ {
+		p := b.data[offset+i*size:]
+		v := int(p[0])
+		if size == 2 {
+			// Little-endian, as the hosts that run the tests are.
+			v |= int(p[1]) << 8
+		}
+		last = max(last, v)
+	}
+	c.checkDraw(last)
+}
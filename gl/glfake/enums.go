@@ -0,0 +1,595 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import "github.com/as/shiny/gl"
+
+// enumNames are the names of the enums, as printed by the gl package's
+// gldebug build. Values shared by several enums are printed as numbers.
+var enumNames = map[gl.Enum]string{
+	0x3:        "LINE_STRIP",
+	0x5:        "TRIANGLE_STRIP",
+	0x6:        "TRIANGLE_FAN",
+	0x300:      "SRC_COLOR",
+	0x301:      "ONE_MINUS_SRC_COLOR",
+	0x302:      "SRC_ALPHA",
+	0x303:      "ONE_MINUS_SRC_ALPHA",
+	0x304:      "DST_ALPHA",
+	0x305:      "ONE_MINUS_DST_ALPHA",
+	0x306:      "DST_COLOR",
+	0x307:      "ONE_MINUS_DST_COLOR",
+	0x308:      "SRC_ALPHA_SATURATE",
+	0x8006:     "FUNC_ADD",
+	0x883d:     "BLEND_EQUATION_ALPHA",
+	0x800a:     "FUNC_SUBTRACT",
+	0x800b:     "FUNC_REVERSE_SUBTRACT",
+	0x80c8:     "BLEND_DST_RGB",
+	0x80c9:     "BLEND_SRC_RGB",
+	0x80ca:     "BLEND_DST_ALPHA",
+	0x80cb:     "BLEND_SRC_ALPHA",
+	0x8001:     "CONSTANT_COLOR",
+	0x8002:     "ONE_MINUS_CONSTANT_COLOR",
+	0x8003:     "CONSTANT_ALPHA",
+	0x8004:     "ONE_MINUS_CONSTANT_ALPHA",
+	0x8005:     "BLEND_COLOR",
+	0x8892:     "ARRAY_BUFFER",
+	0x8893:     "ELEMENT_ARRAY_BUFFER",
+	0x8894:     "ARRAY_BUFFER_BINDING",
+	0x8895:     "ELEMENT_ARRAY_BUFFER_BINDING",
+	0x88e0:     "STREAM_DRAW",
+	0x88e4:     "STATIC_DRAW",
+	0x88e8:     "DYNAMIC_DRAW",
+	0x8764:     "BUFFER_SIZE",
+	0x8765:     "BUFFER_USAGE",
+	0x8626:     "CURRENT_VERTEX_ATTRIB",
+	0x404:      "FRONT",
+	0x405:      "BACK",
+	0x408:      "FRONT_AND_BACK",
+	0xde1:      "TEXTURE_2D",
+	0xb44:      "CULL_FACE",
+	0xbe2:      "BLEND",
+	0xbd0:      "DITHER",
+	0xb90:      "STENCIL_TEST",
+	0xb71:      "DEPTH_TEST",
+	0xc11:      "SCISSOR_TEST",
+	0x8037:     "POLYGON_OFFSET_FILL",
+	0x809e:     "SAMPLE_ALPHA_TO_COVERAGE",
+	0x80a0:     "SAMPLE_COVERAGE",
+	0x500:      "INVALID_ENUM",
+	0x501:      "INVALID_VALUE",
+	0x502:      "INVALID_OPERATION",
+	0x505:      "OUT_OF_MEMORY",
+	0x900:      "CW",
+	0x901:      "CCW",
+	0xb21:      "LINE_WIDTH",
+	0x846d:     "ALIASED_POINT_SIZE_RANGE",
+	0x846e:     "ALIASED_LINE_WIDTH_RANGE",
+	0xb45:      "CULL_FACE_MODE",
+	0xb46:      "FRONT_FACE",
+	0xb70:      "DEPTH_RANGE",
+	0xb72:      "DEPTH_WRITEMASK",
+	0xb73:      "DEPTH_CLEAR_VALUE",
+	0xb74:      "DEPTH_FUNC",
+	0xb91:      "STENCIL_CLEAR_VALUE",
+	0xb92:      "STENCIL_FUNC",
+	0xb94:      "STENCIL_FAIL",
+	0xb95:      "STENCIL_PASS_DEPTH_FAIL",
+	0xb96:      "STENCIL_PASS_DEPTH_PASS",
+	0xb97:      "STENCIL_REF",
+	0xb93:      "STENCIL_VALUE_MASK",
+	0xb98:      "STENCIL_WRITEMASK",
+	0x8800:     "STENCIL_BACK_FUNC",
+	0x8801:     "STENCIL_BACK_FAIL",
+	0x8802:     "STENCIL_BACK_PASS_DEPTH_FAIL",
+	0x8803:     "STENCIL_BACK_PASS_DEPTH_PASS",
+	0x8ca3:     "STENCIL_BACK_REF",
+	0x8ca4:     "STENCIL_BACK_VALUE_MASK",
+	0x8ca5:     "STENCIL_BACK_WRITEMASK",
+	0xba2:      "VIEWPORT",
+	0xc10:      "SCISSOR_BOX",
+	0xc22:      "COLOR_CLEAR_VALUE",
+	0xc23:      "COLOR_WRITEMASK",
+	0xcf5:      "UNPACK_ALIGNMENT",
+	0xd05:      "PACK_ALIGNMENT",
+	0xd33:      "MAX_TEXTURE_SIZE",
+	0xd3a:      "MAX_VIEWPORT_DIMS",
+	0xd50:      "SUBPIXEL_BITS",
+	0xd52:      "RED_BITS",
+	0xd53:      "GREEN_BITS",
+	0xd54:      "BLUE_BITS",
+	0xd55:      "ALPHA_BITS",
+	0xd56:      "DEPTH_BITS",
+	0xd57:      "STENCIL_BITS",
+	0x2a00:     "POLYGON_OFFSET_UNITS",
+	0x8038:     "POLYGON_OFFSET_FACTOR",
+	0x8069:     "TEXTURE_BINDING_2D",
+	0x80a8:     "SAMPLE_BUFFERS",
+	0x80a9:     "SAMPLES",
+	0x80aa:     "SAMPLE_COVERAGE_VALUE",
+	0x80ab:     "SAMPLE_COVERAGE_INVERT",
+	0x86a2:     "NUM_COMPRESSED_TEXTURE_FORMATS",
+	0x86a3:     "COMPRESSED_TEXTURE_FORMATS",
+	0x1100:     "DONT_CARE",
+	0x1101:     "FASTEST",
+	0x1102:     "NICEST",
+	0x8192:     "GENERATE_MIPMAP_HINT",
+	0x1400:     "BYTE",
+	0x1401:     "UNSIGNED_BYTE",
+	0x1402:     "SHORT",
+	0x1403:     "UNSIGNED_SHORT",
+	0x1404:     "INT",
+	0x1405:     "UNSIGNED_INT",
+	0x1406:     "FLOAT",
+	0x140c:     "FIXED",
+	0x1902:     "DEPTH_COMPONENT",
+	0x1906:     "ALPHA",
+	0x1907:     "RGB",
+	0x1908:     "RGBA",
+	0x1909:     "LUMINANCE",
+	0x190a:     "LUMINANCE_ALPHA",
+	0x8033:     "UNSIGNED_SHORT_4_4_4_4",
+	0x8034:     "UNSIGNED_SHORT_5_5_5_1",
+	0x8363:     "UNSIGNED_SHORT_5_6_5",
+	0x8869:     "MAX_VERTEX_ATTRIBS",
+	0x8dfb:     "MAX_VERTEX_UNIFORM_VECTORS",
+	0x8dfc:     "MAX_VARYING_VECTORS",
+	0x8b4d:     "MAX_COMBINED_TEXTURE_IMAGE_UNITS",
+	0x8b4c:     "MAX_VERTEX_TEXTURE_IMAGE_UNITS",
+	0x8872:     "MAX_TEXTURE_IMAGE_UNITS",
+	0x8dfd:     "MAX_FRAGMENT_UNIFORM_VECTORS",
+	0x8b4f:     "SHADER_TYPE",
+	0x8b80:     "DELETE_STATUS",
+	0x8b82:     "LINK_STATUS",
+	0x8b83:     "VALIDATE_STATUS",
+	0x8b85:     "ATTACHED_SHADERS",
+	0x8b86:     "ACTIVE_UNIFORMS",
+	0x8b87:     "ACTIVE_UNIFORM_MAX_LENGTH",
+	0x8b89:     "ACTIVE_ATTRIBUTES",
+	0x8b8a:     "ACTIVE_ATTRIBUTE_MAX_LENGTH",
+	0x8b8c:     "SHADING_LANGUAGE_VERSION",
+	0x8b8d:     "CURRENT_PROGRAM",
+	0x200:      "NEVER",
+	0x201:      "LESS",
+	0x202:      "EQUAL",
+	0x203:      "LEQUAL",
+	0x204:      "GREATER",
+	0x205:      "NOTEQUAL",
+	0x206:      "GEQUAL",
+	0x207:      "ALWAYS",
+	0x1e00:     "KEEP",
+	0x1e01:     "REPLACE",
+	0x1e02:     "INCR",
+	0x1e03:     "DECR",
+	0x150a:     "INVERT",
+	0x8507:     "INCR_WRAP",
+	0x8508:     "DECR_WRAP",
+	0x1f00:     "VENDOR",
+	0x1f01:     "RENDERER",
+	0x1f02:     "VERSION",
+	0x1f03:     "EXTENSIONS",
+	0x2600:     "NEAREST",
+	0x2601:     "LINEAR",
+	0x2700:     "NEAREST_MIPMAP_NEAREST",
+	0x2701:     "LINEAR_MIPMAP_NEAREST",
+	0x2702:     "NEAREST_MIPMAP_LINEAR",
+	0x2703:     "LINEAR_MIPMAP_LINEAR",
+	0x2800:     "TEXTURE_MAG_FILTER",
+	0x2801:     "TEXTURE_MIN_FILTER",
+	0x2802:     "TEXTURE_WRAP_S",
+	0x2803:     "TEXTURE_WRAP_T",
+	0x1702:     "TEXTURE",
+	0x8513:     "TEXTURE_CUBE_MAP",
+	0x8514:     "TEXTURE_BINDING_CUBE_MAP",
+	0x8515:     "TEXTURE_CUBE_MAP_POSITIVE_X",
+	0x8516:     "TEXTURE_CUBE_MAP_NEGATIVE_X",
+	0x8517:     "TEXTURE_CUBE_MAP_POSITIVE_Y",
+	0x8518:     "TEXTURE_CUBE_MAP_NEGATIVE_Y",
+	0x8519:     "TEXTURE_CUBE_MAP_POSITIVE_Z",
+	0x851a:     "TEXTURE_CUBE_MAP_NEGATIVE_Z",
+	0x851c:     "MAX_CUBE_MAP_TEXTURE_SIZE",
+	0x84c0:     "TEXTURE0",
+	0x84c1:     "TEXTURE1",
+	0x84c2:     "TEXTURE2",
+	0x84c3:     "TEXTURE3",
+	0x84c4:     "TEXTURE4",
+	0x84c5:     "TEXTURE5",
+	0x84c6:     "TEXTURE6",
+	0x84c7:     "TEXTURE7",
+	0x84c8:     "TEXTURE8",
+	0x84c9:     "TEXTURE9",
+	0x84ca:     "TEXTURE10",
+	0x84cb:     "TEXTURE11",
+	0x84cc:     "TEXTURE12",
+	0x84cd:     "TEXTURE13",
+	0x84ce:     "TEXTURE14",
+	0x84cf:     "TEXTURE15",
+	0x84d0:     "TEXTURE16",
+	0x84d1:     "TEXTURE17",
+	0x84d2:     "TEXTURE18",
+	0x84d3:     "TEXTURE19",
+	0x84d4:     "TEXTURE20",
+	0x84d5:     "TEXTURE21",
+	0x84d6:     "TEXTURE22",
+	0x84d7:     "TEXTURE23",
+	0x84d8:     "TEXTURE24",
+	0x84d9:     "TEXTURE25",
+	0x84da:     "TEXTURE26",
+	0x84db:     "TEXTURE27",
+	0x84dc:     "TEXTURE28",
+	0x84dd:     "TEXTURE29",
+	0x84de:     "TEXTURE30",
+	0x84df:     "TEXTURE31",
+	0x84e0:     "ACTIVE_TEXTURE",
+	0x2901:     "REPEAT",
+	0x812f:     "CLAMP_TO_EDGE",
+	0x8370:     "MIRRORED_REPEAT",
+	0x8622:     "VERTEX_ATTRIB_ARRAY_ENABLED",
+	0x8623:     "VERTEX_ATTRIB_ARRAY_SIZE",
+	0x8624:     "VERTEX_ATTRIB_ARRAY_STRIDE",
+	0x8625:     "VERTEX_ATTRIB_ARRAY_TYPE",
+	0x886a:     "VERTEX_ATTRIB_ARRAY_NORMALIZED",
+	0x8645:     "VERTEX_ATTRIB_ARRAY_POINTER",
+	0x889f:     "VERTEX_ATTRIB_ARRAY_BUFFER_BINDING",
+	0x8b9a:     "IMPLEMENTATION_COLOR_READ_TYPE",
+	0x8b9b:     "IMPLEMENTATION_COLOR_READ_FORMAT",
+	0x8b81:     "COMPILE_STATUS",
+	0x8b84:     "INFO_LOG_LENGTH",
+	0x8b88:     "SHADER_SOURCE_LENGTH",
+	0x8dfa:     "SHADER_COMPILER",
+	0x8df8:     "SHADER_BINARY_FORMATS",
+	0x8df9:     "NUM_SHADER_BINARY_FORMATS",
+	0x8df0:     "LOW_FLOAT",
+	0x8df1:     "MEDIUM_FLOAT",
+	0x8df2:     "HIGH_FLOAT",
+	0x8df3:     "LOW_INT",
+	0x8df4:     "MEDIUM_INT",
+	0x8df5:     "HIGH_INT",
+	0x8d40:     "FRAMEBUFFER",
+	0x8d41:     "RENDERBUFFER",
+	0x8056:     "RGBA4",
+	0x8057:     "RGB5_A1",
+	0x8d62:     "RGB565",
+	0x81a5:     "DEPTH_COMPONENT16",
+	0x8d48:     "STENCIL_INDEX8",
+	0x8d42:     "RENDERBUFFER_WIDTH",
+	0x8d43:     "RENDERBUFFER_HEIGHT",
+	0x8d44:     "RENDERBUFFER_INTERNAL_FORMAT",
+	0x8d50:     "RENDERBUFFER_RED_SIZE",
+	0x8d51:     "RENDERBUFFER_GREEN_SIZE",
+	0x8d52:     "RENDERBUFFER_BLUE_SIZE",
+	0x8d53:     "RENDERBUFFER_ALPHA_SIZE",
+	0x8d54:     "RENDERBUFFER_DEPTH_SIZE",
+	0x8d55:     "RENDERBUFFER_STENCIL_SIZE",
+	0x8cd0:     "FRAMEBUFFER_ATTACHMENT_OBJECT_TYPE",
+	0x8cd1:     "FRAMEBUFFER_ATTACHMENT_OBJECT_NAME",
+	0x8cd2:     "FRAMEBUFFER_ATTACHMENT_TEXTURE_LEVEL",
+	0x8cd3:     "FRAMEBUFFER_ATTACHMENT_TEXTURE_CUBE_MAP_FACE",
+	0x8ce0:     "COLOR_ATTACHMENT0",
+	0x8d00:     "DEPTH_ATTACHMENT",
+	0x8d20:     "STENCIL_ATTACHMENT",
+	0x8cd5:     "FRAMEBUFFER_COMPLETE",
+	0x8cd6:     "FRAMEBUFFER_INCOMPLETE_ATTACHMENT",
+	0x8cd7:     "FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT",
+	0x8cd9:     "FRAMEBUFFER_INCOMPLETE_DIMENSIONS",
+	0x8cdd:     "FRAMEBUFFER_UNSUPPORTED",
+	0x8ca7:     "RENDERBUFFER_BINDING",
+	0x84e8:     "MAX_RENDERBUFFER_SIZE",
+	0x506:      "INVALID_FRAMEBUFFER_OPERATION",
+	0x100:      "DEPTH_BUFFER_BIT",
+	0x400:      "STENCIL_BUFFER_BIT",
+	0x4000:     "COLOR_BUFFER_BIT",
+	0x8b50:     "FLOAT_VEC2",
+	0x8b51:     "FLOAT_VEC3",
+	0x8b52:     "FLOAT_VEC4",
+	0x8b53:     "INT_VEC2",
+	0x8b54:     "INT_VEC3",
+	0x8b55:     "INT_VEC4",
+	0x8b56:     "BOOL",
+	0x8b57:     "BOOL_VEC2",
+	0x8b58:     "BOOL_VEC3",
+	0x8b59:     "BOOL_VEC4",
+	0x8b5a:     "FLOAT_MAT2",
+	0x8b5b:     "FLOAT_MAT3",
+	0x8b5c:     "FLOAT_MAT4",
+	0x8b5e:     "SAMPLER_2D",
+	0x8b60:     "SAMPLER_CUBE",
+	0x8b30:     "FRAGMENT_SHADER",
+	0x8b31:     "VERTEX_SHADER",
+	0x8a35:     "ACTIVE_UNIFORM_BLOCK_MAX_NAME_LENGTH",
+	0x8a36:     "ACTIVE_UNIFORM_BLOCKS",
+	0x911a:     "ALREADY_SIGNALED",
+	0x8c2f:     "ANY_SAMPLES_PASSED",
+	0x8d6a:     "ANY_SAMPLES_PASSED_CONSERVATIVE",
+	0x1905:     "BLUE",
+	0x911f:     "BUFFER_ACCESS_FLAGS",
+	0x9120:     "BUFFER_MAP_LENGTH",
+	0x9121:     "BUFFER_MAP_OFFSET",
+	0x88bc:     "BUFFER_MAPPED",
+	0x88bd:     "BUFFER_MAP_POINTER",
+	0x1800:     "COLOR",
+	0x8cea:     "COLOR_ATTACHMENT10",
+	0x8ce1:     "COLOR_ATTACHMENT1",
+	0x8ceb:     "COLOR_ATTACHMENT11",
+	0x8cec:     "COLOR_ATTACHMENT12",
+	0x8ced:     "COLOR_ATTACHMENT13",
+	0x8cee:     "COLOR_ATTACHMENT14",
+	0x8cef:     "COLOR_ATTACHMENT15",
+	0x8ce2:     "COLOR_ATTACHMENT2",
+	0x8ce3:     "COLOR_ATTACHMENT3",
+	0x8ce4:     "COLOR_ATTACHMENT4",
+	0x8ce5:     "COLOR_ATTACHMENT5",
+	0x8ce6:     "COLOR_ATTACHMENT6",
+	0x8ce7:     "COLOR_ATTACHMENT7",
+	0x8ce8:     "COLOR_ATTACHMENT8",
+	0x8ce9:     "COLOR_ATTACHMENT9",
+	0x884e:     "COMPARE_REF_TO_TEXTURE",
+	0x9270:     "COMPRESSED_R11_EAC",
+	0x9272:     "COMPRESSED_RG11_EAC",
+	0x9274:     "COMPRESSED_RGB8_ETC2",
+	0x9276:     "COMPRESSED_RGB8_PUNCHTHROUGH_ALPHA1_ETC2",
+	0x9278:     "COMPRESSED_RGBA8_ETC2_EAC",
+	0x9271:     "COMPRESSED_SIGNED_R11_EAC",
+	0x9273:     "COMPRESSED_SIGNED_RG11_EAC",
+	0x9279:     "COMPRESSED_SRGB8_ALPHA8_ETC2_EAC",
+	0x9275:     "COMPRESSED_SRGB8_ETC2",
+	0x9277:     "COMPRESSED_SRGB8_PUNCHTHROUGH_ALPHA1_ETC2",
+	0x911c:     "CONDITION_SATISFIED",
+	0x8865:     "CURRENT_QUERY",
+	0x1801:     "DEPTH",
+	0x88f0:     "DEPTH24_STENCIL8",
+	0x8cad:     "DEPTH32F_STENCIL8",
+	0x81a6:     "DEPTH_COMPONENT24",
+	0x8cac:     "DEPTH_COMPONENT32F",
+	0x84f9:     "DEPTH_STENCIL",
+	0x821a:     "DEPTH_STENCIL_ATTACHMENT",
+	0x8825:     "DRAW_BUFFER0",
+	0x882f:     "DRAW_BUFFER10",
+	0x8826:     "DRAW_BUFFER1",
+	0x8830:     "DRAW_BUFFER11",
+	0x8831:     "DRAW_BUFFER12",
+	0x8832:     "DRAW_BUFFER13",
+	0x8833:     "DRAW_BUFFER14",
+	0x8834:     "DRAW_BUFFER15",
+	0x8827:     "DRAW_BUFFER2",
+	0x8828:     "DRAW_BUFFER3",
+	0x8829:     "DRAW_BUFFER4",
+	0x882a:     "DRAW_BUFFER5",
+	0x882b:     "DRAW_BUFFER6",
+	0x882c:     "DRAW_BUFFER7",
+	0x882d:     "DRAW_BUFFER8",
+	0x882e:     "DRAW_BUFFER9",
+	0x8ca9:     "DRAW_FRAMEBUFFER",
+	0x88ea:     "DYNAMIC_COPY",
+	0x88e9:     "DYNAMIC_READ",
+	0x8dad:     "FLOAT_32_UNSIGNED_INT_24_8_REV",
+	0x8b65:     "FLOAT_MAT2x3",
+	0x8b66:     "FLOAT_MAT2x4",
+	0x8b67:     "FLOAT_MAT3x2",
+	0x8b68:     "FLOAT_MAT3x4",
+	0x8b69:     "FLOAT_MAT4x2",
+	0x8b6a:     "FLOAT_MAT4x3",
+	0x8b8b:     "FRAGMENT_SHADER_DERIVATIVE_HINT",
+	0x8215:     "FRAMEBUFFER_ATTACHMENT_ALPHA_SIZE",
+	0x8214:     "FRAMEBUFFER_ATTACHMENT_BLUE_SIZE",
+	0x8210:     "FRAMEBUFFER_ATTACHMENT_COLOR_ENCODING",
+	0x8211:     "FRAMEBUFFER_ATTACHMENT_COMPONENT_TYPE",
+	0x8216:     "FRAMEBUFFER_ATTACHMENT_DEPTH_SIZE",
+	0x8213:     "FRAMEBUFFER_ATTACHMENT_GREEN_SIZE",
+	0x8212:     "FRAMEBUFFER_ATTACHMENT_RED_SIZE",
+	0x8217:     "FRAMEBUFFER_ATTACHMENT_STENCIL_SIZE",
+	0x8cd4:     "FRAMEBUFFER_ATTACHMENT_TEXTURE_LAYER",
+	0x8218:     "FRAMEBUFFER_DEFAULT",
+	0x8d56:     "FRAMEBUFFER_INCOMPLETE_MULTISAMPLE",
+	0x8219:     "FRAMEBUFFER_UNDEFINED",
+	0x1904:     "GREEN",
+	0x140b:     "HALF_FLOAT",
+	0x8d9f:     "INT_2_10_10_10_REV",
+	0x8c8c:     "INTERLEAVED_ATTRIBS",
+	0x8dca:     "INT_SAMPLER_2D",
+	0x8dcf:     "INT_SAMPLER_2D_ARRAY",
+	0x8dcb:     "INT_SAMPLER_3D",
+	0x8dcc:     "INT_SAMPLER_CUBE",
+	0xffffffff: "INVALID_INDEX",
+	0x821b:     "MAJOR_VERSION",
+	0x10:       "MAP_FLUSH_EXPLICIT_BIT",
+	0x8:        "MAP_INVALIDATE_BUFFER_BIT",
+	0x20:       "MAP_UNSYNCHRONIZED_BIT",
+	0x8008:     "MAX",
+	0x8073:     "MAX_3D_TEXTURE_SIZE",
+	0x88ff:     "MAX_ARRAY_TEXTURE_LAYERS",
+	0x8cdf:     "MAX_COLOR_ATTACHMENTS",
+	0x8a33:     "MAX_COMBINED_FRAGMENT_UNIFORM_COMPONENTS",
+	0x8a2e:     "MAX_COMBINED_UNIFORM_BLOCKS",
+	0x8a31:     "MAX_COMBINED_VERTEX_UNIFORM_COMPONENTS",
+	0x8824:     "MAX_DRAW_BUFFERS",
+	0x8d6b:     "MAX_ELEMENT_INDEX",
+	0x80e9:     "MAX_ELEMENTS_INDICES",
+	0x80e8:     "MAX_ELEMENTS_VERTICES",
+	0x9125:     "MAX_FRAGMENT_INPUT_COMPONENTS",
+	0x8a2d:     "MAX_FRAGMENT_UNIFORM_BLOCKS",
+	0x8b49:     "MAX_FRAGMENT_UNIFORM_COMPONENTS",
+	0x8905:     "MAX_PROGRAM_TEXEL_OFFSET",
+	0x8d57:     "MAX_SAMPLES",
+	0x9111:     "MAX_SERVER_WAIT_TIMEOUT",
+	0x84fd:     "MAX_TEXTURE_LOD_BIAS",
+	0x8c8a:     "MAX_TRANSFORM_FEEDBACK_INTERLEAVED_COMPONENTS",
+	0x8c8b:     "MAX_TRANSFORM_FEEDBACK_SEPARATE_ATTRIBS",
+	0x8c80:     "MAX_TRANSFORM_FEEDBACK_SEPARATE_COMPONENTS",
+	0x8a30:     "MAX_UNIFORM_BLOCK_SIZE",
+	0x8a2f:     "MAX_UNIFORM_BUFFER_BINDINGS",
+	0x8b4b:     "MAX_VARYING_COMPONENTS",
+	0x9122:     "MAX_VERTEX_OUTPUT_COMPONENTS",
+	0x8a2b:     "MAX_VERTEX_UNIFORM_BLOCKS",
+	0x8b4a:     "MAX_VERTEX_UNIFORM_COMPONENTS",
+	0x8007:     "MIN",
+	0x821c:     "MINOR_VERSION",
+	0x8904:     "MIN_PROGRAM_TEXEL_OFFSET",
+	0x821d:     "NUM_EXTENSIONS",
+	0x87fe:     "NUM_PROGRAM_BINARY_FORMATS",
+	0x9380:     "NUM_SAMPLE_COUNTS",
+	0x9112:     "OBJECT_TYPE",
+	0xd02:      "PACK_ROW_LENGTH",
+	0xd04:      "PACK_SKIP_PIXELS",
+	0xd03:      "PACK_SKIP_ROWS",
+	0x88eb:     "PIXEL_PACK_BUFFER",
+	0x88ed:     "PIXEL_PACK_BUFFER_BINDING",
+	0x88ec:     "PIXEL_UNPACK_BUFFER",
+	0x88ef:     "PIXEL_UNPACK_BUFFER_BINDING",
+	0x8d69:     "PRIMITIVE_RESTART_FIXED_INDEX",
+	0x87ff:     "PROGRAM_BINARY_FORMATS",
+	0x8741:     "PROGRAM_BINARY_LENGTH",
+	0x8257:     "PROGRAM_BINARY_RETRIEVABLE_HINT",
+	0x8866:     "QUERY_RESULT",
+	0x8867:     "QUERY_RESULT_AVAILABLE",
+	0x8c3a:     "R11F_G11F_B10F",
+	0x822d:     "R16F",
+	0x8233:     "R16I",
+	0x8234:     "R16UI",
+	0x822e:     "R32F",
+	0x8235:     "R32I",
+	0x8236:     "R32UI",
+	0x8229:     "R8",
+	0x8231:     "R8I",
+	0x8f94:     "R8_SNORM",
+	0x8232:     "R8UI",
+	0x8c89:     "RASTERIZER_DISCARD",
+	0xc02:      "READ_BUFFER",
+	0x8ca8:     "READ_FRAMEBUFFER",
+	0x8caa:     "READ_FRAMEBUFFER_BINDING",
+	0x1903:     "RED",
+	0x8d94:     "RED_INTEGER",
+	0x8cab:     "RENDERBUFFER_SAMPLES",
+	0x8227:     "RG",
+	0x822f:     "RG16F",
+	0x8239:     "RG16I",
+	0x823a:     "RG16UI",
+	0x8230:     "RG32F",
+	0x823b:     "RG32I",
+	0x823c:     "RG32UI",
+	0x822b:     "RG8",
+	0x8237:     "RG8I",
+	0x8f95:     "RG8_SNORM",
+	0x8238:     "RG8UI",
+	0x8059:     "RGB10_A2",
+	0x906f:     "RGB10_A2UI",
+	0x881b:     "RGB16F",
+	0x8d89:     "RGB16I",
+	0x8d77:     "RGB16UI",
+	0x8815:     "RGB32F",
+	0x8d83:     "RGB32I",
+	0x8d71:     "RGB32UI",
+	0x8051:     "RGB8",
+	0x8d8f:     "RGB8I",
+	0x8f96:     "RGB8_SNORM",
+	0x8d7d:     "RGB8UI",
+	0x8c3d:     "RGB9_E5",
+	0x881a:     "RGBA16F",
+	0x8d88:     "RGBA16I",
+	0x8d76:     "RGBA16UI",
+	0x8814:     "RGBA32F",
+	0x8d82:     "RGBA32I",
+	0x8d70:     "RGBA32UI",
+	0x8058:     "RGBA8",
+	0x8d8e:     "RGBA8I",
+	0x8f97:     "RGBA8_SNORM",
+	0x8d7c:     "RGBA8UI",
+	0x8d99:     "RGBA_INTEGER",
+	0x8d98:     "RGB_INTEGER",
+	0x8228:     "RG_INTEGER",
+	0x8dc1:     "SAMPLER_2D_ARRAY",
+	0x8dc4:     "SAMPLER_2D_ARRAY_SHADOW",
+	0x8b62:     "SAMPLER_2D_SHADOW",
+	0x8b5f:     "SAMPLER_3D",
+	0x8919:     "SAMPLER_BINDING",
+	0x8dc5:     "SAMPLER_CUBE_SHADOW",
+	0x8c8d:     "SEPARATE_ATTRIBS",
+	0x9119:     "SIGNALED",
+	0x8f9c:     "SIGNED_NORMALIZED",
+	0x8c40:     "SRGB",
+	0x8c41:     "SRGB8",
+	0x8c43:     "SRGB8_ALPHA8",
+	0x88e6:     "STATIC_COPY",
+	0x88e5:     "STATIC_READ",
+	0x1802:     "STENCIL",
+	0x88e2:     "STREAM_COPY",
+	0x88e1:     "STREAM_READ",
+	0x9113:     "SYNC_CONDITION",
+	0x9116:     "SYNC_FENCE",
+	0x9115:     "SYNC_FLAGS",
+	0x9117:     "SYNC_GPU_COMMANDS_COMPLETE",
+	0x9114:     "SYNC_STATUS",
+	0x8c1a:     "TEXTURE_2D_ARRAY",
+	0x806f:     "TEXTURE_3D",
+	0x813c:     "TEXTURE_BASE_LEVEL",
+	0x8c1d:     "TEXTURE_BINDING_2D_ARRAY",
+	0x806a:     "TEXTURE_BINDING_3D",
+	0x884d:     "TEXTURE_COMPARE_FUNC",
+	0x884c:     "TEXTURE_COMPARE_MODE",
+	0x912f:     "TEXTURE_IMMUTABLE_FORMAT",
+	0x82df:     "TEXTURE_IMMUTABLE_LEVELS",
+	0x813d:     "TEXTURE_MAX_LEVEL",
+	0x813b:     "TEXTURE_MAX_LOD",
+	0x813a:     "TEXTURE_MIN_LOD",
+	0x8e45:     "TEXTURE_SWIZZLE_A",
+	0x8e44:     "TEXTURE_SWIZZLE_B",
+	0x8e43:     "TEXTURE_SWIZZLE_G",
+	0x8e42:     "TEXTURE_SWIZZLE_R",
+	0x8072:     "TEXTURE_WRAP_R",
+	0x911b:     "TIMEOUT_EXPIRED",
+	0x8e22:     "TRANSFORM_FEEDBACK",
+	0x8e24:     "TRANSFORM_FEEDBACK_ACTIVE",
+	0x8e25:     "TRANSFORM_FEEDBACK_BINDING",
+	0x8c8e:     "TRANSFORM_FEEDBACK_BUFFER",
+	0x8c8f:     "TRANSFORM_FEEDBACK_BUFFER_BINDING",
+	0x8c7f:     "TRANSFORM_FEEDBACK_BUFFER_MODE",
+	0x8c85:     "TRANSFORM_FEEDBACK_BUFFER_SIZE",
+	0x8c84:     "TRANSFORM_FEEDBACK_BUFFER_START",
+	0x8e23:     "TRANSFORM_FEEDBACK_PAUSED",
+	0x8c88:     "TRANSFORM_FEEDBACK_PRIMITIVES_WRITTEN",
+	0x8c76:     "TRANSFORM_FEEDBACK_VARYING_MAX_LENGTH",
+	0x8c83:     "TRANSFORM_FEEDBACK_VARYINGS",
+	0x8a3c:     "UNIFORM_ARRAY_STRIDE",
+	0x8a43:     "UNIFORM_BLOCK_ACTIVE_UNIFORM_INDICES",
+	0x8a42:     "UNIFORM_BLOCK_ACTIVE_UNIFORMS",
+	0x8a3f:     "UNIFORM_BLOCK_BINDING",
+	0x8a40:     "UNIFORM_BLOCK_DATA_SIZE",
+	0x8a3a:     "UNIFORM_BLOCK_INDEX",
+	0x8a41:     "UNIFORM_BLOCK_NAME_LENGTH",
+	0x8a46:     "UNIFORM_BLOCK_REFERENCED_BY_FRAGMENT_SHADER",
+	0x8a44:     "UNIFORM_BLOCK_REFERENCED_BY_VERTEX_SHADER",
+	0x8a11:     "UNIFORM_BUFFER",
+	0x8a28:     "UNIFORM_BUFFER_BINDING",
+	0x8a34:     "UNIFORM_BUFFER_OFFSET_ALIGNMENT",
+	0x8a2a:     "UNIFORM_BUFFER_SIZE",
+	0x8a29:     "UNIFORM_BUFFER_START",
+	0x8a3e:     "UNIFORM_IS_ROW_MAJOR",
+	0x8a3d:     "UNIFORM_MATRIX_STRIDE",
+	0x8a39:     "UNIFORM_NAME_LENGTH",
+	0x8a3b:     "UNIFORM_OFFSET",
+	0x8a38:     "UNIFORM_SIZE",
+	0x8a37:     "UNIFORM_TYPE",
+	0x806e:     "UNPACK_IMAGE_HEIGHT",
+	0xcf2:      "UNPACK_ROW_LENGTH",
+	0x806d:     "UNPACK_SKIP_IMAGES",
+	0xcf4:      "UNPACK_SKIP_PIXELS",
+	0xcf3:      "UNPACK_SKIP_ROWS",
+	0x9118:     "UNSIGNALED",
+	0x8c3b:     "UNSIGNED_INT_10F_11F_11F_REV",
+	0x8368:     "UNSIGNED_INT_2_10_10_10_REV",
+	0x84fa:     "UNSIGNED_INT_24_8",
+	0x8c3e:     "UNSIGNED_INT_5_9_9_9_REV",
+	0x8dd2:     "UNSIGNED_INT_SAMPLER_2D",
+	0x8dd7:     "UNSIGNED_INT_SAMPLER_2D_ARRAY",
+	0x8dd3:     "UNSIGNED_INT_SAMPLER_3D",
+	0x8dd4:     "UNSIGNED_INT_SAMPLER_CUBE",
+	0x8dc6:     "UNSIGNED_INT_VEC2",
+	0x8dc7:     "UNSIGNED_INT_VEC3",
+	0x8dc8:     "UNSIGNED_INT_VEC4",
+	0x8c17:     "UNSIGNED_NORMALIZED",
+	0x85b5:     "VERTEX_ARRAY_BINDING",
+	0x88fe:     "VERTEX_ATTRIB_ARRAY_DIVISOR",
+	0x88fd:     "VERTEX_ATTRIB_ARRAY_INTEGER",
+	0x911d:     "WAIT_FAILED",
+}
@@ -0,0 +1,373 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package glfake implements gl.Context in Go, for testing code that uses
+// OpenGL ES on machines without a GPU.
+//
+// A Context keeps the state that an OpenGL ES 2.0 implementation would: it
+// allocates and deletes textures, buffers, framebuffers, renderbuffers,
+// shaders and programs, tracks what is bound, and reports misuse of the API
+// through GetError as the ES 2.0 specification describes. It records every
+// call, with its arguments, results and error, so that a test can check the
+// calls that the code under test makes:
+//
+//	glctx := glfake.NewContext()
+//	f(glctx)
+//	for _, c := range glctx.Calls() {
+//		fmt.Println(c)
+//	}
+//	// Output:
+//	// CreateTexture() Texture(1)
+//	// BindTexture(TEXTURE_2D, Texture(1))
+//	// TexImage2D(TEXTURE_2D, 0, 4, 4, RGBA, UNSIGNED_BYTE, len(64))
+//
+// A Context does not render. Clear and ReadPixels work on framebuffers with
+// a texture attached, so that uploads and clears can be read back, but draw
+// calls are only checked and recorded.
+//
+// Where the specification leaves the result of misuse undefined, such as a
+// draw with no current program or an upload from too short a slice, the
+// Context reports INVALID_OPERATION or INVALID_VALUE. Those checks are noted
+// on the methods that make them.
+package glfake // import "github.com/as/shiny/gl/glfake"
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/as/shiny/gl"
+)
+
+// The implementation limits. The texture and renderbuffer sizes are those of
+// common hardware, and the others are the minimums that the specification
+// allows, to catch code that assumes more.
+const (
+	maxTextureSize      = 4096
+	maxRenderbufferSize = 4096
+	maxTextureUnits     = 8
+	maxVertexAttribs    = 8
+)
+
+// Context is a fake gl.Context. It is also a gl.Worker, whose work is always
+// done, as calls run when they are made.
+//
+// Like a real Context, calls must not be made concurrently. A Context panics
+// if they are. The methods that are not part of gl.Context, such as Calls,
+// may be called from any goroutine.
+type Context struct {
+	busy int32 // The number of calls in progress, which must be 0 or 1.
+
+	mu    sync.Mutex // Guards calls and the state of the objects.
+	calls []Call
+	call  *Call // The call in progress.
+	err   gl.Enum
+	next  uint32 // The last name given to an object.
+
+	textures      map[uint32]*texture
+	buffers       map[uint32]*buffer
+	framebuffers  map[uint32]*framebuffer
+	renderbuffers map[uint32]*renderbuffer
+	vertexArrays  map[uint32]*vertexArray
+	shaders       map[uint32]*shader
+	programs      map[uint32]*program
+
+	// The default textures, which are name 0.
+	default2D, defaultCube texture
+
+	activeTexture int
+	units         [maxTextureUnits]struct{ tex2D, cube uint32 }
+	arrayBuffer   uint32
+	elementBuffer uint32
+	framebuffer   uint32
+	renderbuffer  uint32
+	vertexArray   uint32
+	program       uint32
+	attribs       [maxVertexAttribs]attrib
+
+	enabled        map[gl.Enum]bool
+	viewport       [4]int32
+	scissor        [4]int32
+	clearColor     [4]float32
+	clearDepth     float32
+	clearStencil   int32
+	colorMask      [4]bool
+	depthMask      bool
+	depthFunc      gl.Enum
+	depthRange     [2]float32
+	blendColor     [4]float32
+	blendEquation  [2]gl.Enum // RGB, alpha.
+	blendFunc      [4]gl.Enum // Source RGB, destination RGB, source alpha, destination alpha.
+	cullFace       gl.Enum
+	frontFace      gl.Enum
+	lineWidth      float32
+	polygonOffset  [2]float32
+	sampleCoverage float32
+	sampleInvert   bool
+	stencil        [2]stencil // Front, back.
+	hints          map[gl.Enum]gl.Enum
+	pixelStore     map[gl.Enum]int32
+
+	work chan struct{}
+}
+
+// Call is a call made to a Context.
+type Call struct {
+	Name string
+	// Args are the arguments, with slices copied. Program, Shader and the
+	// other names are of their gl types.
+	Args []interface{}
+	// Results are the values returned, if any.
+	Results []interface{}
+	// Err is the error that the call caused, or 0. It is set even if an
+	// earlier error, that GetError has not yet returned, means that Err will
+	// not be.
+	Err gl.Enum
+}
+
+// String returns the call as it would be written in Go, followed by its
+// results and error, if any, like the log of the gl package's gldebug build.
+// Enums are written by name, and slices by length.
+func (c Call) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(c.Name)
+	buf.WriteByte('(')
+	for i, a := range c.Args {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(format(a))
+	}
+	buf.WriteByte(')')
+	for i, r := range c.Results {
+		if i == 0 {
+			buf.WriteByte(' ')
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(format(r))
+	}
+	if c.Err != 0 {
+		buf.WriteString(" error: ")
+		buf.WriteString(EnumString(c.Err))
+	}
+	return buf.String()
+}
+
+func format(v interface{}) string {
+	switch v := v.(type) {
+	case gl.Enum:
+		return EnumString(v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []byte:
+		return fmt.Sprintf("len(%d)", len(v))
+	case []float32:
+		return fmt.Sprintf("len(%d)", len(v))
+	case []int32:
+		return fmt.Sprintf("len(%d)", len(v))
+	case []bool:
+		return fmt.Sprintf("len(%d)", len(v))
+	}
+	return fmt.Sprint(v)
+}
+
+// EnumString returns the name of e, such as "TEXTURE_2D". Values shared by
+// several enums, such as 0 and 1, are returned as numbers.
+func EnumString(e gl.Enum) string {
+	if s, ok := enumNames[e]; ok {
+		return s
+	}
+	if e < 0x100 {
+		return fmt.Sprint(uint32(e))
+	}
+	return fmt.Sprintf("gl.Enum(0x%x)", uint32(e))
+}
+
+// NewContext returns a Context in the initial state of an OpenGL ES 2.0
+// context.
+func NewContext() *Context {
+	c := &Context{
+		textures:      make(map[uint32]*texture),
+		buffers:       make(map[uint32]*buffer),
+		framebuffers:  make(map[uint32]*framebuffer),
+		renderbuffers: make(map[uint32]*renderbuffer),
+		vertexArrays:  make(map[uint32]*vertexArray),
+		shaders:       make(map[uint32]*shader),
+		programs:      make(map[uint32]*program),
+
+		default2D:   texture{target: gl.TEXTURE_2D, bound: true},
+		defaultCube: texture{target: gl.TEXTURE_CUBE_MAP, bound: true},
+
+		enabled:        map[gl.Enum]bool{gl.DITHER: true},
+		colorMask:      [4]bool{true, true, true, true},
+		depthMask:      true,
+		depthFunc:      gl.LESS,
+		depthRange:     [2]float32{0, 1},
+		clearDepth:     1,
+		blendEquation:  [2]gl.Enum{gl.FUNC_ADD, gl.FUNC_ADD},
+		blendFunc:      [4]gl.Enum{gl.ONE, gl.ZERO, gl.ONE, gl.ZERO},
+		cullFace:       gl.BACK,
+		frontFace:      gl.CCW,
+		lineWidth:      1,
+		sampleCoverage: 1,
+		hints:          map[gl.Enum]gl.Enum{gl.GENERATE_MIPMAP_HINT: gl.DONT_CARE},
+		pixelStore:     map[gl.Enum]int32{gl.PACK_ALIGNMENT: 4, gl.UNPACK_ALIGNMENT: 4},
+
+		work: make(chan struct{}),
+	}
+	for i := range c.stencil {
+		c.stencil[i] = stencil{
+			fn: gl.ALWAYS, mask: ^uint32(0), writeMask: ^uint32(0),
+			fail: gl.KEEP, zfail: gl.KEEP, zpass: gl.KEEP,
+		}
+	}
+	for i := range c.attribs {
+		c.attribs[i] = attrib{size: 4, ty: gl.FLOAT, current: [4]float32{0, 0, 0, 1}}
+	}
+	c.default2D.init()
+	c.defaultCube.init()
+	return c
+}
+
+// Calls returns the calls made so far, in order.
+func (c *Context) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// ResetCalls forgets the calls made so far, but not their effect on the
+// state of c.
+func (c *Context) ResetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// Counts are numbers of GL objects.
+type Counts struct {
+	Textures      int
+	Buffers       int
+	Framebuffers  int
+	Renderbuffers int
+	VertexArrays  int
+	Shaders       int
+	Programs      int
+}
+
+// Live returns the number of objects of each type that have been created
+// and not deleted, which a test can use to check for leaks. Shaders and
+// programs that are flagged for deletion, but are still in use, are live.
+func (c *Context) Live() Counts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Counts{
+		Textures:      len(c.textures),
+		Buffers:       len(c.buffers),
+		Framebuffers:  len(c.framebuffers),
+		Renderbuffers: len(c.renderbuffers),
+		VertexArrays:  len(c.vertexArrays),
+		Shaders:       len(c.shaders),
+		Programs:      len(c.programs),
+	}
+}
+
+// Image is the contents of a level of a texture.
+type Image struct {
+	Width, Height int
+	Format, Type  gl.Enum
+	// Pix holds the pixels, from the bottom row up as in GL, without
+	// padding between rows. It is nil if the level was defined without
+	// data.
+	Pix []byte
+}
+
+// TexImage returns a copy of the given level of the 2D texture t, and
+// whether the level is defined.
+func (c *Context) TexImage(t gl.Texture, level int) (Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tex := c.textures[t.Value]
+	if t.Value == 0 {
+		tex = &c.default2D
+	}
+	if tex == nil || tex.target != gl.TEXTURE_2D {
+		return Image{}, false
+	}
+	l := tex.level(gl.TEXTURE_2D, level)
+	if l == nil {
+		return Image{}, false
+	}
+	m := *l
+	m.Pix = append([]byte(nil), l.Pix...)
+	if l.Pix == nil {
+		m.Pix = nil
+	}
+	return m, true
+}
+
+// WorkAvailable implements gl.Worker. It never fires, as a Context does its
+// work as calls are made.
+func (c *Context) WorkAvailable() <-chan struct{} { return c.work }
+
+// DoWork implements gl.Worker. It does nothing.
+func (c *Context) DoWork() {}
+
+// record starts the call name, which is to be ended by the returned
+// function.
+func (c *Context) record(name string, args ...interface{}) func() {
+	if atomic.AddInt32(&c.busy, 1) > 1 {
+		panic("glfake: concurrent calls made to the same GL context")
+	}
+	c.mu.Lock()
+	for i, a := range args {
+		switch a := a.(type) {
+		case []byte:
+			args[i] = append([]byte(nil), a...)
+		case []float32:
+			args[i] = append([]float32(nil), a...)
+		case []int32:
+			args[i] = append([]int32(nil), a...)
+		}
+	}
+	c.call = &Call{Name: name, Args: args}
+	return c.end
+}
+
+func (c *Context) end() {
+	c.calls = append(c.calls, *c.call)
+	c.call = nil
+	c.mu.Unlock()
+	atomic.AddInt32(&c.busy, -1)
+}
+
+// result records the results of the call in progress.
+func (c *Context) result(results ...interface{}) {
+	c.call.Results = results
+}
+
+// fail records the error e, unless an earlier one is yet to be returned by
+// GetError.
+func (c *Context) fail(e gl.Enum) {
+	if c.call.Err == 0 {
+		c.call.Err = e
+	}
+	if c.err == 0 {
+		c.err = e
+	}
+}
+
+// newName returns a new object name. Names are never reused, so that a
+// stale name is never mistaken for a live one.
+func (c *Context) newName() uint32 {
+	c.next++
+	return c.next
+}
+
+var (
+	_ gl.Context = (*Context)(nil)
+	_ gl.Worker  = (*Context)(nil)
+)
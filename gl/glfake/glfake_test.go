@@ -0,0 +1,366 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/as/shiny/gl"
+)
+
+const vertexSrc = `#version 100
+uniform mat3 mvp;
+attribute vec2 pos;
+attribute vec2 inUV;
+varying vec2 uv;
+void main() {
+	gl_Position = vec4(mvp * vec3(pos, 1), 1);
+	uv = inUV;
+}
+`
+
+const fragmentSrc = `#version 100
+precision mediump float;
+varying vec2 uv;
+uniform sampler2D sample;
+uniform vec4 color[2];
+void main() {
+	gl_FragColor = texture2D(sample, uv) * color[0];
+}
+`
+
+// compile compiles and links a program in the way that gldriver does.
+func compile(glctx gl.Context, vSrc, fSrc string) gl.Program {
+	p := glctx.CreateProgram()
+	vs := glctx.CreateShader(gl.VERTEX_SHADER)
+	glctx.ShaderSource(vs, vSrc)
+	glctx.CompileShader(vs)
+	fs := glctx.CreateShader(gl.FRAGMENT_SHADER)
+	glctx.ShaderSource(fs, fSrc)
+	glctx.CompileShader(fs)
+	glctx.AttachShader(p, vs)
+	glctx.AttachShader(p, fs)
+	glctx.LinkProgram(p)
+	glctx.DeleteShader(vs)
+	glctx.DeleteShader(fs)
+	return p
+}
+
+func names(calls []Call) string {
+	var s []string
+	for _, c := range calls {
+		s = append(s, c.Name)
+	}
+	return strings.Join(s, " ")
+}
+
+func checkErr(t *testing.T, glctx *Context, want gl.Enum) {
+	t.Helper()
+	if got := glctx.GetError(); got != want {
+		calls := glctx.Calls()
+		t.Fatalf("GetError: got %s, want %s, after %v", EnumString(got), EnumString(want), calls[len(calls)-2])
+	}
+}
+
+func TestProgram(t *testing.T) {
+	glctx := NewContext()
+	p := compile(glctx, vertexSrc, fragmentSrc)
+	want := "CreateProgram CreateShader ShaderSource CompileShader CreateShader ShaderSource CompileShader " +
+		"AttachShader AttachShader LinkProgram DeleteShader DeleteShader"
+	if got := names(glctx.Calls()); got != want {
+		t.Fatalf("calls:\ngot  %s\nwant %s", got, want)
+	}
+	for _, c := range glctx.Calls() {
+		if c.Err != 0 {
+			t.Errorf("%v", c)
+		}
+	}
+	if got := glctx.GetProgrami(p, gl.LINK_STATUS); got != 1 {
+		t.Fatalf("LINK_STATUS: got %d, log %q", got, glctx.GetProgramInfoLog(p))
+	}
+	// The shaders are flagged for deletion, but still attached.
+	if got := glctx.Live(); got.Shaders != 2 || got.Programs != 1 {
+		t.Errorf("Live: got %+v", got)
+	}
+
+	if a := glctx.GetAttribLocation(p, "pos"); a.Value >= maxVertexAttribs {
+		t.Errorf("GetAttribLocation(pos): got %d", a.Value)
+	}
+	if a := glctx.GetAttribLocation(p, "missing"); a.Value != ^uint(0) {
+		t.Errorf("GetAttribLocation(missing): got %d", a.Value)
+	}
+	mvp := glctx.GetUniformLocation(p, "mvp")
+	color1 := glctx.GetUniformLocation(p, "color[1]")
+	sample := glctx.GetUniformLocation(p, "sample")
+	if mvp.Value < 0 || color1.Value < 0 || sample.Value < 0 {
+		t.Fatalf("GetUniformLocation: got %d, %d, %d", mvp.Value, color1.Value, sample.Value)
+	}
+	checkErr(t, glctx, 0)
+
+	// Setting a uniform requires the program to be current, and the right
+	// type and size.
+	glctx.Uniform4f(color1, 1, 2, 3, 4)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+	glctx.UseProgram(p)
+	glctx.Uniform4f(color1, 1, 2, 3, 4)
+	checkErr(t, glctx, 0)
+	glctx.Uniform1f(color1, 1)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+	glctx.UniformMatrix3fv(mvp, []float32{1, 0, 0, 0, 1, 0, 0, 0})
+	checkErr(t, glctx, gl.INVALID_VALUE)
+	glctx.Uniform1i(sample, maxTextureUnits)
+	checkErr(t, glctx, gl.INVALID_VALUE)
+	glctx.Uniform1f(gl.Uniform{Value: -1}, 1)
+	checkErr(t, glctx, 0)
+	dst := make([]float32, 4)
+	glctx.GetUniformfv(dst, color1, p)
+	if want := []float32{1, 2, 3, 4}; !equal(dst, want) {
+		t.Errorf("GetUniformfv: got %v, want %v", dst, want)
+	}
+
+	// Deleting the current program only flags it, and it goes, with its
+	// shaders, once it is no longer current.
+	glctx.DeleteProgram(p)
+	if got := glctx.Live(); got.Programs != 1 {
+		t.Errorf("Live after DeleteProgram: got %+v", got)
+	}
+	glctx.UseProgram(gl.Program{Init: true})
+	if got := glctx.Live(); got != (Counts{}) {
+		t.Errorf("Live after UseProgram(0): got %+v", got)
+	}
+	checkErr(t, glctx, 0)
+}
+
+func equal(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCompileError(t *testing.T) {
+	glctx := NewContext()
+	p := compile(glctx, vertexSrc, "#version 100\n#error broken\nvoid main() {}\n")
+	if got := glctx.GetProgrami(p, gl.LINK_STATUS); got != 0 {
+		t.Errorf("LINK_STATUS: got %d, want 0", got)
+	}
+	if log := glctx.GetProgramInfoLog(p); log == "" {
+		t.Errorf("GetProgramInfoLog: got empty")
+	}
+	glctx.UseProgram(p)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+}
+
+func TestErrors(t *testing.T) {
+	glctx := NewContext()
+	glctx.BindTexture(gl.TEXTURE_3D, gl.Texture{})
+	glctx.Viewport(0, 0, -1, 1)
+	// Only the first error is kept until GetError returns it.
+	checkErr(t, glctx, gl.INVALID_ENUM)
+	checkErr(t, glctx, 0)
+	calls := glctx.Calls()
+	if got := calls[1]; got.Err != gl.INVALID_VALUE {
+		t.Errorf("%v: got no error", got)
+	}
+	if got, want := calls[0].String(), "BindTexture(TEXTURE_3D, Texture(0)) error: INVALID_ENUM"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+
+	tex := glctx.CreateTexture()
+	glctx.BindTexture(gl.TEXTURE_CUBE_MAP, tex)
+	glctx.BindTexture(gl.TEXTURE_2D, tex)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+
+	glctx.DrawArrays(gl.TRIANGLES, 0, 3)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+	glctx.DrawArrays(gl.TEXTURE_2D, 0, 3)
+	checkErr(t, glctx, gl.INVALID_ENUM)
+
+	fb := glctx.CreateFramebuffer()
+	glctx.BindFramebuffer(gl.FRAMEBUFFER, fb)
+	if got := glctx.CheckFramebufferStatus(gl.FRAMEBUFFER); got != gl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT {
+		t.Errorf("CheckFramebufferStatus: got %s", EnumString(got))
+	}
+	glctx.Clear(gl.COLOR_BUFFER_BIT)
+	checkErr(t, glctx, gl.INVALID_FRAMEBUFFER_OPERATION)
+
+	glctx.ResetCalls()
+	if got := glctx.Calls(); len(got) != 0 {
+		t.Errorf("Calls after ResetCalls: got %v", got)
+	}
+}
+
+func TestDraw(t *testing.T) {
+	glctx := NewContext()
+	p := compile(glctx, vertexSrc, fragmentSrc)
+	glctx.UseProgram(p)
+	pos := glctx.GetAttribLocation(p, "pos")
+	buf := glctx.CreateBuffer()
+	glctx.BindBuffer(gl.ARRAY_BUFFER, buf)
+	glctx.BufferInit(gl.ARRAY_BUFFER, 4*2*4, gl.STATIC_DRAW)
+	glctx.EnableVertexAttribArray(pos)
+	glctx.VertexAttribPointer(pos, 2, gl.FLOAT, false, 0, 0)
+	glctx.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
+	checkErr(t, glctx, 0)
+	// Five vertices would read beyond the end of the buffer.
+	glctx.DrawArrays(gl.TRIANGLE_STRIP, 0, 5)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+
+	glctx.DrawElements(gl.TRIANGLES, 3, gl.UNSIGNED_SHORT, 0)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+	elems := glctx.CreateBuffer()
+	glctx.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, elems)
+	glctx.BufferData(gl.ELEMENT_ARRAY_BUFFER, []byte{0, 0, 1, 0, 3, 0, 4, 0}, gl.STATIC_DRAW)
+	glctx.DrawElements(gl.TRIANGLES, 3, gl.UNSIGNED_SHORT, 0)
+	checkErr(t, glctx, 0)
+	glctx.DrawElements(gl.TRIANGLES, 3, gl.UNSIGNED_SHORT, 2)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+
+	glctx.DeleteBuffer(buf)
+	if got := glctx.GetVertexAttribi(pos, gl.VERTEX_ATTRIB_ARRAY_BUFFER_BINDING); got != 0 {
+		t.Errorf("VERTEX_ATTRIB_ARRAY_BUFFER_BINDING after DeleteBuffer: got %d", got)
+	}
+	glctx.DrawElements(gl.TRIANGLES, 3, gl.UNSIGNED_SHORT, 0)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+}
+
+func TestTextures(t *testing.T) {
+	glctx := NewContext()
+	tex := glctx.CreateTexture()
+	glctx.BindTexture(gl.TEXTURE_2D, tex)
+
+	// A 3x2 RGB image, with rows padded to the 4-byte alignment.
+	src := []byte{
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 0, 0,
+		10, 11, 12, 13, 14, 15, 16, 17, 18,
+	}
+	glctx.TexImage2D(gl.TEXTURE_2D, 0, 3, 2, gl.RGB, gl.UNSIGNED_BYTE, src[:len(src)-1])
+	checkErr(t, glctx, gl.INVALID_VALUE)
+	glctx.TexImage2D(gl.TEXTURE_2D, 0, 3, 2, gl.RGB, gl.UNSIGNED_BYTE, src)
+	checkErr(t, glctx, 0)
+	m, ok := glctx.TexImage(tex, 0)
+	if !ok {
+		t.Fatal("TexImage: level 0 not defined")
+	}
+	if want := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18}; !bytes.Equal(m.Pix, want) {
+		t.Errorf("Pix: got %v, want %v", m.Pix, want)
+	}
+	glctx.TexImage2D(gl.TEXTURE_2D, 0, 2, 2, gl.RGBA, gl.UNSIGNED_SHORT_5_6_5, nil)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+	glctx.GenerateMipmap(gl.TEXTURE_2D)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+
+	glctx.TexImage2D(gl.TEXTURE_2D, 0, 2, 2, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	glctx.TexSubImage2D(gl.TEXTURE_2D, 0, 1, 1, 1, 1, gl.RGBA, gl.UNSIGNED_BYTE, []byte{200, 100, 40, 4})
+	glctx.GenerateMipmap(gl.TEXTURE_2D)
+	checkErr(t, glctx, 0)
+	if m, ok := glctx.TexImage(tex, 1); !ok || !bytes.Equal(m.Pix, []byte{50, 25, 10, 1}) {
+		t.Errorf("level 1: got %v, %t", m.Pix, ok)
+	}
+
+	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR_MIPMAP_LINEAR)
+	glctx.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR_MIPMAP_LINEAR)
+	checkErr(t, glctx, gl.INVALID_ENUM)
+	dst := make([]int32, 1)
+	glctx.GetTexParameteriv(dst, gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER)
+	if dst[0] != gl.LINEAR_MIPMAP_LINEAR {
+		t.Errorf("TEXTURE_MIN_FILTER: got %s", EnumString(gl.Enum(dst[0])))
+	}
+
+	glctx.DeleteTexture(tex)
+	if _, ok := glctx.TexImage(tex, 0); ok || glctx.Live().Textures != 0 {
+		t.Errorf("texture not deleted")
+	}
+	if got := glctx.GetInteger(gl.TEXTURE_BINDING_2D); got != 0 {
+		t.Errorf("TEXTURE_BINDING_2D after DeleteTexture: got %d", got)
+	}
+}
+
+func TestReadPixels(t *testing.T) {
+	glctx := NewContext()
+	tex := glctx.CreateTexture()
+	glctx.BindTexture(gl.TEXTURE_2D, tex)
+	glctx.TexImage2D(gl.TEXTURE_2D, 0, 2, 2, gl.RGBA, gl.UNSIGNED_BYTE, []byte{
+		1, 2, 3, 4, 5, 6, 7, 8,
+		9, 10, 11, 12, 13, 14, 15, 16,
+	})
+	fb := glctx.CreateFramebuffer()
+	glctx.BindFramebuffer(gl.FRAMEBUFFER, fb)
+	glctx.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, tex, 0)
+	if got := glctx.CheckFramebufferStatus(gl.FRAMEBUFFER); got != gl.FRAMEBUFFER_COMPLETE {
+		t.Fatalf("CheckFramebufferStatus: got %s", EnumString(got))
+	}
+
+	dst := make([]byte, 8)
+	glctx.ReadPixels(dst, 0, 1, 2, 1, gl.RGBA, gl.UNSIGNED_BYTE)
+	if want := []byte{9, 10, 11, 12, 13, 14, 15, 16}; !bytes.Equal(dst, want) {
+		t.Errorf("ReadPixels: got %v, want %v", dst, want)
+	}
+	glctx.ReadPixels(dst, 0, 0, 2, 2, gl.RGBA, gl.UNSIGNED_BYTE)
+	checkErr(t, glctx, gl.INVALID_VALUE)
+	glctx.ReadPixels(dst, 0, 0, 1, 1, gl.RGB, gl.UNSIGNED_BYTE)
+	checkErr(t, glctx, gl.INVALID_OPERATION)
+
+	glctx.ClearColor(1, 0, 0.5, 1)
+	glctx.Enable(gl.SCISSOR_TEST)
+	glctx.Scissor(1, 0, 1, 1)
+	glctx.ColorMask(true, true, true, false)
+	glctx.Clear(gl.COLOR_BUFFER_BIT)
+	checkErr(t, glctx, 0)
+	glctx.ReadPixels(dst, 0, 0, 2, 1, gl.RGBA, gl.UNSIGNED_BYTE)
+	if want := []byte{1, 2, 3, 4, 255, 0, 128, 8}; !bytes.Equal(dst, want) {
+		t.Errorf("ReadPixels after Clear: got %v, want %v", dst, want)
+	}
+
+	// Deleting the texture detaches it.
+	glctx.DeleteTexture(tex)
+	if got := glctx.CheckFramebufferStatus(gl.FRAMEBUFFER); got != gl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT {
+		t.Errorf("CheckFramebufferStatus after DeleteTexture: got %s", EnumString(got))
+	}
+}
+
+func TestState(t *testing.T) {
+	glctx := NewContext()
+	glctx.Viewport(1, 2, 3, 4)
+	glctx.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
+	glctx.Enable(gl.BLEND)
+	dst := make([]int32, 4)
+	glctx.GetIntegerv(dst, gl.VIEWPORT)
+	if dst != nil && (dst[0] != 1 || dst[1] != 2 || dst[2] != 3 || dst[3] != 4) {
+		t.Errorf("VIEWPORT: got %v", dst)
+	}
+	if got := glctx.GetInteger(gl.BLEND_DST_ALPHA); got != gl.ONE_MINUS_SRC_ALPHA {
+		t.Errorf("BLEND_DST_ALPHA: got %d", got)
+	}
+	if !glctx.IsEnabled(gl.BLEND) || glctx.IsEnabled(gl.DEPTH_TEST) {
+		t.Errorf("IsEnabled: wrong")
+	}
+	glctx.GetIntegerv(dst[:1], gl.VIEWPORT)
+	checkErr(t, glctx, gl.INVALID_VALUE)
+	glctx.Enable(gl.TEXTURE_2D)
+	checkErr(t, glctx, gl.INVALID_ENUM)
+	if got := glctx.GetInteger(gl.MAX_TEXTURE_SIZE); got != maxTextureSize {
+		t.Errorf("MAX_TEXTURE_SIZE: got %d", got)
+	}
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	glctx := NewContext()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("no panic")
+		}
+	}()
+	// Fake a call in progress on another goroutine.
+	glctx.busy = 1
+	glctx.Flush()
+}
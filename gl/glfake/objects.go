@@ -0,0 +1,416 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import (
+	"github.com/as/shiny/gl"
+)
+
+// In GL, Create and Gen only reserve a name. The object is created when the
+// name is first bound, and only then do the Is functions report true for it.
+// Binding a name that was never reserved also creates the object.
+
+type texture struct {
+	target gl.Enum // TEXTURE_2D or TEXTURE_CUBE_MAP, once bound.
+	bound  bool
+	levels map[gl.Enum][]*Image // The levels of each face, or of TEXTURE_2D.
+	params map[gl.Enum]int32
+}
+
+func (t *texture) init() {
+	t.levels = make(map[gl.Enum][]*Image)
+	t.params = map[gl.Enum]int32{
+		gl.TEXTURE_MIN_FILTER: gl.NEAREST_MIPMAP_LINEAR,
+		gl.TEXTURE_MAG_FILTER: gl.LINEAR,
+		gl.TEXTURE_WRAP_S:     gl.REPEAT,
+		gl.TEXTURE_WRAP_T:     gl.REPEAT,
+	}
+}
+
+// level returns the given level of face, or nil if it is not defined.
+func (t *texture) level(face gl.Enum, level int) *Image {
+	l := t.levels[face]
+	if level < 0 || level >= len(l) {
+		return nil
+	}
+	return l[level]
+}
+
+func (t *texture) setLevel(face gl.Enum, level int, m *Image) {
+	l := t.levels[face]
+	for len(l) <= level {
+		l = append(l, nil)
+	}
+	l[level] = m
+	t.levels[face] = l
+}
+
+type buffer struct {
+	bound bool
+	data  []byte
+	usage gl.Enum
+}
+
+type framebuffer struct {
+	bound       bool
+	attachments map[gl.Enum]attachment
+}
+
+// attachment is an image attached to a framebuffer: a renderbuffer, or a
+// level of a texture face.
+type attachment struct {
+	renderbuffer uint32
+	texture      uint32
+	face         gl.Enum
+	level        int
+}
+
+type renderbuffer struct {
+	bound         bool
+	format        gl.Enum
+	width, height int
+}
+
+type vertexArray struct {
+	bound bool
+}
+
+// isFace reports whether target is TEXTURE_2D or a face of a cube map, as
+// taken by TexImage2D.
+func isFace(target gl.Enum) bool {
+	return target == gl.TEXTURE_2D ||
+		gl.TEXTURE_CUBE_MAP_POSITIVE_X <= target && target <= gl.TEXTURE_CUBE_MAP_NEGATIVE_Z
+}
+
+// faceTarget returns the texture target that face belongs to.
+func faceTarget(face gl.Enum) gl.Enum {
+	if face == gl.TEXTURE_2D {
+		return gl.TEXTURE_2D
+	}
+	return gl.TEXTURE_CUBE_MAP
+}
+
+// boundTexture returns the name of the texture bound to target, which is
+// TEXTURE_2D or TEXTURE_CUBE_MAP, in the active unit.
+func (c *Context) boundTexture(target gl.Enum) uint32 {
+	u := &c.units[c.activeTexture]
+	if target == gl.TEXTURE_2D {
+		return u.tex2D
+	}
+	return u.cube
+}
+
+// texture returns the texture bound to target, which is TEXTURE_2D or
+// TEXTURE_CUBE_MAP, in the active unit.
+func (c *Context) texture(target gl.Enum) *texture {
+	name := c.boundTexture(target)
+	if name != 0 {
+		return c.textures[name]
+	}
+	if target == gl.TEXTURE_2D {
+		return &c.default2D
+	}
+	return &c.defaultCube
+}
+
+// ActiveTexture implements gl.Context.
+func (c *Context) ActiveTexture(texture gl.Enum) {
+	defer c.record("ActiveTexture", texture)()
+	if texture < gl.TEXTURE0 || texture >= gl.TEXTURE0+maxTextureUnits {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.activeTexture = int(texture - gl.TEXTURE0)
+}
+
+// CreateTexture implements gl.Context.
+func (c *Context) CreateTexture() gl.Texture {
+	defer c.record("CreateTexture")()
+	t := gl.Texture{Value: c.newName()}
+	tex := &texture{}
+	tex.init()
+	c.textures[t.Value] = tex
+	c.result(t)
+	return t
+}
+
+// BindTexture implements gl.Context.
+func (c *Context) BindTexture(target gl.Enum, t gl.Texture) {
+	defer c.record("BindTexture", target, t)()
+	if target != gl.TEXTURE_2D && target != gl.TEXTURE_CUBE_MAP {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if t.Value != 0 {
+		tex := c.textures[t.Value]
+		if tex == nil {
+			tex = &texture{}
+			tex.init()
+			c.textures[t.Value] = tex
+		}
+		if tex.bound && tex.target != target {
+			c.fail(gl.INVALID_OPERATION)
+			return
+		}
+		tex.bound, tex.target = true, target
+	}
+	u := &c.units[c.activeTexture]
+	if target == gl.TEXTURE_2D {
+		u.tex2D = t.Value
+	} else {
+		u.cube = t.Value
+	}
+}
+
+// DeleteTexture implements gl.Context. The texture is unbound from every
+// unit, and detached from the bound framebuffer.
+func (c *Context) DeleteTexture(v gl.Texture) {
+	defer c.record("DeleteTexture", v)()
+	if v.Value == 0 || c.textures[v.Value] == nil {
+		return
+	}
+	delete(c.textures, v.Value)
+	for i := range c.units {
+		u := &c.units[i]
+		if u.tex2D == v.Value {
+			u.tex2D = 0
+		}
+		if u.cube == v.Value {
+			u.cube = 0
+		}
+	}
+	if fb := c.framebuffers[c.framebuffer]; fb != nil {
+		for k, a := range fb.attachments {
+			if a.texture == v.Value {
+				delete(fb.attachments, k)
+			}
+		}
+	}
+}
+
+// IsTexture implements gl.Context.
+func (c *Context) IsTexture(t gl.Texture) bool {
+	defer c.record("IsTexture", t)()
+	tex := c.textures[t.Value]
+	ok := tex != nil && tex.bound
+	c.result(ok)
+	return ok
+}
+
+// CreateBuffer implements gl.Context.
+func (c *Context) CreateBuffer() gl.Buffer {
+	defer c.record("CreateBuffer")()
+	b := gl.Buffer{Value: c.newName()}
+	c.buffers[b.Value] = &buffer{usage: gl.STATIC_DRAW}
+	c.result(b)
+	return b
+}
+
+// BindBuffer implements gl.Context.
+func (c *Context) BindBuffer(target gl.Enum, b gl.Buffer) {
+	defer c.record("BindBuffer", target, b)()
+	var p *uint32
+	switch target {
+	case gl.ARRAY_BUFFER:
+		p = &c.arrayBuffer
+	case gl.ELEMENT_ARRAY_BUFFER:
+		p = &c.elementBuffer
+	default:
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if b.Value != 0 {
+		buf := c.buffers[b.Value]
+		if buf == nil {
+			buf = &buffer{usage: gl.STATIC_DRAW}
+			c.buffers[b.Value] = buf
+		}
+		buf.bound = true
+	}
+	*p = b.Value
+}
+
+// buffer returns the buffer bound to target, or nil if there is none. It
+// reports INVALID_ENUM if target is not a buffer target.
+func (c *Context) buffer(target gl.Enum) *buffer {
+	switch target {
+	case gl.ARRAY_BUFFER:
+		return c.buffers[c.arrayBuffer]
+	case gl.ELEMENT_ARRAY_BUFFER:
+		return c.buffers[c.elementBuffer]
+	}
+	c.fail(gl.INVALID_ENUM)
+	return nil
+}
+
+// DeleteBuffer implements gl.Context. The buffer is unbound, including from
+// the vertex attribute arrays.
+func (c *Context) DeleteBuffer(v gl.Buffer) {
+	defer c.record("DeleteBuffer", v)()
+	if v.Value == 0 || c.buffers[v.Value] == nil {
+		return
+	}
+	delete(c.buffers, v.Value)
+	if c.arrayBuffer == v.Value {
+		c.arrayBuffer = 0
+	}
+	if c.elementBuffer == v.Value {
+		c.elementBuffer = 0
+	}
+	for i := range c.attribs {
+		if c.attribs[i].buffer == v.Value {
+			c.attribs[i].buffer = 0
+		}
+	}
+}
+
+// IsBuffer implements gl.Context.
+func (c *Context) IsBuffer(b gl.Buffer) bool {
+	defer c.record("IsBuffer", b)()
+	buf := c.buffers[b.Value]
+	ok := buf != nil && buf.bound
+	c.result(ok)
+	return ok
+}
+
+// CreateFramebuffer implements gl.Context.
+func (c *Context) CreateFramebuffer() gl.Framebuffer {
+	defer c.record("CreateFramebuffer")()
+	fb := gl.Framebuffer{Value: c.newName()}
+	c.framebuffers[fb.Value] = &framebuffer{attachments: make(map[gl.Enum]attachment)}
+	c.result(fb)
+	return fb
+}
+
+// BindFramebuffer implements gl.Context.
+func (c *Context) BindFramebuffer(target gl.Enum, fb gl.Framebuffer) {
+	defer c.record("BindFramebuffer", target, fb)()
+	if target != gl.FRAMEBUFFER {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if fb.Value != 0 {
+		f := c.framebuffers[fb.Value]
+		if f == nil {
+			f = &framebuffer{attachments: make(map[gl.Enum]attachment)}
+			c.framebuffers[fb.Value] = f
+		}
+		f.bound = true
+	}
+	c.framebuffer = fb.Value
+}
+
+// DeleteFramebuffer implements gl.Context.
+func (c *Context) DeleteFramebuffer(v gl.Framebuffer) {
+	defer c.record("DeleteFramebuffer", v)()
+	if v.Value == 0 || c.framebuffers[v.Value] == nil {
+		return
+	}
+	delete(c.framebuffers, v.Value)
+	if c.framebuffer == v.Value {
+		c.framebuffer = 0
+	}
+}
+
+// IsFramebuffer implements gl.Context.
+func (c *Context) IsFramebuffer(fb gl.Framebuffer) bool {
+	defer c.record("IsFramebuffer", fb)()
+	f := c.framebuffers[fb.Value]
+	ok := f != nil && f.bound
+	c.result(ok)
+	return ok
+}
+
+// CreateRenderbuffer implements gl.Context.
+func (c *Context) CreateRenderbuffer() gl.Renderbuffer {
+	defer c.record("CreateRenderbuffer")()
+	rb := gl.Renderbuffer{Value: c.newName()}
+	c.renderbuffers[rb.Value] = &renderbuffer{format: gl.RGBA4}
+	c.result(rb)
+	return rb
+}
+
+// BindRenderbuffer implements gl.Context.
+func (c *Context) BindRenderbuffer(target gl.Enum, rb gl.Renderbuffer) {
+	defer c.record("BindRenderbuffer", target, rb)()
+	if target != gl.RENDERBUFFER {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if rb.Value != 0 {
+		r := c.renderbuffers[rb.Value]
+		if r == nil {
+			r = &renderbuffer{format: gl.RGBA4}
+			c.renderbuffers[rb.Value] = r
+		}
+		r.bound = true
+	}
+	c.renderbuffer = rb.Value
+}
+
+// DeleteRenderbuffer implements gl.Context. The renderbuffer is unbound, and
+// detached from the bound framebuffer.
+func (c *Context) DeleteRenderbuffer(v gl.Renderbuffer) {
+	defer c.record("DeleteRenderbuffer", v)()
+	if v.Value == 0 || c.renderbuffers[v.Value] == nil {
+		return
+	}
+	delete(c.renderbuffers, v.Value)
+	if c.renderbuffer == v.Value {
+		c.renderbuffer = 0
+	}
+	if fb := c.framebuffers[c.framebuffer]; fb != nil {
+		for k, a := range fb.attachments {
+			if a.renderbuffer == v.Value {
+				delete(fb.attachments, k)
+			}
+		}
+	}
+}
+
+// IsRenderbuffer implements gl.Context.
+func (c *Context) IsRenderbuffer(rb gl.Renderbuffer) bool {
+	defer c.record("IsRenderbuffer", rb)()
+	r := c.renderbuffers[rb.Value]
+	ok := r != nil && r.bound
+	c.result(ok)
+	return ok
+}
+
+// CreateVertexArray implements gl.Context.
+func (c *Context) CreateVertexArray() gl.VertexArray {
+	defer c.record("CreateVertexArray")()
+	va := gl.VertexArray{Value: c.newName()}
+	c.vertexArrays[va.Value] = &vertexArray{}
+	c.result(va)
+	return va
+}
+
+// BindVertexArray implements gl.Context. Unlike the other objects, vertex
+// arrays, which are from ES 3, must have been created before they are bound.
+func (c *Context) BindVertexArray(va gl.VertexArray) {
+	defer c.record("BindVertexArray", va)()
+	if va.Value != 0 {
+		v := c.vertexArrays[va.Value]
+		if v == nil {
+			c.fail(gl.INVALID_OPERATION)
+			return
+		}
+		v.bound = true
+	}
+	c.vertexArray = va.Value
+}
+
+// DeleteVertexArray implements gl.Context.
+func (c *Context) DeleteVertexArray(v gl.VertexArray) {
+	defer c.record("DeleteVertexArray", v)()
+	if v.Value == 0 || c.vertexArrays[v.Value] == nil {
+		return
+	}
+	delete(c.vertexArrays, v.Value)
+	if c.vertexArray == v.Value {
+		c.vertexArray = 0
+	}
+}
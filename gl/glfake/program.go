@@ -0,0 +1,922 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/as/shiny/gl"
+)
+
+// Shaders are not compiled, only scanned for their attribute and uniform
+// declarations. A shader fails to compile if it has no main function, or if
+// it has an #error directive, which a test can use to check the handling of
+// compile errors.
+
+type shader struct {
+	ty       gl.Enum
+	src      string
+	compiled bool
+	log      string
+	deleted  bool     // Flagged for deletion, but still attached.
+	attribs  []global // The attributes declared.
+	uniforms []global // The uniforms declared.
+}
+
+// global is an attribute or uniform variable.
+type global struct {
+	name string
+	ty   gl.Enum
+	size int // The length of an array, or 1.
+}
+
+type program struct {
+	shaders   []uint32
+	bindings  map[string]uint // From BindAttribLocation.
+	linked    bool
+	validated bool
+	deleted   bool // Flagged for deletion, but still in use.
+	log       string
+
+	// The active variables, once linked, ordered by location.
+	attribs  []activeAttrib
+	uniforms []activeUniform
+}
+
+type activeAttrib struct {
+	global
+	loc uint
+}
+
+type activeUniform struct {
+	global
+	loc   int32
+	value []float32 // Ints and bools are stored as floats.
+}
+
+var (
+	comments = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	decl     = regexp.MustCompile(`\b(attribute|uniform)\s+(?:(?:lowp|mediump|highp)\s+)?(\w+)\s+(\w+)\s*(?:\[\s*(\d+)\s*\])?\s*;`)
+	mainFunc = regexp.MustCompile(`\bvoid\s+main\s*\(`)
+	errorDir = regexp.MustCompile(`(?m)^\s*#\s*error\b(.*)$`)
+)
+
+var glslTypes = map[string]gl.Enum{
+	"float":       gl.FLOAT,
+	"vec2":        gl.FLOAT_VEC2,
+	"vec3":        gl.FLOAT_VEC3,
+	"vec4":        gl.FLOAT_VEC4,
+	"int":         gl.INT,
+	"ivec2":       gl.INT_VEC2,
+	"ivec3":       gl.INT_VEC3,
+	"ivec4":       gl.INT_VEC4,
+	"bool":        gl.BOOL,
+	"bvec2":       gl.BOOL_VEC2,
+	"bvec3":       gl.BOOL_VEC3,
+	"bvec4":       gl.BOOL_VEC4,
+	"mat2":        gl.FLOAT_MAT2,
+	"mat3":        gl.FLOAT_MAT3,
+	"mat4":        gl.FLOAT_MAT4,
+	"sampler2D":   gl.SAMPLER_2D,
+	"samplerCube": gl.SAMPLER_CUBE,
+}
+
+// components returns the number of values in a variable of type ty, and the
+// kind of Uniform function that sets it: 'f', 'i' or 'm' for a matrix.
+func components(ty gl.Enum) (n int, kind byte) {
+	switch ty {
+	case gl.FLOAT:
+		return 1, 'f'
+	case gl.FLOAT_VEC2:
+		return 2, 'f'
+	case gl.FLOAT_VEC3:
+		return 3, 'f'
+	case gl.FLOAT_VEC4:
+		return 4, 'f'
+	case gl.INT, gl.BOOL, gl.SAMPLER_2D, gl.SAMPLER_CUBE:
+		return 1, 'i'
+	case gl.INT_VEC2, gl.BOOL_VEC2:
+		return 2, 'i'
+	case gl.INT_VEC3, gl.BOOL_VEC3:
+		return 3, 'i'
+	case gl.INT_VEC4, gl.BOOL_VEC4:
+		return 4, 'i'
+	case gl.FLOAT_MAT2:
+		return 4, 'm'
+	case gl.FLOAT_MAT3:
+		return 9, 'm'
+	case gl.FLOAT_MAT4:
+		return 16, 'm'
+	}
+	return 0, 0
+}
+
+// programName returns the name that p is passed to GL as. A Program that was
+// not made by CreateProgram is passed as -1, which is never a valid name.
+func programName(p gl.Program) uint32 {
+	if !p.Init {
+		return ^uint32(0)
+	}
+	return p.Value
+}
+
+// shaderObj returns the shader s. It reports INVALID_OPERATION if s is a
+// program, and INVALID_VALUE if it is neither.
+func (c *Context) shaderObj(s uint32) *shader {
+	if sh := c.shaders[s]; sh != nil {
+		return sh
+	}
+	if c.programs[s] != nil {
+		c.fail(gl.INVALID_OPERATION)
+	} else {
+		c.fail(gl.INVALID_VALUE)
+	}
+	return nil
+}
+
+// programObj returns the program p. It reports INVALID_OPERATION if p is a
+// shader, and INVALID_VALUE if it is neither.
+func (c *Context) programObj(p gl.Program) *program {
+	name := programName(p)
+	if prog := c.programs[name]; prog != nil {
+		return prog
+	}
+	if c.shaders[name] != nil {
+		c.fail(gl.INVALID_OPERATION)
+	} else {
+		c.fail(gl.INVALID_VALUE)
+	}
+	return nil
+}
+
+// CreateShader implements gl.Context.
+func (c *Context) CreateShader(ty gl.Enum) gl.Shader {
+	defer c.record("CreateShader", ty)()
+	if ty != gl.VERTEX_SHADER && ty != gl.FRAGMENT_SHADER {
+		c.fail(gl.INVALID_ENUM)
+		c.result(gl.Shader{})
+		return gl.Shader{}
+	}
+	s := gl.Shader{Value: c.newName()}
+	c.shaders[s.Value] = &shader{ty: ty}
+	c.result(s)
+	return s
+}
+
+// ShaderSource implements gl.Context.
+func (c *Context) ShaderSource(s gl.Shader, src string) {
+	defer c.record("ShaderSource", s, src)()
+	if sh := c.shaderObj(s.Value); sh != nil {
+		sh.src = src
+	}
+}
+
+// CompileShader implements gl.Context.
+func (c *Context) CompileShader(s gl.Shader) {
+	defer c.record("CompileShader", s)()
+	sh := c.shaderObj(s.Value)
+	if sh == nil {
+		return
+	}
+	sh.attribs, sh.uniforms = nil, nil
+	src := comments.ReplaceAllString(sh.src, " ")
+	if m := errorDir.FindStringSubmatch(src); m != nil {
+		sh.compiled, sh.log = false, "ERROR: #error"+m[1]
+		return
+	}
+	if !mainFunc.MatchString(src) {
+		sh.compiled, sh.log = false, "ERROR: no main function"
+		return
+	}
+	for _, m := range decl.FindAllStringSubmatch(src, -1) {
+		ty, ok := glslTypes[m[2]]
+		if !ok {
+			sh.compiled, sh.log = false, fmt.Sprintf("ERROR: unknown type %s", m[2])
+			return
+		}
+		g := global{name: m[3], ty: ty, size: 1}
+		if m[4] != "" {
+			g.size, _ = strconv.Atoi(m[4])
+		}
+		if m[1] == "attribute" {
+			if sh.ty != gl.VERTEX_SHADER {
+				sh.compiled, sh.log = false, "ERROR: attribute in a fragment shader"
+				return
+			}
+			sh.attribs = append(sh.attribs, g)
+		} else {
+			sh.uniforms = append(sh.uniforms, g)
+		}
+	}
+	sh.compiled, sh.log = true, ""
+}
+
+// DeleteShader implements gl.Context. A shader that is attached to a program
+// is only flagged for deletion, until it is detached.
+func (c *Context) DeleteShader(s gl.Shader) {
+	defer c.record("DeleteShader", s)()
+	if s.Value == 0 {
+		return
+	}
+	sh := c.shaderObj(s.Value)
+	if sh == nil {
+		return
+	}
+	sh.deleted = true
+	c.collectShader(s.Value)
+}
+
+// collectShader deletes the shader s if it is flagged for deletion and is no
+// longer attached to any program.
+func (c *Context) collectShader(s uint32) {
+	sh := c.shaders[s]
+	if sh == nil || !sh.deleted {
+		return
+	}
+	for _, p := range c.programs {
+		for _, t := range p.shaders {
+			if t == s {
+				return
+			}
+		}
+	}
+	delete(c.shaders, s)
+}
+
+// IsShader implements gl.Context.
+func (c *Context) IsShader(s gl.Shader) bool {
+	defer c.record("IsShader", s)()
+	ok := c.shaders[s.Value] != nil
+	c.result(ok)
+	return ok
+}
+
+// GetShaderi implements gl.Context.
+func (c *Context) GetShaderi(s gl.Shader, pname gl.Enum) int {
+	defer c.record("GetShaderi", s, pname)()
+	sh := c.shaderObj(s.Value)
+	if sh == nil {
+		c.result(0)
+		return 0
+	}
+	var v int
+	switch pname {
+	case gl.SHADER_TYPE:
+		v = int(sh.ty)
+	case gl.DELETE_STATUS:
+		v = boolInt(sh.deleted)
+	case gl.COMPILE_STATUS:
+		v = boolInt(sh.compiled)
+	case gl.INFO_LOG_LENGTH:
+		v = nulLen(sh.log)
+	case gl.SHADER_SOURCE_LENGTH:
+		v = nulLen(sh.src)
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(v)
+	return v
+}
+
+// GetShaderInfoLog implements gl.Context.
+func (c *Context) GetShaderInfoLog(s gl.Shader) string {
+	defer c.record("GetShaderInfoLog", s)()
+	var log string
+	if sh := c.shaderObj(s.Value); sh != nil {
+		log = sh.log
+	}
+	c.result(log)
+	return log
+}
+
+// GetShaderSource implements gl.Context.
+func (c *Context) GetShaderSource(s gl.Shader) string {
+	defer c.record("GetShaderSource", s)()
+	var src string
+	if sh := c.shaderObj(s.Value); sh != nil {
+		src = sh.src
+	}
+	c.result(src)
+	return src
+}
+
+// GetShaderPrecisionFormat implements gl.Context. It reports the precision
+// of IEEE floats and 32-bit ints for every precision.
+func (c *Context) GetShaderPrecisionFormat(shadertype, precisiontype gl.Enum) (rangeLow, rangeHigh, precision int) {
+	defer c.record("GetShaderPrecisionFormat", shadertype, precisiontype)()
+	if shadertype != gl.VERTEX_SHADER && shadertype != gl.FRAGMENT_SHADER {
+		c.fail(gl.INVALID_ENUM)
+		c.result(0, 0, 0)
+		return 0, 0, 0
+	}
+	switch precisiontype {
+	case gl.LOW_FLOAT, gl.MEDIUM_FLOAT, gl.HIGH_FLOAT:
+		rangeLow, rangeHigh, precision = 127, 127, 23
+	case gl.LOW_INT, gl.MEDIUM_INT, gl.HIGH_INT:
+		rangeLow, rangeHigh, precision = 31, 30, 0
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(rangeLow, rangeHigh, precision)
+	return rangeLow, rangeHigh, precision
+}
+
+// ReleaseShaderCompiler implements gl.Context.
+func (c *Context) ReleaseShaderCompiler() {
+	defer c.record("ReleaseShaderCompiler")()
+}
+
+// CreateProgram implements gl.Context.
+func (c *Context) CreateProgram() gl.Program {
+	defer c.record("CreateProgram")()
+	p := gl.Program{Init: true, Value: c.newName()}
+	c.programs[p.Value] = &program{bindings: make(map[string]uint)}
+	c.result(p)
+	return p
+}
+
+// AttachShader implements gl.Context.
+func (c *Context) AttachShader(p gl.Program, s gl.Shader) {
+	defer c.record("AttachShader", p, s)()
+	prog := c.programObj(p)
+	if prog == nil {
+		return
+	}
+	sh := c.shaderObj(s.Value)
+	if sh == nil {
+		return
+	}
+	for _, t := range prog.shaders {
+		if t == s.Value || c.shaders[t].ty == sh.ty {
+			c.fail(gl.INVALID_OPERATION)
+			return
+		}
+	}
+	prog.shaders = append(prog.shaders, s.Value)
+}
+
+// DetachShader implements gl.Context.
+func (c *Context) DetachShader(p gl.Program, s gl.Shader) {
+	defer c.record("DetachShader", p, s)()
+	prog := c.programObj(p)
+	if prog == nil || c.shaderObj(s.Value) == nil {
+		return
+	}
+	for i, t := range prog.shaders {
+		if t == s.Value {
+			prog.shaders = append(prog.shaders[:i], prog.shaders[i+1:]...)
+			c.collectShader(s.Value)
+			return
+		}
+	}
+	c.fail(gl.INVALID_OPERATION)
+}
+
+// GetAttachedShaders implements gl.Context.
+func (c *Context) GetAttachedShaders(p gl.Program) []gl.Shader {
+	defer c.record("GetAttachedShaders", p)()
+	var shaders []gl.Shader
+	if prog := c.programObj(p); prog != nil {
+		for _, s := range prog.shaders {
+			shaders = append(shaders, gl.Shader{Value: s})
+		}
+	}
+	c.result(shaders)
+	return shaders
+}
+
+// BindAttribLocation implements gl.Context. The binding takes effect when
+// the program is next linked.
+func (c *Context) BindAttribLocation(p gl.Program, a gl.Attrib, name string) {
+	defer c.record("BindAttribLocation", p, a, name)()
+	if a.Value >= maxVertexAttribs {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	prog := c.programObj(p)
+	if prog == nil {
+		return
+	}
+	if strings.HasPrefix(name, "gl_") {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	prog.bindings[name] = a.Value
+}
+
+// LinkProgram implements gl.Context. It links a program that has a compiled
+// vertex shader and a compiled fragment shader. Attributes get the locations
+// bound by BindAttribLocation or else the lowest free ones, and uniforms get
+// locations in the order that they are declared.
+func (c *Context) LinkProgram(p gl.Program) {
+	defer c.record("LinkProgram", p)()
+	prog := c.programObj(p)
+	if prog == nil {
+		return
+	}
+	prog.linked, prog.attribs, prog.uniforms = false, nil, nil
+	var vs, fs *shader
+	for _, s := range prog.shaders {
+		switch sh := c.shaders[s]; sh.ty {
+		case gl.VERTEX_SHADER:
+			vs = sh
+		case gl.FRAGMENT_SHADER:
+			fs = sh
+		}
+	}
+	switch {
+	case vs == nil || fs == nil:
+		prog.log = "ERROR: a vertex shader and a fragment shader must be attached"
+		return
+	case !vs.compiled || !fs.compiled:
+		prog.log = "ERROR: a shader is not compiled"
+		return
+	}
+
+	var used [maxVertexAttribs]bool
+	var free []global
+	for _, g := range vs.attribs {
+		if loc, ok := prog.bindings[g.name]; ok {
+			prog.attribs = append(prog.attribs, activeAttrib{g, loc})
+			used[loc] = true
+			continue
+		}
+		free = append(free, g)
+	}
+	for _, g := range free {
+		loc := uint(0)
+		for loc < maxVertexAttribs && used[loc] {
+			loc++
+		}
+		if loc == maxVertexAttribs {
+			prog.log = "ERROR: too many attributes"
+			prog.attribs = nil
+			return
+		}
+		prog.attribs = append(prog.attribs, activeAttrib{g, loc})
+		used[loc] = true
+	}
+	sort.Slice(prog.attribs, func(i, j int) bool { return prog.attribs[i].loc < prog.attribs[j].loc })
+
+	seen := make(map[string]global)
+	loc := int32(0)
+	for _, sh := range []*shader{vs, fs} {
+		for _, g := range sh.uniforms {
+			if h, ok := seen[g.name]; ok {
+				if h != g {
+					prog.log = fmt.Sprintf("ERROR: uniform %s declared differently in the shaders", g.name)
+					prog.attribs, prog.uniforms = nil, nil
+					return
+				}
+				continue
+			}
+			seen[g.name] = g
+			n, _ := components(g.ty)
+			prog.uniforms = append(prog.uniforms, activeUniform{g, loc, make([]float32, n*g.size)})
+			loc += int32(g.size)
+		}
+	}
+	prog.linked, prog.log = true, ""
+}
+
+// ValidateProgram implements gl.Context.
+func (c *Context) ValidateProgram(p gl.Program) {
+	defer c.record("ValidateProgram", p)()
+	if prog := c.programObj(p); prog != nil {
+		prog.validated = prog.linked
+	}
+}
+
+// UseProgram implements gl.Context.
+func (c *Context) UseProgram(p gl.Program) {
+	defer c.record("UseProgram", p)()
+	if p.Init && p.Value == 0 {
+		c.useProgram(0)
+		return
+	}
+	prog := c.programObj(p)
+	if prog == nil {
+		return
+	}
+	if !prog.linked {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	c.useProgram(p.Value)
+}
+
+func (c *Context) useProgram(p uint32) {
+	old := c.program
+	c.program = p
+	if prog := c.programs[old]; prog != nil && prog.deleted && old != p {
+		c.deleteProgram(old)
+	}
+}
+
+// DeleteProgram implements gl.Context. The current program is only flagged
+// for deletion, until it is no longer current.
+func (c *Context) DeleteProgram(p gl.Program) {
+	defer c.record("DeleteProgram", p)()
+	if p.Init && p.Value == 0 {
+		return
+	}
+	prog := c.programObj(p)
+	if prog == nil {
+		return
+	}
+	prog.deleted = true
+	if c.program != p.Value {
+		c.deleteProgram(p.Value)
+	}
+}
+
+func (c *Context) deleteProgram(p uint32) {
+	shaders := c.programs[p].shaders
+	delete(c.programs, p)
+	for _, s := range shaders {
+		c.collectShader(s)
+	}
+}
+
+// IsProgram implements gl.Context.
+func (c *Context) IsProgram(p gl.Program) bool {
+	defer c.record("IsProgram", p)()
+	ok := c.programs[programName(p)] != nil
+	c.result(ok)
+	return ok
+}
+
+// GetProgrami implements gl.Context.
+func (c *Context) GetProgrami(p gl.Program, pname gl.Enum) int {
+	defer c.record("GetProgrami", p, pname)()
+	prog := c.programObj(p)
+	if prog == nil {
+		c.result(0)
+		return 0
+	}
+	var v int
+	switch pname {
+	case gl.DELETE_STATUS:
+		v = boolInt(prog.deleted)
+	case gl.LINK_STATUS:
+		v = boolInt(prog.linked)
+	case gl.VALIDATE_STATUS:
+		v = boolInt(prog.validated)
+	case gl.INFO_LOG_LENGTH:
+		v = nulLen(prog.log)
+	case gl.ATTACHED_SHADERS:
+		v = len(prog.shaders)
+	case gl.ACTIVE_ATTRIBUTES:
+		v = len(prog.attribs)
+	case gl.ACTIVE_UNIFORMS:
+		v = len(prog.uniforms)
+	case gl.ACTIVE_ATTRIBUTE_MAX_LENGTH:
+		for _, a := range prog.attribs {
+			if n := nulLen(a.name); v < n {
+				v = n
+			}
+		}
+	case gl.ACTIVE_UNIFORM_MAX_LENGTH:
+		for _, u := range prog.uniforms {
+			if n := nulLen(u.name); v < n {
+				v = n
+			}
+		}
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(v)
+	return v
+}
+
+// GetProgramInfoLog implements gl.Context.
+func (c *Context) GetProgramInfoLog(p gl.Program) string {
+	defer c.record("GetProgramInfoLog", p)()
+	var log string
+	if prog := c.programObj(p); prog != nil {
+		log = prog.log
+	}
+	c.result(log)
+	return log
+}
+
+// GetActiveAttrib implements gl.Context.
+func (c *Context) GetActiveAttrib(p gl.Program, index uint32) (name string, size int, ty gl.Enum) {
+	defer c.record("GetActiveAttrib", p, index)()
+	if prog := c.programObj(p); prog != nil {
+		if int(index) < len(prog.attribs) {
+			a := prog.attribs[index]
+			name, size, ty = a.name, a.size, a.ty
+		} else {
+			c.fail(gl.INVALID_VALUE)
+		}
+	}
+	c.result(name, size, ty)
+	return name, size, ty
+}
+
+// GetActiveUniform implements gl.Context.
+func (c *Context) GetActiveUniform(p gl.Program, index uint32) (name string, size int, ty gl.Enum) {
+	defer c.record("GetActiveUniform", p, index)()
+	if prog := c.programObj(p); prog != nil {
+		if int(index) < len(prog.uniforms) {
+			u := prog.uniforms[index]
+			name, size, ty = u.name, u.size, u.ty
+			if size > 1 {
+				name += "[0]"
+			}
+		} else {
+			c.fail(gl.INVALID_VALUE)
+		}
+	}
+	c.result(name, size, ty)
+	return name, size, ty
+}
+
+// GetAttribLocation implements gl.Context. An unknown attribute is at -1,
+// which, as in the gl package, is the largest uint.
+func (c *Context) GetAttribLocation(p gl.Program, name string) gl.Attrib {
+	defer c.record("GetAttribLocation", p, name)()
+	a := gl.Attrib{Value: ^uint(0)}
+	if prog := c.programObj(p); prog != nil {
+		if !prog.linked {
+			c.fail(gl.INVALID_OPERATION)
+		}
+		for _, b := range prog.attribs {
+			if b.name == name {
+				a.Value = b.loc
+			}
+		}
+	}
+	c.result(a)
+	return a
+}
+
+// GetUniformLocation implements gl.Context. Array elements may be named as
+// in GLSL, such as "colors[2]".
+func (c *Context) GetUniformLocation(p gl.Program, name string) gl.Uniform {
+	defer c.record("GetUniformLocation", p, name)()
+	u := gl.Uniform{Value: -1}
+	if prog := c.programObj(p); prog != nil {
+		if !prog.linked {
+			c.fail(gl.INVALID_OPERATION)
+		}
+		base, index := name, 0
+		if i := strings.IndexByte(name, '['); i >= 0 && strings.HasSuffix(name, "]") {
+			n, err := strconv.Atoi(name[i+1 : len(name)-1])
+			if err != nil || n < 0 {
+				n = -1
+			}
+			base, index = name[:i], n
+		}
+		for _, v := range prog.uniforms {
+			if v.name == base && 0 <= index && index < v.size {
+				u.Value = v.loc + int32(index)
+			}
+		}
+	}
+	c.result(u)
+	return u
+}
+
+// uniform returns the uniform of the current program at loc, and the index
+// of the array element there. It reports INVALID_OPERATION if there is no
+// current program, or no uniform at loc.
+func (c *Context) uniform(loc gl.Uniform) (*activeUniform, int) {
+	prog := c.programs[c.program]
+	if prog == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return nil, 0
+	}
+	for i := range prog.uniforms {
+		u := &prog.uniforms[i]
+		if u.loc <= loc.Value && loc.Value < u.loc+int32(u.size) {
+			return u, int(loc.Value - u.loc)
+		}
+	}
+	c.fail(gl.INVALID_OPERATION)
+	return nil, 0
+}
+
+// setUniform sets count elements of the uniform at dst, from src, which has n
+// values per element. The location -1 is ignored. It is INVALID_OPERATION
+// for kind, 'f' for Uniform*f, 'i' for Uniform*i or 'm' for UniformMatrix*,
+// or n to not match the uniform's type, or to set more than one element of a
+// uniform that is not an array. As an addition to the specification, it is
+// INVALID_VALUE for src to not be a whole number of elements.
+func (c *Context) setUniform(dst gl.Uniform, kind byte, n int, src []float32) {
+	if dst.Value == -1 {
+		return
+	}
+	u, i := c.uniform(dst)
+	if u == nil {
+		return
+	}
+	if len(src)%n != 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	count := len(src) / n
+	un, ukind := components(u.ty)
+	if un != n || ukind != kind || kind == 'f' && isSampler(u.ty) || count > 1 && u.size == 1 {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	if i+count > u.size {
+		// Elements past the end of the array are ignored.
+		count = u.size - i
+	}
+	copy(u.value[i*n:], src[:count*n])
+}
+
+func isSampler(ty gl.Enum) bool { return ty == gl.SAMPLER_2D || ty == gl.SAMPLER_CUBE }
+
+func ints(v ...int32) []float32 {
+	f := make([]float32, len(v))
+	for i, x := range v {
+		f[i] = float32(x)
+	}
+	return f
+}
+
+// Uniform1f implements gl.Context.
+func (c *Context) Uniform1f(dst gl.Uniform, v float32) {
+	defer c.record("Uniform1f", dst, v)()
+	c.setUniform(dst, 'f', 1, []float32{v})
+}
+
+// Uniform1fv implements gl.Context.
+func (c *Context) Uniform1fv(dst gl.Uniform, src []float32) {
+	defer c.record("Uniform1fv", dst, src)()
+	c.setUniform(dst, 'f', 1, src)
+}
+
+// Uniform1i implements gl.Context. It is INVALID_VALUE to set a sampler to a
+// texture unit that does not exist.
+func (c *Context) Uniform1i(dst gl.Uniform, v int) {
+	defer c.record("Uniform1i", dst, v)()
+	if dst.Value != -1 && (v < 0 || v >= maxTextureUnits) {
+		if u, _ := c.uniform(dst); u == nil {
+			return
+		} else if isSampler(u.ty) {
+			c.fail(gl.INVALID_VALUE)
+			return
+		}
+	}
+	c.setUniform(dst, 'i', 1, ints(int32(v)))
+}
+
+// Uniform1iv implements gl.Context.
+func (c *Context) Uniform1iv(dst gl.Uniform, src []int32) {
+	defer c.record("Uniform1iv", dst, src)()
+	c.setUniform(dst, 'i', 1, ints(src...))
+}
+
+// Uniform2f implements gl.Context.
+func (c *Context) Uniform2f(dst gl.Uniform, v0, v1 float32) {
+	defer c.record("Uniform2f", dst, v0, v1)()
+	c.setUniform(dst, 'f', 2, []float32{v0, v1})
+}
+
+// Uniform2fv implements gl.Context.
+func (c *Context) Uniform2fv(dst gl.Uniform, src []float32) {
+	defer c.record("Uniform2fv", dst, src)()
+	c.setUniform(dst, 'f', 2, src)
+}
+
+// Uniform2i implements gl.Context.
+func (c *Context) Uniform2i(dst gl.Uniform, v0, v1 int) {
+	defer c.record("Uniform2i", dst, v0, v1)()
+	c.setUniform(dst, 'i', 2, ints(int32(v0), int32(v1)))
+}
+
+// Uniform2iv implements gl.Context.
+func (c *Context) Uniform2iv(dst gl.Uniform, src []int32) {
+	defer c.record("Uniform2iv", dst, src)()
+	c.setUniform(dst, 'i', 2, ints(src...))
+}
+
+// Uniform3f implements gl.Context.
+func (c *Context) Uniform3f(dst gl.Uniform, v0, v1, v2 float32) {
+	defer c.record("Uniform3f", dst, v0, v1, v2)()
+	c.setUniform(dst, 'f', 3, []float32{v0, v1, v2})
+}
+
+// Uniform3fv implements gl.Context.
+func (c *Context) Uniform3fv(dst gl.Uniform, src []float32) {
+	defer c.record("Uniform3fv", dst, src)()
+	c.setUniform(dst, 'f', 3, src)
+}
+
+// Uniform3i implements gl.Context.
+func (c *Context) Uniform3i(dst gl.Uniform, v0, v1, v2 int32) {
+	defer c.record("Uniform3i", dst, v0, v1, v2)()
+	c.setUniform(dst, 'i', 3, ints(v0, v1, v2))
+}
+
+// Uniform3iv implements gl.Context.
+func (c *Context) Uniform3iv(dst gl.Uniform, src []int32) {
+	defer c.record("Uniform3iv", dst, src)()
+	c.setUniform(dst, 'i', 3, ints(src...))
+}
+
+// Uniform4f implements gl.Context.
+func (c *Context) Uniform4f(dst gl.Uniform, v0, v1, v2, v3 float32) {
+	defer c.record("Uniform4f", dst, v0, v1, v2, v3)()
+	c.setUniform(dst, 'f', 4, []float32{v0, v1, v2, v3})
+}
+
+// Uniform4fv implements gl.Context.
+func (c *Context) Uniform4fv(dst gl.Uniform, src []float32) {
+	defer c.record("Uniform4fv", dst, src)()
+	c.setUniform(dst, 'f', 4, src)
+}
+
+// Uniform4i implements gl.Context.
+func (c *Context) Uniform4i(dst gl.Uniform, v0, v1, v2, v3 int32) {
+	defer c.record("Uniform4i", dst, v0, v1, v2, v3)()
+	c.setUniform(dst, 'i', 4, ints(v0, v1, v2, v3))
+}
+
+// Uniform4iv implements gl.Context.
+func (c *Context) Uniform4iv(dst gl.Uniform, src []int32) {
+	defer c.record("Uniform4iv", dst, src)()
+	c.setUniform(dst, 'i', 4, ints(src...))
+}
+
+// UniformMatrix2fv implements gl.Context.
+func (c *Context) UniformMatrix2fv(dst gl.Uniform, src []float32) {
+	defer c.record("UniformMatrix2fv", dst, src)()
+	c.setUniform(dst, 'm', 4, src)
+}
+
+// UniformMatrix3fv implements gl.Context.
+func (c *Context) UniformMatrix3fv(dst gl.Uniform, src []float32) {
+	defer c.record("UniformMatrix3fv", dst, src)()
+	c.setUniform(dst, 'm', 9, src)
+}
+
+// UniformMatrix4fv implements gl.Context.
+func (c *Context) UniformMatrix4fv(dst gl.Uniform, src []float32) {
+	defer c.record("UniformMatrix4fv", dst, src)()
+	c.setUniform(dst, 'm', 16, src)
+}
+
+// getUniform returns the value of the uniform at src of the program p.
+func (c *Context) getUniform(src gl.Uniform, p gl.Program) []float32 {
+	prog := c.programObj(p)
+	if prog == nil {
+		return nil
+	}
+	if !prog.linked {
+		c.fail(gl.INVALID_OPERATION)
+		return nil
+	}
+	for _, u := range prog.uniforms {
+		if u.loc <= src.Value && src.Value < u.loc+int32(u.size) {
+			n, _ := components(u.ty)
+			i := int(src.Value-u.loc) * n
+			return u.value[i : i+n]
+		}
+	}
+	c.fail(gl.INVALID_OPERATION)
+	return nil
+}
+
+// GetUniformfv implements gl.Context.
+func (c *Context) GetUniformfv(dst []float32, src gl.Uniform, p gl.Program) {
+	defer c.record("GetUniformfv", dst, src, p)()
+	copy(dst, c.getUniform(src, p))
+}
+
+// GetUniformiv implements gl.Context.
+func (c *Context) GetUniformiv(dst []int32, src gl.Uniform, p gl.Program) {
+	defer c.record("GetUniformiv", dst, src, p)()
+	for i, v := range c.getUniform(src, p) {
+		if i < len(dst) {
+			dst[i] = int32(v)
+		}
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// nulLen returns the length of s as a C string, with its NUL, or 0 if s is
+// empty, as GL reports the lengths of logs and sources.
+func nulLen(s string) int {
+	if s == "" {
+		return 0
+	}
+	return len(s) + 1
+}
@@ -0,0 +1,625 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import (
+	"github.com/as/shiny/gl"
+)
+
+// stencil is the stencil test state of one face.
+type stencil struct {
+	fn                 gl.Enum
+	ref                int
+	mask, writeMask    uint32
+	fail, zfail, zpass gl.Enum
+}
+
+// isCap reports whether cap can be passed to Enable.
+func isCap(cap gl.Enum) bool {
+	switch cap {
+	case gl.BLEND, gl.CULL_FACE, gl.DEPTH_TEST, gl.DITHER, gl.POLYGON_OFFSET_FILL,
+		gl.SAMPLE_ALPHA_TO_COVERAGE, gl.SAMPLE_COVERAGE, gl.SCISSOR_TEST, gl.STENCIL_TEST:
+		return true
+	}
+	return false
+}
+
+// Enable implements gl.Context.
+func (c *Context) Enable(cap gl.Enum) {
+	defer c.record("Enable", cap)()
+	if !isCap(cap) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.enabled[cap] = true
+}
+
+// Disable implements gl.Context.
+func (c *Context) Disable(cap gl.Enum) {
+	defer c.record("Disable", cap)()
+	if !isCap(cap) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.enabled[cap] = false
+}
+
+// IsEnabled implements gl.Context.
+func (c *Context) IsEnabled(cap gl.Enum) bool {
+	defer c.record("IsEnabled", cap)()
+	if !isCap(cap) {
+		c.fail(gl.INVALID_ENUM)
+	}
+	v := c.enabled[cap]
+	c.result(v)
+	return v
+}
+
+func isBlendEquation(mode gl.Enum) bool {
+	return mode == gl.FUNC_ADD || mode == gl.FUNC_SUBTRACT || mode == gl.FUNC_REVERSE_SUBTRACT
+}
+
+func isBlendFactor(f gl.Enum) bool {
+	switch f {
+	case gl.ZERO, gl.ONE, gl.SRC_COLOR, gl.ONE_MINUS_SRC_COLOR, gl.DST_COLOR, gl.ONE_MINUS_DST_COLOR,
+		gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA, gl.DST_ALPHA, gl.ONE_MINUS_DST_ALPHA,
+		gl.CONSTANT_COLOR, gl.ONE_MINUS_CONSTANT_COLOR, gl.CONSTANT_ALPHA, gl.ONE_MINUS_CONSTANT_ALPHA,
+		gl.SRC_ALPHA_SATURATE:
+		return true
+	}
+	return false
+}
+
+// BlendColor implements gl.Context.
+func (c *Context) BlendColor(red, green, blue, alpha float32) {
+	defer c.record("BlendColor", red, green, blue, alpha)()
+	c.blendColor = [4]float32{clamp(red), clamp(green), clamp(blue), clamp(alpha)}
+}
+
+// BlendEquation implements gl.Context.
+func (c *Context) BlendEquation(mode gl.Enum) {
+	defer c.record("BlendEquation", mode)()
+	if !isBlendEquation(mode) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.blendEquation = [2]gl.Enum{mode, mode}
+}
+
+// BlendEquationSeparate implements gl.Context.
+func (c *Context) BlendEquationSeparate(modeRGB, modeAlpha gl.Enum) {
+	defer c.record("BlendEquationSeparate", modeRGB, modeAlpha)()
+	if !isBlendEquation(modeRGB) || !isBlendEquation(modeAlpha) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.blendEquation = [2]gl.Enum{modeRGB, modeAlpha}
+}
+
+// BlendFunc implements gl.Context.
+func (c *Context) BlendFunc(sfactor, dfactor gl.Enum) {
+	defer c.record("BlendFunc", sfactor, dfactor)()
+	if !isBlendFactor(sfactor) || !isBlendFactor(dfactor) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.blendFunc = [4]gl.Enum{sfactor, dfactor, sfactor, dfactor}
+}
+
+// BlendFuncSeparate implements gl.Context.
+func (c *Context) BlendFuncSeparate(sfactorRGB, dfactorRGB, sfactorAlpha, dfactorAlpha gl.Enum) {
+	defer c.record("BlendFuncSeparate", sfactorRGB, dfactorRGB, sfactorAlpha, dfactorAlpha)()
+	for _, f := range []gl.Enum{sfactorRGB, dfactorRGB, sfactorAlpha, dfactorAlpha} {
+		if !isBlendFactor(f) {
+			c.fail(gl.INVALID_ENUM)
+			return
+		}
+	}
+	c.blendFunc = [4]gl.Enum{sfactorRGB, dfactorRGB, sfactorAlpha, dfactorAlpha}
+}
+
+// ColorMask implements gl.Context.
+func (c *Context) ColorMask(red, green, blue, alpha bool) {
+	defer c.record("ColorMask", red, green, blue, alpha)()
+	c.colorMask = [4]bool{red, green, blue, alpha}
+}
+
+func isFunc(fn gl.Enum) bool {
+	switch fn {
+	case gl.NEVER, gl.LESS, gl.EQUAL, gl.LEQUAL, gl.GREATER, gl.NOTEQUAL, gl.GEQUAL, gl.ALWAYS:
+		return true
+	}
+	return false
+}
+
+// DepthFunc implements gl.Context.
+func (c *Context) DepthFunc(fn gl.Enum) {
+	defer c.record("DepthFunc", fn)()
+	if !isFunc(fn) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.depthFunc = fn
+}
+
+// DepthMask implements gl.Context.
+func (c *Context) DepthMask(flag bool) {
+	defer c.record("DepthMask", flag)()
+	c.depthMask = flag
+}
+
+// DepthRangef implements gl.Context.
+func (c *Context) DepthRangef(n, f float32) {
+	defer c.record("DepthRangef", n, f)()
+	c.depthRange = [2]float32{clamp(n), clamp(f)}
+}
+
+// faces returns the indexes into c.stencil of the given face, or false if
+// face is not FRONT, BACK or FRONT_AND_BACK.
+func faces(face gl.Enum) ([]int, bool) {
+	switch face {
+	case gl.FRONT:
+		return []int{0}, true
+	case gl.BACK:
+		return []int{1}, true
+	case gl.FRONT_AND_BACK:
+		return []int{0, 1}, true
+	}
+	return nil, false
+}
+
+func isStencilOp(op gl.Enum) bool {
+	switch op {
+	case gl.KEEP, gl.ZERO, gl.REPLACE, gl.INCR, gl.INCR_WRAP, gl.DECR, gl.DECR_WRAP, gl.INVERT:
+		return true
+	}
+	return false
+}
+
+func (c *Context) stencilFunc(face, fn gl.Enum, ref int, mask uint32) {
+	f, ok := faces(face)
+	if !ok || !isFunc(fn) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	for _, i := range f {
+		c.stencil[i].fn, c.stencil[i].ref, c.stencil[i].mask = fn, ref, mask
+	}
+}
+
+// StencilFunc implements gl.Context.
+func (c *Context) StencilFunc(fn gl.Enum, ref int, mask uint32) {
+	defer c.record("StencilFunc", fn, ref, mask)()
+	c.stencilFunc(gl.FRONT_AND_BACK, fn, ref, mask)
+}
+
+// StencilFuncSeparate implements gl.Context.
+func (c *Context) StencilFuncSeparate(face, fn gl.Enum, ref int, mask uint32) {
+	defer c.record("StencilFuncSeparate", face, fn, ref, mask)()
+	c.stencilFunc(face, fn, ref, mask)
+}
+
+func (c *Context) stencilMask(face gl.Enum, mask uint32) {
+	f, ok := faces(face)
+	if !ok {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	for _, i := range f {
+		c.stencil[i].writeMask = mask
+	}
+}
+
+// StencilMask implements gl.Context.
+func (c *Context) StencilMask(mask uint32) {
+	defer c.record("StencilMask", mask)()
+	c.stencilMask(gl.FRONT_AND_BACK, mask)
+}
+
+// StencilMaskSeparate implements gl.Context.
+func (c *Context) StencilMaskSeparate(face gl.Enum, mask uint32) {
+	defer c.record("StencilMaskSeparate", face, mask)()
+	c.stencilMask(face, mask)
+}
+
+func (c *Context) stencilOp(face, fail, zfail, zpass gl.Enum) {
+	f, ok := faces(face)
+	if !ok || !isStencilOp(fail) || !isStencilOp(zfail) || !isStencilOp(zpass) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	for _, i := range f {
+		c.stencil[i].fail, c.stencil[i].zfail, c.stencil[i].zpass = fail, zfail, zpass
+	}
+}
+
+// StencilOp implements gl.Context.
+func (c *Context) StencilOp(fail, zfail, zpass gl.Enum) {
+	defer c.record("StencilOp", fail, zfail, zpass)()
+	c.stencilOp(gl.FRONT_AND_BACK, fail, zfail, zpass)
+}
+
+// StencilOpSeparate implements gl.Context.
+func (c *Context) StencilOpSeparate(face, sfail, dpfail, dppass gl.Enum) {
+	defer c.record("StencilOpSeparate", face, sfail, dpfail, dppass)()
+	c.stencilOp(face, sfail, dpfail, dppass)
+}
+
+// CullFace implements gl.Context.
+func (c *Context) CullFace(mode gl.Enum) {
+	defer c.record("CullFace", mode)()
+	if _, ok := faces(mode); !ok {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.cullFace = mode
+}
+
+// FrontFace implements gl.Context.
+func (c *Context) FrontFace(mode gl.Enum) {
+	defer c.record("FrontFace", mode)()
+	if mode != gl.CW && mode != gl.CCW {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.frontFace = mode
+}
+
+// Hint implements gl.Context.
+func (c *Context) Hint(target, mode gl.Enum) {
+	defer c.record("Hint", target, mode)()
+	if target != gl.GENERATE_MIPMAP_HINT || (mode != gl.FASTEST && mode != gl.NICEST && mode != gl.DONT_CARE) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	c.hints[target] = mode
+}
+
+// LineWidth implements gl.Context.
+func (c *Context) LineWidth(width float32) {
+	defer c.record("LineWidth", width)()
+	if width <= 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.lineWidth = width
+}
+
+// PixelStorei implements gl.Context.
+func (c *Context) PixelStorei(pname gl.Enum, param int32) {
+	defer c.record("PixelStorei", pname, param)()
+	if pname != gl.PACK_ALIGNMENT && pname != gl.UNPACK_ALIGNMENT {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if param != 1 && param != 2 && param != 4 && param != 8 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.pixelStore[pname] = param
+}
+
+// PolygonOffset implements gl.Context.
+func (c *Context) PolygonOffset(factor, units float32) {
+	defer c.record("PolygonOffset", factor, units)()
+	c.polygonOffset = [2]float32{factor, units}
+}
+
+// SampleCoverage implements gl.Context.
+func (c *Context) SampleCoverage(value float32, invert bool) {
+	defer c.record("SampleCoverage", value, invert)()
+	c.sampleCoverage, c.sampleInvert = clamp(value), invert
+}
+
+// Viewport implements gl.Context.
+func (c *Context) Viewport(x, y, width, height int) {
+	defer c.record("Viewport", x, y, width, height)()
+	if width < 0 || height < 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.viewport = [4]int32{int32(x), int32(y), int32(width), int32(height)}
+}
+
+// Scissor implements gl.Context.
+func (c *Context) Scissor(x, y, width, height int32) {
+	defer c.record("Scissor", x, y, width, height)()
+	if width < 0 || height < 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.scissor = [4]int32{x, y, width, height}
+}
+
+// ClearColor implements gl.Context.
+func (c *Context) ClearColor(red, green, blue, alpha float32) {
+	defer c.record("ClearColor", red, green, blue, alpha)()
+	c.clearColor = [4]float32{clamp(red), clamp(green), clamp(blue), clamp(alpha)}
+}
+
+// ClearDepthf implements gl.Context.
+func (c *Context) ClearDepthf(d float32) {
+	defer c.record("ClearDepthf", d)()
+	c.clearDepth = clamp(d)
+}
+
+// ClearStencil implements gl.Context.
+func (c *Context) ClearStencil(s int) {
+	defer c.record("ClearStencil", s)()
+	c.clearStencil = int32(s)
+}
+
+// clamp clamps x to [0, 1].
+func clamp(x float32) float32 {
+	switch {
+	case x < 0:
+		return 0
+	case x > 1:
+		return 1
+	}
+	return x
+}
+
+// GetError implements gl.Context.
+func (c *Context) GetError() gl.Enum {
+	defer c.record("GetError")()
+	e := c.err
+	c.err = 0
+	c.result(e)
+	return e
+}
+
+// GetString implements gl.Context.
+func (c *Context) GetString(pname gl.Enum) string {
+	defer c.record("GetString", pname)()
+	var s string
+	switch pname {
+	case gl.VENDOR, gl.RENDERER:
+		s = "glfake"
+	case gl.VERSION:
+		s = "OpenGL ES 2.0 glfake"
+	case gl.SHADING_LANGUAGE_VERSION:
+		s = "OpenGL ES GLSL ES 1.00"
+	case gl.EXTENSIONS:
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(s)
+	return s
+}
+
+// state returns the value of pname, as returned by the Get functions, or
+// false if pname is not a state variable.
+func (c *Context) state(pname gl.Enum) ([]float64, bool) {
+	n := func(v ...float64) []float64 { return v }
+	b := func(v ...bool) []float64 {
+		f := make([]float64, len(v))
+		for i := range v {
+			if v[i] {
+				f[i] = 1
+			}
+		}
+		return f
+	}
+	f32 := func(v ...float32) []float64 {
+		f := make([]float64, len(v))
+		for i := range v {
+			f[i] = float64(v[i])
+		}
+		return f
+	}
+	i32 := func(v ...int32) []float64 {
+		f := make([]float64, len(v))
+		for i := range v {
+			f[i] = float64(v[i])
+		}
+		return f
+	}
+	e := func(v gl.Enum) []float64 { return n(float64(v)) }
+	if isCap(pname) {
+		return b(c.enabled[pname]), true
+	}
+	unit := c.units[c.activeTexture]
+	switch pname {
+	case gl.ACTIVE_TEXTURE:
+		return n(float64(gl.TEXTURE0) + float64(c.activeTexture)), true
+	case gl.TEXTURE_BINDING_2D:
+		return n(float64(unit.tex2D)), true
+	case gl.TEXTURE_BINDING_CUBE_MAP:
+		return n(float64(unit.cube)), true
+	case gl.ARRAY_BUFFER_BINDING:
+		return n(float64(c.arrayBuffer)), true
+	case gl.ELEMENT_ARRAY_BUFFER_BINDING:
+		return n(float64(c.elementBuffer)), true
+	case gl.FRAMEBUFFER_BINDING:
+		return n(float64(c.framebuffer)), true
+	case gl.RENDERBUFFER_BINDING:
+		return n(float64(c.renderbuffer)), true
+	case gl.CURRENT_PROGRAM:
+		return n(float64(c.program)), true
+	case gl.VIEWPORT:
+		return i32(c.viewport[:]...), true
+	case gl.SCISSOR_BOX:
+		return i32(c.scissor[:]...), true
+	case gl.COLOR_CLEAR_VALUE:
+		return f32(c.clearColor[:]...), true
+	case gl.DEPTH_CLEAR_VALUE:
+		return f32(c.clearDepth), true
+	case gl.STENCIL_CLEAR_VALUE:
+		return i32(c.clearStencil), true
+	case gl.COLOR_WRITEMASK:
+		return b(c.colorMask[:]...), true
+	case gl.DEPTH_WRITEMASK:
+		return b(c.depthMask), true
+	case gl.DEPTH_FUNC:
+		return e(c.depthFunc), true
+	case gl.DEPTH_RANGE:
+		return f32(c.depthRange[:]...), true
+	case gl.BLEND_COLOR:
+		return f32(c.blendColor[:]...), true
+	case gl.BLEND_EQUATION_RGB:
+		return e(c.blendEquation[0]), true
+	case gl.BLEND_EQUATION_ALPHA:
+		return e(c.blendEquation[1]), true
+	case gl.BLEND_SRC_RGB:
+		return e(c.blendFunc[0]), true
+	case gl.BLEND_DST_RGB:
+		return e(c.blendFunc[1]), true
+	case gl.BLEND_SRC_ALPHA:
+		return e(c.blendFunc[2]), true
+	case gl.BLEND_DST_ALPHA:
+		return e(c.blendFunc[3]), true
+	case gl.CULL_FACE_MODE:
+		return e(c.cullFace), true
+	case gl.FRONT_FACE:
+		return e(c.frontFace), true
+	case gl.LINE_WIDTH:
+		return f32(c.lineWidth), true
+	case gl.POLYGON_OFFSET_FACTOR:
+		return f32(c.polygonOffset[0]), true
+	case gl.POLYGON_OFFSET_UNITS:
+		return f32(c.polygonOffset[1]), true
+	case gl.SAMPLE_COVERAGE_VALUE:
+		return f32(c.sampleCoverage), true
+	case gl.SAMPLE_COVERAGE_INVERT:
+		return b(c.sampleInvert), true
+	case gl.STENCIL_FUNC:
+		return e(c.stencil[0].fn), true
+	case gl.STENCIL_REF:
+		return n(float64(c.stencil[0].ref)), true
+	case gl.STENCIL_VALUE_MASK:
+		return n(float64(c.stencil[0].mask)), true
+	case gl.STENCIL_WRITEMASK:
+		return n(float64(c.stencil[0].writeMask)), true
+	case gl.STENCIL_FAIL:
+		return e(c.stencil[0].fail), true
+	case gl.STENCIL_PASS_DEPTH_FAIL:
+		return e(c.stencil[0].zfail), true
+	case gl.STENCIL_PASS_DEPTH_PASS:
+		return e(c.stencil[0].zpass), true
+	case gl.STENCIL_BACK_FUNC:
+		return e(c.stencil[1].fn), true
+	case gl.STENCIL_BACK_REF:
+		return n(float64(c.stencil[1].ref)), true
+	case gl.STENCIL_BACK_VALUE_MASK:
+		return n(float64(c.stencil[1].mask)), true
+	case gl.STENCIL_BACK_WRITEMASK:
+		return n(float64(c.stencil[1].writeMask)), true
+	case gl.STENCIL_BACK_FAIL:
+		return e(c.stencil[1].fail), true
+	case gl.STENCIL_BACK_PASS_DEPTH_FAIL:
+		return e(c.stencil[1].zfail), true
+	case gl.STENCIL_BACK_PASS_DEPTH_PASS:
+		return e(c.stencil[1].zpass), true
+	case gl.GENERATE_MIPMAP_HINT:
+		return e(c.hints[pname]), true
+	case gl.PACK_ALIGNMENT, gl.UNPACK_ALIGNMENT:
+		return i32(c.pixelStore[pname]), true
+	case gl.MAX_TEXTURE_SIZE, gl.MAX_CUBE_MAP_TEXTURE_SIZE:
+		return n(maxTextureSize), true
+	case gl.MAX_RENDERBUFFER_SIZE:
+		return n(maxRenderbufferSize), true
+	case gl.MAX_VIEWPORT_DIMS:
+		return n(maxRenderbufferSize, maxRenderbufferSize), true
+	case gl.MAX_TEXTURE_IMAGE_UNITS, gl.MAX_COMBINED_TEXTURE_IMAGE_UNITS:
+		return n(maxTextureUnits), true
+	case gl.MAX_VERTEX_TEXTURE_IMAGE_UNITS:
+		return n(0), true
+	case gl.MAX_VERTEX_ATTRIBS:
+		return n(maxVertexAttribs), true
+	case gl.MAX_VERTEX_UNIFORM_VECTORS:
+		return n(128), true
+	case gl.MAX_FRAGMENT_UNIFORM_VECTORS:
+		return n(16), true
+	case gl.MAX_VARYING_VECTORS:
+		return n(8), true
+	case gl.ALIASED_LINE_WIDTH_RANGE, gl.ALIASED_POINT_SIZE_RANGE:
+		return n(1, 1), true
+	case gl.NUM_COMPRESSED_TEXTURE_FORMATS, gl.NUM_SHADER_BINARY_FORMATS:
+		return n(0), true
+	case gl.COMPRESSED_TEXTURE_FORMATS, gl.SHADER_BINARY_FORMATS:
+		return n(), true
+	case gl.SHADER_COMPILER:
+		return n(1), true
+	case gl.SUBPIXEL_BITS:
+		return n(4), true
+	case gl.SAMPLE_BUFFERS:
+		return n(0), true
+	case gl.SAMPLES:
+		return n(0), true
+	case gl.RED_BITS, gl.GREEN_BITS, gl.BLUE_BITS, gl.ALPHA_BITS:
+		return n(8), true
+	case gl.DEPTH_BITS, gl.STENCIL_BITS:
+		return n(0), true
+	case gl.IMPLEMENTATION_COLOR_READ_FORMAT:
+		return e(gl.RGBA), true
+	case gl.IMPLEMENTATION_COLOR_READ_TYPE:
+		return e(gl.UNSIGNED_BYTE), true
+	}
+	return nil, false
+}
+
+// get returns the value of pname, reporting INVALID_ENUM if it is not a
+// state variable, or INVALID_VALUE if it does not fit in n values.
+func (c *Context) get(pname gl.Enum, n int) []float64 {
+	v, ok := c.state(pname)
+	if !ok {
+		c.fail(gl.INVALID_ENUM)
+		return nil
+	}
+	if len(v) > n {
+		c.fail(gl.INVALID_VALUE)
+		return nil
+	}
+	return v
+}
+
+// GetBooleanv implements gl.Context. As an addition to the specification,
+// it is INVALID_VALUE for dst to be too short for the value.
+func (c *Context) GetBooleanv(dst []bool, pname gl.Enum) {
+	defer c.record("GetBooleanv", dst, pname)()
+	for i, v := range c.get(pname, len(dst)) {
+		dst[i] = v != 0
+	}
+}
+
+// GetFloatv implements gl.Context. As an addition to the specification, it
+// is INVALID_VALUE for dst to be too short for the value.
+func (c *Context) GetFloatv(dst []float32, pname gl.Enum) {
+	defer c.record("GetFloatv", dst, pname)()
+	for i, v := range c.get(pname, len(dst)) {
+		dst[i] = float32(v)
+	}
+}
+
+// GetIntegerv implements gl.Context. As an addition to the specification,
+// it is INVALID_VALUE for dst to be too short for the value.
+func (c *Context) GetIntegerv(dst []int32, pname gl.Enum) {
+	defer c.record("GetIntegerv", dst, pname)()
+	for i, v := range c.get(pname, len(dst)) {
+		dst[i] = int32(v)
+	}
+}
+
+// GetInteger implements gl.Context.
+func (c *Context) GetInteger(pname gl.Enum) int {
+	defer c.record("GetInteger", pname)()
+	var x int
+	if v := c.get(pname, 4); len(v) > 0 {
+		x = int(v[0])
+	}
+	c.result(x)
+	return x
+}
+
+// Finish implements gl.Context.
+func (c *Context) Finish() {
+	defer c.record("Finish")()
+}
+
+// Flush implements gl.Context.
+func (c *Context) Flush() {
+	defer c.record("Flush")()
+}
@@ -0,0 +1,733 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package glfake
+
+import (
+	"github.com/as/shiny/gl"
+)
+
+// pixelSize returns the number of bytes in a pixel of the given format and
+// type, or the error that TexImage2D reports for them.
+func pixelSize(format, ty gl.Enum) (int, gl.Enum) {
+	var channels int
+	switch format {
+	case gl.ALPHA, gl.LUMINANCE:
+		channels = 1
+	case gl.LUMINANCE_ALPHA:
+		channels = 2
+	case gl.RGB:
+		channels = 3
+	case gl.RGBA:
+		channels = 4
+	default:
+		return 0, gl.INVALID_ENUM
+	}
+	switch ty {
+	case gl.UNSIGNED_BYTE:
+		return channels, 0
+	case gl.UNSIGNED_SHORT_5_6_5:
+		if format != gl.RGB {
+			return 0, gl.INVALID_OPERATION
+		}
+		return 2, 0
+	case gl.UNSIGNED_SHORT_4_4_4_4, gl.UNSIGNED_SHORT_5_5_5_1:
+		if format != gl.RGBA {
+			return 0, gl.INVALID_OPERATION
+		}
+		return 2, 0
+	}
+	return 0, gl.INVALID_ENUM
+}
+
+// imageSize returns the number of bytes that GL reads for an image, whose
+// rows are aligned to align bytes, and the length of its rows with padding.
+func imageSize(width, height, bpp int, align int32) (size, stride int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+	a := int(align)
+	stride = (width*bpp + a - 1) / a * a
+	return stride*(height-1) + width*bpp, stride
+}
+
+// maxLevel is the largest mipmap level of a texture of the largest size.
+func maxLevel() int {
+	n := 0
+	for s := maxTextureSize; s > 1; s >>= 1 {
+		n++
+	}
+	return n
+}
+
+// checkTexImage returns the error, if any, in the arguments that define a
+// texture image.
+func checkTexImage(target gl.Enum, level, width, height int) gl.Enum {
+	switch {
+	case !isFace(target):
+		return gl.INVALID_ENUM
+	case level < 0 || level > maxLevel():
+		return gl.INVALID_VALUE
+	case width < 0 || height < 0 || width > maxTextureSize>>uint(level) || height > maxTextureSize>>uint(level):
+		return gl.INVALID_VALUE
+	case target != gl.TEXTURE_2D && width != height:
+		return gl.INVALID_VALUE
+	}
+	return 0
+}
+
+// TexImage2D implements gl.Context. As an addition to the specification, it
+// is INVALID_VALUE for data to be non-empty but shorter than the image.
+func (c *Context) TexImage2D(target gl.Enum, level int, width, height int, format gl.Enum, ty gl.Enum, data []byte) {
+	defer c.record("TexImage2D", target, level, width, height, format, ty, data)()
+	if e := checkTexImage(target, level, width, height); e != 0 {
+		c.fail(e)
+		return
+	}
+	bpp, e := pixelSize(format, ty)
+	if e != 0 {
+		c.fail(e)
+		return
+	}
+	m := &Image{Width: width, Height: height, Format: format, Type: ty}
+	if len(data) > 0 {
+		size, stride := imageSize(width, height, bpp, c.pixelStore[gl.UNPACK_ALIGNMENT])
+		if len(data) < size {
+			c.fail(gl.INVALID_VALUE)
+			return
+		}
+		m.Pix = make([]byte, width*height*bpp)
+		for y := 0; y < height; y++ {
+			copy(m.Pix[y*width*bpp:(y+1)*width*bpp], data[y*stride:])
+		}
+	}
+	c.texture(faceTarget(target)).setLevel(target, level, m)
+}
+
+// TexSubImage2D implements gl.Context. As an addition to the specification,
+// it is INVALID_VALUE for data to be shorter than the image.
+func (c *Context) TexSubImage2D(target gl.Enum, level int, x, y, width, height int, format, ty gl.Enum, data []byte) {
+	defer c.record("TexSubImage2D", target, level, x, y, width, height, format, ty, data)()
+	if !isFace(target) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	bpp, e := pixelSize(format, ty)
+	if e != 0 {
+		c.fail(e)
+		return
+	}
+	m := c.texture(faceTarget(target)).level(target, level)
+	switch {
+	case m == nil:
+		c.fail(gl.INVALID_OPERATION)
+		return
+	case x < 0 || y < 0 || width < 0 || height < 0 || x+width > m.Width || y+height > m.Height:
+		c.fail(gl.INVALID_VALUE)
+		return
+	case format != m.Format || ty != m.Type:
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	size, stride := imageSize(width, height, bpp, c.pixelStore[gl.UNPACK_ALIGNMENT])
+	if len(data) < size {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	if m.Pix == nil {
+		m.Pix = make([]byte, m.Width*m.Height*bpp)
+	}
+	for j := 0; j < height; j++ {
+		i := ((y+j)*m.Width + x) * bpp
+		copy(m.Pix[i:i+width*bpp], data[j*stride:])
+	}
+}
+
+// CompressedTexImage2D implements gl.Context. No compressed formats are
+// supported, so it always reports INVALID_ENUM.
+func (c *Context) CompressedTexImage2D(target gl.Enum, level int, internalformat gl.Enum, width, height, border int, data []byte) {
+	defer c.record("CompressedTexImage2D", target, level, internalformat, width, height, border, data)()
+	c.fail(gl.INVALID_ENUM)
+}
+
+// CompressedTexSubImage2D implements gl.Context. No compressed formats are
+// supported, so it always reports INVALID_ENUM.
+func (c *Context) CompressedTexSubImage2D(target gl.Enum, level, xoffset, yoffset, width, height int, format gl.Enum, data []byte) {
+	defer c.record("CompressedTexSubImage2D", target, level, xoffset, yoffset, width, height, format, data)()
+	c.fail(gl.INVALID_ENUM)
+}
+
+// CopyTexImage2D implements gl.Context.
+func (c *Context) CopyTexImage2D(target gl.Enum, level int, internalformat gl.Enum, x, y, width, height, border int) {
+	defer c.record("CopyTexImage2D", target, level, internalformat, x, y, width, height, border)()
+	if e := checkTexImage(target, level, width, height); e != 0 {
+		c.fail(e)
+		return
+	}
+	bpp, e := pixelSize(internalformat, gl.UNSIGNED_BYTE)
+	if e != 0 {
+		c.fail(e)
+		return
+	}
+	if border != 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	if c.checkFramebuffer() {
+		return
+	}
+	m := &Image{Width: width, Height: height, Format: internalformat, Type: gl.UNSIGNED_BYTE}
+	m.Pix = make([]byte, width*height*bpp)
+	rgba := c.readFramebuffer(x, y, width, height)
+	for i := 0; i < width*height; i++ {
+		setPixel(m, i, rgba[4*i:4*i+4])
+	}
+	c.texture(faceTarget(target)).setLevel(target, level, m)
+}
+
+// CopyTexSubImage2D implements gl.Context.
+func (c *Context) CopyTexSubImage2D(target gl.Enum, level, xoffset, yoffset, x, y, width, height int) {
+	defer c.record("CopyTexSubImage2D", target, level, xoffset, yoffset, x, y, width, height)()
+	if !isFace(target) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	m := c.texture(faceTarget(target)).level(target, level)
+	switch {
+	case m == nil:
+		c.fail(gl.INVALID_OPERATION)
+		return
+	case xoffset < 0 || yoffset < 0 || width < 0 || height < 0 || xoffset+width > m.Width || yoffset+height > m.Height:
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	if c.checkFramebuffer() || m.Type != gl.UNSIGNED_BYTE {
+		return
+	}
+	bpp, _ := pixelSize(m.Format, m.Type)
+	if m.Pix == nil {
+		m.Pix = make([]byte, m.Width*m.Height*bpp)
+	}
+	rgba := c.readFramebuffer(x, y, width, height)
+	for j := 0; j < height; j++ {
+		for i := 0; i < width; i++ {
+			k := 4 * (j*width + i)
+			setPixel(m, (yoffset+j)*m.Width+xoffset+i, rgba[k:k+4])
+		}
+	}
+}
+
+// pixel returns the i'th pixel of m, which is of type UNSIGNED_BYTE, as RGBA.
+func pixel(m *Image, i int) [4]byte {
+	if m.Pix == nil {
+		return [4]byte{}
+	}
+	switch m.Format {
+	case gl.ALPHA:
+		return [4]byte{0, 0, 0, m.Pix[i]}
+	case gl.LUMINANCE:
+		l := m.Pix[i]
+		return [4]byte{l, l, l, 0xff}
+	case gl.LUMINANCE_ALPHA:
+		l := m.Pix[2*i]
+		return [4]byte{l, l, l, m.Pix[2*i+1]}
+	case gl.RGB:
+		p := m.Pix[3*i:]
+		return [4]byte{p[0], p[1], p[2], 0xff}
+	}
+	p := m.Pix[4*i:]
+	return [4]byte{p[0], p[1], p[2], p[3]}
+}
+
+// setPixel sets the i'th pixel of m, which is of type UNSIGNED_BYTE, from
+// the RGBA p.
+func setPixel(m *Image, i int, p []byte) {
+	switch m.Format {
+	case gl.ALPHA:
+		m.Pix[i] = p[3]
+	case gl.LUMINANCE:
+		m.Pix[i] = p[0]
+	case gl.LUMINANCE_ALPHA:
+		m.Pix[2*i], m.Pix[2*i+1] = p[0], p[3]
+	case gl.RGB:
+		copy(m.Pix[3*i:3*i+3], p)
+	default:
+		copy(m.Pix[4*i:4*i+4], p)
+	}
+}
+
+// GenerateMipmap implements gl.Context. Levels of type UNSIGNED_BYTE are
+// made by averaging 2x2 blocks of pixels. The others are defined, but have
+// no data.
+func (c *Context) GenerateMipmap(target gl.Enum) {
+	defer c.record("GenerateMipmap", target)()
+	var faces []gl.Enum
+	switch target {
+	case gl.TEXTURE_2D:
+		faces = []gl.Enum{gl.TEXTURE_2D}
+	case gl.TEXTURE_CUBE_MAP:
+		for f := gl.Enum(gl.TEXTURE_CUBE_MAP_POSITIVE_X); f <= gl.TEXTURE_CUBE_MAP_NEGATIVE_Z; f++ {
+			faces = append(faces, f)
+		}
+	default:
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	tex := c.texture(target)
+	base := tex.level(faces[0], 0)
+	for _, f := range faces {
+		m := tex.level(f, 0)
+		// ES 2 can only make mipmaps of power-of-two textures.
+		if m == nil || base.Width != m.Width || base.Height != m.Height || base.Format != m.Format || base.Type != m.Type ||
+			!pow2(m.Width) || !pow2(m.Height) {
+			c.fail(gl.INVALID_OPERATION)
+			return
+		}
+	}
+	for _, f := range faces {
+		m := tex.level(f, 0)
+		for level := 1; m.Width > 1 || m.Height > 1; level++ {
+			m = halve(m)
+			tex.setLevel(f, level, m)
+		}
+	}
+}
+
+func pow2(n int) bool { return n > 0 && n&(n-1) == 0 }
+
+// halve returns the next mipmap level after m.
+func halve(m *Image) *Image {
+	w, h := (m.Width+1)/2, (m.Height+1)/2
+	n := &Image{Width: w, Height: h, Format: m.Format, Type: m.Type}
+	if m.Pix == nil || m.Type != gl.UNSIGNED_BYTE {
+		return n
+	}
+	bpp, _ := pixelSize(m.Format, m.Type)
+	n.Pix = make([]byte, w*h*bpp)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var sum [4]int
+			for _, d := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				sx, sy := 2*x+d[0], 2*y+d[1]
+				if sx >= m.Width {
+					sx = m.Width - 1
+				}
+				if sy >= m.Height {
+					sy = m.Height - 1
+				}
+				p := pixel(m, sy*m.Width+sx)
+				for i := range sum {
+					sum[i] += int(p[i])
+				}
+			}
+			var p [4]byte
+			for i := range p {
+				p[i] = byte((sum[i] + 2) / 4)
+			}
+			setPixel(n, y*w+x, p[:])
+		}
+	}
+	return n
+}
+
+// texParam returns the error, if any, in setting pname of a texture to v.
+func texParam(target, pname gl.Enum, v int32) gl.Enum {
+	if target != gl.TEXTURE_2D && target != gl.TEXTURE_CUBE_MAP {
+		return gl.INVALID_ENUM
+	}
+	e := gl.Enum(v)
+	switch pname {
+	case gl.TEXTURE_MIN_FILTER:
+		switch e {
+		case gl.NEAREST, gl.LINEAR, gl.NEAREST_MIPMAP_NEAREST, gl.LINEAR_MIPMAP_NEAREST,
+			gl.NEAREST_MIPMAP_LINEAR, gl.LINEAR_MIPMAP_LINEAR:
+			return 0
+		}
+	case gl.TEXTURE_MAG_FILTER:
+		switch e {
+		case gl.NEAREST, gl.LINEAR:
+			return 0
+		}
+	case gl.TEXTURE_WRAP_S, gl.TEXTURE_WRAP_T:
+		switch e {
+		case gl.REPEAT, gl.CLAMP_TO_EDGE, gl.MIRRORED_REPEAT:
+			return 0
+		}
+	}
+	return gl.INVALID_ENUM
+}
+
+func (c *Context) texParameter(target, pname gl.Enum, v int32) {
+	if e := texParam(target, pname, v); e != 0 {
+		c.fail(e)
+		return
+	}
+	c.texture(target).params[pname] = v
+}
+
+// TexParameterf implements gl.Context.
+func (c *Context) TexParameterf(target, pname gl.Enum, param float32) {
+	defer c.record("TexParameterf", target, pname, param)()
+	c.texParameter(target, pname, int32(param))
+}
+
+// TexParameterfv implements gl.Context.
+func (c *Context) TexParameterfv(target, pname gl.Enum, params []float32) {
+	defer c.record("TexParameterfv", target, pname, params)()
+	if len(params) == 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.texParameter(target, pname, int32(params[0]))
+}
+
+// TexParameteri implements gl.Context.
+func (c *Context) TexParameteri(target, pname gl.Enum, param int) {
+	defer c.record("TexParameteri", target, pname, param)()
+	c.texParameter(target, pname, int32(param))
+}
+
+// TexParameteriv implements gl.Context.
+func (c *Context) TexParameteriv(target, pname gl.Enum, params []int32) {
+	defer c.record("TexParameteriv", target, pname, params)()
+	if len(params) == 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	c.texParameter(target, pname, params[0])
+}
+
+func (c *Context) getTexParameter(target, pname gl.Enum) (int32, bool) {
+	if target != gl.TEXTURE_2D && target != gl.TEXTURE_CUBE_MAP {
+		c.fail(gl.INVALID_ENUM)
+		return 0, false
+	}
+	v, ok := c.texture(target).params[pname]
+	if !ok {
+		c.fail(gl.INVALID_ENUM)
+	}
+	return v, ok
+}
+
+// GetTexParameterfv implements gl.Context.
+func (c *Context) GetTexParameterfv(dst []float32, target, pname gl.Enum) {
+	defer c.record("GetTexParameterfv", dst, target, pname)()
+	if v, ok := c.getTexParameter(target, pname); ok && len(dst) > 0 {
+		dst[0] = float32(v)
+	}
+}
+
+// GetTexParameteriv implements gl.Context.
+func (c *Context) GetTexParameteriv(dst []int32, target, pname gl.Enum) {
+	defer c.record("GetTexParameteriv", dst, target, pname)()
+	if v, ok := c.getTexParameter(target, pname); ok && len(dst) > 0 {
+		dst[0] = v
+	}
+}
+
+// RenderbufferStorage implements gl.Context.
+func (c *Context) RenderbufferStorage(target, internalFormat gl.Enum, width, height int) {
+	defer c.record("RenderbufferStorage", target, internalFormat, width, height)()
+	if target != gl.RENDERBUFFER {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	switch internalFormat {
+	case gl.RGBA4, gl.RGB565, gl.RGB5_A1, gl.DEPTH_COMPONENT16, gl.STENCIL_INDEX8:
+	default:
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if width < 0 || height < 0 || width > maxRenderbufferSize || height > maxRenderbufferSize {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	rb := c.renderbuffers[c.renderbuffer]
+	if rb == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	rb.format, rb.width, rb.height = internalFormat, width, height
+}
+
+// GetRenderbufferParameteri implements gl.Context.
+func (c *Context) GetRenderbufferParameteri(target, pname gl.Enum) int {
+	defer c.record("GetRenderbufferParameteri", target, pname)()
+	if target != gl.RENDERBUFFER {
+		c.fail(gl.INVALID_ENUM)
+		c.result(0)
+		return 0
+	}
+	rb := c.renderbuffers[c.renderbuffer]
+	if rb == nil {
+		c.fail(gl.INVALID_OPERATION)
+		c.result(0)
+		return 0
+	}
+	// The sizes of the red, green, blue, alpha, depth and stencil bits.
+	bits := map[gl.Enum][6]int{
+		gl.RGBA4:             {4, 4, 4, 4, 0, 0},
+		gl.RGB565:            {5, 6, 5, 0, 0, 0},
+		gl.RGB5_A1:           {5, 5, 5, 1, 0, 0},
+		gl.DEPTH_COMPONENT16: {0, 0, 0, 0, 16, 0},
+		gl.STENCIL_INDEX8:    {0, 0, 0, 0, 0, 8},
+	}[rb.format]
+	var v int
+	switch pname {
+	case gl.RENDERBUFFER_WIDTH:
+		v = rb.width
+	case gl.RENDERBUFFER_HEIGHT:
+		v = rb.height
+	case gl.RENDERBUFFER_INTERNAL_FORMAT:
+		v = int(rb.format)
+	case gl.RENDERBUFFER_RED_SIZE:
+		v = bits[0]
+	case gl.RENDERBUFFER_GREEN_SIZE:
+		v = bits[1]
+	case gl.RENDERBUFFER_BLUE_SIZE:
+		v = bits[2]
+	case gl.RENDERBUFFER_ALPHA_SIZE:
+		v = bits[3]
+	case gl.RENDERBUFFER_DEPTH_SIZE:
+		v = bits[4]
+	case gl.RENDERBUFFER_STENCIL_SIZE:
+		v = bits[5]
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(v)
+	return v
+}
+
+func isAttachment(a gl.Enum) bool {
+	return a == gl.COLOR_ATTACHMENT0 || a == gl.DEPTH_ATTACHMENT || a == gl.STENCIL_ATTACHMENT
+}
+
+// FramebufferTexture2D implements gl.Context.
+func (c *Context) FramebufferTexture2D(target, point, texTarget gl.Enum, t gl.Texture, level int) {
+	defer c.record("FramebufferTexture2D", target, point, texTarget, t, level)()
+	if target != gl.FRAMEBUFFER || !isAttachment(point) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	fb := c.framebuffers[c.framebuffer]
+	if fb == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	if t.Value == 0 {
+		delete(fb.attachments, point)
+		return
+	}
+	if !isFace(texTarget) {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	if level != 0 {
+		c.fail(gl.INVALID_VALUE)
+		return
+	}
+	tex := c.textures[t.Value]
+	if tex == nil || !tex.bound || tex.target != faceTarget(texTarget) {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	fb.attachments[point] = attachment{texture: t.Value, face: texTarget, level: level}
+}
+
+// FramebufferRenderbuffer implements gl.Context.
+func (c *Context) FramebufferRenderbuffer(target, point, rbTarget gl.Enum, rb gl.Renderbuffer) {
+	defer c.record("FramebufferRenderbuffer", target, point, rbTarget, rb)()
+	if target != gl.FRAMEBUFFER || !isAttachment(point) || rbTarget != gl.RENDERBUFFER {
+		c.fail(gl.INVALID_ENUM)
+		return
+	}
+	fb := c.framebuffers[c.framebuffer]
+	if fb == nil {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	if rb.Value == 0 {
+		delete(fb.attachments, point)
+		return
+	}
+	if r := c.renderbuffers[rb.Value]; r == nil || !r.bound {
+		c.fail(gl.INVALID_OPERATION)
+		return
+	}
+	fb.attachments[point] = attachment{renderbuffer: rb.Value}
+}
+
+// GetFramebufferAttachmentParameteri implements gl.Context.
+func (c *Context) GetFramebufferAttachmentParameteri(target, point, pname gl.Enum) int {
+	defer c.record("GetFramebufferAttachmentParameteri", target, point, pname)()
+	if target != gl.FRAMEBUFFER || !isAttachment(point) {
+		c.fail(gl.INVALID_ENUM)
+		c.result(0)
+		return 0
+	}
+	fb := c.framebuffers[c.framebuffer]
+	if fb == nil {
+		c.fail(gl.INVALID_OPERATION)
+		c.result(0)
+		return 0
+	}
+	a, ok := fb.attachments[point]
+	var v int
+	switch pname {
+	case gl.FRAMEBUFFER_ATTACHMENT_OBJECT_TYPE:
+		switch {
+		case !ok:
+			v = int(gl.NONE)
+		case a.texture != 0:
+			v = int(gl.TEXTURE)
+		default:
+			v = int(gl.RENDERBUFFER)
+		}
+	case gl.FRAMEBUFFER_ATTACHMENT_OBJECT_NAME:
+		v = int(a.texture + a.renderbuffer)
+	case gl.FRAMEBUFFER_ATTACHMENT_TEXTURE_LEVEL:
+		if a.texture == 0 {
+			c.fail(gl.INVALID_ENUM)
+		}
+		v = a.level
+	case gl.FRAMEBUFFER_ATTACHMENT_TEXTURE_CUBE_MAP_FACE:
+		if a.texture == 0 {
+			c.fail(gl.INVALID_ENUM)
+		}
+		if a.face != gl.TEXTURE_2D {
+			v = int(a.face)
+		}
+	default:
+		c.fail(gl.INVALID_ENUM)
+	}
+	c.result(v)
+	return v
+}
+
+// attachmentImage returns the size and format of the image attached at a,
+// and whether it can be rendered to as attachment point at.
+func (c *Context) attachmentImage(at gl.Enum, a attachment) (width, height int, ok bool) {
+	if a.renderbuffer != 0 {
+		rb := c.renderbuffers[a.renderbuffer]
+		if rb == nil {
+			return 0, 0, false
+		}
+		switch at {
+		case gl.COLOR_ATTACHMENT0:
+			ok = rb.format == gl.RGBA4 || rb.format == gl.RGB565 || rb.format == gl.RGB5_A1
+		case gl.DEPTH_ATTACHMENT:
+			ok = rb.format == gl.DEPTH_COMPONENT16
+		case gl.STENCIL_ATTACHMENT:
+			ok = rb.format == gl.STENCIL_INDEX8
+		}
+		return rb.width, rb.height, ok && rb.width > 0 && rb.height > 0
+	}
+	tex := c.textures[a.texture]
+	if tex == nil {
+		return 0, 0, false
+	}
+	m := tex.level(a.face, a.level)
+	if m == nil || m.Width == 0 || m.Height == 0 {
+		return 0, 0, false
+	}
+	// Only RGB and RGBA textures are color-renderable, and ES 2 has no
+	// depth or stencil textures.
+	ok = at == gl.COLOR_ATTACHMENT0 && (m.Format == gl.RGB || m.Format == gl.RGBA)
+	return m.Width, m.Height, ok
+}
+
+func (c *Context) framebufferStatus() gl.Enum {
+	fb := c.framebuffers[c.framebuffer]
+	if fb == nil {
+		return gl.FRAMEBUFFER_COMPLETE
+	}
+	if len(fb.attachments) == 0 {
+		return gl.FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT
+	}
+	w, h := -1, -1
+	status := gl.Enum(gl.FRAMEBUFFER_COMPLETE)
+	for _, at := range []gl.Enum{gl.COLOR_ATTACHMENT0, gl.DEPTH_ATTACHMENT, gl.STENCIL_ATTACHMENT} {
+		a, ok := fb.attachments[at]
+		if !ok {
+			continue
+		}
+		aw, ah, ok := c.attachmentImage(at, a)
+		if !ok {
+			return gl.FRAMEBUFFER_INCOMPLETE_ATTACHMENT
+		}
+		if w >= 0 && (aw != w || ah != h) {
+			status = gl.FRAMEBUFFER_INCOMPLETE_DIMENSIONS
+		}
+		w, h = aw, ah
+	}
+	return status
+}
+
+// CheckFramebufferStatus implements gl.Context.
+func (c *Context) CheckFramebufferStatus(target gl.Enum) gl.Enum {
+	defer c.record("CheckFramebufferStatus", target)()
+	if target != gl.FRAMEBUFFER {
+		c.fail(gl.INVALID_ENUM)
+		c.result(gl.Enum(0))
+		return 0
+	}
+	s := c.framebufferStatus()
+	c.result(s)
+	return s
+}
+
+// checkFramebuffer reports INVALID_FRAMEBUFFER_OPERATION, and returns true,
+// if the bound framebuffer is not complete.
+func (c *Context) checkFramebuffer() bool {
+	if c.framebufferStatus() != gl.FRAMEBUFFER_COMPLETE {
+		c.fail(gl.INVALID_FRAMEBUFFER_OPERATION)
+		return true
+	}
+	return false
+}
+
+// colorImage returns the texture image attached as the color buffer of the
+// bound framebuffer, if it is of type UNSIGNED_BYTE, or nil.
+func (c *Context) colorImage() *Image {
+	fb := c.framebuffers[c.framebuffer]
+	if fb == nil {
+		return nil
+	}
+	a, ok := fb.attachments[gl.COLOR_ATTACHMENT0]
+	if !ok || a.texture == 0 {
+		return nil
+	}
+	m := c.textures[a.texture].level(a.face, a.level)
+	if m == nil || m.Type != gl.UNSIGNED_BYTE {
+		return nil
+	}
+	if m.Pix == nil {
+		bpp, _ := pixelSize(m.Format, m.Type)
+		m.Pix = make([]byte, m.Width*m.Height*bpp)
+	}
+	return m
+}
+
+// readFramebuffer returns the RGBA pixels of the given rectangle of the
+// bound framebuffer. Pixels outside the color image, or of a framebuffer
+// without a texture image, are zero.
+func (c *Context) readFramebuffer(x, y, width, height int) []byte {
+	dst := make([]byte, 4*width*height)
+	m := c.colorImage()
+	if m == nil {
+		return dst
+	}
+	for j := 0; j < height; j++ {
+		for i := 0; i < width; i++ {
+			sx, sy := x+i, y+j
+			if sx < 0 || sy < 0 || sx >= m.Width || sy >= m.Height {
+				continue
+			}
+			p := pixel(m, sy*m.Width+sx)
+			copy(dst[4*(j*width+i):], p[:])
+		}
+	}
+	return dst
+}
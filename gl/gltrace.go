@@ -0,0 +1,3841 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated from interface.go using go generate. DO NOT EDIT.
+// See doc.go for details.
+
+package gl
+
+import "fmt"
+
+var traceNames = []string{
+	"ActiveTexture",
+	"AttachShader",
+	"BindAttribLocation",
+	"BindBuffer",
+	"BindFramebuffer",
+	"BindRenderbuffer",
+	"BindTexture",
+	"BindVertexArray",
+	"BlendColor",
+	"BlendEquation",
+	"BlendEquationSeparate",
+	"BlendFunc",
+	"BlendFuncSeparate",
+	"BufferData",
+	"BufferInit",
+	"BufferSubData",
+	"CheckFramebufferStatus",
+	"Clear",
+	"ClearColor",
+	"ClearDepthf",
+	"ClearStencil",
+	"ColorMask",
+	"CompileShader",
+	"CompressedTexImage2D",
+	"CompressedTexSubImage2D",
+	"CopyTexImage2D",
+	"CopyTexSubImage2D",
+	"CreateBuffer",
+	"CreateFramebuffer",
+	"CreateProgram",
+	"CreateRenderbuffer",
+	"CreateShader",
+	"CreateTexture",
+	"CreateVertexArray",
+	"CullFace",
+	"DeleteBuffer",
+	"DeleteFramebuffer",
+	"DeleteProgram",
+	"DeleteRenderbuffer",
+	"DeleteShader",
+	"DeleteTexture",
+	"DeleteVertexArray",
+	"DepthFunc",
+	"DepthMask",
+	"DepthRangef",
+	"DetachShader",
+	"Disable",
+	"DisableVertexAttribArray",
+	"DrawArrays",
+	"DrawElements",
+	"Enable",
+	"EnableVertexAttribArray",
+	"Finish",
+	"Flush",
+	"FramebufferRenderbuffer",
+	"FramebufferTexture2D",
+	"FrontFace",
+	"GenerateMipmap",
+	"GetActiveAttrib",
+	"GetActiveUniform",
+	"GetAttachedShaders",
+	"GetAttribLocation",
+	"GetBooleanv",
+	"GetFloatv",
+	"GetIntegerv",
+	"GetInteger",
+	"GetBufferParameteri",
+	"GetError",
+	"GetFramebufferAttachmentParameteri",
+	"GetProgrami",
+	"GetProgramInfoLog",
+	"GetRenderbufferParameteri",
+	"GetShaderi",
+	"GetShaderInfoLog",
+	"GetShaderPrecisionFormat",
+	"GetShaderSource",
+	"GetString",
+	"GetTexParameterfv",
+	"GetTexParameteriv",
+	"GetUniformfv",
+	"GetUniformiv",
+	"GetUniformLocation",
+	"GetVertexAttribf",
+	"GetVertexAttribfv",
+	"GetVertexAttribi",
+	"GetVertexAttribiv",
+	"Hint",
+	"IsBuffer",
+	"IsEnabled",
+	"IsFramebuffer",
+	"IsProgram",
+	"IsRenderbuffer",
+	"IsShader",
+	"IsTexture",
+	"LineWidth",
+	"LinkProgram",
+	"PixelStorei",
+	"PolygonOffset",
+	"ReadPixels",
+	"ReleaseShaderCompiler",
+	"RenderbufferStorage",
+	"SampleCoverage",
+	"Scissor",
+	"ShaderSource",
+	"StencilFunc",
+	"StencilFuncSeparate",
+	"StencilMask",
+	"StencilMaskSeparate",
+	"StencilOp",
+	"StencilOpSeparate",
+	"TexImage2D",
+	"TexSubImage2D",
+	"TexParameterf",
+	"TexParameterfv",
+	"TexParameteri",
+	"TexParameteriv",
+	"Uniform1f",
+	"Uniform1fv",
+	"Uniform1i",
+	"Uniform1iv",
+	"Uniform2f",
+	"Uniform2fv",
+	"Uniform2i",
+	"Uniform2iv",
+	"Uniform3f",
+	"Uniform3fv",
+	"Uniform3i",
+	"Uniform3iv",
+	"Uniform4f",
+	"Uniform4fv",
+	"Uniform4i",
+	"Uniform4iv",
+	"UniformMatrix2fv",
+	"UniformMatrix3fv",
+	"UniformMatrix4fv",
+	"UseProgram",
+	"ValidateProgram",
+	"VertexAttrib1f",
+	"VertexAttrib1fv",
+	"VertexAttrib2f",
+	"VertexAttrib2fv",
+	"VertexAttrib3f",
+	"VertexAttrib3fv",
+	"VertexAttrib4f",
+	"VertexAttrib4fv",
+	"VertexAttribPointer",
+	"Viewport",
+	"BeginTransformFeedback",
+	"BindBufferBase",
+	"BindBufferRange",
+	"BindSampler",
+	"BindTransformFeedback",
+	"BlitFramebuffer",
+	"ClearBufferfi",
+	"ClearBufferfv",
+	"ClearBufferiv",
+	"ClearBufferuiv",
+	"ClientWaitSync",
+	"CopyBufferSubData",
+	"CreateSampler",
+	"CreateTransformFeedback",
+	"DeleteSampler",
+	"DeleteSync",
+	"DeleteTransformFeedback",
+	"DrawArraysInstanced",
+	"DrawBuffers",
+	"DrawElementsInstanced",
+	"EndTransformFeedback",
+	"FenceSync",
+	"GetActiveUniformBlocki",
+	"GetActiveUniformBlockName",
+	"GetSynci",
+	"GetUniformBlockIndex",
+	"GetUniformuiv",
+	"PauseTransformFeedback",
+	"ReadBuffer",
+	"ReadPixelsOffset",
+	"RenderbufferStorageMultisample",
+	"ResumeTransformFeedback",
+	"SamplerParameterf",
+	"SamplerParameteri",
+	"TexStorage2D",
+	"TexSubImage2DOffset",
+	"TransformFeedbackVaryings",
+	"Uniform1ui",
+	"Uniform1uiv",
+	"Uniform2ui",
+	"Uniform2uiv",
+	"Uniform3ui",
+	"Uniform3uiv",
+	"Uniform4ui",
+	"Uniform4uiv",
+	"UniformBlockBinding",
+	"UniformMatrix2x3fv",
+	"UniformMatrix2x4fv",
+	"UniformMatrix3x2fv",
+	"UniformMatrix3x4fv",
+	"UniformMatrix4x2fv",
+	"UniformMatrix4x3fv",
+	"VertexAttribDivisor",
+	"VertexAttribIPointer",
+	"WaitSync",
+}
+
+var traceSigs = map[string]*traceSig{
+	"ActiveTexture":                      {args: []traceType{traceEnum}},
+	"AttachShader":                       {args: []traceType{traceProgram, traceShader}},
+	"BindAttribLocation":                 {args: []traceType{traceProgram, traceAttrib, traceString}},
+	"BindBuffer":                         {args: []traceType{traceEnum, traceBuffer}},
+	"BindFramebuffer":                    {args: []traceType{traceEnum, traceFramebuffer}},
+	"BindRenderbuffer":                   {args: []traceType{traceEnum, traceRenderbuffer}},
+	"BindTexture":                        {args: []traceType{traceEnum, traceTexture}},
+	"BindVertexArray":                    {args: []traceType{traceVertexArray}},
+	"BlendColor":                         {args: []traceType{traceFloat32, traceFloat32, traceFloat32, traceFloat32}},
+	"BlendEquation":                      {args: []traceType{traceEnum}},
+	"BlendEquationSeparate":              {args: []traceType{traceEnum, traceEnum}},
+	"BlendFunc":                          {args: []traceType{traceEnum, traceEnum}},
+	"BlendFuncSeparate":                  {args: []traceType{traceEnum, traceEnum, traceEnum, traceEnum}},
+	"BufferData":                         {args: []traceType{traceEnum, traceBytes, traceEnum}},
+	"BufferInit":                         {args: []traceType{traceEnum, traceInt, traceEnum}},
+	"BufferSubData":                      {args: []traceType{traceEnum, traceInt, traceBytes}},
+	"CheckFramebufferStatus":             {args: []traceType{traceEnum}, results: []traceType{traceEnum}},
+	"Clear":                              {args: []traceType{traceEnum}},
+	"ClearColor":                         {args: []traceType{traceFloat32, traceFloat32, traceFloat32, traceFloat32}},
+	"ClearDepthf":                        {args: []traceType{traceFloat32}},
+	"ClearStencil":                       {args: []traceType{traceInt}},
+	"ColorMask":                          {args: []traceType{traceBool, traceBool, traceBool, traceBool}},
+	"CompileShader":                      {args: []traceType{traceShader}},
+	"CompressedTexImage2D":               {args: []traceType{traceEnum, traceInt, traceEnum, traceInt, traceInt, traceInt, traceBytes}},
+	"CompressedTexSubImage2D":            {args: []traceType{traceEnum, traceInt, traceInt, traceInt, traceInt, traceInt, traceEnum, traceBytes}},
+	"CopyTexImage2D":                     {args: []traceType{traceEnum, traceInt, traceEnum, traceInt, traceInt, traceInt, traceInt, traceInt}},
+	"CopyTexSubImage2D":                  {args: []traceType{traceEnum, traceInt, traceInt, traceInt, traceInt, traceInt, traceInt, traceInt}},
+	"CreateBuffer":                       {results: []traceType{traceBuffer}},
+	"CreateFramebuffer":                  {results: []traceType{traceFramebuffer}},
+	"CreateProgram":                      {results: []traceType{traceProgram}},
+	"CreateRenderbuffer":                 {results: []traceType{traceRenderbuffer}},
+	"CreateShader":                       {args: []traceType{traceEnum}, results: []traceType{traceShader}},
+	"CreateTexture":                      {results: []traceType{traceTexture}},
+	"CreateVertexArray":                  {results: []traceType{traceVertexArray}},
+	"CullFace":                           {args: []traceType{traceEnum}},
+	"DeleteBuffer":                       {args: []traceType{traceBuffer}},
+	"DeleteFramebuffer":                  {args: []traceType{traceFramebuffer}},
+	"DeleteProgram":                      {args: []traceType{traceProgram}},
+	"DeleteRenderbuffer":                 {args: []traceType{traceRenderbuffer}},
+	"DeleteShader":                       {args: []traceType{traceShader}},
+	"DeleteTexture":                      {args: []traceType{traceTexture}},
+	"DeleteVertexArray":                  {args: []traceType{traceVertexArray}},
+	"DepthFunc":                          {args: []traceType{traceEnum}},
+	"DepthMask":                          {args: []traceType{traceBool}},
+	"DepthRangef":                        {args: []traceType{traceFloat32, traceFloat32}},
+	"DetachShader":                       {args: []traceType{traceProgram, traceShader}},
+	"Disable":                            {args: []traceType{traceEnum}},
+	"DisableVertexAttribArray":           {args: []traceType{traceAttrib}},
+	"DrawArrays":                         {args: []traceType{traceEnum, traceInt, traceInt}},
+	"DrawElements":                       {args: []traceType{traceEnum, traceInt, traceEnum, traceInt}},
+	"Enable":                             {args: []traceType{traceEnum}},
+	"EnableVertexAttribArray":            {args: []traceType{traceAttrib}},
+	"Finish":                             {},
+	"Flush":                              {},
+	"FramebufferRenderbuffer":            {args: []traceType{traceEnum, traceEnum, traceEnum, traceRenderbuffer}},
+	"FramebufferTexture2D":               {args: []traceType{traceEnum, traceEnum, traceEnum, traceTexture, traceInt}},
+	"FrontFace":                          {args: []traceType{traceEnum}},
+	"GenerateMipmap":                     {args: []traceType{traceEnum}},
+	"GetActiveAttrib":                    {args: []traceType{traceProgram, traceUint32}, results: []traceType{traceString, traceInt, traceEnum}},
+	"GetActiveUniform":                   {args: []traceType{traceProgram, traceUint32}, results: []traceType{traceString, traceInt, traceEnum}},
+	"GetAttachedShaders":                 {args: []traceType{traceProgram}, results: []traceType{traceShaders}},
+	"GetAttribLocation":                  {args: []traceType{traceProgram, traceString}, results: []traceType{traceAttrib}},
+	"GetBooleanv":                        {args: []traceType{traceBools, traceEnum}},
+	"GetFloatv":                          {args: []traceType{traceFloat32s, traceEnum}},
+	"GetIntegerv":                        {args: []traceType{traceInt32s, traceEnum}},
+	"GetInteger":                         {args: []traceType{traceEnum}, results: []traceType{traceInt}},
+	"GetBufferParameteri":                {args: []traceType{traceEnum, traceEnum}, results: []traceType{traceInt}},
+	"GetError":                           {results: []traceType{traceEnum}},
+	"GetFramebufferAttachmentParameteri": {args: []traceType{traceEnum, traceEnum, traceEnum}, results: []traceType{traceInt}},
+	"GetProgrami":                        {args: []traceType{traceProgram, traceEnum}, results: []traceType{traceInt}},
+	"GetProgramInfoLog":                  {args: []traceType{traceProgram}, results: []traceType{traceString}},
+	"GetRenderbufferParameteri":          {args: []traceType{traceEnum, traceEnum}, results: []traceType{traceInt}},
+	"GetShaderi":                         {args: []traceType{traceShader, traceEnum}, results: []traceType{traceInt}},
+	"GetShaderInfoLog":                   {args: []traceType{traceShader}, results: []traceType{traceString}},
+	"GetShaderPrecisionFormat":           {args: []traceType{traceEnum, traceEnum}, results: []traceType{traceInt, traceInt, traceInt}},
+	"GetShaderSource":                    {args: []traceType{traceShader}, results: []traceType{traceString}},
+	"GetString":                          {args: []traceType{traceEnum}, results: []traceType{traceString}},
+	"GetTexParameterfv":                  {args: []traceType{traceFloat32s, traceEnum, traceEnum}},
+	"GetTexParameteriv":                  {args: []traceType{traceInt32s, traceEnum, traceEnum}},
+	"GetUniformfv":                       {args: []traceType{traceFloat32s, traceUniform, traceProgram}},
+	"GetUniformiv":                       {args: []traceType{traceInt32s, traceUniform, traceProgram}},
+	"GetUniformLocation":                 {args: []traceType{traceProgram, traceString}, results: []traceType{traceUniform}},
+	"GetVertexAttribf":                   {args: []traceType{traceAttrib, traceEnum}, results: []traceType{traceFloat32}},
+	"GetVertexAttribfv":                  {args: []traceType{traceFloat32s, traceAttrib, traceEnum}},
+	"GetVertexAttribi":                   {args: []traceType{traceAttrib, traceEnum}, results: []traceType{traceInt32}},
+	"GetVertexAttribiv":                  {args: []traceType{traceInt32s, traceAttrib, traceEnum}},
+	"Hint":                               {args: []traceType{traceEnum, traceEnum}},
+	"IsBuffer":                           {args: []traceType{traceBuffer}, results: []traceType{traceBool}},
+	"IsEnabled":                          {args: []traceType{traceEnum}, results: []traceType{traceBool}},
+	"IsFramebuffer":                      {args: []traceType{traceFramebuffer}, results: []traceType{traceBool}},
+	"IsProgram":                          {args: []traceType{traceProgram}, results: []traceType{traceBool}},
+	"IsRenderbuffer":                     {args: []traceType{traceRenderbuffer}, results: []traceType{traceBool}},
+	"IsShader":                           {args: []traceType{traceShader}, results: []traceType{traceBool}},
+	"IsTexture":                          {args: []traceType{traceTexture}, results: []traceType{traceBool}},
+	"LineWidth":                          {args: []traceType{traceFloat32}},
+	"LinkProgram":                        {args: []traceType{traceProgram}},
+	"PixelStorei":                        {args: []traceType{traceEnum, traceInt32}},
+	"PolygonOffset":                      {args: []traceType{traceFloat32, traceFloat32}},
+	"ReadPixels":                         {args: []traceType{traceBytes, traceInt, traceInt, traceInt, traceInt, traceEnum, traceEnum}},
+	"ReleaseShaderCompiler":              {},
+	"RenderbufferStorage":                {args: []traceType{traceEnum, traceEnum, traceInt, traceInt}},
+	"SampleCoverage":                     {args: []traceType{traceFloat32, traceBool}},
+	"Scissor":                            {args: []traceType{traceInt32, traceInt32, traceInt32, traceInt32}},
+	"ShaderSource":                       {args: []traceType{traceShader, traceString}},
+	"StencilFunc":                        {args: []traceType{traceEnum, traceInt, traceUint32}},
+	"StencilFuncSeparate":                {args: []traceType{traceEnum, traceEnum, traceInt, traceUint32}},
+	"StencilMask":                        {args: []traceType{traceUint32}},
+	"StencilMaskSeparate":                {args: []traceType{traceEnum, traceUint32}},
+	"StencilOp":                          {args: []traceType{traceEnum, traceEnum, traceEnum}},
+	"StencilOpSeparate":                  {args: []traceType{traceEnum, traceEnum, traceEnum, traceEnum}},
+	"TexImage2D":                         {args: []traceType{traceEnum, traceInt, traceInt, traceInt, traceEnum, traceEnum, traceBytes}},
+	"TexSubImage2D":                      {args: []traceType{traceEnum, traceInt, traceInt, traceInt, traceInt, traceInt, traceEnum, traceEnum, traceBytes}},
+	"TexParameterf":                      {args: []traceType{traceEnum, traceEnum, traceFloat32}},
+	"TexParameterfv":                     {args: []traceType{traceEnum, traceEnum, traceFloat32s}},
+	"TexParameteri":                      {args: []traceType{traceEnum, traceEnum, traceInt}},
+	"TexParameteriv":                     {args: []traceType{traceEnum, traceEnum, traceInt32s}},
+	"Uniform1f":                          {args: []traceType{traceUniform, traceFloat32}},
+	"Uniform1fv":                         {args: []traceType{traceUniform, traceFloat32s}},
+	"Uniform1i":                          {args: []traceType{traceUniform, traceInt}},
+	"Uniform1iv":                         {args: []traceType{traceUniform, traceInt32s}},
+	"Uniform2f":                          {args: []traceType{traceUniform, traceFloat32, traceFloat32}},
+	"Uniform2fv":                         {args: []traceType{traceUniform, traceFloat32s}},
+	"Uniform2i":                          {args: []traceType{traceUniform, traceInt, traceInt}},
+	"Uniform2iv":                         {args: []traceType{traceUniform, traceInt32s}},
+	"Uniform3f":                          {args: []traceType{traceUniform, traceFloat32, traceFloat32, traceFloat32}},
+	"Uniform3fv":                         {args: []traceType{traceUniform, traceFloat32s}},
+	"Uniform3i":                          {args: []traceType{traceUniform, traceInt32, traceInt32, traceInt32}},
+	"Uniform3iv":                         {args: []traceType{traceUniform, traceInt32s}},
+	"Uniform4f":                          {args: []traceType{traceUniform, traceFloat32, traceFloat32, traceFloat32, traceFloat32}},
+	"Uniform4fv":                         {args: []traceType{traceUniform, traceFloat32s}},
+	"Uniform4i":                          {args: []traceType{traceUniform, traceInt32, traceInt32, traceInt32, traceInt32}},
+	"Uniform4iv":                         {args: []traceType{traceUniform, traceInt32s}},
+	"UniformMatrix2fv":                   {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix3fv":                   {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix4fv":                   {args: []traceType{traceUniform, traceFloat32s}},
+	"UseProgram":                         {args: []traceType{traceProgram}},
+	"ValidateProgram":                    {args: []traceType{traceProgram}},
+	"VertexAttrib1f":                     {args: []traceType{traceAttrib, traceFloat32}},
+	"VertexAttrib1fv":                    {args: []traceType{traceAttrib, traceFloat32s}},
+	"VertexAttrib2f":                     {args: []traceType{traceAttrib, traceFloat32, traceFloat32}},
+	"VertexAttrib2fv":                    {args: []traceType{traceAttrib, traceFloat32s}},
+	"VertexAttrib3f":                     {args: []traceType{traceAttrib, traceFloat32, traceFloat32, traceFloat32}},
+	"VertexAttrib3fv":                    {args: []traceType{traceAttrib, traceFloat32s}},
+	"VertexAttrib4f":                     {args: []traceType{traceAttrib, traceFloat32, traceFloat32, traceFloat32, traceFloat32}},
+	"VertexAttrib4fv":                    {args: []traceType{traceAttrib, traceFloat32s}},
+	"VertexAttribPointer":                {args: []traceType{traceAttrib, traceInt, traceEnum, traceBool, traceInt, traceInt}},
+	"Viewport":                           {args: []traceType{traceInt, traceInt, traceInt, traceInt}},
+	"BeginTransformFeedback":             {args: []traceType{traceEnum}},
+	"BindBufferBase":                     {args: []traceType{traceEnum, traceUint32, traceBuffer}},
+	"BindBufferRange":                    {args: []traceType{traceEnum, traceUint32, traceBuffer, traceInt, traceInt}},
+	"BindSampler":                        {args: []traceType{traceUint32, traceSampler}},
+	"BindTransformFeedback":              {args: []traceType{traceEnum, traceTransformFeedback}},
+	"BlitFramebuffer":                    {args: []traceType{traceInt, traceInt, traceInt, traceInt, traceInt, traceInt, traceInt, traceInt, traceUint, traceEnum}},
+	"ClearBufferfi":                      {args: []traceType{traceEnum, traceInt, traceFloat32, traceInt}},
+	"ClearBufferfv":                      {args: []traceType{traceEnum, traceInt, traceFloat32s}},
+	"ClearBufferiv":                      {args: []traceType{traceEnum, traceInt, traceInt32s}},
+	"ClearBufferuiv":                     {args: []traceType{traceEnum, traceInt, traceUint32s}},
+	"ClientWaitSync":                     {args: []traceType{traceSync, traceUint32, traceUint64}, results: []traceType{traceEnum}},
+	"CopyBufferSubData":                  {args: []traceType{traceEnum, traceEnum, traceInt, traceInt, traceInt}},
+	"CreateSampler":                      {results: []traceType{traceSampler}},
+	"CreateTransformFeedback":            {results: []traceType{traceTransformFeedback}},
+	"DeleteSampler":                      {args: []traceType{traceSampler}},
+	"DeleteSync":                         {args: []traceType{traceSync}},
+	"DeleteTransformFeedback":            {args: []traceType{traceTransformFeedback}},
+	"DrawArraysInstanced":                {args: []traceType{traceEnum, traceInt, traceInt, traceInt}},
+	"DrawBuffers":                        {args: []traceType{traceEnums}},
+	"DrawElementsInstanced":              {args: []traceType{traceEnum, traceInt, traceEnum, traceInt, traceInt}},
+	"EndTransformFeedback":               {},
+	"FenceSync":                          {args: []traceType{traceEnum, traceUint32}, results: []traceType{traceSync}},
+	"GetActiveUniformBlocki":             {args: []traceType{traceProgram, traceUint32, traceEnum}, results: []traceType{traceInt}},
+	"GetActiveUniformBlockName":          {args: []traceType{traceProgram, traceUint32}, results: []traceType{traceString}},
+	"GetSynci":                           {args: []traceType{traceSync, traceEnum}, results: []traceType{traceInt}},
+	"GetUniformBlockIndex":               {args: []traceType{traceProgram, traceString}, results: []traceType{traceUint32}},
+	"GetUniformuiv":                      {args: []traceType{traceUint32s, traceUniform, traceProgram}},
+	"PauseTransformFeedback":             {},
+	"ReadBuffer":                         {args: []traceType{traceEnum}},
+	"ReadPixelsOffset":                   {args: []traceType{traceInt, traceInt, traceInt, traceInt, traceEnum, traceEnum, traceInt}},
+	"RenderbufferStorageMultisample":     {args: []traceType{traceEnum, traceInt, traceEnum, traceInt, traceInt}},
+	"ResumeTransformFeedback":            {},
+	"SamplerParameterf":                  {args: []traceType{traceSampler, traceEnum, traceFloat32}},
+	"SamplerParameteri":                  {args: []traceType{traceSampler, traceEnum, traceInt}},
+	"TexStorage2D":                       {args: []traceType{traceEnum, traceInt, traceEnum, traceInt, traceInt}},
+	"TexSubImage2DOffset":                {args: []traceType{traceEnum, traceInt, traceInt, traceInt, traceInt, traceInt, traceEnum, traceEnum, traceInt}},
+	"TransformFeedbackVaryings":          {args: []traceType{traceProgram, traceStrings, traceEnum}},
+	"Uniform1ui":                         {args: []traceType{traceUniform, traceUint32}},
+	"Uniform1uiv":                        {args: []traceType{traceUniform, traceUint32s}},
+	"Uniform2ui":                         {args: []traceType{traceUniform, traceUint32, traceUint32}},
+	"Uniform2uiv":                        {args: []traceType{traceUniform, traceUint32s}},
+	"Uniform3ui":                         {args: []traceType{traceUniform, traceUint32, traceUint32, traceUint32}},
+	"Uniform3uiv":                        {args: []traceType{traceUniform, traceUint32s}},
+	"Uniform4ui":                         {args: []traceType{traceUniform, traceUint32, traceUint32, traceUint32, traceUint32}},
+	"Uniform4uiv":                        {args: []traceType{traceUniform, traceUint32s}},
+	"UniformBlockBinding":                {args: []traceType{traceProgram, traceUint32, traceUint32}},
+	"UniformMatrix2x3fv":                 {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix2x4fv":                 {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix3x2fv":                 {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix3x4fv":                 {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix4x2fv":                 {args: []traceType{traceUniform, traceFloat32s}},
+	"UniformMatrix4x3fv":                 {args: []traceType{traceUniform, traceFloat32s}},
+	"VertexAttribDivisor":                {args: []traceType{traceAttrib, traceInt}},
+	"VertexAttribIPointer":               {args: []traceType{traceAttrib, traceInt, traceEnum, traceInt, traceInt}},
+	"WaitSync":                           {args: []traceType{traceSync, traceUint32, traceUint64}},
+}
+
+var traceEnumNames = map[Enum]string{
+	0x0:        "0",
+	0x1:        "1",
+	0x2:        "2",
+	0x3:        "LINE_STRIP",
+	0x4:        "4",
+	0x5:        "TRIANGLE_STRIP",
+	0x6:        "TRIANGLE_FAN",
+	0x300:      "SRC_COLOR",
+	0x301:      "ONE_MINUS_SRC_COLOR",
+	0x302:      "SRC_ALPHA",
+	0x303:      "ONE_MINUS_SRC_ALPHA",
+	0x304:      "DST_ALPHA",
+	0x305:      "ONE_MINUS_DST_ALPHA",
+	0x306:      "DST_COLOR",
+	0x307:      "ONE_MINUS_DST_COLOR",
+	0x308:      "SRC_ALPHA_SATURATE",
+	0x8006:     "FUNC_ADD",
+	0x8009:     "32777",
+	0x883d:     "BLEND_EQUATION_ALPHA",
+	0x800a:     "FUNC_SUBTRACT",
+	0x800b:     "FUNC_REVERSE_SUBTRACT",
+	0x80c8:     "BLEND_DST_RGB",
+	0x80c9:     "BLEND_SRC_RGB",
+	0x80ca:     "BLEND_DST_ALPHA",
+	0x80cb:     "BLEND_SRC_ALPHA",
+	0x8001:     "CONSTANT_COLOR",
+	0x8002:     "ONE_MINUS_CONSTANT_COLOR",
+	0x8003:     "CONSTANT_ALPHA",
+	0x8004:     "ONE_MINUS_CONSTANT_ALPHA",
+	0x8005:     "BLEND_COLOR",
+	0x8892:     "ARRAY_BUFFER",
+	0x8893:     "ELEMENT_ARRAY_BUFFER",
+	0x8894:     "ARRAY_BUFFER_BINDING",
+	0x8895:     "ELEMENT_ARRAY_BUFFER_BINDING",
+	0x88e0:     "STREAM_DRAW",
+	0x88e4:     "STATIC_DRAW",
+	0x88e8:     "DYNAMIC_DRAW",
+	0x8764:     "BUFFER_SIZE",
+	0x8765:     "BUFFER_USAGE",
+	0x8626:     "CURRENT_VERTEX_ATTRIB",
+	0x404:      "FRONT",
+	0x405:      "BACK",
+	0x408:      "FRONT_AND_BACK",
+	0xde1:      "TEXTURE_2D",
+	0xb44:      "CULL_FACE",
+	0xbe2:      "BLEND",
+	0xbd0:      "DITHER",
+	0xb90:      "STENCIL_TEST",
+	0xb71:      "DEPTH_TEST",
+	0xc11:      "SCISSOR_TEST",
+	0x8037:     "POLYGON_OFFSET_FILL",
+	0x809e:     "SAMPLE_ALPHA_TO_COVERAGE",
+	0x80a0:     "SAMPLE_COVERAGE",
+	0x500:      "INVALID_ENUM",
+	0x501:      "INVALID_VALUE",
+	0x502:      "INVALID_OPERATION",
+	0x505:      "OUT_OF_MEMORY",
+	0x900:      "CW",
+	0x901:      "CCW",
+	0xb21:      "LINE_WIDTH",
+	0x846d:     "ALIASED_POINT_SIZE_RANGE",
+	0x846e:     "ALIASED_LINE_WIDTH_RANGE",
+	0xb45:      "CULL_FACE_MODE",
+	0xb46:      "FRONT_FACE",
+	0xb70:      "DEPTH_RANGE",
+	0xb72:      "DEPTH_WRITEMASK",
+	0xb73:      "DEPTH_CLEAR_VALUE",
+	0xb74:      "DEPTH_FUNC",
+	0xb91:      "STENCIL_CLEAR_VALUE",
+	0xb92:      "STENCIL_FUNC",
+	0xb94:      "STENCIL_FAIL",
+	0xb95:      "STENCIL_PASS_DEPTH_FAIL",
+	0xb96:      "STENCIL_PASS_DEPTH_PASS",
+	0xb97:      "STENCIL_REF",
+	0xb93:      "STENCIL_VALUE_MASK",
+	0xb98:      "STENCIL_WRITEMASK",
+	0x8800:     "STENCIL_BACK_FUNC",
+	0x8801:     "STENCIL_BACK_FAIL",
+	0x8802:     "STENCIL_BACK_PASS_DEPTH_FAIL",
+	0x8803:     "STENCIL_BACK_PASS_DEPTH_PASS",
+	0x8ca3:     "STENCIL_BACK_REF",
+	0x8ca4:     "STENCIL_BACK_VALUE_MASK",
+	0x8ca5:     "STENCIL_BACK_WRITEMASK",
+	0xba2:      "VIEWPORT",
+	0xc10:      "SCISSOR_BOX",
+	0xc22:      "COLOR_CLEAR_VALUE",
+	0xc23:      "COLOR_WRITEMASK",
+	0xcf5:      "UNPACK_ALIGNMENT",
+	0xd05:      "PACK_ALIGNMENT",
+	0xd33:      "MAX_TEXTURE_SIZE",
+	0xd3a:      "MAX_VIEWPORT_DIMS",
+	0xd50:      "SUBPIXEL_BITS",
+	0xd52:      "RED_BITS",
+	0xd53:      "GREEN_BITS",
+	0xd54:      "BLUE_BITS",
+	0xd55:      "ALPHA_BITS",
+	0xd56:      "DEPTH_BITS",
+	0xd57:      "STENCIL_BITS",
+	0x2a00:     "POLYGON_OFFSET_UNITS",
+	0x8038:     "POLYGON_OFFSET_FACTOR",
+	0x8069:     "TEXTURE_BINDING_2D",
+	0x80a8:     "SAMPLE_BUFFERS",
+	0x80a9:     "SAMPLES",
+	0x80aa:     "SAMPLE_COVERAGE_VALUE",
+	0x80ab:     "SAMPLE_COVERAGE_INVERT",
+	0x86a2:     "NUM_COMPRESSED_TEXTURE_FORMATS",
+	0x86a3:     "COMPRESSED_TEXTURE_FORMATS",
+	0x1100:     "DONT_CARE",
+	0x1101:     "FASTEST",
+	0x1102:     "NICEST",
+	0x8192:     "GENERATE_MIPMAP_HINT",
+	0x1400:     "BYTE",
+	0x1401:     "UNSIGNED_BYTE",
+	0x1402:     "SHORT",
+	0x1403:     "UNSIGNED_SHORT",
+	0x1404:     "INT",
+	0x1405:     "UNSIGNED_INT",
+	0x1406:     "FLOAT",
+	0x140c:     "FIXED",
+	0x1902:     "DEPTH_COMPONENT",
+	0x1906:     "ALPHA",
+	0x1907:     "RGB",
+	0x1908:     "RGBA",
+	0x1909:     "LUMINANCE",
+	0x190a:     "LUMINANCE_ALPHA",
+	0x8033:     "UNSIGNED_SHORT_4_4_4_4",
+	0x8034:     "UNSIGNED_SHORT_5_5_5_1",
+	0x8363:     "UNSIGNED_SHORT_5_6_5",
+	0x8869:     "MAX_VERTEX_ATTRIBS",
+	0x8dfb:     "MAX_VERTEX_UNIFORM_VECTORS",
+	0x8dfc:     "MAX_VARYING_VECTORS",
+	0x8b4d:     "MAX_COMBINED_TEXTURE_IMAGE_UNITS",
+	0x8b4c:     "MAX_VERTEX_TEXTURE_IMAGE_UNITS",
+	0x8872:     "MAX_TEXTURE_IMAGE_UNITS",
+	0x8dfd:     "MAX_FRAGMENT_UNIFORM_VECTORS",
+	0x8b4f:     "SHADER_TYPE",
+	0x8b80:     "DELETE_STATUS",
+	0x8b82:     "LINK_STATUS",
+	0x8b83:     "VALIDATE_STATUS",
+	0x8b85:     "ATTACHED_SHADERS",
+	0x8b86:     "ACTIVE_UNIFORMS",
+	0x8b87:     "ACTIVE_UNIFORM_MAX_LENGTH",
+	0x8b89:     "ACTIVE_ATTRIBUTES",
+	0x8b8a:     "ACTIVE_ATTRIBUTE_MAX_LENGTH",
+	0x8b8c:     "SHADING_LANGUAGE_VERSION",
+	0x8b8d:     "CURRENT_PROGRAM",
+	0x200:      "NEVER",
+	0x201:      "LESS",
+	0x202:      "EQUAL",
+	0x203:      "LEQUAL",
+	0x204:      "GREATER",
+	0x205:      "NOTEQUAL",
+	0x206:      "GEQUAL",
+	0x207:      "ALWAYS",
+	0x1e00:     "KEEP",
+	0x1e01:     "REPLACE",
+	0x1e02:     "INCR",
+	0x1e03:     "DECR",
+	0x150a:     "INVERT",
+	0x8507:     "INCR_WRAP",
+	0x8508:     "DECR_WRAP",
+	0x1f00:     "VENDOR",
+	0x1f01:     "RENDERER",
+	0x1f02:     "VERSION",
+	0x1f03:     "EXTENSIONS",
+	0x2600:     "NEAREST",
+	0x2601:     "LINEAR",
+	0x2700:     "NEAREST_MIPMAP_NEAREST",
+	0x2701:     "LINEAR_MIPMAP_NEAREST",
+	0x2702:     "NEAREST_MIPMAP_LINEAR",
+	0x2703:     "LINEAR_MIPMAP_LINEAR",
+	0x2800:     "TEXTURE_MAG_FILTER",
+	0x2801:     "TEXTURE_MIN_FILTER",
+	0x2802:     "TEXTURE_WRAP_S",
+	0x2803:     "TEXTURE_WRAP_T",
+	0x1702:     "TEXTURE",
+	0x8513:     "TEXTURE_CUBE_MAP",
+	0x8514:     "TEXTURE_BINDING_CUBE_MAP",
+	0x8515:     "TEXTURE_CUBE_MAP_POSITIVE_X",
+	0x8516:     "TEXTURE_CUBE_MAP_NEGATIVE_X",
+	0x8517:     "TEXTURE_CUBE_MAP_POSITIVE_Y",
+	0x8518:     "TEXTURE_CUBE_MAP_NEGATIVE_Y",
+	0x8519:     "TEXTURE_CUBE_MAP_POSITIVE_Z",
+	0x851a:     "TEXTURE_CUBE_MAP_NEGATIVE_Z",
+	0x851c:     "MAX_CUBE_MAP_TEXTURE_SIZE",
+	0x84c0:     "TEXTURE0",
+	0x84c1:     "TEXTURE1",
+	0x84c2:     "TEXTURE2",
+	0x84c3:     "TEXTURE3",
+	0x84c4:     "TEXTURE4",
+	0x84c5:     "TEXTURE5",
+	0x84c6:     "TEXTURE6",
+	0x84c7:     "TEXTURE7",
+	0x84c8:     "TEXTURE8",
+	0x84c9:     "TEXTURE9",
+	0x84ca:     "TEXTURE10",
+	0x84cb:     "TEXTURE11",
+	0x84cc:     "TEXTURE12",
+	0x84cd:     "TEXTURE13",
+	0x84ce:     "TEXTURE14",
+	0x84cf:     "TEXTURE15",
+	0x84d0:     "TEXTURE16",
+	0x84d1:     "TEXTURE17",
+	0x84d2:     "TEXTURE18",
+	0x84d3:     "TEXTURE19",
+	0x84d4:     "TEXTURE20",
+	0x84d5:     "TEXTURE21",
+	0x84d6:     "TEXTURE22",
+	0x84d7:     "TEXTURE23",
+	0x84d8:     "TEXTURE24",
+	0x84d9:     "TEXTURE25",
+	0x84da:     "TEXTURE26",
+	0x84db:     "TEXTURE27",
+	0x84dc:     "TEXTURE28",
+	0x84dd:     "TEXTURE29",
+	0x84de:     "TEXTURE30",
+	0x84df:     "TEXTURE31",
+	0x84e0:     "ACTIVE_TEXTURE",
+	0x2901:     "REPEAT",
+	0x812f:     "CLAMP_TO_EDGE",
+	0x8370:     "MIRRORED_REPEAT",
+	0x8622:     "VERTEX_ATTRIB_ARRAY_ENABLED",
+	0x8623:     "VERTEX_ATTRIB_ARRAY_SIZE",
+	0x8624:     "VERTEX_ATTRIB_ARRAY_STRIDE",
+	0x8625:     "VERTEX_ATTRIB_ARRAY_TYPE",
+	0x886a:     "VERTEX_ATTRIB_ARRAY_NORMALIZED",
+	0x8645:     "VERTEX_ATTRIB_ARRAY_POINTER",
+	0x889f:     "VERTEX_ATTRIB_ARRAY_BUFFER_BINDING",
+	0x8b9a:     "IMPLEMENTATION_COLOR_READ_TYPE",
+	0x8b9b:     "IMPLEMENTATION_COLOR_READ_FORMAT",
+	0x8b81:     "COMPILE_STATUS",
+	0x8b84:     "INFO_LOG_LENGTH",
+	0x8b88:     "SHADER_SOURCE_LENGTH",
+	0x8dfa:     "SHADER_COMPILER",
+	0x8df8:     "SHADER_BINARY_FORMATS",
+	0x8df9:     "NUM_SHADER_BINARY_FORMATS",
+	0x8df0:     "LOW_FLOAT",
+	0x8df1:     "MEDIUM_FLOAT",
+	0x8df2:     "HIGH_FLOAT",
+	0x8df3:     "LOW_INT",
+	0x8df4:     "MEDIUM_INT",
+	0x8df5:     "HIGH_INT",
+	0x8d40:     "FRAMEBUFFER",
+	0x8d41:     "RENDERBUFFER",
+	0x8056:     "RGBA4",
+	0x8057:     "RGB5_A1",
+	0x8d62:     "RGB565",
+	0x81a5:     "DEPTH_COMPONENT16",
+	0x8d48:     "STENCIL_INDEX8",
+	0x8d42:     "RENDERBUFFER_WIDTH",
+	0x8d43:     "RENDERBUFFER_HEIGHT",
+	0x8d44:     "RENDERBUFFER_INTERNAL_FORMAT",
+	0x8d50:     "RENDERBUFFER_RED_SIZE",
+	0x8d51:     "RENDERBUFFER_GREEN_SIZE",
+	0x8d52:     "RENDERBUFFER_BLUE_SIZE",
+	0x8d53:     "RENDERBUFFER_ALPHA_SIZE",
+	0x8d54:     "RENDERBUFFER_DEPTH_SIZE",
+	0x8d55:     "RENDERBUFFER_STENCIL_SIZE",
+	0x8cd0:     "FRAMEBUFFER_ATTACHMENT_OBJECT_TYPE",
+	0x8cd1:     "FRAMEBUFFER_ATTACHMENT_OBJECT_NAME",
+	0x8cd2:     "FRAMEBUFFER_ATTACHMENT_TEXTURE_LEVEL",
+	0x8cd3:     "FRAMEBUFFER_ATTACHMENT_TEXTURE_CUBE_MAP_FACE",
+	0x8ce0:     "COLOR_ATTACHMENT0",
+	0x8d00:     "DEPTH_ATTACHMENT",
+	0x8d20:     "STENCIL_ATTACHMENT",
+	0x8cd5:     "FRAMEBUFFER_COMPLETE",
+	0x8cd6:     "FRAMEBUFFER_INCOMPLETE_ATTACHMENT",
+	0x8cd7:     "FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT",
+	0x8cd9:     "FRAMEBUFFER_INCOMPLETE_DIMENSIONS",
+	0x8cdd:     "FRAMEBUFFER_UNSUPPORTED",
+	0x8ca6:     "36006",
+	0x8ca7:     "RENDERBUFFER_BINDING",
+	0x84e8:     "MAX_RENDERBUFFER_SIZE",
+	0x506:      "INVALID_FRAMEBUFFER_OPERATION",
+	0x100:      "DEPTH_BUFFER_BIT",
+	0x400:      "STENCIL_BUFFER_BIT",
+	0x4000:     "COLOR_BUFFER_BIT",
+	0x8b50:     "FLOAT_VEC2",
+	0x8b51:     "FLOAT_VEC3",
+	0x8b52:     "FLOAT_VEC4",
+	0x8b53:     "INT_VEC2",
+	0x8b54:     "INT_VEC3",
+	0x8b55:     "INT_VEC4",
+	0x8b56:     "BOOL",
+	0x8b57:     "BOOL_VEC2",
+	0x8b58:     "BOOL_VEC3",
+	0x8b59:     "BOOL_VEC4",
+	0x8b5a:     "FLOAT_MAT2",
+	0x8b5b:     "FLOAT_MAT3",
+	0x8b5c:     "FLOAT_MAT4",
+	0x8b5e:     "SAMPLER_2D",
+	0x8b60:     "SAMPLER_CUBE",
+	0x8b30:     "FRAGMENT_SHADER",
+	0x8b31:     "VERTEX_SHADER",
+	0x8a35:     "ACTIVE_UNIFORM_BLOCK_MAX_NAME_LENGTH",
+	0x8a36:     "ACTIVE_UNIFORM_BLOCKS",
+	0x911a:     "ALREADY_SIGNALED",
+	0x8c2f:     "ANY_SAMPLES_PASSED",
+	0x8d6a:     "ANY_SAMPLES_PASSED_CONSERVATIVE",
+	0x1905:     "BLUE",
+	0x911f:     "BUFFER_ACCESS_FLAGS",
+	0x9120:     "BUFFER_MAP_LENGTH",
+	0x9121:     "BUFFER_MAP_OFFSET",
+	0x88bc:     "BUFFER_MAPPED",
+	0x88bd:     "BUFFER_MAP_POINTER",
+	0x1800:     "COLOR",
+	0x8cea:     "COLOR_ATTACHMENT10",
+	0x8ce1:     "COLOR_ATTACHMENT1",
+	0x8ceb:     "COLOR_ATTACHMENT11",
+	0x8cec:     "COLOR_ATTACHMENT12",
+	0x8ced:     "COLOR_ATTACHMENT13",
+	0x8cee:     "COLOR_ATTACHMENT14",
+	0x8cef:     "COLOR_ATTACHMENT15",
+	0x8ce2:     "COLOR_ATTACHMENT2",
+	0x8ce3:     "COLOR_ATTACHMENT3",
+	0x8ce4:     "COLOR_ATTACHMENT4",
+	0x8ce5:     "COLOR_ATTACHMENT5",
+	0x8ce6:     "COLOR_ATTACHMENT6",
+	0x8ce7:     "COLOR_ATTACHMENT7",
+	0x8ce8:     "COLOR_ATTACHMENT8",
+	0x8ce9:     "COLOR_ATTACHMENT9",
+	0x884e:     "COMPARE_REF_TO_TEXTURE",
+	0x9270:     "COMPRESSED_R11_EAC",
+	0x9272:     "COMPRESSED_RG11_EAC",
+	0x9274:     "COMPRESSED_RGB8_ETC2",
+	0x9276:     "COMPRESSED_RGB8_PUNCHTHROUGH_ALPHA1_ETC2",
+	0x9278:     "COMPRESSED_RGBA8_ETC2_EAC",
+	0x9271:     "COMPRESSED_SIGNED_R11_EAC",
+	0x9273:     "COMPRESSED_SIGNED_RG11_EAC",
+	0x9279:     "COMPRESSED_SRGB8_ALPHA8_ETC2_EAC",
+	0x9275:     "COMPRESSED_SRGB8_ETC2",
+	0x9277:     "COMPRESSED_SRGB8_PUNCHTHROUGH_ALPHA1_ETC2",
+	0x911c:     "CONDITION_SATISFIED",
+	0x8f36:     "36662",
+	0x8f37:     "36663",
+	0x8865:     "CURRENT_QUERY",
+	0x1801:     "DEPTH",
+	0x88f0:     "DEPTH24_STENCIL8",
+	0x8cad:     "DEPTH32F_STENCIL8",
+	0x81a6:     "DEPTH_COMPONENT24",
+	0x8cac:     "DEPTH_COMPONENT32F",
+	0x84f9:     "DEPTH_STENCIL",
+	0x821a:     "DEPTH_STENCIL_ATTACHMENT",
+	0x8825:     "DRAW_BUFFER0",
+	0x882f:     "DRAW_BUFFER10",
+	0x8826:     "DRAW_BUFFER1",
+	0x8830:     "DRAW_BUFFER11",
+	0x8831:     "DRAW_BUFFER12",
+	0x8832:     "DRAW_BUFFER13",
+	0x8833:     "DRAW_BUFFER14",
+	0x8834:     "DRAW_BUFFER15",
+	0x8827:     "DRAW_BUFFER2",
+	0x8828:     "DRAW_BUFFER3",
+	0x8829:     "DRAW_BUFFER4",
+	0x882a:     "DRAW_BUFFER5",
+	0x882b:     "DRAW_BUFFER6",
+	0x882c:     "DRAW_BUFFER7",
+	0x882d:     "DRAW_BUFFER8",
+	0x882e:     "DRAW_BUFFER9",
+	0x8ca9:     "DRAW_FRAMEBUFFER",
+	0x88ea:     "DYNAMIC_COPY",
+	0x88e9:     "DYNAMIC_READ",
+	0x8dad:     "FLOAT_32_UNSIGNED_INT_24_8_REV",
+	0x8b65:     "FLOAT_MAT2x3",
+	0x8b66:     "FLOAT_MAT2x4",
+	0x8b67:     "FLOAT_MAT3x2",
+	0x8b68:     "FLOAT_MAT3x4",
+	0x8b69:     "FLOAT_MAT4x2",
+	0x8b6a:     "FLOAT_MAT4x3",
+	0x8b8b:     "FRAGMENT_SHADER_DERIVATIVE_HINT",
+	0x8215:     "FRAMEBUFFER_ATTACHMENT_ALPHA_SIZE",
+	0x8214:     "FRAMEBUFFER_ATTACHMENT_BLUE_SIZE",
+	0x8210:     "FRAMEBUFFER_ATTACHMENT_COLOR_ENCODING",
+	0x8211:     "FRAMEBUFFER_ATTACHMENT_COMPONENT_TYPE",
+	0x8216:     "FRAMEBUFFER_ATTACHMENT_DEPTH_SIZE",
+	0x8213:     "FRAMEBUFFER_ATTACHMENT_GREEN_SIZE",
+	0x8212:     "FRAMEBUFFER_ATTACHMENT_RED_SIZE",
+	0x8217:     "FRAMEBUFFER_ATTACHMENT_STENCIL_SIZE",
+	0x8cd4:     "FRAMEBUFFER_ATTACHMENT_TEXTURE_LAYER",
+	0x8218:     "FRAMEBUFFER_DEFAULT",
+	0x8d56:     "FRAMEBUFFER_INCOMPLETE_MULTISAMPLE",
+	0x8219:     "FRAMEBUFFER_UNDEFINED",
+	0x1904:     "GREEN",
+	0x140b:     "HALF_FLOAT",
+	0x8d9f:     "INT_2_10_10_10_REV",
+	0x8c8c:     "INTERLEAVED_ATTRIBS",
+	0x8dca:     "INT_SAMPLER_2D",
+	0x8dcf:     "INT_SAMPLER_2D_ARRAY",
+	0x8dcb:     "INT_SAMPLER_3D",
+	0x8dcc:     "INT_SAMPLER_CUBE",
+	0xffffffff: "INVALID_INDEX",
+	0x821b:     "MAJOR_VERSION",
+	0x10:       "MAP_FLUSH_EXPLICIT_BIT",
+	0x8:        "MAP_INVALIDATE_BUFFER_BIT",
+	0x20:       "MAP_UNSYNCHRONIZED_BIT",
+	0x8008:     "MAX",
+	0x8073:     "MAX_3D_TEXTURE_SIZE",
+	0x88ff:     "MAX_ARRAY_TEXTURE_LAYERS",
+	0x8cdf:     "MAX_COLOR_ATTACHMENTS",
+	0x8a33:     "MAX_COMBINED_FRAGMENT_UNIFORM_COMPONENTS",
+	0x8a2e:     "MAX_COMBINED_UNIFORM_BLOCKS",
+	0x8a31:     "MAX_COMBINED_VERTEX_UNIFORM_COMPONENTS",
+	0x8824:     "MAX_DRAW_BUFFERS",
+	0x8d6b:     "MAX_ELEMENT_INDEX",
+	0x80e9:     "MAX_ELEMENTS_INDICES",
+	0x80e8:     "MAX_ELEMENTS_VERTICES",
+	0x9125:     "MAX_FRAGMENT_INPUT_COMPONENTS",
+	0x8a2d:     "MAX_FRAGMENT_UNIFORM_BLOCKS",
+	0x8b49:     "MAX_FRAGMENT_UNIFORM_COMPONENTS",
+	0x8905:     "MAX_PROGRAM_TEXEL_OFFSET",
+	0x8d57:     "MAX_SAMPLES",
+	0x9111:     "MAX_SERVER_WAIT_TIMEOUT",
+	0x84fd:     "MAX_TEXTURE_LOD_BIAS",
+	0x8c8a:     "MAX_TRANSFORM_FEEDBACK_INTERLEAVED_COMPONENTS",
+	0x8c8b:     "MAX_TRANSFORM_FEEDBACK_SEPARATE_ATTRIBS",
+	0x8c80:     "MAX_TRANSFORM_FEEDBACK_SEPARATE_COMPONENTS",
+	0x8a30:     "MAX_UNIFORM_BLOCK_SIZE",
+	0x8a2f:     "MAX_UNIFORM_BUFFER_BINDINGS",
+	0x8b4b:     "MAX_VARYING_COMPONENTS",
+	0x9122:     "MAX_VERTEX_OUTPUT_COMPONENTS",
+	0x8a2b:     "MAX_VERTEX_UNIFORM_BLOCKS",
+	0x8b4a:     "MAX_VERTEX_UNIFORM_COMPONENTS",
+	0x8007:     "MIN",
+	0x821c:     "MINOR_VERSION",
+	0x8904:     "MIN_PROGRAM_TEXEL_OFFSET",
+	0x821d:     "NUM_EXTENSIONS",
+	0x87fe:     "NUM_PROGRAM_BINARY_FORMATS",
+	0x9380:     "NUM_SAMPLE_COUNTS",
+	0x9112:     "OBJECT_TYPE",
+	0xd02:      "PACK_ROW_LENGTH",
+	0xd04:      "PACK_SKIP_PIXELS",
+	0xd03:      "PACK_SKIP_ROWS",
+	0x88eb:     "PIXEL_PACK_BUFFER",
+	0x88ed:     "PIXEL_PACK_BUFFER_BINDING",
+	0x88ec:     "PIXEL_UNPACK_BUFFER",
+	0x88ef:     "PIXEL_UNPACK_BUFFER_BINDING",
+	0x8d69:     "PRIMITIVE_RESTART_FIXED_INDEX",
+	0x87ff:     "PROGRAM_BINARY_FORMATS",
+	0x8741:     "PROGRAM_BINARY_LENGTH",
+	0x8257:     "PROGRAM_BINARY_RETRIEVABLE_HINT",
+	0x8866:     "QUERY_RESULT",
+	0x8867:     "QUERY_RESULT_AVAILABLE",
+	0x8c3a:     "R11F_G11F_B10F",
+	0x822d:     "R16F",
+	0x8233:     "R16I",
+	0x8234:     "R16UI",
+	0x822e:     "R32F",
+	0x8235:     "R32I",
+	0x8236:     "R32UI",
+	0x8229:     "R8",
+	0x8231:     "R8I",
+	0x8f94:     "R8_SNORM",
+	0x8232:     "R8UI",
+	0x8c89:     "RASTERIZER_DISCARD",
+	0xc02:      "READ_BUFFER",
+	0x8ca8:     "READ_FRAMEBUFFER",
+	0x8caa:     "READ_FRAMEBUFFER_BINDING",
+	0x1903:     "RED",
+	0x8d94:     "RED_INTEGER",
+	0x8cab:     "RENDERBUFFER_SAMPLES",
+	0x8227:     "RG",
+	0x822f:     "RG16F",
+	0x8239:     "RG16I",
+	0x823a:     "RG16UI",
+	0x8230:     "RG32F",
+	0x823b:     "RG32I",
+	0x823c:     "RG32UI",
+	0x822b:     "RG8",
+	0x8237:     "RG8I",
+	0x8f95:     "RG8_SNORM",
+	0x8238:     "RG8UI",
+	0x8059:     "RGB10_A2",
+	0x906f:     "RGB10_A2UI",
+	0x881b:     "RGB16F",
+	0x8d89:     "RGB16I",
+	0x8d77:     "RGB16UI",
+	0x8815:     "RGB32F",
+	0x8d83:     "RGB32I",
+	0x8d71:     "RGB32UI",
+	0x8051:     "RGB8",
+	0x8d8f:     "RGB8I",
+	0x8f96:     "RGB8_SNORM",
+	0x8d7d:     "RGB8UI",
+	0x8c3d:     "RGB9_E5",
+	0x881a:     "RGBA16F",
+	0x8d88:     "RGBA16I",
+	0x8d76:     "RGBA16UI",
+	0x8814:     "RGBA32F",
+	0x8d82:     "RGBA32I",
+	0x8d70:     "RGBA32UI",
+	0x8058:     "RGBA8",
+	0x8d8e:     "RGBA8I",
+	0x8f97:     "RGBA8_SNORM",
+	0x8d7c:     "RGBA8UI",
+	0x8d99:     "RGBA_INTEGER",
+	0x8d98:     "RGB_INTEGER",
+	0x8228:     "RG_INTEGER",
+	0x8dc1:     "SAMPLER_2D_ARRAY",
+	0x8dc4:     "SAMPLER_2D_ARRAY_SHADOW",
+	0x8b62:     "SAMPLER_2D_SHADOW",
+	0x8b5f:     "SAMPLER_3D",
+	0x8919:     "SAMPLER_BINDING",
+	0x8dc5:     "SAMPLER_CUBE_SHADOW",
+	0x8c8d:     "SEPARATE_ATTRIBS",
+	0x9119:     "SIGNALED",
+	0x8f9c:     "SIGNED_NORMALIZED",
+	0x8c40:     "SRGB",
+	0x8c41:     "SRGB8",
+	0x8c43:     "SRGB8_ALPHA8",
+	0x88e6:     "STATIC_COPY",
+	0x88e5:     "STATIC_READ",
+	0x1802:     "STENCIL",
+	0x88e2:     "STREAM_COPY",
+	0x88e1:     "STREAM_READ",
+	0x9113:     "SYNC_CONDITION",
+	0x9116:     "SYNC_FENCE",
+	0x9115:     "SYNC_FLAGS",
+	0x9117:     "SYNC_GPU_COMMANDS_COMPLETE",
+	0x9114:     "SYNC_STATUS",
+	0x8c1a:     "TEXTURE_2D_ARRAY",
+	0x806f:     "TEXTURE_3D",
+	0x813c:     "TEXTURE_BASE_LEVEL",
+	0x8c1d:     "TEXTURE_BINDING_2D_ARRAY",
+	0x806a:     "TEXTURE_BINDING_3D",
+	0x884d:     "TEXTURE_COMPARE_FUNC",
+	0x884c:     "TEXTURE_COMPARE_MODE",
+	0x912f:     "TEXTURE_IMMUTABLE_FORMAT",
+	0x82df:     "TEXTURE_IMMUTABLE_LEVELS",
+	0x813d:     "TEXTURE_MAX_LEVEL",
+	0x813b:     "TEXTURE_MAX_LOD",
+	0x813a:     "TEXTURE_MIN_LOD",
+	0x8e45:     "TEXTURE_SWIZZLE_A",
+	0x8e44:     "TEXTURE_SWIZZLE_B",
+	0x8e43:     "TEXTURE_SWIZZLE_G",
+	0x8e42:     "TEXTURE_SWIZZLE_R",
+	0x8072:     "TEXTURE_WRAP_R",
+	0x911b:     "TIMEOUT_EXPIRED",
+	0x8e22:     "TRANSFORM_FEEDBACK",
+	0x8e24:     "TRANSFORM_FEEDBACK_ACTIVE",
+	0x8e25:     "TRANSFORM_FEEDBACK_BINDING",
+	0x8c8e:     "TRANSFORM_FEEDBACK_BUFFER",
+	0x8c8f:     "TRANSFORM_FEEDBACK_BUFFER_BINDING",
+	0x8c7f:     "TRANSFORM_FEEDBACK_BUFFER_MODE",
+	0x8c85:     "TRANSFORM_FEEDBACK_BUFFER_SIZE",
+	0x8c84:     "TRANSFORM_FEEDBACK_BUFFER_START",
+	0x8e23:     "TRANSFORM_FEEDBACK_PAUSED",
+	0x8c88:     "TRANSFORM_FEEDBACK_PRIMITIVES_WRITTEN",
+	0x8c76:     "TRANSFORM_FEEDBACK_VARYING_MAX_LENGTH",
+	0x8c83:     "TRANSFORM_FEEDBACK_VARYINGS",
+	0x8a3c:     "UNIFORM_ARRAY_STRIDE",
+	0x8a43:     "UNIFORM_BLOCK_ACTIVE_UNIFORM_INDICES",
+	0x8a42:     "UNIFORM_BLOCK_ACTIVE_UNIFORMS",
+	0x8a3f:     "UNIFORM_BLOCK_BINDING",
+	0x8a40:     "UNIFORM_BLOCK_DATA_SIZE",
+	0x8a3a:     "UNIFORM_BLOCK_INDEX",
+	0x8a41:     "UNIFORM_BLOCK_NAME_LENGTH",
+	0x8a46:     "UNIFORM_BLOCK_REFERENCED_BY_FRAGMENT_SHADER",
+	0x8a44:     "UNIFORM_BLOCK_REFERENCED_BY_VERTEX_SHADER",
+	0x8a11:     "UNIFORM_BUFFER",
+	0x8a28:     "UNIFORM_BUFFER_BINDING",
+	0x8a34:     "UNIFORM_BUFFER_OFFSET_ALIGNMENT",
+	0x8a2a:     "UNIFORM_BUFFER_SIZE",
+	0x8a29:     "UNIFORM_BUFFER_START",
+	0x8a3e:     "UNIFORM_IS_ROW_MAJOR",
+	0x8a3d:     "UNIFORM_MATRIX_STRIDE",
+	0x8a39:     "UNIFORM_NAME_LENGTH",
+	0x8a3b:     "UNIFORM_OFFSET",
+	0x8a38:     "UNIFORM_SIZE",
+	0x8a37:     "UNIFORM_TYPE",
+	0x806e:     "UNPACK_IMAGE_HEIGHT",
+	0xcf2:      "UNPACK_ROW_LENGTH",
+	0x806d:     "UNPACK_SKIP_IMAGES",
+	0xcf4:      "UNPACK_SKIP_PIXELS",
+	0xcf3:      "UNPACK_SKIP_ROWS",
+	0x9118:     "UNSIGNALED",
+	0x8c3b:     "UNSIGNED_INT_10F_11F_11F_REV",
+	0x8368:     "UNSIGNED_INT_2_10_10_10_REV",
+	0x84fa:     "UNSIGNED_INT_24_8",
+	0x8c3e:     "UNSIGNED_INT_5_9_9_9_REV",
+	0x8dd2:     "UNSIGNED_INT_SAMPLER_2D",
+	0x8dd7:     "UNSIGNED_INT_SAMPLER_2D_ARRAY",
+	0x8dd3:     "UNSIGNED_INT_SAMPLER_3D",
+	0x8dd4:     "UNSIGNED_INT_SAMPLER_CUBE",
+	0x8dc6:     "UNSIGNED_INT_VEC2",
+	0x8dc7:     "UNSIGNED_INT_VEC3",
+	0x8dc8:     "UNSIGNED_INT_VEC4",
+	0x8c17:     "UNSIGNED_NORMALIZED",
+	0x85b5:     "VERTEX_ARRAY_BINDING",
+	0x88fe:     "VERTEX_ATTRIB_ARRAY_DIVISOR",
+	0x88fd:     "VERTEX_ATTRIB_ARRAY_INTEGER",
+	0x911d:     "WAIT_FAILED",
+}
+
+func (ctx *traceContext) ActiveTexture(texture Enum) {
+	ctx.ctx.ActiveTexture(texture)
+	if e := ctx.t.begin(0); e != nil {
+		e.enum(texture)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) AttachShader(p Program, s Shader) {
+	ctx.ctx.AttachShader(p, s)
+	if e := ctx.t.begin(1); e != nil {
+		e.program(p)
+		e.shader(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BindAttribLocation(p Program, a Attrib, name string) {
+	ctx.ctx.BindAttribLocation(p, a, name)
+	if e := ctx.t.begin(2); e != nil {
+		e.program(p)
+		e.attrib(a)
+		e.string(name)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BindBuffer(target Enum, b Buffer) {
+	ctx.ctx.BindBuffer(target, b)
+	if e := ctx.t.begin(3); e != nil {
+		e.enum(target)
+		e.buffer(b)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BindFramebuffer(target Enum, fb Framebuffer) {
+	ctx.ctx.BindFramebuffer(target, fb)
+	if e := ctx.t.begin(4); e != nil {
+		e.enum(target)
+		e.framebuffer(fb)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BindRenderbuffer(target Enum, rb Renderbuffer) {
+	ctx.ctx.BindRenderbuffer(target, rb)
+	if e := ctx.t.begin(5); e != nil {
+		e.enum(target)
+		e.renderbuffer(rb)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BindTexture(target Enum, t Texture) {
+	ctx.ctx.BindTexture(target, t)
+	if e := ctx.t.begin(6); e != nil {
+		e.enum(target)
+		e.texture(t)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BindVertexArray(rb VertexArray) {
+	ctx.ctx.BindVertexArray(rb)
+	if e := ctx.t.begin(7); e != nil {
+		e.vertexArray(rb)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BlendColor(red float32, green float32, blue float32, alpha float32) {
+	ctx.ctx.BlendColor(red, green, blue, alpha)
+	if e := ctx.t.begin(8); e != nil {
+		e.float32(red)
+		e.float32(green)
+		e.float32(blue)
+		e.float32(alpha)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BlendEquation(mode Enum) {
+	ctx.ctx.BlendEquation(mode)
+	if e := ctx.t.begin(9); e != nil {
+		e.enum(mode)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BlendEquationSeparate(modeRGB Enum, modeAlpha Enum) {
+	ctx.ctx.BlendEquationSeparate(modeRGB, modeAlpha)
+	if e := ctx.t.begin(10); e != nil {
+		e.enum(modeRGB)
+		e.enum(modeAlpha)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BlendFunc(sfactor Enum, dfactor Enum) {
+	ctx.ctx.BlendFunc(sfactor, dfactor)
+	if e := ctx.t.begin(11); e != nil {
+		e.enum(sfactor)
+		e.enum(dfactor)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BlendFuncSeparate(sfactorRGB Enum, dfactorRGB Enum, sfactorAlpha Enum, dfactorAlpha Enum) {
+	ctx.ctx.BlendFuncSeparate(sfactorRGB, dfactorRGB, sfactorAlpha, dfactorAlpha)
+	if e := ctx.t.begin(12); e != nil {
+		e.enum(sfactorRGB)
+		e.enum(dfactorRGB)
+		e.enum(sfactorAlpha)
+		e.enum(dfactorAlpha)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BufferData(target Enum, src []byte, usage Enum) {
+	ctx.ctx.BufferData(target, src, usage)
+	if e := ctx.t.begin(13); e != nil {
+		e.enum(target)
+		e.bytes(src)
+		e.enum(usage)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BufferInit(target Enum, size int, usage Enum) {
+	ctx.ctx.BufferInit(target, size, usage)
+	if e := ctx.t.begin(14); e != nil {
+		e.enum(target)
+		e.int(size)
+		e.enum(usage)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) BufferSubData(target Enum, offset int, data []byte) {
+	ctx.ctx.BufferSubData(target, offset, data)
+	if e := ctx.t.begin(15); e != nil {
+		e.enum(target)
+		e.int(offset)
+		e.bytes(data)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CheckFramebufferStatus(target Enum) (r0 Enum) {
+	r0 = ctx.ctx.CheckFramebufferStatus(target)
+	if e := ctx.t.begin(16); e != nil {
+		e.enum(target)
+		e.enum(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) Clear(mask Enum) {
+	ctx.ctx.Clear(mask)
+	if e := ctx.t.begin(17); e != nil {
+		e.enum(mask)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ClearColor(red float32, green float32, blue float32, alpha float32) {
+	ctx.ctx.ClearColor(red, green, blue, alpha)
+	if e := ctx.t.begin(18); e != nil {
+		e.float32(red)
+		e.float32(green)
+		e.float32(blue)
+		e.float32(alpha)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ClearDepthf(d float32) {
+	ctx.ctx.ClearDepthf(d)
+	if e := ctx.t.begin(19); e != nil {
+		e.float32(d)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ClearStencil(s int) {
+	ctx.ctx.ClearStencil(s)
+	if e := ctx.t.begin(20); e != nil {
+		e.int(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ColorMask(red bool, green bool, blue bool, alpha bool) {
+	ctx.ctx.ColorMask(red, green, blue, alpha)
+	if e := ctx.t.begin(21); e != nil {
+		e.bool(red)
+		e.bool(green)
+		e.bool(blue)
+		e.bool(alpha)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CompileShader(s Shader) {
+	ctx.ctx.CompileShader(s)
+	if e := ctx.t.begin(22); e != nil {
+		e.shader(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CompressedTexImage2D(target Enum, level int, internalformat Enum, width int, height int, border int, data []byte) {
+	ctx.ctx.CompressedTexImage2D(target, level, internalformat, width, height, border, data)
+	if e := ctx.t.begin(23); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.enum(internalformat)
+		e.int(width)
+		e.int(height)
+		e.int(border)
+		e.bytes(data)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CompressedTexSubImage2D(target Enum, level int, xoffset int, yoffset int, width int, height int, format Enum, data []byte) {
+	ctx.ctx.CompressedTexSubImage2D(target, level, xoffset, yoffset, width, height, format, data)
+	if e := ctx.t.begin(24); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.int(xoffset)
+		e.int(yoffset)
+		e.int(width)
+		e.int(height)
+		e.enum(format)
+		e.bytes(data)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CopyTexImage2D(target Enum, level int, internalformat Enum, x int, y int, width int, height int, border int) {
+	ctx.ctx.CopyTexImage2D(target, level, internalformat, x, y, width, height, border)
+	if e := ctx.t.begin(25); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.enum(internalformat)
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.int(border)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CopyTexSubImage2D(target Enum, level int, xoffset int, yoffset int, x int, y int, width int, height int) {
+	ctx.ctx.CopyTexSubImage2D(target, level, xoffset, yoffset, x, y, width, height)
+	if e := ctx.t.begin(26); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.int(xoffset)
+		e.int(yoffset)
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) CreateBuffer() (r0 Buffer) {
+	r0 = ctx.ctx.CreateBuffer()
+	if e := ctx.t.begin(27); e != nil {
+		e.buffer(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CreateFramebuffer() (r0 Framebuffer) {
+	r0 = ctx.ctx.CreateFramebuffer()
+	if e := ctx.t.begin(28); e != nil {
+		e.framebuffer(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CreateProgram() (r0 Program) {
+	r0 = ctx.ctx.CreateProgram()
+	if e := ctx.t.begin(29); e != nil {
+		e.program(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CreateRenderbuffer() (r0 Renderbuffer) {
+	r0 = ctx.ctx.CreateRenderbuffer()
+	if e := ctx.t.begin(30); e != nil {
+		e.renderbuffer(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CreateShader(ty Enum) (r0 Shader) {
+	r0 = ctx.ctx.CreateShader(ty)
+	if e := ctx.t.begin(31); e != nil {
+		e.enum(ty)
+		e.shader(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CreateTexture() (r0 Texture) {
+	r0 = ctx.ctx.CreateTexture()
+	if e := ctx.t.begin(32); e != nil {
+		e.texture(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CreateVertexArray() (r0 VertexArray) {
+	r0 = ctx.ctx.CreateVertexArray()
+	if e := ctx.t.begin(33); e != nil {
+		e.vertexArray(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) CullFace(mode Enum) {
+	ctx.ctx.CullFace(mode)
+	if e := ctx.t.begin(34); e != nil {
+		e.enum(mode)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteBuffer(v Buffer) {
+	ctx.ctx.DeleteBuffer(v)
+	if e := ctx.t.begin(35); e != nil {
+		e.buffer(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteFramebuffer(v Framebuffer) {
+	ctx.ctx.DeleteFramebuffer(v)
+	if e := ctx.t.begin(36); e != nil {
+		e.framebuffer(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteProgram(p Program) {
+	ctx.ctx.DeleteProgram(p)
+	if e := ctx.t.begin(37); e != nil {
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteRenderbuffer(v Renderbuffer) {
+	ctx.ctx.DeleteRenderbuffer(v)
+	if e := ctx.t.begin(38); e != nil {
+		e.renderbuffer(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteShader(s Shader) {
+	ctx.ctx.DeleteShader(s)
+	if e := ctx.t.begin(39); e != nil {
+		e.shader(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteTexture(v Texture) {
+	ctx.ctx.DeleteTexture(v)
+	if e := ctx.t.begin(40); e != nil {
+		e.texture(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DeleteVertexArray(v VertexArray) {
+	ctx.ctx.DeleteVertexArray(v)
+	if e := ctx.t.begin(41); e != nil {
+		e.vertexArray(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DepthFunc(fn Enum) {
+	ctx.ctx.DepthFunc(fn)
+	if e := ctx.t.begin(42); e != nil {
+		e.enum(fn)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DepthMask(flag bool) {
+	ctx.ctx.DepthMask(flag)
+	if e := ctx.t.begin(43); e != nil {
+		e.bool(flag)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DepthRangef(n float32, f float32) {
+	ctx.ctx.DepthRangef(n, f)
+	if e := ctx.t.begin(44); e != nil {
+		e.float32(n)
+		e.float32(f)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DetachShader(p Program, s Shader) {
+	ctx.ctx.DetachShader(p, s)
+	if e := ctx.t.begin(45); e != nil {
+		e.program(p)
+		e.shader(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Disable(cap Enum) {
+	ctx.ctx.Disable(cap)
+	if e := ctx.t.begin(46); e != nil {
+		e.enum(cap)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DisableVertexAttribArray(a Attrib) {
+	ctx.ctx.DisableVertexAttribArray(a)
+	if e := ctx.t.begin(47); e != nil {
+		e.attrib(a)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DrawArrays(mode Enum, first int, count int) {
+	ctx.ctx.DrawArrays(mode, first, count)
+	if e := ctx.t.begin(48); e != nil {
+		e.enum(mode)
+		e.int(first)
+		e.int(count)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) DrawElements(mode Enum, count int, ty Enum, offset int) {
+	ctx.ctx.DrawElements(mode, count, ty, offset)
+	if e := ctx.t.begin(49); e != nil {
+		e.enum(mode)
+		e.int(count)
+		e.enum(ty)
+		e.int(offset)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Enable(cap Enum) {
+	ctx.ctx.Enable(cap)
+	if e := ctx.t.begin(50); e != nil {
+		e.enum(cap)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) EnableVertexAttribArray(a Attrib) {
+	ctx.ctx.EnableVertexAttribArray(a)
+	if e := ctx.t.begin(51); e != nil {
+		e.attrib(a)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Finish() {
+	ctx.ctx.Finish()
+	if e := ctx.t.begin(52); e != nil {
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Flush() {
+	ctx.ctx.Flush()
+	if e := ctx.t.begin(53); e != nil {
+		e.end()
+	}
+}
+
+func (ctx *traceContext) FramebufferRenderbuffer(target Enum, attachment Enum, rbTarget Enum, rb Renderbuffer) {
+	ctx.ctx.FramebufferRenderbuffer(target, attachment, rbTarget, rb)
+	if e := ctx.t.begin(54); e != nil {
+		e.enum(target)
+		e.enum(attachment)
+		e.enum(rbTarget)
+		e.renderbuffer(rb)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) FramebufferTexture2D(target Enum, attachment Enum, texTarget Enum, t Texture, level int) {
+	ctx.ctx.FramebufferTexture2D(target, attachment, texTarget, t, level)
+	if e := ctx.t.begin(55); e != nil {
+		e.enum(target)
+		e.enum(attachment)
+		e.enum(texTarget)
+		e.texture(t)
+		e.int(level)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) FrontFace(mode Enum) {
+	ctx.ctx.FrontFace(mode)
+	if e := ctx.t.begin(56); e != nil {
+		e.enum(mode)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GenerateMipmap(target Enum) {
+	ctx.ctx.GenerateMipmap(target)
+	if e := ctx.t.begin(57); e != nil {
+		e.enum(target)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetActiveAttrib(p Program, index uint32) (r0 string, r1 int, r2 Enum) {
+	r0, r1, r2 = ctx.ctx.GetActiveAttrib(p, index)
+	if e := ctx.t.begin(58); e != nil {
+		e.program(p)
+		e.uint32(index)
+		e.string(r0)
+		e.int(r1)
+		e.enum(r2)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetActiveUniform(p Program, index uint32) (r0 string, r1 int, r2 Enum) {
+	r0, r1, r2 = ctx.ctx.GetActiveUniform(p, index)
+	if e := ctx.t.begin(59); e != nil {
+		e.program(p)
+		e.uint32(index)
+		e.string(r0)
+		e.int(r1)
+		e.enum(r2)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetAttachedShaders(p Program) (r0 []Shader) {
+	r0 = ctx.ctx.GetAttachedShaders(p)
+	if e := ctx.t.begin(60); e != nil {
+		e.program(p)
+		e.shaders(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetAttribLocation(p Program, name string) (r0 Attrib) {
+	r0 = ctx.ctx.GetAttribLocation(p, name)
+	if e := ctx.t.begin(61); e != nil {
+		e.program(p)
+		e.string(name)
+		e.attrib(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetBooleanv(dst []bool, pname Enum) {
+	ctx.ctx.GetBooleanv(dst, pname)
+	if e := ctx.t.begin(62); e != nil {
+		e.bools(dst)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetFloatv(dst []float32, pname Enum) {
+	ctx.ctx.GetFloatv(dst, pname)
+	if e := ctx.t.begin(63); e != nil {
+		e.float32s(dst)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetIntegerv(dst []int32, pname Enum) {
+	ctx.ctx.GetIntegerv(dst, pname)
+	if e := ctx.t.begin(64); e != nil {
+		e.int32s(dst)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetInteger(pname Enum) (r0 int) {
+	r0 = ctx.ctx.GetInteger(pname)
+	if e := ctx.t.begin(65); e != nil {
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetBufferParameteri(target Enum, value Enum) (r0 int) {
+	r0 = ctx.ctx.GetBufferParameteri(target, value)
+	if e := ctx.t.begin(66); e != nil {
+		e.enum(target)
+		e.enum(value)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetError() (r0 Enum) {
+	r0 = ctx.ctx.GetError()
+	if e := ctx.t.begin(67); e != nil {
+		e.enum(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetFramebufferAttachmentParameteri(target Enum, attachment Enum, pname Enum) (r0 int) {
+	r0 = ctx.ctx.GetFramebufferAttachmentParameteri(target, attachment, pname)
+	if e := ctx.t.begin(68); e != nil {
+		e.enum(target)
+		e.enum(attachment)
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetProgrami(p Program, pname Enum) (r0 int) {
+	r0 = ctx.ctx.GetProgrami(p, pname)
+	if e := ctx.t.begin(69); e != nil {
+		e.program(p)
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetProgramInfoLog(p Program) (r0 string) {
+	r0 = ctx.ctx.GetProgramInfoLog(p)
+	if e := ctx.t.begin(70); e != nil {
+		e.program(p)
+		e.string(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetRenderbufferParameteri(target Enum, pname Enum) (r0 int) {
+	r0 = ctx.ctx.GetRenderbufferParameteri(target, pname)
+	if e := ctx.t.begin(71); e != nil {
+		e.enum(target)
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetShaderi(s Shader, pname Enum) (r0 int) {
+	r0 = ctx.ctx.GetShaderi(s, pname)
+	if e := ctx.t.begin(72); e != nil {
+		e.shader(s)
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetShaderInfoLog(s Shader) (r0 string) {
+	r0 = ctx.ctx.GetShaderInfoLog(s)
+	if e := ctx.t.begin(73); e != nil {
+		e.shader(s)
+		e.string(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetShaderPrecisionFormat(shadertype Enum, precisiontype Enum) (r0 int, r1 int, r2 int) {
+	r0, r1, r2 = ctx.ctx.GetShaderPrecisionFormat(shadertype, precisiontype)
+	if e := ctx.t.begin(74); e != nil {
+		e.enum(shadertype)
+		e.enum(precisiontype)
+		e.int(r0)
+		e.int(r1)
+		e.int(r2)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetShaderSource(s Shader) (r0 string) {
+	r0 = ctx.ctx.GetShaderSource(s)
+	if e := ctx.t.begin(75); e != nil {
+		e.shader(s)
+		e.string(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetString(pname Enum) (r0 string) {
+	r0 = ctx.ctx.GetString(pname)
+	if e := ctx.t.begin(76); e != nil {
+		e.enum(pname)
+		e.string(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetTexParameterfv(dst []float32, target Enum, pname Enum) {
+	ctx.ctx.GetTexParameterfv(dst, target, pname)
+	if e := ctx.t.begin(77); e != nil {
+		e.float32s(dst)
+		e.enum(target)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetTexParameteriv(dst []int32, target Enum, pname Enum) {
+	ctx.ctx.GetTexParameteriv(dst, target, pname)
+	if e := ctx.t.begin(78); e != nil {
+		e.int32s(dst)
+		e.enum(target)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetUniformfv(dst []float32, src Uniform, p Program) {
+	ctx.ctx.GetUniformfv(dst, src, p)
+	if e := ctx.t.begin(79); e != nil {
+		e.float32s(dst)
+		e.uniform(src)
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetUniformiv(dst []int32, src Uniform, p Program) {
+	ctx.ctx.GetUniformiv(dst, src, p)
+	if e := ctx.t.begin(80); e != nil {
+		e.int32s(dst)
+		e.uniform(src)
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetUniformLocation(p Program, name string) (r0 Uniform) {
+	r0 = ctx.ctx.GetUniformLocation(p, name)
+	if e := ctx.t.begin(81); e != nil {
+		e.program(p)
+		e.string(name)
+		e.uniform(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetVertexAttribf(src Attrib, pname Enum) (r0 float32) {
+	r0 = ctx.ctx.GetVertexAttribf(src, pname)
+	if e := ctx.t.begin(82); e != nil {
+		e.attrib(src)
+		e.enum(pname)
+		e.float32(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetVertexAttribfv(dst []float32, src Attrib, pname Enum) {
+	ctx.ctx.GetVertexAttribfv(dst, src, pname)
+	if e := ctx.t.begin(83); e != nil {
+		e.float32s(dst)
+		e.attrib(src)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) GetVertexAttribi(src Attrib, pname Enum) (r0 int32) {
+	r0 = ctx.ctx.GetVertexAttribi(src, pname)
+	if e := ctx.t.begin(84); e != nil {
+		e.attrib(src)
+		e.enum(pname)
+		e.int32(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) GetVertexAttribiv(dst []int32, src Attrib, pname Enum) {
+	ctx.ctx.GetVertexAttribiv(dst, src, pname)
+	if e := ctx.t.begin(85); e != nil {
+		e.int32s(dst)
+		e.attrib(src)
+		e.enum(pname)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Hint(target Enum, mode Enum) {
+	ctx.ctx.Hint(target, mode)
+	if e := ctx.t.begin(86); e != nil {
+		e.enum(target)
+		e.enum(mode)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) IsBuffer(b Buffer) (r0 bool) {
+	r0 = ctx.ctx.IsBuffer(b)
+	if e := ctx.t.begin(87); e != nil {
+		e.buffer(b)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) IsEnabled(cap Enum) (r0 bool) {
+	r0 = ctx.ctx.IsEnabled(cap)
+	if e := ctx.t.begin(88); e != nil {
+		e.enum(cap)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) IsFramebuffer(fb Framebuffer) (r0 bool) {
+	r0 = ctx.ctx.IsFramebuffer(fb)
+	if e := ctx.t.begin(89); e != nil {
+		e.framebuffer(fb)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) IsProgram(p Program) (r0 bool) {
+	r0 = ctx.ctx.IsProgram(p)
+	if e := ctx.t.begin(90); e != nil {
+		e.program(p)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) IsRenderbuffer(rb Renderbuffer) (r0 bool) {
+	r0 = ctx.ctx.IsRenderbuffer(rb)
+	if e := ctx.t.begin(91); e != nil {
+		e.renderbuffer(rb)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) IsShader(s Shader) (r0 bool) {
+	r0 = ctx.ctx.IsShader(s)
+	if e := ctx.t.begin(92); e != nil {
+		e.shader(s)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) IsTexture(t Texture) (r0 bool) {
+	r0 = ctx.ctx.IsTexture(t)
+	if e := ctx.t.begin(93); e != nil {
+		e.texture(t)
+		e.bool(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext) LineWidth(width float32) {
+	ctx.ctx.LineWidth(width)
+	if e := ctx.t.begin(94); e != nil {
+		e.float32(width)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) LinkProgram(p Program) {
+	ctx.ctx.LinkProgram(p)
+	if e := ctx.t.begin(95); e != nil {
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) PixelStorei(pname Enum, param int32) {
+	ctx.ctx.PixelStorei(pname, param)
+	if e := ctx.t.begin(96); e != nil {
+		e.enum(pname)
+		e.int32(param)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) PolygonOffset(factor float32, units float32) {
+	ctx.ctx.PolygonOffset(factor, units)
+	if e := ctx.t.begin(97); e != nil {
+		e.float32(factor)
+		e.float32(units)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ReadPixels(dst []byte, x int, y int, width int, height int, format Enum, ty Enum) {
+	ctx.ctx.ReadPixels(dst, x, y, width, height, format, ty)
+	if e := ctx.t.begin(98); e != nil {
+		e.bytes(dst)
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.enum(format)
+		e.enum(ty)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ReleaseShaderCompiler() {
+	ctx.ctx.ReleaseShaderCompiler()
+	if e := ctx.t.begin(99); e != nil {
+		e.end()
+	}
+}
+
+func (ctx *traceContext) RenderbufferStorage(target Enum, internalFormat Enum, width int, height int) {
+	ctx.ctx.RenderbufferStorage(target, internalFormat, width, height)
+	if e := ctx.t.begin(100); e != nil {
+		e.enum(target)
+		e.enum(internalFormat)
+		e.int(width)
+		e.int(height)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) SampleCoverage(value float32, invert bool) {
+	ctx.ctx.SampleCoverage(value, invert)
+	if e := ctx.t.begin(101); e != nil {
+		e.float32(value)
+		e.bool(invert)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Scissor(x int32, y int32, width int32, height int32) {
+	ctx.ctx.Scissor(x, y, width, height)
+	if e := ctx.t.begin(102); e != nil {
+		e.int32(x)
+		e.int32(y)
+		e.int32(width)
+		e.int32(height)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ShaderSource(s Shader, src string) {
+	ctx.ctx.ShaderSource(s, src)
+	if e := ctx.t.begin(103); e != nil {
+		e.shader(s)
+		e.string(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) StencilFunc(fn Enum, ref int, mask uint32) {
+	ctx.ctx.StencilFunc(fn, ref, mask)
+	if e := ctx.t.begin(104); e != nil {
+		e.enum(fn)
+		e.int(ref)
+		e.uint32(mask)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) StencilFuncSeparate(face Enum, fn Enum, ref int, mask uint32) {
+	ctx.ctx.StencilFuncSeparate(face, fn, ref, mask)
+	if e := ctx.t.begin(105); e != nil {
+		e.enum(face)
+		e.enum(fn)
+		e.int(ref)
+		e.uint32(mask)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) StencilMask(mask uint32) {
+	ctx.ctx.StencilMask(mask)
+	if e := ctx.t.begin(106); e != nil {
+		e.uint32(mask)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) StencilMaskSeparate(face Enum, mask uint32) {
+	ctx.ctx.StencilMaskSeparate(face, mask)
+	if e := ctx.t.begin(107); e != nil {
+		e.enum(face)
+		e.uint32(mask)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) StencilOp(fail Enum, zfail Enum, zpass Enum) {
+	ctx.ctx.StencilOp(fail, zfail, zpass)
+	if e := ctx.t.begin(108); e != nil {
+		e.enum(fail)
+		e.enum(zfail)
+		e.enum(zpass)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) StencilOpSeparate(face Enum, sfail Enum, dpfail Enum, dppass Enum) {
+	ctx.ctx.StencilOpSeparate(face, sfail, dpfail, dppass)
+	if e := ctx.t.begin(109); e != nil {
+		e.enum(face)
+		e.enum(sfail)
+		e.enum(dpfail)
+		e.enum(dppass)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) TexImage2D(target Enum, level int, width int, height int, format Enum, ty Enum, data []byte) {
+	ctx.ctx.TexImage2D(target, level, width, height, format, ty, data)
+	if e := ctx.t.begin(110); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.int(width)
+		e.int(height)
+		e.enum(format)
+		e.enum(ty)
+		e.bytes(data)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) TexSubImage2D(target Enum, level int, x int, y int, width int, height int, format Enum, ty Enum, data []byte) {
+	ctx.ctx.TexSubImage2D(target, level, x, y, width, height, format, ty, data)
+	if e := ctx.t.begin(111); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.enum(format)
+		e.enum(ty)
+		e.bytes(data)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) TexParameterf(target Enum, pname Enum, param float32) {
+	ctx.ctx.TexParameterf(target, pname, param)
+	if e := ctx.t.begin(112); e != nil {
+		e.enum(target)
+		e.enum(pname)
+		e.float32(param)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) TexParameterfv(target Enum, pname Enum, params []float32) {
+	ctx.ctx.TexParameterfv(target, pname, params)
+	if e := ctx.t.begin(113); e != nil {
+		e.enum(target)
+		e.enum(pname)
+		e.float32s(params)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) TexParameteri(target Enum, pname Enum, param int) {
+	ctx.ctx.TexParameteri(target, pname, param)
+	if e := ctx.t.begin(114); e != nil {
+		e.enum(target)
+		e.enum(pname)
+		e.int(param)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) TexParameteriv(target Enum, pname Enum, params []int32) {
+	ctx.ctx.TexParameteriv(target, pname, params)
+	if e := ctx.t.begin(115); e != nil {
+		e.enum(target)
+		e.enum(pname)
+		e.int32s(params)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform1f(dst Uniform, v float32) {
+	ctx.ctx.Uniform1f(dst, v)
+	if e := ctx.t.begin(116); e != nil {
+		e.uniform(dst)
+		e.float32(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform1fv(dst Uniform, src []float32) {
+	ctx.ctx.Uniform1fv(dst, src)
+	if e := ctx.t.begin(117); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform1i(dst Uniform, v int) {
+	ctx.ctx.Uniform1i(dst, v)
+	if e := ctx.t.begin(118); e != nil {
+		e.uniform(dst)
+		e.int(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform1iv(dst Uniform, src []int32) {
+	ctx.ctx.Uniform1iv(dst, src)
+	if e := ctx.t.begin(119); e != nil {
+		e.uniform(dst)
+		e.int32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform2f(dst Uniform, v0 float32, v1 float32) {
+	ctx.ctx.Uniform2f(dst, v0, v1)
+	if e := ctx.t.begin(120); e != nil {
+		e.uniform(dst)
+		e.float32(v0)
+		e.float32(v1)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform2fv(dst Uniform, src []float32) {
+	ctx.ctx.Uniform2fv(dst, src)
+	if e := ctx.t.begin(121); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform2i(dst Uniform, v0 int, v1 int) {
+	ctx.ctx.Uniform2i(dst, v0, v1)
+	if e := ctx.t.begin(122); e != nil {
+		e.uniform(dst)
+		e.int(v0)
+		e.int(v1)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform2iv(dst Uniform, src []int32) {
+	ctx.ctx.Uniform2iv(dst, src)
+	if e := ctx.t.begin(123); e != nil {
+		e.uniform(dst)
+		e.int32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform3f(dst Uniform, v0 float32, v1 float32, v2 float32) {
+	ctx.ctx.Uniform3f(dst, v0, v1, v2)
+	if e := ctx.t.begin(124); e != nil {
+		e.uniform(dst)
+		e.float32(v0)
+		e.float32(v1)
+		e.float32(v2)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform3fv(dst Uniform, src []float32) {
+	ctx.ctx.Uniform3fv(dst, src)
+	if e := ctx.t.begin(125); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform3i(dst Uniform, v0 int32, v1 int32, v2 int32) {
+	ctx.ctx.Uniform3i(dst, v0, v1, v2)
+	if e := ctx.t.begin(126); e != nil {
+		e.uniform(dst)
+		e.int32(v0)
+		e.int32(v1)
+		e.int32(v2)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform3iv(dst Uniform, src []int32) {
+	ctx.ctx.Uniform3iv(dst, src)
+	if e := ctx.t.begin(127); e != nil {
+		e.uniform(dst)
+		e.int32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform4f(dst Uniform, v0 float32, v1 float32, v2 float32, v3 float32) {
+	ctx.ctx.Uniform4f(dst, v0, v1, v2, v3)
+	if e := ctx.t.begin(128); e != nil {
+		e.uniform(dst)
+		e.float32(v0)
+		e.float32(v1)
+		e.float32(v2)
+		e.float32(v3)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform4fv(dst Uniform, src []float32) {
+	ctx.ctx.Uniform4fv(dst, src)
+	if e := ctx.t.begin(129); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform4i(dst Uniform, v0 int32, v1 int32, v2 int32, v3 int32) {
+	ctx.ctx.Uniform4i(dst, v0, v1, v2, v3)
+	if e := ctx.t.begin(130); e != nil {
+		e.uniform(dst)
+		e.int32(v0)
+		e.int32(v1)
+		e.int32(v2)
+		e.int32(v3)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Uniform4iv(dst Uniform, src []int32) {
+	ctx.ctx.Uniform4iv(dst, src)
+	if e := ctx.t.begin(131); e != nil {
+		e.uniform(dst)
+		e.int32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) UniformMatrix2fv(dst Uniform, src []float32) {
+	ctx.ctx.UniformMatrix2fv(dst, src)
+	if e := ctx.t.begin(132); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) UniformMatrix3fv(dst Uniform, src []float32) {
+	ctx.ctx.UniformMatrix3fv(dst, src)
+	if e := ctx.t.begin(133); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) UniformMatrix4fv(dst Uniform, src []float32) {
+	ctx.ctx.UniformMatrix4fv(dst, src)
+	if e := ctx.t.begin(134); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) UseProgram(p Program) {
+	ctx.ctx.UseProgram(p)
+	if e := ctx.t.begin(135); e != nil {
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) ValidateProgram(p Program) {
+	ctx.ctx.ValidateProgram(p)
+	if e := ctx.t.begin(136); e != nil {
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib1f(dst Attrib, x float32) {
+	ctx.ctx.VertexAttrib1f(dst, x)
+	if e := ctx.t.begin(137); e != nil {
+		e.attrib(dst)
+		e.float32(x)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib1fv(dst Attrib, src []float32) {
+	ctx.ctx.VertexAttrib1fv(dst, src)
+	if e := ctx.t.begin(138); e != nil {
+		e.attrib(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib2f(dst Attrib, x float32, y float32) {
+	ctx.ctx.VertexAttrib2f(dst, x, y)
+	if e := ctx.t.begin(139); e != nil {
+		e.attrib(dst)
+		e.float32(x)
+		e.float32(y)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib2fv(dst Attrib, src []float32) {
+	ctx.ctx.VertexAttrib2fv(dst, src)
+	if e := ctx.t.begin(140); e != nil {
+		e.attrib(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib3f(dst Attrib, x float32, y float32, z float32) {
+	ctx.ctx.VertexAttrib3f(dst, x, y, z)
+	if e := ctx.t.begin(141); e != nil {
+		e.attrib(dst)
+		e.float32(x)
+		e.float32(y)
+		e.float32(z)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib3fv(dst Attrib, src []float32) {
+	ctx.ctx.VertexAttrib3fv(dst, src)
+	if e := ctx.t.begin(142); e != nil {
+		e.attrib(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib4f(dst Attrib, x float32, y float32, z float32, w float32) {
+	ctx.ctx.VertexAttrib4f(dst, x, y, z, w)
+	if e := ctx.t.begin(143); e != nil {
+		e.attrib(dst)
+		e.float32(x)
+		e.float32(y)
+		e.float32(z)
+		e.float32(w)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttrib4fv(dst Attrib, src []float32) {
+	ctx.ctx.VertexAttrib4fv(dst, src)
+	if e := ctx.t.begin(144); e != nil {
+		e.attrib(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) VertexAttribPointer(dst Attrib, size int, ty Enum, normalized bool, stride int, offset int) {
+	ctx.ctx.VertexAttribPointer(dst, size, ty, normalized, stride, offset)
+	if e := ctx.t.begin(145); e != nil {
+		e.attrib(dst)
+		e.int(size)
+		e.enum(ty)
+		e.bool(normalized)
+		e.int(stride)
+		e.int(offset)
+		e.end()
+	}
+}
+
+func (ctx *traceContext) Viewport(x int, y int, width int, height int) {
+	ctx.ctx.Viewport(x, y, width, height)
+	if e := ctx.t.begin(146); e != nil {
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) BeginTransformFeedback(mode Enum) {
+	ctx.ctx.(Context3).BeginTransformFeedback(mode)
+	if e := ctx.t.begin(147); e != nil {
+		e.enum(mode)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) BindBufferBase(target Enum, index uint32, b Buffer) {
+	ctx.ctx.(Context3).BindBufferBase(target, index, b)
+	if e := ctx.t.begin(148); e != nil {
+		e.enum(target)
+		e.uint32(index)
+		e.buffer(b)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) BindBufferRange(target Enum, index uint32, b Buffer, offset int, size int) {
+	ctx.ctx.(Context3).BindBufferRange(target, index, b, offset, size)
+	if e := ctx.t.begin(149); e != nil {
+		e.enum(target)
+		e.uint32(index)
+		e.buffer(b)
+		e.int(offset)
+		e.int(size)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) BindSampler(unit uint32, s Sampler) {
+	ctx.ctx.(Context3).BindSampler(unit, s)
+	if e := ctx.t.begin(150); e != nil {
+		e.uint32(unit)
+		e.sampler(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) BindTransformFeedback(target Enum, tf TransformFeedback) {
+	ctx.ctx.(Context3).BindTransformFeedback(target, tf)
+	if e := ctx.t.begin(151); e != nil {
+		e.enum(target)
+		e.transformFeedback(tf)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) BlitFramebuffer(srcX0 int, srcY0 int, srcX1 int, srcY1 int, dstX0 int, dstY0 int, dstX1 int, dstY1 int, mask uint, filter Enum) {
+	ctx.ctx.(Context3).BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
+	if e := ctx.t.begin(152); e != nil {
+		e.int(srcX0)
+		e.int(srcY0)
+		e.int(srcX1)
+		e.int(srcY1)
+		e.int(dstX0)
+		e.int(dstY0)
+		e.int(dstX1)
+		e.int(dstY1)
+		e.uint(mask)
+		e.enum(filter)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ClearBufferfi(buffer Enum, drawbuffer int, depth float32, stencil int) {
+	ctx.ctx.(Context3).ClearBufferfi(buffer, drawbuffer, depth, stencil)
+	if e := ctx.t.begin(153); e != nil {
+		e.enum(buffer)
+		e.int(drawbuffer)
+		e.float32(depth)
+		e.int(stencil)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ClearBufferfv(buffer Enum, drawbuffer int, value []float32) {
+	ctx.ctx.(Context3).ClearBufferfv(buffer, drawbuffer, value)
+	if e := ctx.t.begin(154); e != nil {
+		e.enum(buffer)
+		e.int(drawbuffer)
+		e.float32s(value)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ClearBufferiv(buffer Enum, drawbuffer int, value []int32) {
+	ctx.ctx.(Context3).ClearBufferiv(buffer, drawbuffer, value)
+	if e := ctx.t.begin(155); e != nil {
+		e.enum(buffer)
+		e.int(drawbuffer)
+		e.int32s(value)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ClearBufferuiv(buffer Enum, drawbuffer int, value []uint32) {
+	ctx.ctx.(Context3).ClearBufferuiv(buffer, drawbuffer, value)
+	if e := ctx.t.begin(156); e != nil {
+		e.enum(buffer)
+		e.int(drawbuffer)
+		e.uint32s(value)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ClientWaitSync(s Sync, flags uint32, timeout uint64) (r0 Enum) {
+	r0 = ctx.ctx.(Context3).ClientWaitSync(s, flags, timeout)
+	if e := ctx.t.begin(157); e != nil {
+		e.sync(s)
+		e.uint32(flags)
+		e.uint64(timeout)
+		e.enum(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) CopyBufferSubData(readTarget Enum, writeTarget Enum, readOffset int, writeOffset int, size int) {
+	ctx.ctx.(Context3).CopyBufferSubData(readTarget, writeTarget, readOffset, writeOffset, size)
+	if e := ctx.t.begin(158); e != nil {
+		e.enum(readTarget)
+		e.enum(writeTarget)
+		e.int(readOffset)
+		e.int(writeOffset)
+		e.int(size)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) CreateSampler() (r0 Sampler) {
+	r0 = ctx.ctx.(Context3).CreateSampler()
+	if e := ctx.t.begin(159); e != nil {
+		e.sampler(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) CreateTransformFeedback() (r0 TransformFeedback) {
+	r0 = ctx.ctx.(Context3).CreateTransformFeedback()
+	if e := ctx.t.begin(160); e != nil {
+		e.transformFeedback(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) DeleteSampler(s Sampler) {
+	ctx.ctx.(Context3).DeleteSampler(s)
+	if e := ctx.t.begin(161); e != nil {
+		e.sampler(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) DeleteSync(s Sync) {
+	ctx.ctx.(Context3).DeleteSync(s)
+	if e := ctx.t.begin(162); e != nil {
+		e.sync(s)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) DeleteTransformFeedback(tf TransformFeedback) {
+	ctx.ctx.(Context3).DeleteTransformFeedback(tf)
+	if e := ctx.t.begin(163); e != nil {
+		e.transformFeedback(tf)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) DrawArraysInstanced(mode Enum, first int, count int, instances int) {
+	ctx.ctx.(Context3).DrawArraysInstanced(mode, first, count, instances)
+	if e := ctx.t.begin(164); e != nil {
+		e.enum(mode)
+		e.int(first)
+		e.int(count)
+		e.int(instances)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) DrawBuffers(bufs []Enum) {
+	ctx.ctx.(Context3).DrawBuffers(bufs)
+	if e := ctx.t.begin(165); e != nil {
+		e.enums(bufs)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) DrawElementsInstanced(mode Enum, count int, ty Enum, offset int, instances int) {
+	ctx.ctx.(Context3).DrawElementsInstanced(mode, count, ty, offset, instances)
+	if e := ctx.t.begin(166); e != nil {
+		e.enum(mode)
+		e.int(count)
+		e.enum(ty)
+		e.int(offset)
+		e.int(instances)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) EndTransformFeedback() {
+	ctx.ctx.(Context3).EndTransformFeedback()
+	if e := ctx.t.begin(167); e != nil {
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) FenceSync(condition Enum, flags uint32) (r0 Sync) {
+	r0 = ctx.ctx.(Context3).FenceSync(condition, flags)
+	if e := ctx.t.begin(168); e != nil {
+		e.enum(condition)
+		e.uint32(flags)
+		e.sync(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) GetActiveUniformBlocki(p Program, index uint32, pname Enum) (r0 int) {
+	r0 = ctx.ctx.(Context3).GetActiveUniformBlocki(p, index, pname)
+	if e := ctx.t.begin(169); e != nil {
+		e.program(p)
+		e.uint32(index)
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) GetActiveUniformBlockName(p Program, index uint32) (r0 string) {
+	r0 = ctx.ctx.(Context3).GetActiveUniformBlockName(p, index)
+	if e := ctx.t.begin(170); e != nil {
+		e.program(p)
+		e.uint32(index)
+		e.string(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) GetSynci(s Sync, pname Enum) (r0 int) {
+	r0 = ctx.ctx.(Context3).GetSynci(s, pname)
+	if e := ctx.t.begin(171); e != nil {
+		e.sync(s)
+		e.enum(pname)
+		e.int(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) GetUniformBlockIndex(p Program, name string) (r0 uint32) {
+	r0 = ctx.ctx.(Context3).GetUniformBlockIndex(p, name)
+	if e := ctx.t.begin(172); e != nil {
+		e.program(p)
+		e.string(name)
+		e.uint32(r0)
+		e.end()
+	}
+	return
+}
+
+func (ctx *traceContext3) GetUniformuiv(dst []uint32, src Uniform, p Program) {
+	ctx.ctx.(Context3).GetUniformuiv(dst, src, p)
+	if e := ctx.t.begin(173); e != nil {
+		e.uint32s(dst)
+		e.uniform(src)
+		e.program(p)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) PauseTransformFeedback() {
+	ctx.ctx.(Context3).PauseTransformFeedback()
+	if e := ctx.t.begin(174); e != nil {
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ReadBuffer(src Enum) {
+	ctx.ctx.(Context3).ReadBuffer(src)
+	if e := ctx.t.begin(175); e != nil {
+		e.enum(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ReadPixelsOffset(x int, y int, width int, height int, format Enum, ty Enum, offset int) {
+	ctx.ctx.(Context3).ReadPixelsOffset(x, y, width, height, format, ty, offset)
+	if e := ctx.t.begin(176); e != nil {
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.enum(format)
+		e.enum(ty)
+		e.int(offset)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) RenderbufferStorageMultisample(target Enum, samples int, internalFormat Enum, width int, height int) {
+	ctx.ctx.(Context3).RenderbufferStorageMultisample(target, samples, internalFormat, width, height)
+	if e := ctx.t.begin(177); e != nil {
+		e.enum(target)
+		e.int(samples)
+		e.enum(internalFormat)
+		e.int(width)
+		e.int(height)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) ResumeTransformFeedback() {
+	ctx.ctx.(Context3).ResumeTransformFeedback()
+	if e := ctx.t.begin(178); e != nil {
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) SamplerParameterf(s Sampler, pname Enum, param float32) {
+	ctx.ctx.(Context3).SamplerParameterf(s, pname, param)
+	if e := ctx.t.begin(179); e != nil {
+		e.sampler(s)
+		e.enum(pname)
+		e.float32(param)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) SamplerParameteri(s Sampler, pname Enum, param int) {
+	ctx.ctx.(Context3).SamplerParameteri(s, pname, param)
+	if e := ctx.t.begin(180); e != nil {
+		e.sampler(s)
+		e.enum(pname)
+		e.int(param)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) TexStorage2D(target Enum, levels int, internalFormat Enum, width int, height int) {
+	ctx.ctx.(Context3).TexStorage2D(target, levels, internalFormat, width, height)
+	if e := ctx.t.begin(181); e != nil {
+		e.enum(target)
+		e.int(levels)
+		e.enum(internalFormat)
+		e.int(width)
+		e.int(height)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) TexSubImage2DOffset(target Enum, level int, x int, y int, width int, height int, format Enum, ty Enum, offset int) {
+	ctx.ctx.(Context3).TexSubImage2DOffset(target, level, x, y, width, height, format, ty, offset)
+	if e := ctx.t.begin(182); e != nil {
+		e.enum(target)
+		e.int(level)
+		e.int(x)
+		e.int(y)
+		e.int(width)
+		e.int(height)
+		e.enum(format)
+		e.enum(ty)
+		e.int(offset)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) TransformFeedbackVaryings(p Program, varyings []string, mode Enum) {
+	ctx.ctx.(Context3).TransformFeedbackVaryings(p, varyings, mode)
+	if e := ctx.t.begin(183); e != nil {
+		e.program(p)
+		e.strings(varyings)
+		e.enum(mode)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform1ui(dst Uniform, v uint32) {
+	ctx.ctx.(Context3).Uniform1ui(dst, v)
+	if e := ctx.t.begin(184); e != nil {
+		e.uniform(dst)
+		e.uint32(v)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform1uiv(dst Uniform, src []uint32) {
+	ctx.ctx.(Context3).Uniform1uiv(dst, src)
+	if e := ctx.t.begin(185); e != nil {
+		e.uniform(dst)
+		e.uint32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform2ui(dst Uniform, v0 uint32, v1 uint32) {
+	ctx.ctx.(Context3).Uniform2ui(dst, v0, v1)
+	if e := ctx.t.begin(186); e != nil {
+		e.uniform(dst)
+		e.uint32(v0)
+		e.uint32(v1)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform2uiv(dst Uniform, src []uint32) {
+	ctx.ctx.(Context3).Uniform2uiv(dst, src)
+	if e := ctx.t.begin(187); e != nil {
+		e.uniform(dst)
+		e.uint32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform3ui(dst Uniform, v0 uint32, v1 uint32, v2 uint32) {
+	ctx.ctx.(Context3).Uniform3ui(dst, v0, v1, v2)
+	if e := ctx.t.begin(188); e != nil {
+		e.uniform(dst)
+		e.uint32(v0)
+		e.uint32(v1)
+		e.uint32(v2)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform3uiv(dst Uniform, src []uint32) {
+	ctx.ctx.(Context3).Uniform3uiv(dst, src)
+	if e := ctx.t.begin(189); e != nil {
+		e.uniform(dst)
+		e.uint32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform4ui(dst Uniform, v0 uint32, v1 uint32, v2 uint32, v3 uint32) {
+	ctx.ctx.(Context3).Uniform4ui(dst, v0, v1, v2, v3)
+	if e := ctx.t.begin(190); e != nil {
+		e.uniform(dst)
+		e.uint32(v0)
+		e.uint32(v1)
+		e.uint32(v2)
+		e.uint32(v3)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) Uniform4uiv(dst Uniform, src []uint32) {
+	ctx.ctx.(Context3).Uniform4uiv(dst, src)
+	if e := ctx.t.begin(191); e != nil {
+		e.uniform(dst)
+		e.uint32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformBlockBinding(p Program, index uint32, binding uint32) {
+	ctx.ctx.(Context3).UniformBlockBinding(p, index, binding)
+	if e := ctx.t.begin(192); e != nil {
+		e.program(p)
+		e.uint32(index)
+		e.uint32(binding)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformMatrix2x3fv(dst Uniform, src []float32) {
+	ctx.ctx.(Context3).UniformMatrix2x3fv(dst, src)
+	if e := ctx.t.begin(193); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformMatrix2x4fv(dst Uniform, src []float32) {
+	ctx.ctx.(Context3).UniformMatrix2x4fv(dst, src)
+	if e := ctx.t.begin(194); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformMatrix3x2fv(dst Uniform, src []float32) {
+	ctx.ctx.(Context3).UniformMatrix3x2fv(dst, src)
+	if e := ctx.t.begin(195); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformMatrix3x4fv(dst Uniform, src []float32) {
+	ctx.ctx.(Context3).UniformMatrix3x4fv(dst, src)
+	if e := ctx.t.begin(196); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformMatrix4x2fv(dst Uniform, src []float32) {
+	ctx.ctx.(Context3).UniformMatrix4x2fv(dst, src)
+	if e := ctx.t.begin(197); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) UniformMatrix4x3fv(dst Uniform, src []float32) {
+	ctx.ctx.(Context3).UniformMatrix4x3fv(dst, src)
+	if e := ctx.t.begin(198); e != nil {
+		e.uniform(dst)
+		e.float32s(src)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) VertexAttribDivisor(dst Attrib, divisor int) {
+	ctx.ctx.(Context3).VertexAttribDivisor(dst, divisor)
+	if e := ctx.t.begin(199); e != nil {
+		e.attrib(dst)
+		e.int(divisor)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) VertexAttribIPointer(dst Attrib, size int, ty Enum, stride int, offset int) {
+	ctx.ctx.(Context3).VertexAttribIPointer(dst, size, ty, stride, offset)
+	if e := ctx.t.begin(200); e != nil {
+		e.attrib(dst)
+		e.int(size)
+		e.enum(ty)
+		e.int(stride)
+		e.int(offset)
+		e.end()
+	}
+}
+
+func (ctx *traceContext3) WaitSync(s Sync, flags uint32, timeout uint64) {
+	ctx.ctx.(Context3).WaitSync(s, flags, timeout)
+	if e := ctx.t.begin(201); e != nil {
+		e.sync(s)
+		e.uint32(flags)
+		e.uint64(timeout)
+		e.end()
+	}
+}
+
+// replayCall calls the method name of ctx with the arguments a.
+func replayCall(ctx Context, name string, a []interface{}) ([]interface{}, error) {
+	switch name {
+	case "ActiveTexture":
+		ctx.ActiveTexture(a[0].(Enum))
+		return nil, nil
+	case "AttachShader":
+		ctx.AttachShader(a[0].(Program), a[1].(Shader))
+		return nil, nil
+	case "BindAttribLocation":
+		ctx.BindAttribLocation(a[0].(Program), a[1].(Attrib), a[2].(string))
+		return nil, nil
+	case "BindBuffer":
+		ctx.BindBuffer(a[0].(Enum), a[1].(Buffer))
+		return nil, nil
+	case "BindFramebuffer":
+		ctx.BindFramebuffer(a[0].(Enum), a[1].(Framebuffer))
+		return nil, nil
+	case "BindRenderbuffer":
+		ctx.BindRenderbuffer(a[0].(Enum), a[1].(Renderbuffer))
+		return nil, nil
+	case "BindTexture":
+		ctx.BindTexture(a[0].(Enum), a[1].(Texture))
+		return nil, nil
+	case "BindVertexArray":
+		ctx.BindVertexArray(a[0].(VertexArray))
+		return nil, nil
+	case "BlendColor":
+		ctx.BlendColor(a[0].(float32), a[1].(float32), a[2].(float32), a[3].(float32))
+		return nil, nil
+	case "BlendEquation":
+		ctx.BlendEquation(a[0].(Enum))
+		return nil, nil
+	case "BlendEquationSeparate":
+		ctx.BlendEquationSeparate(a[0].(Enum), a[1].(Enum))
+		return nil, nil
+	case "BlendFunc":
+		ctx.BlendFunc(a[0].(Enum), a[1].(Enum))
+		return nil, nil
+	case "BlendFuncSeparate":
+		ctx.BlendFuncSeparate(a[0].(Enum), a[1].(Enum), a[2].(Enum), a[3].(Enum))
+		return nil, nil
+	case "BufferData":
+		ctx.BufferData(a[0].(Enum), a[1].([]byte), a[2].(Enum))
+		return nil, nil
+	case "BufferInit":
+		ctx.BufferInit(a[0].(Enum), a[1].(int), a[2].(Enum))
+		return nil, nil
+	case "BufferSubData":
+		ctx.BufferSubData(a[0].(Enum), a[1].(int), a[2].([]byte))
+		return nil, nil
+	case "CheckFramebufferStatus":
+		r0 := ctx.CheckFramebufferStatus(a[0].(Enum))
+		return []interface{}{r0}, nil
+	case "Clear":
+		ctx.Clear(a[0].(Enum))
+		return nil, nil
+	case "ClearColor":
+		ctx.ClearColor(a[0].(float32), a[1].(float32), a[2].(float32), a[3].(float32))
+		return nil, nil
+	case "ClearDepthf":
+		ctx.ClearDepthf(a[0].(float32))
+		return nil, nil
+	case "ClearStencil":
+		ctx.ClearStencil(a[0].(int))
+		return nil, nil
+	case "ColorMask":
+		ctx.ColorMask(a[0].(bool), a[1].(bool), a[2].(bool), a[3].(bool))
+		return nil, nil
+	case "CompileShader":
+		ctx.CompileShader(a[0].(Shader))
+		return nil, nil
+	case "CompressedTexImage2D":
+		ctx.CompressedTexImage2D(a[0].(Enum), a[1].(int), a[2].(Enum), a[3].(int), a[4].(int), a[5].(int), a[6].([]byte))
+		return nil, nil
+	case "CompressedTexSubImage2D":
+		ctx.CompressedTexSubImage2D(a[0].(Enum), a[1].(int), a[2].(int), a[3].(int), a[4].(int), a[5].(int), a[6].(Enum), a[7].([]byte))
+		return nil, nil
+	case "CopyTexImage2D":
+		ctx.CopyTexImage2D(a[0].(Enum), a[1].(int), a[2].(Enum), a[3].(int), a[4].(int), a[5].(int), a[6].(int), a[7].(int))
+		return nil, nil
+	case "CopyTexSubImage2D":
+		ctx.CopyTexSubImage2D(a[0].(Enum), a[1].(int), a[2].(int), a[3].(int), a[4].(int), a[5].(int), a[6].(int), a[7].(int))
+		return nil, nil
+	case "CreateBuffer":
+		r0 := ctx.CreateBuffer()
+		return []interface{}{r0}, nil
+	case "CreateFramebuffer":
+		r0 := ctx.CreateFramebuffer()
+		return []interface{}{r0}, nil
+	case "CreateProgram":
+		r0 := ctx.CreateProgram()
+		return []interface{}{r0}, nil
+	case "CreateRenderbuffer":
+		r0 := ctx.CreateRenderbuffer()
+		return []interface{}{r0}, nil
+	case "CreateShader":
+		r0 := ctx.CreateShader(a[0].(Enum))
+		return []interface{}{r0}, nil
+	case "CreateTexture":
+		r0 := ctx.CreateTexture()
+		return []interface{}{r0}, nil
+	case "CreateVertexArray":
+		r0 := ctx.CreateVertexArray()
+		return []interface{}{r0}, nil
+	case "CullFace":
+		ctx.CullFace(a[0].(Enum))
+		return nil, nil
+	case "DeleteBuffer":
+		ctx.DeleteBuffer(a[0].(Buffer))
+		return nil, nil
+	case "DeleteFramebuffer":
+		ctx.DeleteFramebuffer(a[0].(Framebuffer))
+		return nil, nil
+	case "DeleteProgram":
+		ctx.DeleteProgram(a[0].(Program))
+		return nil, nil
+	case "DeleteRenderbuffer":
+		ctx.DeleteRenderbuffer(a[0].(Renderbuffer))
+		return nil, nil
+	case "DeleteShader":
+		ctx.DeleteShader(a[0].(Shader))
+		return nil, nil
+	case "DeleteTexture":
+		ctx.DeleteTexture(a[0].(Texture))
+		return nil, nil
+	case "DeleteVertexArray":
+		ctx.DeleteVertexArray(a[0].(VertexArray))
+		return nil, nil
+	case "DepthFunc":
+		ctx.DepthFunc(a[0].(Enum))
+		return nil, nil
+	case "DepthMask":
+		ctx.DepthMask(a[0].(bool))
+		return nil, nil
+	case "DepthRangef":
+		ctx.DepthRangef(a[0].(float32), a[1].(float32))
+		return nil, nil
+	case "DetachShader":
+		ctx.DetachShader(a[0].(Program), a[1].(Shader))
+		return nil, nil
+	case "Disable":
+		ctx.Disable(a[0].(Enum))
+		return nil, nil
+	case "DisableVertexAttribArray":
+		ctx.DisableVertexAttribArray(a[0].(Attrib))
+		return nil, nil
+	case "DrawArrays":
+		ctx.DrawArrays(a[0].(Enum), a[1].(int), a[2].(int))
+		return nil, nil
+	case "DrawElements":
+		ctx.DrawElements(a[0].(Enum), a[1].(int), a[2].(Enum), a[3].(int))
+		return nil, nil
+	case "Enable":
+		ctx.Enable(a[0].(Enum))
+		return nil, nil
+	case "EnableVertexAttribArray":
+		ctx.EnableVertexAttribArray(a[0].(Attrib))
+		return nil, nil
+	case "Finish":
+		ctx.Finish()
+		return nil, nil
+	case "Flush":
+		ctx.Flush()
+		return nil, nil
+	case "FramebufferRenderbuffer":
+		ctx.FramebufferRenderbuffer(a[0].(Enum), a[1].(Enum), a[2].(Enum), a[3].(Renderbuffer))
+		return nil, nil
+	case "FramebufferTexture2D":
+		ctx.FramebufferTexture2D(a[0].(Enum), a[1].(Enum), a[2].(Enum), a[3].(Texture), a[4].(int))
+		return nil, nil
+	case "FrontFace":
+		ctx.FrontFace(a[0].(Enum))
+		return nil, nil
+	case "GenerateMipmap":
+		ctx.GenerateMipmap(a[0].(Enum))
+		return nil, nil
+	case "GetActiveAttrib":
+		r0, r1, r2 := ctx.GetActiveAttrib(a[0].(Program), a[1].(uint32))
+		return []interface{}{r0, r1, r2}, nil
+	case "GetActiveUniform":
+		r0, r1, r2 := ctx.GetActiveUniform(a[0].(Program), a[1].(uint32))
+		return []interface{}{r0, r1, r2}, nil
+	case "GetAttachedShaders":
+		r0 := ctx.GetAttachedShaders(a[0].(Program))
+		return []interface{}{r0}, nil
+	case "GetAttribLocation":
+		r0 := ctx.GetAttribLocation(a[0].(Program), a[1].(string))
+		return []interface{}{r0}, nil
+	case "GetBooleanv":
+		ctx.GetBooleanv(a[0].([]bool), a[1].(Enum))
+		return nil, nil
+	case "GetFloatv":
+		ctx.GetFloatv(a[0].([]float32), a[1].(Enum))
+		return nil, nil
+	case "GetIntegerv":
+		ctx.GetIntegerv(a[0].([]int32), a[1].(Enum))
+		return nil, nil
+	case "GetInteger":
+		r0 := ctx.GetInteger(a[0].(Enum))
+		return []interface{}{r0}, nil
+	case "GetBufferParameteri":
+		r0 := ctx.GetBufferParameteri(a[0].(Enum), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetError":
+		r0 := ctx.GetError()
+		return []interface{}{r0}, nil
+	case "GetFramebufferAttachmentParameteri":
+		r0 := ctx.GetFramebufferAttachmentParameteri(a[0].(Enum), a[1].(Enum), a[2].(Enum))
+		return []interface{}{r0}, nil
+	case "GetProgrami":
+		r0 := ctx.GetProgrami(a[0].(Program), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetProgramInfoLog":
+		r0 := ctx.GetProgramInfoLog(a[0].(Program))
+		return []interface{}{r0}, nil
+	case "GetRenderbufferParameteri":
+		r0 := ctx.GetRenderbufferParameteri(a[0].(Enum), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetShaderi":
+		r0 := ctx.GetShaderi(a[0].(Shader), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetShaderInfoLog":
+		r0 := ctx.GetShaderInfoLog(a[0].(Shader))
+		return []interface{}{r0}, nil
+	case "GetShaderPrecisionFormat":
+		r0, r1, r2 := ctx.GetShaderPrecisionFormat(a[0].(Enum), a[1].(Enum))
+		return []interface{}{r0, r1, r2}, nil
+	case "GetShaderSource":
+		r0 := ctx.GetShaderSource(a[0].(Shader))
+		return []interface{}{r0}, nil
+	case "GetString":
+		r0 := ctx.GetString(a[0].(Enum))
+		return []interface{}{r0}, nil
+	case "GetTexParameterfv":
+		ctx.GetTexParameterfv(a[0].([]float32), a[1].(Enum), a[2].(Enum))
+		return nil, nil
+	case "GetTexParameteriv":
+		ctx.GetTexParameteriv(a[0].([]int32), a[1].(Enum), a[2].(Enum))
+		return nil, nil
+	case "GetUniformfv":
+		ctx.GetUniformfv(a[0].([]float32), a[1].(Uniform), a[2].(Program))
+		return nil, nil
+	case "GetUniformiv":
+		ctx.GetUniformiv(a[0].([]int32), a[1].(Uniform), a[2].(Program))
+		return nil, nil
+	case "GetUniformLocation":
+		r0 := ctx.GetUniformLocation(a[0].(Program), a[1].(string))
+		return []interface{}{r0}, nil
+	case "GetVertexAttribf":
+		r0 := ctx.GetVertexAttribf(a[0].(Attrib), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetVertexAttribfv":
+		ctx.GetVertexAttribfv(a[0].([]float32), a[1].(Attrib), a[2].(Enum))
+		return nil, nil
+	case "GetVertexAttribi":
+		r0 := ctx.GetVertexAttribi(a[0].(Attrib), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetVertexAttribiv":
+		ctx.GetVertexAttribiv(a[0].([]int32), a[1].(Attrib), a[2].(Enum))
+		return nil, nil
+	case "Hint":
+		ctx.Hint(a[0].(Enum), a[1].(Enum))
+		return nil, nil
+	case "IsBuffer":
+		r0 := ctx.IsBuffer(a[0].(Buffer))
+		return []interface{}{r0}, nil
+	case "IsEnabled":
+		r0 := ctx.IsEnabled(a[0].(Enum))
+		return []interface{}{r0}, nil
+	case "IsFramebuffer":
+		r0 := ctx.IsFramebuffer(a[0].(Framebuffer))
+		return []interface{}{r0}, nil
+	case "IsProgram":
+		r0 := ctx.IsProgram(a[0].(Program))
+		return []interface{}{r0}, nil
+	case "IsRenderbuffer":
+		r0 := ctx.IsRenderbuffer(a[0].(Renderbuffer))
+		return []interface{}{r0}, nil
+	case "IsShader":
+		r0 := ctx.IsShader(a[0].(Shader))
+		return []interface{}{r0}, nil
+	case "IsTexture":
+		r0 := ctx.IsTexture(a[0].(Texture))
+		return []interface{}{r0}, nil
+	case "LineWidth":
+		ctx.LineWidth(a[0].(float32))
+		return nil, nil
+	case "LinkProgram":
+		ctx.LinkProgram(a[0].(Program))
+		return nil, nil
+	case "PixelStorei":
+		ctx.PixelStorei(a[0].(Enum), a[1].(int32))
+		return nil, nil
+	case "PolygonOffset":
+		ctx.PolygonOffset(a[0].(float32), a[1].(float32))
+		return nil, nil
+	case "ReadPixels":
+		ctx.ReadPixels(a[0].([]byte), a[1].(int), a[2].(int), a[3].(int), a[4].(int), a[5].(Enum), a[6].(Enum))
+		return nil, nil
+	case "ReleaseShaderCompiler":
+		ctx.ReleaseShaderCompiler()
+		return nil, nil
+	case "RenderbufferStorage":
+		ctx.RenderbufferStorage(a[0].(Enum), a[1].(Enum), a[2].(int), a[3].(int))
+		return nil, nil
+	case "SampleCoverage":
+		ctx.SampleCoverage(a[0].(float32), a[1].(bool))
+		return nil, nil
+	case "Scissor":
+		ctx.Scissor(a[0].(int32), a[1].(int32), a[2].(int32), a[3].(int32))
+		return nil, nil
+	case "ShaderSource":
+		ctx.ShaderSource(a[0].(Shader), a[1].(string))
+		return nil, nil
+	case "StencilFunc":
+		ctx.StencilFunc(a[0].(Enum), a[1].(int), a[2].(uint32))
+		return nil, nil
+	case "StencilFuncSeparate":
+		ctx.StencilFuncSeparate(a[0].(Enum), a[1].(Enum), a[2].(int), a[3].(uint32))
+		return nil, nil
+	case "StencilMask":
+		ctx.StencilMask(a[0].(uint32))
+		return nil, nil
+	case "StencilMaskSeparate":
+		ctx.StencilMaskSeparate(a[0].(Enum), a[1].(uint32))
+		return nil, nil
+	case "StencilOp":
+		ctx.StencilOp(a[0].(Enum), a[1].(Enum), a[2].(Enum))
+		return nil, nil
+	case "StencilOpSeparate":
+		ctx.StencilOpSeparate(a[0].(Enum), a[1].(Enum), a[2].(Enum), a[3].(Enum))
+		return nil, nil
+	case "TexImage2D":
+		ctx.TexImage2D(a[0].(Enum), a[1].(int), a[2].(int), a[3].(int), a[4].(Enum), a[5].(Enum), a[6].([]byte))
+		return nil, nil
+	case "TexSubImage2D":
+		ctx.TexSubImage2D(a[0].(Enum), a[1].(int), a[2].(int), a[3].(int), a[4].(int), a[5].(int), a[6].(Enum), a[7].(Enum), a[8].([]byte))
+		return nil, nil
+	case "TexParameterf":
+		ctx.TexParameterf(a[0].(Enum), a[1].(Enum), a[2].(float32))
+		return nil, nil
+	case "TexParameterfv":
+		ctx.TexParameterfv(a[0].(Enum), a[1].(Enum), a[2].([]float32))
+		return nil, nil
+	case "TexParameteri":
+		ctx.TexParameteri(a[0].(Enum), a[1].(Enum), a[2].(int))
+		return nil, nil
+	case "TexParameteriv":
+		ctx.TexParameteriv(a[0].(Enum), a[1].(Enum), a[2].([]int32))
+		return nil, nil
+	case "Uniform1f":
+		ctx.Uniform1f(a[0].(Uniform), a[1].(float32))
+		return nil, nil
+	case "Uniform1fv":
+		ctx.Uniform1fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "Uniform1i":
+		ctx.Uniform1i(a[0].(Uniform), a[1].(int))
+		return nil, nil
+	case "Uniform1iv":
+		ctx.Uniform1iv(a[0].(Uniform), a[1].([]int32))
+		return nil, nil
+	case "Uniform2f":
+		ctx.Uniform2f(a[0].(Uniform), a[1].(float32), a[2].(float32))
+		return nil, nil
+	case "Uniform2fv":
+		ctx.Uniform2fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "Uniform2i":
+		ctx.Uniform2i(a[0].(Uniform), a[1].(int), a[2].(int))
+		return nil, nil
+	case "Uniform2iv":
+		ctx.Uniform2iv(a[0].(Uniform), a[1].([]int32))
+		return nil, nil
+	case "Uniform3f":
+		ctx.Uniform3f(a[0].(Uniform), a[1].(float32), a[2].(float32), a[3].(float32))
+		return nil, nil
+	case "Uniform3fv":
+		ctx.Uniform3fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "Uniform3i":
+		ctx.Uniform3i(a[0].(Uniform), a[1].(int32), a[2].(int32), a[3].(int32))
+		return nil, nil
+	case "Uniform3iv":
+		ctx.Uniform3iv(a[0].(Uniform), a[1].([]int32))
+		return nil, nil
+	case "Uniform4f":
+		ctx.Uniform4f(a[0].(Uniform), a[1].(float32), a[2].(float32), a[3].(float32), a[4].(float32))
+		return nil, nil
+	case "Uniform4fv":
+		ctx.Uniform4fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "Uniform4i":
+		ctx.Uniform4i(a[0].(Uniform), a[1].(int32), a[2].(int32), a[3].(int32), a[4].(int32))
+		return nil, nil
+	case "Uniform4iv":
+		ctx.Uniform4iv(a[0].(Uniform), a[1].([]int32))
+		return nil, nil
+	case "UniformMatrix2fv":
+		ctx.UniformMatrix2fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix3fv":
+		ctx.UniformMatrix3fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix4fv":
+		ctx.UniformMatrix4fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UseProgram":
+		ctx.UseProgram(a[0].(Program))
+		return nil, nil
+	case "ValidateProgram":
+		ctx.ValidateProgram(a[0].(Program))
+		return nil, nil
+	case "VertexAttrib1f":
+		ctx.VertexAttrib1f(a[0].(Attrib), a[1].(float32))
+		return nil, nil
+	case "VertexAttrib1fv":
+		ctx.VertexAttrib1fv(a[0].(Attrib), a[1].([]float32))
+		return nil, nil
+	case "VertexAttrib2f":
+		ctx.VertexAttrib2f(a[0].(Attrib), a[1].(float32), a[2].(float32))
+		return nil, nil
+	case "VertexAttrib2fv":
+		ctx.VertexAttrib2fv(a[0].(Attrib), a[1].([]float32))
+		return nil, nil
+	case "VertexAttrib3f":
+		ctx.VertexAttrib3f(a[0].(Attrib), a[1].(float32), a[2].(float32), a[3].(float32))
+		return nil, nil
+	case "VertexAttrib3fv":
+		ctx.VertexAttrib3fv(a[0].(Attrib), a[1].([]float32))
+		return nil, nil
+	case "VertexAttrib4f":
+		ctx.VertexAttrib4f(a[0].(Attrib), a[1].(float32), a[2].(float32), a[3].(float32), a[4].(float32))
+		return nil, nil
+	case "VertexAttrib4fv":
+		ctx.VertexAttrib4fv(a[0].(Attrib), a[1].([]float32))
+		return nil, nil
+	case "VertexAttribPointer":
+		ctx.VertexAttribPointer(a[0].(Attrib), a[1].(int), a[2].(Enum), a[3].(bool), a[4].(int), a[5].(int))
+		return nil, nil
+	case "Viewport":
+		ctx.Viewport(a[0].(int), a[1].(int), a[2].(int), a[3].(int))
+		return nil, nil
+	case "BeginTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.BeginTransformFeedback(a[0].(Enum))
+		return nil, nil
+	case "BindBufferBase":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.BindBufferBase(a[0].(Enum), a[1].(uint32), a[2].(Buffer))
+		return nil, nil
+	case "BindBufferRange":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.BindBufferRange(a[0].(Enum), a[1].(uint32), a[2].(Buffer), a[3].(int), a[4].(int))
+		return nil, nil
+	case "BindSampler":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.BindSampler(a[0].(uint32), a[1].(Sampler))
+		return nil, nil
+	case "BindTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.BindTransformFeedback(a[0].(Enum), a[1].(TransformFeedback))
+		return nil, nil
+	case "BlitFramebuffer":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.BlitFramebuffer(a[0].(int), a[1].(int), a[2].(int), a[3].(int), a[4].(int), a[5].(int), a[6].(int), a[7].(int), a[8].(uint), a[9].(Enum))
+		return nil, nil
+	case "ClearBufferfi":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ClearBufferfi(a[0].(Enum), a[1].(int), a[2].(float32), a[3].(int))
+		return nil, nil
+	case "ClearBufferfv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ClearBufferfv(a[0].(Enum), a[1].(int), a[2].([]float32))
+		return nil, nil
+	case "ClearBufferiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ClearBufferiv(a[0].(Enum), a[1].(int), a[2].([]int32))
+		return nil, nil
+	case "ClearBufferuiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ClearBufferuiv(a[0].(Enum), a[1].(int), a[2].([]uint32))
+		return nil, nil
+	case "ClientWaitSync":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.ClientWaitSync(a[0].(Sync), a[1].(uint32), a[2].(uint64))
+		return []interface{}{r0}, nil
+	case "CopyBufferSubData":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.CopyBufferSubData(a[0].(Enum), a[1].(Enum), a[2].(int), a[3].(int), a[4].(int))
+		return nil, nil
+	case "CreateSampler":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.CreateSampler()
+		return []interface{}{r0}, nil
+	case "CreateTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.CreateTransformFeedback()
+		return []interface{}{r0}, nil
+	case "DeleteSampler":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.DeleteSampler(a[0].(Sampler))
+		return nil, nil
+	case "DeleteSync":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.DeleteSync(a[0].(Sync))
+		return nil, nil
+	case "DeleteTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.DeleteTransformFeedback(a[0].(TransformFeedback))
+		return nil, nil
+	case "DrawArraysInstanced":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.DrawArraysInstanced(a[0].(Enum), a[1].(int), a[2].(int), a[3].(int))
+		return nil, nil
+	case "DrawBuffers":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.DrawBuffers(a[0].([]Enum))
+		return nil, nil
+	case "DrawElementsInstanced":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.DrawElementsInstanced(a[0].(Enum), a[1].(int), a[2].(Enum), a[3].(int), a[4].(int))
+		return nil, nil
+	case "EndTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.EndTransformFeedback()
+		return nil, nil
+	case "FenceSync":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.FenceSync(a[0].(Enum), a[1].(uint32))
+		return []interface{}{r0}, nil
+	case "GetActiveUniformBlocki":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.GetActiveUniformBlocki(a[0].(Program), a[1].(uint32), a[2].(Enum))
+		return []interface{}{r0}, nil
+	case "GetActiveUniformBlockName":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.GetActiveUniformBlockName(a[0].(Program), a[1].(uint32))
+		return []interface{}{r0}, nil
+	case "GetSynci":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.GetSynci(a[0].(Sync), a[1].(Enum))
+		return []interface{}{r0}, nil
+	case "GetUniformBlockIndex":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		r0 := ctx3.GetUniformBlockIndex(a[0].(Program), a[1].(string))
+		return []interface{}{r0}, nil
+	case "GetUniformuiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.GetUniformuiv(a[0].([]uint32), a[1].(Uniform), a[2].(Program))
+		return nil, nil
+	case "PauseTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.PauseTransformFeedback()
+		return nil, nil
+	case "ReadBuffer":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ReadBuffer(a[0].(Enum))
+		return nil, nil
+	case "ReadPixelsOffset":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ReadPixelsOffset(a[0].(int), a[1].(int), a[2].(int), a[3].(int), a[4].(Enum), a[5].(Enum), a[6].(int))
+		return nil, nil
+	case "RenderbufferStorageMultisample":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.RenderbufferStorageMultisample(a[0].(Enum), a[1].(int), a[2].(Enum), a[3].(int), a[4].(int))
+		return nil, nil
+	case "ResumeTransformFeedback":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.ResumeTransformFeedback()
+		return nil, nil
+	case "SamplerParameterf":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.SamplerParameterf(a[0].(Sampler), a[1].(Enum), a[2].(float32))
+		return nil, nil
+	case "SamplerParameteri":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.SamplerParameteri(a[0].(Sampler), a[1].(Enum), a[2].(int))
+		return nil, nil
+	case "TexStorage2D":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.TexStorage2D(a[0].(Enum), a[1].(int), a[2].(Enum), a[3].(int), a[4].(int))
+		return nil, nil
+	case "TexSubImage2DOffset":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.TexSubImage2DOffset(a[0].(Enum), a[1].(int), a[2].(int), a[3].(int), a[4].(int), a[5].(int), a[6].(Enum), a[7].(Enum), a[8].(int))
+		return nil, nil
+	case "TransformFeedbackVaryings":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.TransformFeedbackVaryings(a[0].(Program), a[1].([]string), a[2].(Enum))
+		return nil, nil
+	case "Uniform1ui":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform1ui(a[0].(Uniform), a[1].(uint32))
+		return nil, nil
+	case "Uniform1uiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform1uiv(a[0].(Uniform), a[1].([]uint32))
+		return nil, nil
+	case "Uniform2ui":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform2ui(a[0].(Uniform), a[1].(uint32), a[2].(uint32))
+		return nil, nil
+	case "Uniform2uiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform2uiv(a[0].(Uniform), a[1].([]uint32))
+		return nil, nil
+	case "Uniform3ui":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform3ui(a[0].(Uniform), a[1].(uint32), a[2].(uint32), a[3].(uint32))
+		return nil, nil
+	case "Uniform3uiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform3uiv(a[0].(Uniform), a[1].([]uint32))
+		return nil, nil
+	case "Uniform4ui":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform4ui(a[0].(Uniform), a[1].(uint32), a[2].(uint32), a[3].(uint32), a[4].(uint32))
+		return nil, nil
+	case "Uniform4uiv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.Uniform4uiv(a[0].(Uniform), a[1].([]uint32))
+		return nil, nil
+	case "UniformBlockBinding":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformBlockBinding(a[0].(Program), a[1].(uint32), a[2].(uint32))
+		return nil, nil
+	case "UniformMatrix2x3fv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformMatrix2x3fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix2x4fv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformMatrix2x4fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix3x2fv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformMatrix3x2fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix3x4fv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformMatrix3x4fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix4x2fv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformMatrix4x2fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "UniformMatrix4x3fv":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.UniformMatrix4x3fv(a[0].(Uniform), a[1].([]float32))
+		return nil, nil
+	case "VertexAttribDivisor":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.VertexAttribDivisor(a[0].(Attrib), a[1].(int))
+		return nil, nil
+	case "VertexAttribIPointer":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.VertexAttribIPointer(a[0].(Attrib), a[1].(int), a[2].(Enum), a[3].(int), a[4].(int))
+		return nil, nil
+	case "WaitSync":
+		ctx3, ok := ctx.(Context3)
+		if !ok {
+			return nil, errNotContext3
+		}
+		ctx3.WaitSync(a[0].(Sync), a[1].(uint32), a[2].(uint64))
+		return nil, nil
+	}
+	return nil, fmt.Errorf("gl: replay of unknown call %q", name)
+}
@@ -14,9 +14,14 @@ package gl
 //		glctx.(gl.Context3).BlitFramebuffer(...)
 //	}
 //
-// Calls are not safe for concurrent use. However calls can be made from
-// any goroutine, the gl package removes the notion of thread-local
-// context.
+// Calls can be made from any goroutine, the gl package removes the notion
+// of thread-local context. They can also be made from several goroutines
+// at once: the calls of each goroutine run in order, and each blocking call
+// returns its own result. But OpenGL is a stateful API, so goroutines that
+// share a Context must still take turns at sequences of calls that depend
+// on each other, such as binding a texture and then uploading to it.
+// Programs built with the gldebug tag, which checks for an error after
+// each call, panic on concurrent calls.
 //
 // Contexts are independent. Two contexts can be used concurrently.
 type Context interface {
@@ -846,10 +851,345 @@ type Context interface {
 type Context3 interface {
 	Context
 
+	// BeginTransformFeedback starts transform feedback, which writes the
+	// outputs of the vertex shader to the buffers bound to the active
+	// TransformFeedback. The mode is POINTS, LINES or TRIANGLES.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBeginTransformFeedback.xhtml
+	BeginTransformFeedback(mode Enum)
+
+	// BindBufferBase binds a buffer to an indexed binding point of target,
+	// which is UNIFORM_BUFFER or TRANSFORM_FEEDBACK_BUFFER.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBindBufferBase.xhtml
+	BindBufferBase(target Enum, index uint32, b Buffer)
+
+	// BindBufferRange binds size bytes of a buffer, from offset, to an
+	// indexed binding point of target.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBindBufferRange.xhtml
+	BindBufferRange(target Enum, index uint32, b Buffer, offset, size int)
+
+	// BindSampler binds a sampler to a texture unit, numbered from 0. Its
+	// parameters replace those of the textures bound to the unit.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBindSampler.xhtml
+	BindSampler(unit uint32, s Sampler)
+
+	// BindTransformFeedback binds a transform feedback object. The target
+	// must be TRANSFORM_FEEDBACK.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBindTransformFeedback.xhtml
+	BindTransformFeedback(target Enum, tf TransformFeedback)
+
 	// BlitFramebuffer copies a block of pixels between framebuffers.
 	//
 	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBlitFramebuffer.xhtml
 	BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1 int, mask uint, filter Enum)
+
+	// ClearBufferfi clears the depth and stencil buffers of the current
+	// framebuffer. The buffer must be DEPTH_STENCIL and drawbuffer 0.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glClearBuffer.xhtml
+	ClearBufferfi(buffer Enum, drawbuffer int, depth float32, stencil int)
+
+	// ClearBufferfv clears one of the color buffers, or the depth buffer,
+	// of the current framebuffer to value.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glClearBuffer.xhtml
+	ClearBufferfv(buffer Enum, drawbuffer int, value []float32)
+
+	// ClearBufferiv clears one of the signed integer color buffers, or the
+	// stencil buffer, of the current framebuffer to value.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glClearBuffer.xhtml
+	ClearBufferiv(buffer Enum, drawbuffer int, value []int32)
+
+	// ClearBufferuiv clears one of the unsigned integer color buffers of
+	// the current framebuffer to value.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glClearBuffer.xhtml
+	ClearBufferuiv(buffer Enum, drawbuffer int, value []uint32)
+
+	// ClientWaitSync waits up to timeout nanoseconds for a sync object to
+	// be signaled, and returns ALREADY_SIGNALED, CONDITION_SATISFIED,
+	// TIMEOUT_EXPIRED or WAIT_FAILED. The flags may be
+	// SYNC_FLUSH_COMMANDS_BIT.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glClientWaitSync.xhtml
+	ClientWaitSync(s Sync, flags uint32, timeout uint64) Enum
+
+	// CopyBufferSubData copies size bytes from the buffer bound to
+	// readTarget, from readOffset, to the buffer bound to writeTarget, at
+	// writeOffset.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glCopyBufferSubData.xhtml
+	CopyBufferSubData(readTarget, writeTarget Enum, readOffset, writeOffset, size int)
+
+	// CreateSampler creates a sampler object.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGenSamplers.xhtml
+	CreateSampler() Sampler
+
+	// CreateTransformFeedback creates a transform feedback object.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGenTransformFeedbacks.xhtml
+	CreateTransformFeedback() TransformFeedback
+
+	// DeleteSampler deletes the given sampler object.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glDeleteSamplers.xhtml
+	DeleteSampler(s Sampler)
+
+	// DeleteSync deletes the given sync object.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glDeleteSync.xhtml
+	DeleteSync(s Sync)
+
+	// DeleteTransformFeedback deletes the given transform feedback object.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glDeleteTransformFeedbacks.xhtml
+	DeleteTransformFeedback(tf TransformFeedback)
+
+	// DrawArraysInstanced renders instances copies of geometric primitives
+	// from the bound data. Attributes with a divisor advance once every
+	// divisor instances, rather than once a vertex.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glDrawArraysInstanced.xhtml
+	DrawArraysInstanced(mode Enum, first, count, instances int)
+
+	// DrawBuffers sets the color buffers that fragment shader outputs are
+	// written to, in order: NONE, BACK or COLOR_ATTACHMENTi.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glDrawBuffers.xhtml
+	DrawBuffers(bufs []Enum)
+
+	// DrawElementsInstanced renders instances copies of primitives from
+	// the bound element array buffer.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glDrawElementsInstanced.xhtml
+	DrawElementsInstanced(mode Enum, count int, ty Enum, offset, instances int)
+
+	// EndTransformFeedback ends transform feedback.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glBeginTransformFeedback.xhtml
+	EndTransformFeedback()
+
+	// FenceSync creates a sync object that is signaled when the commands
+	// before it complete. The condition must be SYNC_GPU_COMMANDS_COMPLETE
+	// and flags 0.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glFenceSync.xhtml
+	FenceSync(condition Enum, flags uint32) Sync
+
+	// GetActiveUniformBlocki returns a parameter of an active uniform
+	// block. For UNIFORM_BLOCK_ACTIVE_UNIFORM_INDICES, which has many
+	// values, it returns only the first.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGetActiveUniformBlockiv.xhtml
+	GetActiveUniformBlocki(p Program, index uint32, pname Enum) int
+
+	// GetActiveUniformBlockName returns the name of an active uniform
+	// block.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGetActiveUniformBlockName.xhtml
+	GetActiveUniformBlockName(p Program, index uint32) string
+
+	// GetSynci returns a parameter of a sync object, such as SYNC_STATUS.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGetSynciv.xhtml
+	GetSynci(s Sync, pname Enum) int
+
+	// GetUniformBlockIndex returns the index of the named uniform block,
+	// or INVALID_INDEX.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGetUniformBlockIndex.xhtml
+	GetUniformBlockIndex(p Program, name string) uint32
+
+	// GetUniformuiv returns the unsigned integer values of a uniform
+	// variable.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glGetUniform.xhtml
+	GetUniformuiv(dst []uint32, src Uniform, p Program)
+
+	// PauseTransformFeedback pauses transform feedback.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glPauseTransformFeedback.xhtml
+	PauseTransformFeedback()
+
+	// ReadBuffer sets the color buffer that ReadPixels, CopyTexImage2D and
+	// BlitFramebuffer read from: NONE, BACK or COLOR_ATTACHMENTi.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glReadBuffer.xhtml
+	ReadBuffer(src Enum)
+
+	// ReadPixelsOffset is like ReadPixels, but writes the pixels to the
+	// buffer bound to PIXEL_PACK_BUFFER, at offset, rather than to memory.
+	// It does not wait for them to be read.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glReadPixels.xhtml
+	ReadPixelsOffset(x, y, width, height int, format, ty Enum, offset int)
+
+	// RenderbufferStorageMultisample is like RenderbufferStorage, but
+	// allocates storage for samples samples a pixel.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glRenderbufferStorageMultisample.xhtml
+	RenderbufferStorageMultisample(target Enum, samples int, internalFormat Enum, width, height int)
+
+	// ResumeTransformFeedback resumes paused transform feedback.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glResumeTransformFeedback.xhtml
+	ResumeTransformFeedback()
+
+	// SamplerParameterf sets a float parameter of a sampler.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glSamplerParameter.xhtml
+	SamplerParameterf(s Sampler, pname Enum, param float32)
+
+	// SamplerParameteri sets an integer parameter of a sampler.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glSamplerParameter.xhtml
+	SamplerParameteri(s Sampler, pname Enum, param int)
+
+	// TexStorage2D allocates immutable storage for levels levels of a 2D
+	// texture, in a sized internal format such as RGBA8 or, for an integer
+	// texture, RGBA32UI. It is missing on macOS, whose OpenGL predates it.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glTexStorage2D.xhtml
+	TexStorage2D(target Enum, levels int, internalFormat Enum, width, height int)
+
+	// TexSubImage2DOffset is like TexSubImage2D, but reads the pixels from
+	// the buffer bound to PIXEL_UNPACK_BUFFER, at offset, rather than from
+	// memory.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glTexSubImage2D.xhtml
+	TexSubImage2DOffset(target Enum, level int, x, y, width, height int, format, ty Enum, offset int)
+
+	// TransformFeedbackVaryings sets the vertex shader outputs that
+	// transform feedback records, which takes effect when p is next
+	// linked. The mode is INTERLEAVED_ATTRIBS or SEPARATE_ATTRIBS.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glTransformFeedbackVaryings.xhtml
+	TransformFeedbackVaryings(p Program, varyings []string, mode Enum)
+
+	// Uniform1ui writes an unsigned integer uniform variable.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform1ui(dst Uniform, v uint32)
+
+	// Uniform1uiv writes an unsigned integer uniform array of len(src)
+	// elements.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform1uiv(dst Uniform, src []uint32)
+
+	// Uniform2ui writes an unsigned integer vec2 uniform variable.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform2ui(dst Uniform, v0, v1 uint32)
+
+	// Uniform2uiv writes an unsigned integer vec2 uniform array of
+	// len(src)/2 elements.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform2uiv(dst Uniform, src []uint32)
+
+	// Uniform3ui writes an unsigned integer vec3 uniform variable.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform3ui(dst Uniform, v0, v1, v2 uint32)
+
+	// Uniform3uiv writes an unsigned integer vec3 uniform array of
+	// len(src)/3 elements.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform3uiv(dst Uniform, src []uint32)
+
+	// Uniform4ui writes an unsigned integer vec4 uniform variable.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform4ui(dst Uniform, v0, v1, v2, v3 uint32)
+
+	// Uniform4uiv writes an unsigned integer vec4 uniform array of
+	// len(src)/4 elements.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	Uniform4uiv(dst Uniform, src []uint32)
+
+	// UniformBlockBinding sets the uniform buffer binding point, as passed
+	// to BindBufferBase, of a uniform block.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniformBlockBinding.xhtml
+	UniformBlockBinding(p Program, index, binding uint32)
+
+	// UniformMatrix2x3fv writes 2x3 matrices. Each matrix uses six
+	// float32 values, so the number of matrices written is len(src)/6.
+	//
+	// Each matrix must be supplied in column major order.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	UniformMatrix2x3fv(dst Uniform, src []float32)
+
+	// UniformMatrix2x4fv writes 2x4 matrices. Each matrix uses eight
+	// float32 values, so the number of matrices written is len(src)/8.
+	//
+	// Each matrix must be supplied in column major order.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	UniformMatrix2x4fv(dst Uniform, src []float32)
+
+	// UniformMatrix3x2fv writes 3x2 matrices. Each matrix uses six
+	// float32 values, so the number of matrices written is len(src)/6.
+	//
+	// Each matrix must be supplied in column major order.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	UniformMatrix3x2fv(dst Uniform, src []float32)
+
+	// UniformMatrix3x4fv writes 3x4 matrices. Each matrix uses twelve
+	// float32 values, so the number of matrices written is len(src)/12.
+	//
+	// Each matrix must be supplied in column major order.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	UniformMatrix3x4fv(dst Uniform, src []float32)
+
+	// UniformMatrix4x2fv writes 4x2 matrices. Each matrix uses eight
+	// float32 values, so the number of matrices written is len(src)/8.
+	//
+	// Each matrix must be supplied in column major order.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	UniformMatrix4x2fv(dst Uniform, src []float32)
+
+	// UniformMatrix4x3fv writes 4x3 matrices. Each matrix uses twelve
+	// float32 values, so the number of matrices written is len(src)/12.
+	//
+	// Each matrix must be supplied in column major order.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glUniform.xhtml
+	UniformMatrix4x3fv(dst Uniform, src []float32)
+
+	// VertexAttribDivisor sets the rate at which an attribute advances in
+	// instanced drawing: once every divisor instances, or once a vertex if
+	// divisor is 0.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glVertexAttribDivisor.xhtml
+	VertexAttribDivisor(dst Attrib, divisor int)
+
+	// VertexAttribIPointer is like VertexAttribPointer, but for an integer
+	// attribute, whose values are not converted to floats. The type is
+	// BYTE, UNSIGNED_BYTE, SHORT, UNSIGNED_SHORT, INT or UNSIGNED_INT.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glVertexAttribPointer.xhtml
+	VertexAttribIPointer(dst Attrib, size int, ty Enum, stride, offset int)
+
+	// WaitSync makes the GL server, rather than the caller, wait for a sync
+	// object to be signaled before running later commands. The flags must
+	// be 0 and timeout TIMEOUT_IGNORED.
+	//
+	// https://www.khronos.org/opengles/sdk/docs/man3/html/glWaitSync.xhtml
+	WaitSync(s Sync, flags uint32, timeout uint64)
 }
 
 // Worker is used by display driver code to execute OpenGL calls.
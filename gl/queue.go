@@ -0,0 +1,81 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build linux darwin windows openbsd
+
+package gl
+
+import "sync"
+
+// workbufLen is the largest number of calls that DoWork runs in one batch,
+// and so in one cgo call.
+const workbufLen = 16
+
+// replies holds the channels on which blocking calls wait for their return
+// values. Each has room for one value, so that the worker never waits for
+// the caller to receive it.
+var replies = sync.Pool{
+	New: func() interface{} { return make(chan uintptr, 1) },
+}
+
+// enqueue adds c to the work queue and, if it is blocking, waits for it to
+// run and returns its return value. It may be called by many goroutines at
+// once: each blocking call is given its own reply channel.
+func (ctx *context) enqueue(c call) uintptr {
+	if c.blocking {
+		c.ret = replies.Get().(chan uintptr)
+	}
+	ctx.work <- c
+
+	select {
+	case ctx.workAvailable <- struct{}{}:
+	default:
+	}
+
+	if !c.blocking {
+		return 0
+	}
+	ret := <-c.ret
+	replies.Put(c.ret)
+	return ret
+}
+
+// drain runs the queued calls until the queue is empty. It takes up to
+// workbufLen of them at a time and hands them to process, which must set
+// ret[i] to the return value of batch[i], and then sends each blocking call
+// its return value.
+//
+// A batch does not end at a blocking call, so the blocking calls of several
+// goroutines can share a batch. The calls of each goroutine still run in the
+// order that it made them.
+func (ctx *context) drain(process func(batch []call, ret []uintptr)) {
+	var (
+		batch [workbufLen]call
+		ret   [workbufLen]uintptr
+	)
+	for {
+		n := 0
+	fill:
+		for n < workbufLen {
+			select {
+			case batch[n] = <-ctx.work:
+				n++
+			default:
+				break fill
+			}
+		}
+		if n == 0 {
+			return
+		}
+
+		process(batch[:n], ret[:n])
+
+		for i := range batch[:n] {
+			if batch[i].blocking {
+				batch[i].ret <- ret[i]
+			}
+			batch[i] = call{}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build linux darwin windows openbsd
+
+package gl
+
+import (
+	"sync"
+	"testing"
+)
+
+// fakeWorker runs the calls of ctx until stop is closed. Rather than making
+// GL calls, which need a current GL context, it returns a0+a1 of each call,
+// and checks that the calls of each goroutine, which is named by a0, come in
+// the order of their sequence numbers, a1.
+func fakeWorker(t *testing.T, ctx *context, stop <-chan struct{}) (batches, calls *int) {
+	batches, calls = new(int), new(int)
+	next := make(map[uintptr]uintptr)
+	process := func(batch []call, ret []uintptr) {
+		if len(batch) > workbufLen {
+			t.Errorf("batch of %d calls, want at most %d", len(batch), workbufLen)
+		}
+		*batches++
+		*calls += len(batch)
+		for i, c := range batch {
+			g, seq := c.args.a0, c.args.a1
+			if seq != next[g] {
+				t.Errorf("goroutine %d: got call %d, want %d", g, seq, next[g])
+			}
+			next[g] = seq + 1
+			ret[i] = g + seq
+		}
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.workAvailable:
+				ctx.drain(process)
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return batches, calls
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	const (
+		goroutines = 32
+		n          = 2000
+	)
+	ctx := &context{
+		workAvailable: make(chan struct{}, 1),
+		work:          make(chan call, workbufLen),
+	}
+	stop := make(chan struct{})
+	batches, calls := fakeWorker(t, ctx, stop)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g uintptr) {
+			defer wg.Done()
+			for seq := uintptr(0); seq < n; seq++ {
+				// Every third call is blocking, and must get its own
+				// result back, not that of another goroutine's call.
+				blocking := seq%3 == 2
+				ret := ctx.enqueue(call{
+					args:     fnargs{fn: glfnGetError, a0: g, a1: seq},
+					blocking: blocking,
+				})
+				if blocking && ret != g+seq {
+					t.Errorf("goroutine %d: call %d returned %d, want %d", g, seq, ret, g+seq)
+					return
+				}
+			}
+			// Wait for the goroutine's non-blocking calls to run.
+			ctx.enqueue(call{
+				args:     fnargs{fn: glfnGetError, a0: g, a1: n},
+				blocking: true,
+			})
+		}(uintptr(g))
+	}
+	wg.Wait()
+	close(stop)
+
+	if want := goroutines * (n + 1); *calls != want {
+		t.Errorf("ran %d calls, want %d", *calls, want)
+	}
+	t.Logf("%d calls in %d batches", *calls, *batches)
+}
+
+func TestBatchBlocking(t *testing.T) {
+	// Queue a full buffer of blocking calls before starting the worker, as
+	// if made by that many goroutines at once. They should share one batch.
+	ctx := &context{
+		workAvailable: make(chan struct{}, 1),
+		work:          make(chan call, workbufLen),
+	}
+	rets := make([]chan uintptr, workbufLen)
+	for i := range rets {
+		rets[i] = make(chan uintptr, 1)
+		ctx.work <- call{
+			args:     fnargs{fn: glfnGetError, a0: uintptr(i)},
+			blocking: true,
+			ret:      rets[i],
+		}
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	batches, _ := fakeWorker(t, ctx, stop)
+	ctx.workAvailable <- struct{}{}
+	for i, r := range rets {
+		if got := <-r; got != uintptr(i) {
+			t.Errorf("call %d: got %d, want %d", i, got, i)
+		}
+	}
+	if *batches != 1 {
+		t.Errorf("got %d batches, want 1", *batches)
+	}
+}
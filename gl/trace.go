@@ -0,0 +1,676 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// A trace is the header
+//
+//	"GLTRACE1"
+//	the number of call names, then each name
+//
+// followed by the calls. A call is the index of its name in the header,
+// then its arguments, then its results, encoded by type:
+//
+//	bool, Program.Init       1 byte
+//	int, int32, Uniform      signed varint
+//	other integers, Enum,
+//	objects, Attrib          unsigned varint
+//	float32                  4 bytes, little-endian IEEE 754
+//	string, slices           unsigned varint length, then the elements
+//
+// Arguments are written after the call returns, so that slices that the
+// call fills in, such as that of ReadPixels, hold what it returned.
+const traceMagic = "GLTRACE1"
+
+// traceType is the type of an argument or result of a traced call.
+type traceType uint8
+
+const (
+	traceAttrib traceType = iota
+	traceBool
+	traceBools
+	traceBuffer
+	traceBytes
+	traceEnum
+	traceEnums
+	traceFloat32
+	traceFloat32s
+	traceFramebuffer
+	traceInt
+	traceInt32
+	traceInt32s
+	traceProgram
+	traceRenderbuffer
+	traceSampler
+	traceShader
+	traceShaders
+	traceString
+	traceStrings
+	traceSync
+	traceTexture
+	traceTransformFeedback
+	traceUint
+	traceUint32
+	traceUint32s
+	traceUint64
+	traceUniform
+	traceVertexArray
+)
+
+// traceSig is the signature of a traced call.
+type traceSig struct {
+	args, results []traceType
+}
+
+// A Tracer records the calls made on a Context while it is started. The
+// calls cost little more than an atomic load while it is stopped, so a
+// program can trace on demand.
+//
+// The calls are written, with their arguments, results and the contents of
+// the slices they take, to a compact binary trace, which a TraceReader reads
+// and the gltrace command dumps, compares and replays.
+type Tracer struct {
+	on int32 // Whether w is set, read atomically.
+
+	mu  sync.Mutex // Guards w, buf and err.
+	w   *bufio.Writer
+	buf []byte
+	err error
+}
+
+// NewTracer returns a Context that makes its calls on ctx, and the Tracer
+// that records them. The Context is a Context3 if ctx is.
+//
+// Like ctx, the Context must not be used by several goroutines at once. The
+// Tracer's methods may be called from any goroutine.
+func NewTracer(ctx Context) (Context, *Tracer) {
+	t := new(Tracer)
+	tc := &traceContext{t: t, ctx: ctx}
+	if _, ok := ctx.(Context3); ok {
+		return &traceContext3{tc}, t
+	}
+	return tc, t
+}
+
+type traceContext struct {
+	t   *Tracer
+	ctx Context
+}
+
+type traceContext3 struct {
+	*traceContext
+}
+
+// Start starts writing a trace of the calls to w. It is an error to start a
+// Tracer that is already started.
+func (t *Tracer) Start(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.w != nil {
+		return errors.New("gl: tracer already started")
+	}
+	t.w, t.err = bufio.NewWriter(w), nil
+	t.buf = append(t.buf[:0], traceMagic...)
+	t.buf = binary.AppendUvarint(t.buf, uint64(len(traceNames)))
+	for _, name := range traceNames {
+		t.buf = appendTraceString(t.buf, name)
+	}
+	t.flushBuf()
+	atomic.StoreInt32(&t.on, 1)
+	return nil
+}
+
+// Stop stops tracing, and returns the first error, if any, in writing the
+// trace since it was started.
+func (t *Tracer) Stop() error {
+	atomic.StoreInt32(&t.on, 0)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.w == nil {
+		return errors.New("gl: tracer not started")
+	}
+	if err := t.w.Flush(); t.err == nil {
+		t.err = err
+	}
+	t.w = nil
+	return t.err
+}
+
+// begin returns an encoder for a call to the method with index id in
+// traceNames, or nil if t is not started. If it is not nil, the call must
+// be finished by calling end.
+func (t *Tracer) begin(id int) *traceEncoder {
+	if atomic.LoadInt32(&t.on) == 0 {
+		return nil
+	}
+	t.mu.Lock()
+	if t.w == nil {
+		t.mu.Unlock()
+		return nil
+	}
+	t.buf = binary.AppendUvarint(t.buf[:0], uint64(id))
+	return (*traceEncoder)(t)
+}
+
+// flushBuf writes t.buf to t.w. After an error, the trace is stopped.
+func (t *Tracer) flushBuf() {
+	if _, err := t.w.Write(t.buf); err != nil && t.err == nil {
+		t.err = err
+		atomic.StoreInt32(&t.on, 0)
+	}
+}
+
+// traceEncoder appends the values of a call to the Tracer's buffer.
+type traceEncoder Tracer
+
+func (e *traceEncoder) end() {
+	t := (*Tracer)(e)
+	t.flushBuf()
+	t.mu.Unlock()
+}
+
+func appendTraceString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func (e *traceEncoder) uvarint(x uint64) { e.buf = binary.AppendUvarint(e.buf, x) }
+func (e *traceEncoder) varint(x int64)   { e.buf = binary.AppendVarint(e.buf, x) }
+
+func (e *traceEncoder) bool(x bool) {
+	if x {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *traceEncoder) float32(x float32) {
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, math.Float32bits(x))
+}
+
+func (e *traceEncoder) attrib(x Attrib)                       { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) buffer(x Buffer)                       { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) enum(x Enum)                           { e.uvarint(uint64(x)) }
+func (e *traceEncoder) framebuffer(x Framebuffer)             { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) int(x int)                             { e.varint(int64(x)) }
+func (e *traceEncoder) int32(x int32)                         { e.varint(int64(x)) }
+func (e *traceEncoder) renderbuffer(x Renderbuffer)           { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) sampler(x Sampler)                     { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) shader(x Shader)                       { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) string(x string)                       { e.buf = appendTraceString(e.buf, x) }
+func (e *traceEncoder) sync(x Sync)                           { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) texture(x Texture)                     { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) transformFeedback(x TransformFeedback) { e.uvarint(uint64(x.Value)) }
+func (e *traceEncoder) uint(x uint)                           { e.uvarint(uint64(x)) }
+func (e *traceEncoder) uint32(x uint32)                       { e.uvarint(uint64(x)) }
+func (e *traceEncoder) uint64(x uint64)                       { e.uvarint(x) }
+func (e *traceEncoder) uniform(x Uniform)                     { e.varint(int64(x.Value)) }
+func (e *traceEncoder) vertexArray(x VertexArray)             { e.uvarint(uint64(x.Value)) }
+
+func (e *traceEncoder) program(x Program) {
+	e.bool(x.Init)
+	e.uvarint(uint64(x.Value))
+}
+
+func (e *traceEncoder) bools(x []bool) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.bool(v)
+	}
+}
+
+func (e *traceEncoder) bytes(x []byte) {
+	e.uvarint(uint64(len(x)))
+	e.buf = append(e.buf, x...)
+}
+
+func (e *traceEncoder) enums(x []Enum) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.enum(v)
+	}
+}
+
+func (e *traceEncoder) float32s(x []float32) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.float32(v)
+	}
+}
+
+func (e *traceEncoder) int32s(x []int32) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.int32(v)
+	}
+}
+
+func (e *traceEncoder) shaders(x []Shader) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.shader(v)
+	}
+}
+
+func (e *traceEncoder) strings(x []string) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.string(v)
+	}
+}
+
+func (e *traceEncoder) uint32s(x []uint32) {
+	e.uvarint(uint64(len(x)))
+	for _, v := range x {
+		e.uint32(v)
+	}
+}
+
+// TraceCall is a call read from a trace.
+type TraceCall struct {
+	Name string
+	// Args and Results have the types of the parameters and results of
+	// the Context method Name.
+	Args    []interface{}
+	Results []interface{}
+}
+
+// String returns the call as it would be written in Go, followed by its
+// results, if any, like the log of the gldebug build. Enums are written by
+// name, and slices by length.
+func (c *TraceCall) String() string {
+	var buf bytes.Buffer
+	buf.WriteString(c.Name)
+	buf.WriteByte('(')
+	for i, a := range c.Args {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(traceFormat(a))
+	}
+	buf.WriteByte(')')
+	for i, r := range c.Results {
+		if i == 0 {
+			buf.WriteByte(' ')
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(traceFormat(r))
+	}
+	return buf.String()
+}
+
+func traceFormat(v interface{}) string {
+	switch v := v.(type) {
+	case Enum:
+		if s, ok := traceEnumNames[v]; ok {
+			return s
+		}
+		return fmt.Sprintf("gl.Enum(0x%x)", uint32(v))
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []bool, []byte, []Enum, []float32, []int32, []Shader, []string, []uint32:
+		return fmt.Sprintf("len(%d)", sliceLen(v))
+	}
+	return fmt.Sprint(v)
+}
+
+func sliceLen(v interface{}) int {
+	switch v := v.(type) {
+	case []bool:
+		return len(v)
+	case []byte:
+		return len(v)
+	case []Enum:
+		return len(v)
+	case []float32:
+		return len(v)
+	case []int32:
+		return len(v)
+	case []Shader:
+		return len(v)
+	case []string:
+		return len(v)
+	case []uint32:
+		return len(v)
+	}
+	return 0
+}
+
+// A TraceReader reads the calls of a trace.
+type TraceReader struct {
+	r     *bufio.Reader
+	names []string
+	sigs  []*traceSig
+}
+
+// maxTraceLen is the longest string or slice that a TraceReader reads, so
+// that a corrupt trace does not exhaust memory.
+const maxTraceLen = 1 << 30
+
+// NewTraceReader returns a TraceReader that reads the trace from r. It
+// reads the header of the trace, and returns an error if it is not valid or
+// names a call that is not a method of Context3.
+func NewTraceReader(r io.Reader) (*TraceReader, error) {
+	tr := &TraceReader{r: bufio.NewReader(r)}
+	magic := make([]byte, len(traceMagic))
+	if _, err := io.ReadFull(tr.r, magic); err != nil || string(magic) != traceMagic {
+		return nil, errors.New("gl: not a trace")
+	}
+	n, err := tr.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < n; i++ {
+		name, err := tr.string()
+		if err != nil {
+			return nil, err
+		}
+		sig, ok := traceSigs[name]
+		if !ok {
+			return nil, fmt.Errorf("gl: trace has unknown call %q", name)
+		}
+		tr.names = append(tr.names, name)
+		tr.sigs = append(tr.sigs, sig)
+	}
+	return tr, nil
+}
+
+// Next returns the next call of the trace, or io.EOF if there are none.
+func (tr *TraceReader) Next() (*TraceCall, error) {
+	id, err := binary.ReadUvarint(tr.r)
+	if err != nil {
+		return nil, err
+	}
+	if id >= uint64(len(tr.names)) {
+		return nil, fmt.Errorf("gl: bad trace call index %d", id)
+	}
+	c := &TraceCall{Name: tr.names[id]}
+	sig := tr.sigs[id]
+	for _, ty := range sig.args {
+		v, err := tr.value(ty)
+		if err != nil {
+			return nil, err
+		}
+		c.Args = append(c.Args, v)
+	}
+	for _, ty := range sig.results {
+		v, err := tr.value(ty)
+		if err != nil {
+			return nil, err
+		}
+		c.Results = append(c.Results, v)
+	}
+	return c, nil
+}
+
+// uvarint reads an unsigned varint, treating the end of the trace as an
+// error, as it is only read within a call or the header.
+func (tr *TraceReader) uvarint() (uint64, error) {
+	x, err := binary.ReadUvarint(tr.r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return x, err
+}
+
+func (tr *TraceReader) varint() (int64, error) {
+	x, err := binary.ReadVarint(tr.r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return x, err
+}
+
+func (tr *TraceReader) len() (int, error) {
+	n, err := tr.uvarint()
+	if err == nil && n > maxTraceLen {
+		err = fmt.Errorf("gl: trace has a slice of length %d", n)
+	}
+	return int(n), err
+}
+
+func (tr *TraceReader) bytes() ([]byte, error) {
+	n, err := tr.len()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(tr.r, b); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return b, nil
+}
+
+func (tr *TraceReader) string() (string, error) {
+	b, err := tr.bytes()
+	return string(b), err
+}
+
+func (tr *TraceReader) bool() (bool, error) {
+	b, err := tr.r.ReadByte()
+	if err != nil {
+		return false, io.ErrUnexpectedEOF
+	}
+	return b != 0, nil
+}
+
+func (tr *TraceReader) float32() (float32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(tr.r, b[:]); err != nil {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return math.Float32frombits(binary.LittleEndian.Uint32(b[:])), nil
+}
+
+// value reads a value of type ty.
+func (tr *TraceReader) value(ty traceType) (interface{}, error) {
+	switch ty {
+	case traceBool:
+		return tr.bool()
+	case traceFloat32:
+		return tr.float32()
+	case traceInt, traceInt32, traceUniform:
+		x, err := tr.varint()
+		switch ty {
+		case traceInt:
+			return int(x), err
+		case traceInt32:
+			return int32(x), err
+		}
+		return Uniform{Value: int32(x)}, err
+	case traceProgram:
+		init, err := tr.bool()
+		if err != nil {
+			return nil, err
+		}
+		x, err := tr.uvarint()
+		return Program{Init: init, Value: uint32(x)}, err
+	case traceString:
+		return tr.string()
+	case traceBytes:
+		return tr.bytes()
+	case traceBools, traceEnums, traceFloat32s, traceInt32s, traceShaders, traceStrings, traceUint32s:
+		return tr.slice(ty)
+	}
+	x, err := tr.uvarint()
+	switch ty {
+	case traceAttrib:
+		return Attrib{Value: uint(x)}, err
+	case traceBuffer:
+		return Buffer{Value: uint32(x)}, err
+	case traceEnum:
+		return Enum(x), err
+	case traceFramebuffer:
+		return Framebuffer{Value: uint32(x)}, err
+	case traceRenderbuffer:
+		return Renderbuffer{Value: uint32(x)}, err
+	case traceSampler:
+		return Sampler{Value: uint32(x)}, err
+	case traceShader:
+		return Shader{Value: uint32(x)}, err
+	case traceSync:
+		return Sync{Value: uintptr(x)}, err
+	case traceTexture:
+		return Texture{Value: uint32(x)}, err
+	case traceTransformFeedback:
+		return TransformFeedback{Value: uint32(x)}, err
+	case traceUint:
+		return uint(x), err
+	case traceUint32:
+		return uint32(x), err
+	case traceUint64:
+		return x, err
+	case traceVertexArray:
+		return VertexArray{Value: uint32(x)}, err
+	}
+	return nil, fmt.Errorf("gl: bad trace type %d", ty)
+}
+
+// slice reads a slice of type ty.
+func (tr *TraceReader) slice(ty traceType) (interface{}, error) {
+	n, err := tr.len()
+	if err != nil {
+		return nil, err
+	}
+	switch ty {
+	case traceBools:
+		s := make([]bool, n)
+		for i := range s {
+			if s[i], err = tr.bool(); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case traceFloat32s:
+		s := make([]float32, n)
+		for i := range s {
+			if s[i], err = tr.float32(); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case traceInt32s:
+		s := make([]int32, n)
+		for i := range s {
+			x, err := tr.varint()
+			if err != nil {
+				return nil, err
+			}
+			s[i] = int32(x)
+		}
+		return s, nil
+	case traceStrings:
+		s := make([]string, n)
+		for i := range s {
+			if s[i], err = tr.string(); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case traceEnums:
+		s := make([]Enum, n)
+		for i := range s {
+			x, err := tr.uvarint()
+			if err != nil {
+				return nil, err
+			}
+			s[i] = Enum(x)
+		}
+		return s, nil
+	case traceUint32s:
+		s := make([]uint32, n)
+		for i := range s {
+			x, err := tr.uvarint()
+			if err != nil {
+				return nil, err
+			}
+			s[i] = uint32(x)
+		}
+		return s, nil
+	}
+	s := make([]Shader, n)
+	for i := range s {
+		x, err := tr.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		s[i] = Shader{Value: uint32(x)}
+	}
+	return s, nil
+}
+
+// A Replayer makes the calls of a trace on a Context.
+//
+// The objects and locations that the Context returns will generally differ
+// from those in the trace, so a Replayer replaces those in the arguments of
+// a call with the ones that the Context returned for them.
+type Replayer struct {
+	ctx   Context
+	names map[interface{}]interface{}
+}
+
+// NewReplayer returns a Replayer that makes calls on ctx.
+func NewReplayer(ctx Context) *Replayer {
+	return &Replayer{ctx: ctx, names: make(map[interface{}]interface{})}
+}
+
+// Replay makes the call c, and returns its results. It returns an error if
+// c is a method of Context3 and the Replayer's Context is not a Context3, or
+// if c's arguments are not of the types of the method's parameters.
+func (r *Replayer) Replay(c *TraceCall) (results []interface{}, err error) {
+	args := make([]interface{}, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = a
+		if isTraceName(a) {
+			if v, ok := r.names[a]; ok {
+				args[i] = v
+			}
+		}
+	}
+	defer func() {
+		// The generated code type-asserts the arguments.
+		if e := recover(); e != nil {
+			if _, ok := e.(error); !ok {
+				panic(e)
+			}
+			err = fmt.Errorf("gl: replay %s: %v", c.Name, e)
+		}
+	}()
+	results, err = replayCall(r.ctx, c.Name, args)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range results {
+		if i < len(c.Results) && isTraceName(v) {
+			r.names[c.Results[i]] = v
+		}
+	}
+	return results, nil
+}
+
+// isTraceName reports whether v is an object or a location, which the
+// Replayer maps.
+func isTraceName(v interface{}) bool {
+	switch v.(type) {
+	case Attrib, Buffer, Framebuffer, Program, Renderbuffer, Sampler, Shader, Sync, Texture, TransformFeedback, Uniform, VertexArray:
+		return true
+	}
+	return false
+}
+
+var errNotContext3 = errors.New("gl: replay of a Context3 call on a Context")
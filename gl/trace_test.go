@@ -0,0 +1,299 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/as/shiny/gl"
+	"github.com/as/shiny/gl/glfake"
+)
+
+const vertexSrc = `#version 100
+uniform mat3 mvp;
+attribute vec2 pos;
+void main() {
+	gl_Position = vec4(mvp * vec3(pos, 1), 1);
+}
+`
+
+const fragmentSrc = `#version 100
+precision mediump float;
+uniform vec4 color;
+void main() {
+	gl_FragColor = color;
+}
+`
+
+// draw makes calls of most kinds on glctx, and returns the pixels that it
+// reads back.
+func draw(glctx gl.Context) []byte {
+	p := glctx.CreateProgram()
+	vs := glctx.CreateShader(gl.VERTEX_SHADER)
+	glctx.ShaderSource(vs, vertexSrc)
+	glctx.CompileShader(vs)
+	fs := glctx.CreateShader(gl.FRAGMENT_SHADER)
+	glctx.ShaderSource(fs, fragmentSrc)
+	glctx.CompileShader(fs)
+	glctx.AttachShader(p, vs)
+	glctx.AttachShader(p, fs)
+	glctx.LinkProgram(p)
+	glctx.UseProgram(p)
+	glctx.Uniform4fv(glctx.GetUniformLocation(p, "color"), []float32{1, 0.5, 0.25, 1})
+	glctx.UniformMatrix3fv(glctx.GetUniformLocation(p, "mvp"), []float32{1, 0, 0, 0, 1, 0, 0, 0, 1})
+
+	buf := glctx.CreateBuffer()
+	glctx.BindBuffer(gl.ARRAY_BUFFER, buf)
+	glctx.BufferData(gl.ARRAY_BUFFER, make([]byte, 4*2*4), gl.STATIC_DRAW)
+	pos := glctx.GetAttribLocation(p, "pos")
+	glctx.EnableVertexAttribArray(pos)
+	glctx.VertexAttribPointer(pos, 2, gl.FLOAT, false, 0, 0)
+
+	tex := glctx.CreateTexture()
+	glctx.BindTexture(gl.TEXTURE_2D, tex)
+	glctx.TexImage2D(gl.TEXTURE_2D, 0, 2, 2, gl.RGBA, gl.UNSIGNED_BYTE, []byte{
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16,
+	})
+	fb := glctx.CreateFramebuffer()
+	glctx.BindFramebuffer(gl.FRAMEBUFFER, fb)
+	glctx.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, tex, 0)
+	glctx.DrawArrays(gl.TRIANGLE_STRIP, 0, 4)
+	dst := make([]byte, 16)
+	glctx.ReadPixels(dst, 0, 0, 2, 2, gl.RGBA, gl.UNSIGNED_BYTE)
+	return dst
+}
+
+func readTrace(t *testing.T, b []byte) []*gl.TraceCall {
+	t.Helper()
+	r, err := gl.NewTraceReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls []*gl.TraceCall
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			return calls
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, c)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	fake := glfake.NewContext()
+	glctx, tracer := gl.NewTracer(fake)
+	if _, ok := glctx.(gl.Context3); ok {
+		t.Errorf("tracer of a Context is a Context3")
+	}
+	glctx.Flush() // Not traced.
+
+	var buf bytes.Buffer
+	if err := tracer.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracer.Start(&buf); err == nil {
+		t.Errorf("second Start: got nil error")
+	}
+	pix := draw(glctx)
+	if err := tracer.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	glctx.Finish() // Not traced.
+
+	calls := readTrace(t, buf.Bytes())
+	want := fake.Calls()
+	want = want[1 : len(want)-1]
+	if len(calls) != len(want) {
+		t.Fatalf("got %d calls, want %d", len(calls), len(want))
+	}
+	for i, c := range calls {
+		w := want[i]
+		if w.Err != 0 {
+			t.Errorf("%v", w)
+		}
+		if c.Name != w.Name || !reflect.DeepEqual(c.Args, w.Args) || !reflect.DeepEqual(c.Results, w.Results) {
+			t.Errorf("call %d: got %v, want %v", i, c, w)
+		}
+	}
+	last := calls[len(calls)-1]
+	if got, want := last.String(), "ReadPixels(len(16), 0, 0, 2, 2, RGBA, UNSIGNED_BYTE)"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+	if !bytes.Equal(last.Args[0].([]byte), pix) {
+		t.Errorf("ReadPixels: traced %v, want %v", last.Args[0], pix)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	glctx, tracer := gl.NewTracer(glfake.NewContext())
+	var buf bytes.Buffer
+	tracer.Start(&buf)
+	want := draw(glctx)
+	tracer.Stop()
+
+	// Make some objects first, so that the names differ from the trace's.
+	fake := glfake.NewContext()
+	fake.CreateTexture()
+	fake.CreateProgram()
+	fake.BindAttribLocation(gl.Program{}, gl.Attrib{}, "")
+	fake.GetError()
+	fake.ResetCalls()
+
+	r := gl.NewReplayer(fake)
+	calls := readTrace(t, buf.Bytes())
+	for _, c := range calls {
+		if _, err := r.Replay(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range fake.Calls() {
+		if c.Err != 0 {
+			t.Errorf("%v", c)
+		}
+	}
+	last := fake.Calls()[len(calls)-1]
+	if got := last.Args[0].([]byte); !bytes.Equal(got, want) {
+		t.Errorf("ReadPixels: got %v, want %v", got, want)
+	}
+
+	_, err := r.Replay(&gl.TraceCall{Name: "BlitFramebuffer", Args: make([]interface{}, 10)})
+	if err == nil {
+		t.Errorf("BlitFramebuffer on a Context: got nil error")
+	}
+	_, err = r.Replay(&gl.TraceCall{Name: "BindTexture", Args: []interface{}{gl.TEXTURE_2D, 1}})
+	if err == nil {
+		t.Errorf("bad argument: got nil error")
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("full") }
+
+func TestTraceErrors(t *testing.T) {
+	glctx, tracer := gl.NewTracer(glfake.NewContext())
+	if err := tracer.Stop(); err == nil {
+		t.Errorf("Stop before Start: got nil error")
+	}
+	tracer.Start(errWriter{})
+	draw(glctx)
+	if err := tracer.Stop(); err == nil {
+		t.Errorf("Stop after a write error: got nil error")
+	}
+
+	if _, err := gl.NewTraceReader(bytes.NewReader([]byte("not a trace"))); err == nil {
+		t.Errorf("NewTraceReader of junk: got nil error")
+	}
+	var buf bytes.Buffer
+	tracer.Start(&buf)
+	draw(glctx)
+	tracer.Stop()
+	r, err := gl.NewTraceReader(bytes.NewReader(buf.Bytes()[:buf.Len()-3]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for err == nil {
+		_, err = r.Next()
+	}
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated trace: got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+// context3 is a Context3 that records some of the calls of Context3 that
+// Context does not have. The others panic.
+type context3 struct {
+	gl.Context3
+	calls []string
+	syncs uintptr
+}
+
+func (c *context3) record(format string, args ...interface{}) {
+	c.calls = append(c.calls, fmt.Sprintf(format, args...))
+}
+
+func (c *context3) DrawBuffers(bufs []gl.Enum) {
+	v := make([]uint32, len(bufs))
+	for i, b := range bufs {
+		v[i] = uint32(b)
+	}
+	c.record("DrawBuffers(%#x)", v)
+}
+
+func (c *context3) FenceSync(condition gl.Enum, flags uint32) gl.Sync {
+	c.syncs += 0x10
+	return gl.Sync{Value: c.syncs}
+}
+
+func (c *context3) ClientWaitSync(s gl.Sync, flags uint32, timeout uint64) gl.Enum {
+	c.record("ClientWaitSync(%v, %d, %d)", s, flags, timeout)
+	return gl.CONDITION_SATISFIED
+}
+
+func (c *context3) TransformFeedbackVaryings(p gl.Program, varyings []string, mode gl.Enum) {
+	c.record("TransformFeedbackVaryings(%d, %q, %#x)", p.Value, varyings, uint32(mode))
+}
+
+func (c *context3) Uniform4uiv(dst gl.Uniform, src []uint32) {
+	c.record("Uniform4uiv(%d, %v)", dst.Value, src)
+}
+
+func draw3(glctx gl.Context3) {
+	glctx.DrawBuffers([]gl.Enum{gl.COLOR_ATTACHMENT0, gl.NONE, gl.COLOR_ATTACHMENT1})
+	s := glctx.FenceSync(gl.SYNC_GPU_COMMANDS_COMPLETE, 0)
+	glctx.ClientWaitSync(s, gl.SYNC_FLUSH_COMMANDS_BIT, gl.TIMEOUT_IGNORED)
+	glctx.TransformFeedbackVaryings(gl.Program{Init: true, Value: 3}, []string{"pos", "color"}, gl.INTERLEAVED_ATTRIBS)
+	glctx.Uniform4uiv(gl.Uniform{Value: 2}, []uint32{1, 2, 3, 1 << 31})
+}
+
+func TestTraceContext3(t *testing.T) {
+	fake := new(context3)
+	glctx, tracer := gl.NewTracer(fake)
+	var buf bytes.Buffer
+	tracer.Start(&buf)
+	draw3(glctx.(gl.Context3))
+	if err := tracer.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := readTrace(t, buf.Bytes())
+	if len(calls) != 5 {
+		t.Fatalf("got %d calls, want 5", len(calls))
+	}
+	if got, want := calls[3].String(), "TransformFeedbackVaryings(Program(3), len(2), INTERLEAVED_ATTRIBS)"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+
+	// Replay on a Context3 whose syncs differ from the trace's.
+	replayed := &context3{syncs: 0x1000}
+	r := gl.NewReplayer(replayed)
+	for _, c := range calls {
+		if _, err := r.Replay(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := func(sync string) []string {
+		return []string{
+			"DrawBuffers([0x8ce0 0x0 0x8ce1])",
+			"ClientWaitSync(Sync(" + sync + "), 1, 18446744073709551615)",
+			`TransformFeedbackVaryings(3, ["pos" "color"], 0x8c8c)`,
+			"Uniform4uiv(2, [1 2 3 2147483648])",
+		}
+	}
+	if !reflect.DeepEqual(fake.calls, want("0x10")) {
+		t.Errorf("calls:\n%q\nwant\n%q", fake.calls, want("0x10"))
+	}
+	if !reflect.DeepEqual(replayed.calls, want("0x1010")) {
+		t.Errorf("replayed calls:\n%q\nwant\n%q", replayed.calls, want("0x1010"))
+	}
+}
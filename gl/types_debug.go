@@ -53,6 +53,18 @@ type VertexArray struct {
 	Value uint32
 }
 
+type Sampler struct {
+	Value uint32
+}
+
+type Sync struct {
+	Value uintptr
+}
+
+type TransformFeedback struct {
+	Value uint32
+}
+
 func (v Attrib) c() uintptr { return uintptr(v.Value) }
 func (v Enum) c() uintptr   { return uintptr(v) }
 func (v Program) c() uintptr {
@@ -63,20 +75,26 @@ func (v Program) c() uintptr {
 	}
 	return uintptr(v.Value)
 }
-func (v Shader) c() uintptr       { return uintptr(v.Value) }
-func (v Buffer) c() uintptr       { return uintptr(v.Value) }
-func (v Framebuffer) c() uintptr  { return uintptr(v.Value) }
-func (v Renderbuffer) c() uintptr { return uintptr(v.Value) }
-func (v Texture) c() uintptr      { return uintptr(v.Value) }
-func (v Uniform) c() uintptr      { return uintptr(v.Value) }
-func (v VertexArray) c() uintptr  { return uintptr(v.Value) }
-
-func (v Attrib) String() string       { return fmt.Sprintf("Attrib(%d:%s)", v.Value, v.name) }
-func (v Program) String() string      { return fmt.Sprintf("Program(%d)", v.Value) }
-func (v Shader) String() string       { return fmt.Sprintf("Shader(%d)", v.Value) }
-func (v Buffer) String() string       { return fmt.Sprintf("Buffer(%d)", v.Value) }
-func (v Framebuffer) String() string  { return fmt.Sprintf("Framebuffer(%d)", v.Value) }
-func (v Renderbuffer) String() string { return fmt.Sprintf("Renderbuffer(%d)", v.Value) }
-func (v Texture) String() string      { return fmt.Sprintf("Texture(%d)", v.Value) }
-func (v Uniform) String() string      { return fmt.Sprintf("Uniform(%d:%s)", v.Value, v.name) }
-func (v VertexArray) String() string  { return fmt.Sprintf("VertexArray(%d)", v.Value) }
+func (v Shader) c() uintptr            { return uintptr(v.Value) }
+func (v Buffer) c() uintptr            { return uintptr(v.Value) }
+func (v Framebuffer) c() uintptr       { return uintptr(v.Value) }
+func (v Renderbuffer) c() uintptr      { return uintptr(v.Value) }
+func (v Texture) c() uintptr           { return uintptr(v.Value) }
+func (v Uniform) c() uintptr           { return uintptr(v.Value) }
+func (v VertexArray) c() uintptr       { return uintptr(v.Value) }
+func (v Sampler) c() uintptr           { return uintptr(v.Value) }
+func (v Sync) c() uintptr              { return v.Value }
+func (v TransformFeedback) c() uintptr { return uintptr(v.Value) }
+
+func (v Attrib) String() string            { return fmt.Sprintf("Attrib(%d:%s)", v.Value, v.name) }
+func (v Program) String() string           { return fmt.Sprintf("Program(%d)", v.Value) }
+func (v Shader) String() string            { return fmt.Sprintf("Shader(%d)", v.Value) }
+func (v Buffer) String() string            { return fmt.Sprintf("Buffer(%d)", v.Value) }
+func (v Framebuffer) String() string       { return fmt.Sprintf("Framebuffer(%d)", v.Value) }
+func (v Renderbuffer) String() string      { return fmt.Sprintf("Renderbuffer(%d)", v.Value) }
+func (v Texture) String() string           { return fmt.Sprintf("Texture(%d)", v.Value) }
+func (v Uniform) String() string           { return fmt.Sprintf("Uniform(%d:%s)", v.Value, v.name) }
+func (v VertexArray) String() string       { return fmt.Sprintf("VertexArray(%d)", v.Value) }
+func (v Sampler) String() string           { return fmt.Sprintf("Sampler(%d)", v.Value) }
+func (v Sync) String() string              { return fmt.Sprintf("Sync(%#x)", v.Value) }
+func (v TransformFeedback) String() string { return fmt.Sprintf("TransformFeedback(%d)", v.Value) }
@@ -0,0 +1,31 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+// contextVersion returns the version of OpenGL ES that glctx provides, as
+// reported by its MAJOR_VERSION and MINOR_VERSION, but at most max, the
+// version of the headers.
+func contextVersion(glctx Context, max string) string {
+	major := glctx.GetInteger(MAJOR_VERSION)
+	minor := glctx.GetInteger(MINOR_VERSION)
+	v := "GL_ES_2_0"
+	switch {
+	case major == 0:
+		// An ES 2.0 context does not know MAJOR_VERSION, and records an
+		// INVALID_ENUM error, which must not be left for the caller.
+		glctx.GetError()
+	case major > 3 || major == 3 && minor >= 2:
+		v = "GL_ES_3_2"
+	case major == 3 && minor == 1:
+		v = "GL_ES_3_1"
+	case major == 3:
+		v = "GL_ES_3_0"
+	}
+	// The versions compare in the same order as their names.
+	if v > max {
+		return max
+	}
+	return v
+}
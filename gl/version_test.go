@@ -0,0 +1,61 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gl
+
+import "testing"
+
+// versionContext is a Context that only reports its version.
+type versionContext struct {
+	Context
+	major, minor int
+	errs         int
+}
+
+func (c *versionContext) GetInteger(pname Enum) int {
+	switch {
+	case c.major == 0:
+		c.errs++
+		return 0
+	case pname == MAJOR_VERSION:
+		return c.major
+	case pname == MINOR_VERSION:
+		return c.minor
+	}
+	return 0
+}
+
+func (c *versionContext) GetError() Enum {
+	if c.errs == 0 {
+		return NO_ERROR
+	}
+	c.errs = 0
+	return INVALID_ENUM
+}
+
+func TestContextVersion(t *testing.T) {
+	testCases := []struct {
+		major, minor int
+		max          string
+		want         string
+	}{
+		{0, 0, "GL_ES_3_2", "GL_ES_2_0"},
+		{3, 0, "GL_ES_3_2", "GL_ES_3_0"},
+		{3, 1, "GL_ES_3_2", "GL_ES_3_1"},
+		{3, 2, "GL_ES_3_2", "GL_ES_3_2"},
+		{4, 6, "GL_ES_3_2", "GL_ES_3_2"},
+		// The headers are an upper bound.
+		{3, 2, "GL_ES_3_0", "GL_ES_3_0"},
+		{3, 0, "GL_ES_2_0", "GL_ES_2_0"},
+	}
+	for _, tc := range testCases {
+		c := &versionContext{major: tc.major, minor: tc.minor}
+		if got := contextVersion(c, tc.max); got != tc.want {
+			t.Errorf("%d.%d, at most %s: got %s, want %s", tc.major, tc.minor, tc.max, got, tc.want)
+		}
+		if c.errs != 0 {
+			t.Errorf("%d.%d: an error was left behind", tc.major, tc.minor)
+		}
+	}
+}
@@ -8,13 +8,19 @@
 #include "_cgo_export.h"
 #include "work.h"
 
-#if defined(GL_ES_VERSION_3_0) && GL_ES_VERSION_3_0
-#else
 #include <stdio.h>
 static void gles3missing() {
 	printf("GLES3 function is missing\n");
 	exit(2);
 }
+
+#ifdef os_osx
+// glTexStorage2D is in OpenGL 4.2, but macOS provides only OpenGL 4.1.
+static void glTexStorage2D(GLenum target, GLsizei levels, GLenum internalformat, GLsizei width, GLsizei height) { gles3missing(); }
+#endif
+
+#if defined(GL_ES_VERSION_3_0) && GL_ES_VERSION_3_0
+#else
 static void glUniformMatrix2x3fv(GLint location, GLsizei count, GLboolean transpose, const GLfloat *value) { gles3missing(); }
 static void glUniformMatrix3x2fv(GLint location, GLsizei count, GLboolean transpose, const GLfloat *value) { gles3missing(); }
 static void glUniformMatrix2x4fv(GLint location, GLsizei count, GLboolean transpose, const GLfloat *value) { gles3missing(); }
@@ -33,6 +39,44 @@ static void glUniform4uiv(GLint location, GLsizei count, const GLuint *value) {
 static void glBindVertexArray(GLuint array) { gles3missing(); }
 static void glGenVertexArrays(GLsizei n, GLuint *arrays) { gles3missing(); }
 static void glDeleteVertexArrays(GLsizei n, const GLuint *arrays) { gles3missing(); }
+static void glBeginTransformFeedback(GLenum primitiveMode) { gles3missing(); }
+static void glBindBufferBase(GLenum target, GLuint index, GLuint buffer) { gles3missing(); }
+static void glBindBufferRange(GLenum target, GLuint index, GLuint buffer, GLintptr offset, GLsizeiptr size) { gles3missing(); }
+static void glBindSampler(GLuint unit, GLuint sampler) { gles3missing(); }
+static void glBindTransformFeedback(GLenum target, GLuint id) { gles3missing(); }
+static void glClearBufferfi(GLenum buffer, GLint drawbuffer, GLfloat depth, GLint stencil) { gles3missing(); }
+static void glClearBufferfv(GLenum buffer, GLint drawbuffer, const GLfloat *value) { gles3missing(); }
+static void glClearBufferiv(GLenum buffer, GLint drawbuffer, const GLint *value) { gles3missing(); }
+static void glClearBufferuiv(GLenum buffer, GLint drawbuffer, const GLuint *value) { gles3missing(); }
+static GLenum glClientWaitSync(GLsync sync, GLbitfield flags, GLuint64 timeout) { gles3missing(); return 0; }
+static void glCopyBufferSubData(GLenum readTarget, GLenum writeTarget, GLintptr readOffset, GLintptr writeOffset, GLsizeiptr size) { gles3missing(); }
+static void glDeleteSamplers(GLsizei count, const GLuint *samplers) { gles3missing(); }
+static void glDeleteSync(GLsync sync) { gles3missing(); }
+static void glDeleteTransformFeedbacks(GLsizei n, const GLuint *ids) { gles3missing(); }
+static void glDrawArraysInstanced(GLenum mode, GLint first, GLsizei count, GLsizei instancecount) { gles3missing(); }
+static void glDrawBuffers(GLsizei n, const GLenum *bufs) { gles3missing(); }
+static void glDrawElementsInstanced(GLenum mode, GLsizei count, GLenum type, const void *indices, GLsizei instancecount) { gles3missing(); }
+static void glEndTransformFeedback(void) { gles3missing(); }
+static GLsync glFenceSync(GLenum condition, GLbitfield flags) { gles3missing(); return 0; }
+static void glGenSamplers(GLsizei count, GLuint *samplers) { gles3missing(); }
+static void glGenTransformFeedbacks(GLsizei n, GLuint *ids) { gles3missing(); }
+static void glGetActiveUniformBlockiv(GLuint program, GLuint uniformBlockIndex, GLenum pname, GLint *params) { gles3missing(); }
+static void glGetActiveUniformBlockName(GLuint program, GLuint uniformBlockIndex, GLsizei bufSize, GLsizei *length, GLchar *uniformBlockName) { gles3missing(); }
+static void glGetSynciv(GLsync sync, GLenum pname, GLsizei bufSize, GLsizei *length, GLint *values) { gles3missing(); }
+static GLuint glGetUniformBlockIndex(GLuint program, const GLchar *uniformBlockName) { gles3missing(); return 0; }
+static void glGetUniformuiv(GLuint program, GLint location, GLuint *params) { gles3missing(); }
+static void glPauseTransformFeedback(void) { gles3missing(); }
+static void glReadBuffer(GLenum src) { gles3missing(); }
+static void glRenderbufferStorageMultisample(GLenum target, GLsizei samples, GLenum internalformat, GLsizei width, GLsizei height) { gles3missing(); }
+static void glResumeTransformFeedback(void) { gles3missing(); }
+static void glSamplerParameterf(GLuint sampler, GLenum pname, GLfloat param) { gles3missing(); }
+static void glSamplerParameteri(GLuint sampler, GLenum pname, GLint param) { gles3missing(); }
+static void glTexStorage2D(GLenum target, GLsizei levels, GLenum internalformat, GLsizei width, GLsizei height) { gles3missing(); }
+static void glTransformFeedbackVaryings(GLuint program, GLsizei count, const GLchar *const*varyings, GLenum bufferMode) { gles3missing(); }
+static void glUniformBlockBinding(GLuint program, GLuint uniformBlockIndex, GLuint uniformBlockBinding) { gles3missing(); }
+static void glVertexAttribDivisor(GLuint index, GLuint divisor) { gles3missing(); }
+static void glVertexAttribIPointer(GLuint index, GLint size, GLenum type, GLsizei stride, const void *pointer) { gles3missing(); }
+static void glWaitSync(GLsync sync, GLbitfield flags, GLuint64 timeout) { gles3missing(); }
 #endif
 
 uintptr_t processFn(struct fnargs* args, char* parg) {
@@ -47,21 +91,36 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnAttachShader:
 		glAttachShader((GLint)args->a0, (GLint)args->a1);
 		break;
+	case glfnBeginTransformFeedback:
+		glBeginTransformFeedback((GLenum)args->a0);
+		break;
 	case glfnBindAttribLocation:
 		glBindAttribLocation((GLint)args->a0, (GLint)args->a1, (GLchar*)args->a2);
 		break;
 	case glfnBindBuffer:
 		glBindBuffer((GLenum)args->a0, (GLuint)args->a1);
 		break;
+	case glfnBindBufferBase:
+		glBindBufferBase((GLenum)args->a0, (GLuint)args->a1, (GLuint)args->a2);
+		break;
+	case glfnBindBufferRange:
+		glBindBufferRange((GLenum)args->a0, (GLuint)args->a1, (GLuint)args->a2, (GLintptr)args->a3, (GLsizeiptr)args->a4);
+		break;
 	case glfnBindFramebuffer:
 		glBindFramebuffer((GLenum)args->a0, (GLint)args->a1);
 		break;
 	case glfnBindRenderbuffer:
 		glBindRenderbuffer((GLenum)args->a0, (GLint)args->a1);
 		break;
+	case glfnBindSampler:
+		glBindSampler((GLuint)args->a0, (GLuint)args->a1);
+		break;
 	case glfnBindTexture:
 		glBindTexture((GLenum)args->a0, (GLint)args->a1);
 		break;
+	case glfnBindTransformFeedback:
+		glBindTransformFeedback((GLenum)args->a0, (GLuint)args->a1);
+		break;
 	case glfnBindVertexArray:
 		glBindVertexArray((GLenum)args->a0);
 		break;
@@ -95,6 +154,18 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnClear:
 		glClear((GLenum)args->a0);
 		break;
+	case glfnClearBufferfi:
+		glClearBufferfi((GLenum)args->a0, (GLint)args->a1, *(GLfloat*)&args->a2, (GLint)args->a3);
+		break;
+	case glfnClearBufferfv:
+		glClearBufferfv((GLenum)args->a0, (GLint)args->a1, (GLfloat*)parg);
+		break;
+	case glfnClearBufferiv:
+		glClearBufferiv((GLenum)args->a0, (GLint)args->a1, (GLint*)parg);
+		break;
+	case glfnClearBufferuiv:
+		glClearBufferuiv((GLenum)args->a0, (GLint)args->a1, (GLuint*)parg);
+		break;
 	case glfnClearColor:
 		glClearColor(*(GLfloat*)&args->a0, *(GLfloat*)&args->a1, *(GLfloat*)&args->a2, *(GLfloat*)&args->a3);
 		break;
@@ -104,6 +175,9 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnClearStencil:
 		glClearStencil((GLint)args->a0);
 		break;
+	case glfnClientWaitSync:
+		ret = glClientWaitSync((GLsync)args->a0, (GLbitfield)args->a1, (GLuint64)args->a2 | (GLuint64)args->a3 << 32);
+		break;
 	case glfnColorMask:
 		glColorMask((GLboolean)args->a0, (GLboolean)args->a1, (GLboolean)args->a2, (GLboolean)args->a3);
 		break;
@@ -116,6 +190,9 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnCompressedTexSubImage2D:
 		glCompressedTexSubImage2D((GLenum)args->a0, (GLint)args->a1, (GLint)args->a2, (GLint)args->a3, (GLint)args->a4, (GLint)args->a5, (GLenum)args->a6, (GLsizeiptr)args->a7, (GLvoid*)parg);
 		break;
+	case glfnCopyBufferSubData:
+		glCopyBufferSubData((GLenum)args->a0, (GLenum)args->a1, (GLintptr)args->a2, (GLintptr)args->a3, (GLsizeiptr)args->a4);
+		break;
 	case glfnCopyTexImage2D:
 		glCopyTexImage2D((GLenum)args->a0, (GLint)args->a1, (GLenum)args->a2, (GLint)args->a3, (GLint)args->a4, (GLint)args->a5, (GLint)args->a6, (GLint)args->a7);
 		break;
@@ -143,12 +220,21 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnDeleteRenderbuffer:
 		glDeleteRenderbuffers(1, (const GLuint*)(&args->a0));
 		break;
+	case glfnDeleteSampler:
+		glDeleteSamplers(1, (const GLuint*)(&args->a0));
+		break;
 	case glfnDeleteShader:
 		glDeleteShader((GLint)args->a0);
 		break;
+	case glfnDeleteSync:
+		glDeleteSync((GLsync)args->a0);
+		break;
 	case glfnDeleteTexture:
 		glDeleteTextures(1, (const GLuint*)(&args->a0));
 		break;
+	case glfnDeleteTransformFeedback:
+		glDeleteTransformFeedbacks(1, (const GLuint*)(&args->a0));
+		break;
 	case glfnDeleteVertexArray:
 		glDeleteVertexArrays(1, (const GLuint*)(&args->a0));
 		break;
@@ -173,15 +259,30 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnDrawArrays:
 		glDrawArrays((GLenum)args->a0, (GLint)args->a1, (GLint)args->a2);
 		break;
+	case glfnDrawArraysInstanced:
+		glDrawArraysInstanced((GLenum)args->a0, (GLint)args->a1, (GLsizei)args->a2, (GLsizei)args->a3);
+		break;
+	case glfnDrawBuffers:
+		glDrawBuffers((GLsizei)args->a0, (const GLenum*)parg);
+		break;
 	case glfnDrawElements:
 		glDrawElements((GLenum)args->a0, (GLint)args->a1, (GLenum)args->a2, (void*)args->a3);
 		break;
+	case glfnDrawElementsInstanced:
+		glDrawElementsInstanced((GLenum)args->a0, (GLsizei)args->a1, (GLenum)args->a2, (void*)args->a3, (GLsizei)args->a4);
+		break;
 	case glfnEnable:
 		glEnable((GLenum)args->a0);
 		break;
 	case glfnEnableVertexAttribArray:
 		glEnableVertexAttribArray((GLint)args->a0);
 		break;
+	case glfnEndTransformFeedback:
+		glEndTransformFeedback();
+		break;
+	case glfnFenceSync:
+		ret = (uintptr_t)glFenceSync((GLenum)args->a0, (GLbitfield)args->a1);
+		break;
 	case glfnFinish:
 		glFinish();
 		break;
@@ -206,9 +307,15 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnGenRenderbuffer:
 		glGenRenderbuffers(1, (GLuint*)&ret);
 		break;
+	case glfnGenSampler:
+		glGenSamplers(1, (GLuint*)&ret);
+		break;
 	case glfnGenTexture:
 		glGenTextures(1, (GLuint*)&ret);
 		break;
+	case glfnGenTransformFeedback:
+		glGenTransformFeedbacks(1, (GLuint*)&ret);
+		break;
 	case glfnGenVertexArray:
 		glGenVertexArrays(1, (GLuint*)&ret);
 		break;
@@ -235,6 +342,12 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 			(GLenum*)args->a3,
 			(GLchar*)parg);
 		break;
+	case glfnGetActiveUniformBlockiv:
+		glGetActiveUniformBlockiv((GLuint)args->a0, (GLuint)args->a1, (GLenum)args->a2, (GLint*)&ret);
+		break;
+	case glfnGetActiveUniformBlockName:
+		glGetActiveUniformBlockName((GLuint)args->a0, (GLuint)args->a1, (GLsizei)args->a2, 0, (GLchar*)parg);
+		break;
 	case glfnGetAttachedShaders:
 		glGetAttachedShaders((GLuint)args->a0, (GLsizei)args->a1, (GLsizei*)&ret, (GLuint*)parg);
 		break;
@@ -283,12 +396,18 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnGetString:
 		ret = (uintptr_t)glGetString((GLenum)args->a0);
 		break;
+	case glfnGetSynciv:
+		glGetSynciv((GLsync)args->a0, (GLenum)args->a1, 1, 0, (GLint*)&ret);
+		break;
 	case glfnGetTexParameterfv:
 		glGetTexParameterfv((GLenum)args->a0, (GLenum)args->a1, (GLfloat*)parg);
 		break;
 	case glfnGetTexParameteriv:
 		glGetTexParameteriv((GLenum)args->a0, (GLenum)args->a1, (GLint*)parg);
 		break;
+	case glfnGetUniformBlockIndex:
+		ret = glGetUniformBlockIndex((GLuint)args->a0, (GLchar*)args->a1);
+		break;
 	case glfnGetUniformfv:
 		glGetUniformfv((GLuint)args->a0, (GLint)args->a1, (GLfloat*)parg);
 		break;
@@ -298,6 +417,9 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnGetUniformLocation:
 		ret = glGetUniformLocation((GLint)args->a0, (GLchar*)args->a1);
 		break;
+	case glfnGetUniformuiv:
+		glGetUniformuiv((GLuint)args->a0, (GLint)args->a1, (GLuint*)parg);
+		break;
 	case glfnGetVertexAttribfv:
 		glGetVertexAttribfv((GLuint)args->a0, (GLenum)args->a1, (GLfloat*)parg);
 		break;
@@ -334,24 +456,45 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnLinkProgram:
 		glLinkProgram((GLint)args->a0);
 		break;
+	case glfnPauseTransformFeedback:
+		glPauseTransformFeedback();
+		break;
 	case glfnPixelStorei:
 		glPixelStorei((GLenum)args->a0, (GLint)args->a1);
 		break;
 	case glfnPolygonOffset:
 		glPolygonOffset(*(GLfloat*)&args->a0, *(GLfloat*)&args->a1);
 		break;
+	case glfnReadBuffer:
+		glReadBuffer((GLenum)args->a0);
+		break;
 	case glfnReadPixels:
 		glReadPixels((GLint)args->a0, (GLint)args->a1, (GLsizei)args->a2, (GLsizei)args->a3, (GLenum)args->a4, (GLenum)args->a5, (void*)parg);
 		break;
+	case glfnReadPixelsOffset:
+		glReadPixels((GLint)args->a0, (GLint)args->a1, (GLsizei)args->a2, (GLsizei)args->a3, (GLenum)args->a4, (GLenum)args->a5, (void*)args->a6);
+		break;
 	case glfnReleaseShaderCompiler:
 		glReleaseShaderCompiler();
 		break;
 	case glfnRenderbufferStorage:
 		glRenderbufferStorage((GLenum)args->a0, (GLenum)args->a1, (GLint)args->a2, (GLint)args->a3);
 		break;
+	case glfnRenderbufferStorageMultisample:
+		glRenderbufferStorageMultisample((GLenum)args->a0, (GLsizei)args->a1, (GLenum)args->a2, (GLsizei)args->a3, (GLsizei)args->a4);
+		break;
+	case glfnResumeTransformFeedback:
+		glResumeTransformFeedback();
+		break;
 	case glfnSampleCoverage:
 		glSampleCoverage(*(GLfloat*)&args->a0, (GLboolean)args->a1);
 		break;
+	case glfnSamplerParameterf:
+		glSamplerParameterf((GLuint)args->a0, (GLenum)args->a1, *(GLfloat*)&args->a2);
+		break;
+	case glfnSamplerParameteri:
+		glSamplerParameteri((GLuint)args->a0, (GLenum)args->a1, (GLint)args->a2);
+		break;
 	case glfnScissor:
 		glScissor((GLint)args->a0, (GLint)args->a1, (GLint)args->a2, (GLint)args->a3);
 		break;
@@ -392,6 +535,9 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 			(GLenum)args->a6,
 			(const GLvoid*)parg);
 		break;
+	case glfnTexStorage2D:
+		glTexStorage2D((GLenum)args->a0, (GLsizei)args->a1, (GLenum)args->a2, (GLsizei)args->a3, (GLsizei)args->a4);
+		break;
 	case glfnTexSubImage2D:
 		glTexSubImage2D(
 			(GLenum)args->a0,
@@ -416,6 +562,12 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnTexParameteriv:
 		glTexParameteriv((GLenum)args->a0, (GLenum)args->a1, (GLint*)parg);
 		break;
+	case glfnTexSubImage2DOffset:
+		glTexSubImage2D((GLenum)args->a0, (GLint)args->a1, (GLint)args->a2, (GLint)args->a3, (GLsizei)args->a4, (GLsizei)args->a5, (GLenum)args->a6, (GLenum)args->a7, (void*)args->a8);
+		break;
+	case glfnTransformFeedbackVaryings:
+		glTransformFeedbackVaryings((GLuint)args->a0, (GLsizei)args->a1, (const GLchar* const*)args->a2, (GLenum)args->a3);
+		break;
 	case glfnUniform1f:
 		glUniform1f((GLint)args->a0, *(GLfloat*)&args->a1);
 		break;
@@ -488,6 +640,9 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnUniform4iv:
 		glUniform4iv((GLint)args->a0, (GLsizeiptr)args->a1, (GLvoid*)parg);
 		break;
+	case glfnUniformBlockBinding:
+		glUniformBlockBinding((GLuint)args->a0, (GLuint)args->a1, (GLuint)args->a2);
+		break;
 	case glfnUniformMatrix2fv:
 		glUniformMatrix2fv((GLint)args->a0, (GLsizeiptr)args->a1, 0, (GLvoid*)parg);
 		break;
@@ -545,12 +700,21 @@ uintptr_t processFn(struct fnargs* args, char* parg) {
 	case glfnVertexAttrib4fv:
 		glVertexAttrib4fv((GLint)args->a0, (GLfloat*)parg);
 		break;
+	case glfnVertexAttribDivisor:
+		glVertexAttribDivisor((GLuint)args->a0, (GLuint)args->a1);
+		break;
+	case glfnVertexAttribIPointer:
+		glVertexAttribIPointer((GLuint)args->a0, (GLint)args->a1, (GLenum)args->a2, (GLsizei)args->a3, (const GLvoid*)args->a4);
+		break;
 	case glfnVertexAttribPointer:
 		glVertexAttribPointer((GLuint)args->a0, (GLint)args->a1, (GLenum)args->a2, (GLboolean)args->a3, (GLsizei)args->a4, (const GLvoid*)args->a5);
 		break;
 	case glfnViewport:
 		glViewport((GLint)args->a0, (GLint)args->a1, (GLint)args->a2, (GLint)args->a3);
 		break;
+	case glfnWaitSync:
+		glWaitSync((GLsync)args->a0, (GLbitfield)args->a1, (GLuint64)args->a2 | (GLuint64)args->a3 << 32);
+		break;
 	}
 	return ret;
 }
@@ -27,26 +27,30 @@ package gl
 #include <stdint.h>
 #include "work.h"
 
-uintptr_t process(struct fnargs* cargs, char* parg0, char* parg1, char* parg2, int count) {
-	uintptr_t ret;
-
-	ret = processFn(&cargs[0], parg0);
-	if (count > 1) {
-		ret = processFn(&cargs[1], parg1);
-	}
-	if (count > 2) {
-		ret = processFn(&cargs[2], parg2);
+// process runs count calls, setting ret[i] to the return value of cargs[i].
+// The pargs are passed separately, as cgo does not allow passing Go memory
+// that holds Go pointers.
+void process(struct fnargs* cargs, uintptr_t* ret,
+		char* parg0, char* parg1, char* parg2, char* parg3,
+		char* parg4, char* parg5, char* parg6, char* parg7,
+		char* parg8, char* parg9, char* parg10, char* parg11,
+		char* parg12, char* parg13, char* parg14, char* parg15,
+		int count) {
+	char* parg[] = {
+		parg0, parg1, parg2, parg3, parg4, parg5, parg6, parg7,
+		parg8, parg9, parg10, parg11, parg12, parg13, parg14, parg15,
+	};
+	int i;
+
+	for (i = 0; i < count; i++) {
+		ret[i] = processFn(&cargs[i], parg[i]);
 	}
-
-	return ret;
 }
 */
 import "C"
 
 import "unsafe"
 
-const workbufLen = 3
-
 type context struct {
 	cptr  uintptr
 	debug int32
@@ -56,19 +60,8 @@ type context struct {
 	// work is a queue of calls to execute.
 	work chan call
 
-	// retvalue is sent a return value when blocking calls complete.
-	// It is safe to use a global unbuffered channel here as calls
-	// cannot currently be made concurrently.
-	//
-	// TODO: the comment above about concurrent calls isn't actually true: package
-	// app calls package gl, but it has to do so in a separate goroutine, which
-	// means that its gl calls (which may be blocking) can race with other gl calls
-	// in the main program. We should make it safe to issue blocking gl calls
-	// concurrently, or get the gl calls out of package app, or both.
-	retvalue chan C.uintptr_t
-
 	cargs [workbufLen]C.struct_fnargs
-	parg  [workbufLen]*C.char
+	cret  [workbufLen]C.uintptr_t
 }
 
 func (ctx *context) WorkAvailable() <-chan struct{} { return ctx.workAvailable }
@@ -84,7 +77,6 @@ func NewContext() (Context, Worker) {
 	glctx := &context{
 		workAvailable: make(chan struct{}, 1),
 		work:          make(chan call, workbufLen),
-		retvalue:      make(chan C.uintptr_t),
 	}
 	if C.GLES_VERSION == "GL_ES_2_0" {
 		return glctx, glctx
@@ -92,61 +84,39 @@ func NewContext() (Context, Worker) {
 	return context3{glctx}, glctx
 }
 
-// Version returns a GL ES version string, either "GL_ES_2_0" or "GL_ES_3_0".
-// Future versions of the gl package may return "GL_ES_3_1".
+// Version returns the version of the GL ES headers that the package was
+// compiled against: "GL_ES_2_0", "GL_ES_3_0", "GL_ES_3_1" or "GL_ES_3_2".
+// With any version but "GL_ES_2_0", a Context is also a Context3. The driver
+// that runs the program may provide an older version; see ContextVersion.
 func Version() string {
 	return C.GLES_VERSION
 }
 
-func (ctx *context) enqueue(c call) uintptr {
-	ctx.work <- c
-
-	select {
-	case ctx.workAvailable <- struct{}{}:
-	default:
-	}
-
-	if c.blocking {
-		return uintptr(<-ctx.retvalue)
-	}
-	return 0
+// ContextVersion returns the version of OpenGL ES that glctx provides, in
+// the same form as Version, which it is at most. It queries MAJOR_VERSION and
+// MINOR_VERSION, so glctx must be current, and an ES 3.1 or 3.2 function must
+// only be called if ContextVersion reports that version.
+func ContextVersion(glctx Context) string {
+	return contextVersion(glctx, C.GLES_VERSION)
 }
 
 func (ctx *context) DoWork() {
-	queue := make([]call, 0, workbufLen)
-	for {
-		// Wait until at least one piece of work is ready.
-		// Accumulate work until a piece is marked as blocking.
-		select {
-		case w := <-ctx.work:
-			queue = append(queue, w)
-		default:
-			return
-		}
-		blocking := queue[len(queue)-1].blocking
-	enqueue:
-		for len(queue) < cap(queue) && !blocking {
-			select {
-			case w := <-ctx.work:
-				queue = append(queue, w)
-				blocking = queue[len(queue)-1].blocking
-			default:
-				break enqueue
-			}
-		}
-
-		// Process the queued GL functions.
-		for i, q := range queue {
-			ctx.cargs[i] = *(*C.struct_fnargs)(unsafe.Pointer(&q.args))
-			ctx.parg[i] = (*C.char)(q.parg)
-		}
-		ret := C.process(&ctx.cargs[0], ctx.parg[0], ctx.parg[1], ctx.parg[2], C.int(len(queue)))
+	ctx.drain(ctx.process)
+}
 
-		// Cleanup and signal.
-		queue = queue[:0]
-		if blocking {
-			ctx.retvalue <- ret
-		}
+// process runs a batch of calls in a single cgo call.
+func (ctx *context) process(batch []call, ret []uintptr) {
+	var parg [workbufLen]*C.char
+	for i, c := range batch {
+		ctx.cargs[i] = *(*C.struct_fnargs)(unsafe.Pointer(&c.args))
+		parg[i] = (*C.char)(c.parg)
+	}
+	C.process(&ctx.cargs[0], &ctx.cret[0],
+		parg[0], parg[1], parg[2], parg[3], parg[4], parg[5], parg[6], parg[7],
+		parg[8], parg[9], parg[10], parg[11], parg[12], parg[13], parg[14], parg[15],
+		C.int(len(batch)))
+	for i := range batch {
+		ret[i] = uintptr(ctx.cret[i])
 	}
 }
 
@@ -174,3 +144,22 @@ func (ctx *context) cStringPtr(str string) (uintptr, func()) {
 		C.free(ptr)
 	}
 }
+
+// cStringArray creates an array of C strings off the Go heap.
+// ret is a **char.
+func (ctx *context) cStringArray(strs []string) (uintptr, func()) {
+	ptrSize := unsafe.Sizeof((*int)(nil))
+	ptr := C.calloc(C.size_t(len(strs)+1), C.size_t(ptrSize))
+	frees := make([]func(), len(strs))
+	for i, str := range strs {
+		s, free := ctx.cString(str)
+		*(*uintptr)(unsafe.Pointer(uintptr(ptr) + uintptr(i)*ptrSize)) = s
+		frees[i] = free
+	}
+	return uintptr(ptr), func() {
+		for _, free := range frees {
+			free()
+		}
+		C.free(ptr)
+	}
+}
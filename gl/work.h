@@ -8,10 +8,16 @@
 // we also need to add -lGLESv3 to LDFLAGS, which we cannot do
 // from inside an ifdef.
 #include <GLES2/gl2.h>
-#elif os_linux
+#elif os_linux || os_openbsd
+// The newest headers are used, so that GLES_VERSION reports the version
+// they provide. The package itself only calls ES 3.0 functions.
+#if defined(__has_include) && __has_include(<GLES3/gl32.h>)
+#include <GLES3/gl32.h>
+#elif defined(__has_include) && __has_include(<GLES3/gl31.h>)
+#include <GLES3/gl31.h>
+#else
 #include <GLES3/gl3.h> // install on Ubuntu with: sudo apt-get install libegl1-mesa-dev libgles2-mesa-dev libx11-dev
-#elif os_openbsd
-#include <GLES3/gl3.h>
+#endif
 #endif
 
 #ifdef os_ios
@@ -23,7 +29,11 @@
 #define GL_ES_VERSION_3_0 1
 #endif
 
-#if defined(GL_ES_VERSION_3_0) && GL_ES_VERSION_3_0
+#if defined(GL_ES_VERSION_3_2) && GL_ES_VERSION_3_2
+#define GLES_VERSION "GL_ES_3_2"
+#elif defined(GL_ES_VERSION_3_1) && GL_ES_VERSION_3_1
+#define GLES_VERSION "GL_ES_3_1"
+#elif defined(GL_ES_VERSION_3_0) && GL_ES_VERSION_3_0
 #define GLES_VERSION "GL_ES_3_0"
 #else
 #define GLES_VERSION "GL_ES_2_0"
@@ -196,6 +206,46 @@ typedef enum {
 	glfnUniform2uiv,
 	glfnUniform3uiv,
 	glfnUniform4uiv,
+	glfnBeginTransformFeedback,
+	glfnBindBufferBase,
+	glfnBindBufferRange,
+	glfnBindSampler,
+	glfnBindTransformFeedback,
+	glfnClearBufferfi,
+	glfnClearBufferfv,
+	glfnClearBufferiv,
+	glfnClearBufferuiv,
+	glfnClientWaitSync,
+	glfnCopyBufferSubData,
+	glfnDeleteSampler,
+	glfnDeleteSync,
+	glfnDeleteTransformFeedback,
+	glfnDrawArraysInstanced,
+	glfnDrawBuffers,
+	glfnDrawElementsInstanced,
+	glfnEndTransformFeedback,
+	glfnFenceSync,
+	glfnGenSampler,
+	glfnGenTransformFeedback,
+	glfnGetActiveUniformBlockiv,
+	glfnGetActiveUniformBlockName,
+	glfnGetSynciv,
+	glfnGetUniformBlockIndex,
+	glfnGetUniformuiv,
+	glfnPauseTransformFeedback,
+	glfnReadBuffer,
+	glfnReadPixelsOffset,
+	glfnRenderbufferStorageMultisample,
+	glfnResumeTransformFeedback,
+	glfnSamplerParameterf,
+	glfnSamplerParameteri,
+	glfnTexStorage2D,
+	glfnTexSubImage2DOffset,
+	glfnTransformFeedbackVaryings,
+	glfnUniformBlockBinding,
+	glfnVertexAttribDivisor,
+	glfnVertexAttribIPointer,
+	glfnWaitSync,
 } glfn;
 
 // TODO: generate this type from fn.go.
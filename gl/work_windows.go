@@ -6,6 +6,7 @@ package gl
 
 import (
 	"runtime"
+	"sync"
 	"syscall"
 	"unsafe"
 )
@@ -15,9 +16,9 @@ type context struct {
 	debug         int32
 	workAvailable chan struct{}
 	work          chan call
-	retvalue      chan uintptr
 
 	// TODO(crawshaw): will not work with a moving collector
+	cStringsMu     sync.Mutex // protects cStringCounter and cStrings
 	cStringCounter int
 	cStrings       map[int]unsafe.Pointer
 }
@@ -34,39 +35,37 @@ func NewContext() (Context, Worker) {
 	}
 	glctx := &context{
 		workAvailable: make(chan struct{}, 1),
-		work:          make(chan call, 3),
-		retvalue:      make(chan uintptr),
+		work:          make(chan call, workbufLen),
 		cStrings:      make(map[int]unsafe.Pointer),
 	}
 	return glctx, glctx
 }
 
-func (ctx *context) enqueue(c call) uintptr {
-	ctx.work <- c
-
-	select {
-	case ctx.workAvailable <- struct{}{}:
-	default:
-	}
-
-	if c.blocking {
-		return <-ctx.retvalue
-	}
-	return 0
+// ContextVersion returns the version of OpenGL ES that glctx provides:
+// "GL_ES_2_0", since a Context is never a Context3 on Windows.
+func ContextVersion(glctx Context) string {
+	return contextVersion(glctx, "GL_ES_2_0")
 }
 
 func (ctx *context) DoWork() {
-	// TODO: add a work queue
-	for {
-		select {
-		case w := <-ctx.work:
-			ret := ctx.doWork(w)
-			if w.blocking {
-				ctx.retvalue <- ret
-			}
-		default:
-			return
+	ctx.drain(func(batch []call, ret []uintptr) {
+		for i, c := range batch {
+			ret[i] = ctx.doWork(c)
 		}
+	})
+}
+
+// keep stores p in cStrings until the returned func is called.
+func (ctx *context) keep(p unsafe.Pointer) func() {
+	ctx.cStringsMu.Lock()
+	defer ctx.cStringsMu.Unlock()
+	id := ctx.cStringCounter
+	ctx.cStringCounter++
+	ctx.cStrings[id] = p
+	return func() {
+		ctx.cStringsMu.Lock()
+		delete(ctx.cStrings, id)
+		ctx.cStringsMu.Unlock()
 	}
 }
 
@@ -76,20 +75,31 @@ func (ctx *context) cString(s string) (uintptr, func()) {
 		buf[i] = s[i]
 	}
 	ret := unsafe.Pointer(&buf[0])
-	id := ctx.cStringCounter
-	ctx.cStringCounter++
-	ctx.cStrings[id] = ret
-	return uintptr(ret), func() { delete(ctx.cStrings, id) }
+	return uintptr(ret), ctx.keep(ret)
 }
 
 func (ctx *context) cStringPtr(str string) (uintptr, func()) {
 	s, sfree := ctx.cString(str)
 	sptr := [2]uintptr{s, 0}
 	ret := unsafe.Pointer(&sptr[0])
-	id := ctx.cStringCounter
-	ctx.cStringCounter++
-	ctx.cStrings[id] = ret
-	return uintptr(ret), func() { sfree(); delete(ctx.cStrings, id) }
+	free := ctx.keep(ret)
+	return uintptr(ret), func() { sfree(); free() }
+}
+
+func (ctx *context) cStringArray(strs []string) (uintptr, func()) {
+	sptr := make([]uintptr, len(strs)+1)
+	frees := make([]func(), len(strs))
+	for i, str := range strs {
+		sptr[i], frees[i] = ctx.cString(str)
+	}
+	ret := unsafe.Pointer(&sptr[0])
+	free := ctx.keep(ret)
+	return uintptr(ret), func() {
+		for _, f := range frees {
+			f()
+		}
+		free()
+	}
 }
 
 // fixFloat copies the first four arguments into the XMM registers.
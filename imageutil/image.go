@@ -0,0 +1,212 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageutil
+
+import (
+	"image"
+	"image/color"
+)
+
+// BGRA is an in-memory image whose At method returns color.RGBA values. It
+// is like image.RGBA, except that each pixel's bytes are in B, G, R, A order,
+// which is what many windowing systems expect.
+type BGRA struct {
+	// Pix holds the image's pixels, in B, G, R, A order. The pixel at
+	// (x, y) starts at Pix[(y-Rect.Min.Y)*Stride + (x-Rect.Min.X)*4].
+	Pix []uint8
+	// Stride is the Pix stride (in bytes) between vertically adjacent pixels.
+	Stride int
+	// Rect is the image's bounds.
+	Rect image.Rectangle
+}
+
+// NewBGRA returns a new BGRA image with the given bounds.
+func NewBGRA(r image.Rectangle) *BGRA {
+	w, h := r.Dx(), r.Dy()
+	return &BGRA{Pix: make([]uint8, 4*w*h), Stride: 4 * w, Rect: r}
+}
+
+func (p *BGRA) ColorModel() color.Model { return color.RGBAModel }
+
+func (p *BGRA) Bounds() image.Rectangle { return p.Rect }
+
+func (p *BGRA) At(x, y int) color.Color { return p.RGBAAt(x, y) }
+
+func (p *BGRA) RGBAAt(x, y int) color.RGBA {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return color.RGBA{}
+	}
+	i := p.PixOffset(x, y)
+	s := p.Pix[i : i+4 : i+4]
+	return color.RGBA{s[2], s[1], s[0], s[3]}
+}
+
+// PixOffset returns the index of the first element of Pix that corresponds to
+// the pixel at (x, y).
+func (p *BGRA) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*4
+}
+
+func (p *BGRA) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.SetRGBA(x, y, color.RGBAModel.Convert(c).(color.RGBA))
+}
+
+func (p *BGRA) SetRGBA(x, y int, c color.RGBA) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	i := p.PixOffset(x, y)
+	s := p.Pix[i : i+4 : i+4]
+	s[0], s[1], s[2], s[3] = c.B, c.G, c.R, c.A
+}
+
+// SubImage returns an image representing the portion of the image p visible
+// through r. The returned value shares pixels with the original image.
+func (p *BGRA) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(p.Rect)
+	if r.Empty() {
+		return &BGRA{}
+	}
+	i := p.PixOffset(r.Min.X, r.Min.Y)
+	return &BGRA{Pix: p.Pix[i:], Stride: p.Stride, Rect: r}
+}
+
+// Opaque scans the entire image and reports whether it is fully opaque.
+func (p *BGRA) Opaque() bool {
+	if p.Rect.Empty() {
+		return true
+	}
+	i0, i1 := 3, p.Rect.Dx()*4
+	for y := p.Rect.Min.Y; y < p.Rect.Max.Y; y++ {
+		for i := i0; i < i1; i += 4 {
+			if p.Pix[i] != 0xff {
+				return false
+			}
+		}
+		i0 += p.Stride
+		i1 += p.Stride
+	}
+	return true
+}
+
+// RGB565Model is the color model of RGB565 images. It quantizes colors to 5
+// bits of red, 6 of green and 5 of blue, and makes them opaque.
+var RGB565Model color.Model = color.ModelFunc(rgb565Model)
+
+func rgb565Model(c color.Color) color.Color {
+	return expand565(pack565(c))
+}
+
+// pack565 returns c, quantized to a 5-6-5 pixel value.
+func pack565(c color.Color) uint16 {
+	r, g, b, _ := c.RGBA()
+	return uint16(r>>11<<11 | g>>10<<5 | b>>11)
+}
+
+// expand565 returns the color of the 5-6-5 pixel value v, with each channel
+// scaled to the full 8 bits by replicating its high bits.
+func expand565(v uint16) color.RGBA {
+	r, g, b := uint8(v>>11), uint8(v>>5&0x3f), uint8(v&0x1f)
+	return color.RGBA{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 0xff}
+}
+
+// RGB565 is an in-memory image of opaque pixels with 5 bits of red, 6 of
+// green and 5 of blue. Each pixel is a little-endian uint16, with red in the
+// high bits.
+type RGB565 struct {
+	// Pix holds the image's pixels. The pixel at (x, y) starts at
+	// Pix[(y-Rect.Min.Y)*Stride + (x-Rect.Min.X)*2].
+	Pix []uint8
+	// Stride is the Pix stride (in bytes) between vertically adjacent pixels.
+	Stride int
+	// Rect is the image's bounds.
+	Rect image.Rectangle
+}
+
+// NewRGB565 returns a new RGB565 image with the given bounds.
+func NewRGB565(r image.Rectangle) *RGB565 {
+	w, h := r.Dx(), r.Dy()
+	return &RGB565{Pix: make([]uint8, 2*w*h), Stride: 2 * w, Rect: r}
+}
+
+func (p *RGB565) ColorModel() color.Model { return RGB565Model }
+
+func (p *RGB565) Bounds() image.Rectangle { return p.Rect }
+
+func (p *RGB565) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return color.RGBA{}
+	}
+	i := p.PixOffset(x, y)
+	return expand565(uint16(p.Pix[i]) | uint16(p.Pix[i+1])<<8)
+}
+
+// PixOffset returns the index of the first element of Pix that corresponds to
+// the pixel at (x, y).
+func (p *RGB565) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*2
+}
+
+func (p *RGB565) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	v := pack565(c)
+	i := p.PixOffset(x, y)
+	p.Pix[i], p.Pix[i+1] = uint8(v), uint8(v>>8)
+}
+
+// SubImage returns an image representing the portion of the image p visible
+// through r. The returned value shares pixels with the original image.
+func (p *RGB565) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(p.Rect)
+	if r.Empty() {
+		return &RGB565{}
+	}
+	i := p.PixOffset(r.Min.X, r.Min.Y)
+	return &RGB565{Pix: p.Pix[i:], Stride: p.Stride, Rect: r}
+}
+
+// Opaque returns true, as RGB565 images have no alpha channel.
+func (p *RGB565) Opaque() bool { return true }
+
+// YCbCr is an image.YCbCr that can also be drawn on.
+type YCbCr struct {
+	image.YCbCr
+}
+
+// NewYCbCr returns a new YCbCr image with the given bounds and subsample
+// ratio.
+func NewYCbCr(r image.Rectangle, subsampleRatio image.YCbCrSubsampleRatio) *YCbCr {
+	return &YCbCr{*image.NewYCbCr(r, subsampleRatio)}
+}
+
+// Set sets the pixel at (x, y) to c. With a subsampled chroma, the Cb and Cr
+// samples are shared by neighboring pixels, so setting one pixel also sets
+// their chroma.
+func (p *YCbCr) Set(x, y int, c color.Color) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.SetYCbCr(x, y, color.YCbCrModel.Convert(c).(color.YCbCr))
+}
+
+func (p *YCbCr) SetYCbCr(x, y int, c color.YCbCr) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+	p.Y[p.YOffset(x, y)] = c.Y
+	i := p.COffset(x, y)
+	p.Cb[i], p.Cr[i] = c.Cb, c.Cr
+}
+
+// SubImage returns an image representing the portion of the image p visible
+// through r. The returned value shares pixels with the original image.
+func (p *YCbCr) SubImage(r image.Rectangle) image.Image {
+	return &YCbCr{*p.YCbCr.SubImage(r).(*image.YCbCr)}
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageutil
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestBGRA(t *testing.T) {
+	m := NewBGRA(image.Rect(-2, 3, 6, 9))
+	c := color.RGBA{0x10, 0x20, 0x30, 0x40}
+	m.Set(1, 4, c)
+	if got := m.At(1, 4); got != c {
+		t.Errorf("At: got %v, want %v", got, c)
+	}
+	i := m.PixOffset(1, 4)
+	if got, want := m.Pix[i:i+4], []byte{0x30, 0x20, 0x10, 0x40}; string(got) != string(want) {
+		t.Errorf("Pix: got % x, want % x", got, want)
+	}
+	if m.Opaque() {
+		t.Errorf("Opaque: got true, want false")
+	}
+
+	sub := m.SubImage(image.Rect(0, 4, 2, 5)).(*BGRA)
+	if got := sub.At(1, 4); got != c {
+		t.Errorf("SubImage: At: got %v, want %v", got, c)
+	}
+	if got := sub.At(1, 3); got != (color.RGBA{}) {
+		t.Errorf("SubImage: At outside: got %v, want transparent", got)
+	}
+
+	// Drawing through the generic path gives the same result as image.RGBA.
+	want := image.NewRGBA(m.Rect)
+	src := image.NewUniform(color.NRGBA{0xc0, 0x80, 0x40, 0x80})
+	draw.Draw(want, want.Rect, src, image.Point{}, draw.Over)
+	draw.Draw(m, m.Rect, src, image.Point{}, draw.Over)
+	for y := m.Rect.Min.Y; y < m.Rect.Max.Y; y++ {
+		for x := m.Rect.Min.X; x < m.Rect.Max.X; x++ {
+			if got, want := m.At(x, y), want.At(x, y); got != want && (x != 1 || y != 4) {
+				t.Fatalf("draw: (%d, %d): got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestRGB565(t *testing.T) {
+	m := NewRGB565(image.Rect(0, 0, 4, 4))
+	testCases := []struct {
+		in, want color.Color
+		pix      [2]byte
+	}{
+		{color.RGBA{0xff, 0xff, 0xff, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}, [2]byte{0xff, 0xff}},
+		{color.RGBA{0xff, 0x00, 0x00, 0xff}, color.RGBA{0xff, 0x00, 0x00, 0xff}, [2]byte{0x00, 0xf8}},
+		{color.RGBA{0x00, 0xff, 0x00, 0xff}, color.RGBA{0x00, 0xff, 0x00, 0xff}, [2]byte{0xe0, 0x07}},
+		{color.RGBA{0x00, 0x00, 0xff, 0xff}, color.RGBA{0x00, 0x00, 0xff, 0xff}, [2]byte{0x1f, 0x00}},
+		{color.RGBA{0x84, 0x82, 0x84, 0xff}, color.RGBA{0x84, 0x82, 0x84, 0xff}, [2]byte{0x10, 0x84}},
+		// Low bits are truncated, and the result is opaque.
+		{color.RGBA{0x87, 0x83, 0x87, 0xff}, color.RGBA{0x84, 0x82, 0x84, 0xff}, [2]byte{0x10, 0x84}},
+		{color.RGBA{}, color.RGBA{0, 0, 0, 0xff}, [2]byte{}},
+	}
+	for _, tc := range testCases {
+		m.Set(2, 1, tc.in)
+		if got := m.At(2, 1); got != tc.want {
+			t.Errorf("%v: At: got %v, want %v", tc.in, got, tc.want)
+		}
+		if got := RGB565Model.Convert(tc.in); got != tc.want {
+			t.Errorf("%v: Convert: got %v, want %v", tc.in, got, tc.want)
+		}
+		i := m.PixOffset(2, 1)
+		if got := [2]byte{m.Pix[i], m.Pix[i+1]}; got != tc.pix {
+			t.Errorf("%v: Pix: got % x, want % x", tc.in, got, tc.pix)
+		}
+	}
+	if !m.Opaque() {
+		t.Errorf("Opaque: got false, want true")
+	}
+}
+
+func TestYCbCr(t *testing.T) {
+	m := NewYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+	c := color.YCbCr{0x80, 0x40, 0xc0}
+	m.Set(3, 2, c)
+	if got := m.At(3, 2); got != c {
+		t.Errorf("At: got %v, want %v", got, c)
+	}
+	// (2, 3) shares its chroma with (3, 2), but not its luma.
+	if got, want := m.At(2, 3), (color.YCbCr{0, 0x40, 0xc0}); got != want {
+		t.Errorf("At(2, 3): got %v, want %v", got, want)
+	}
+
+	var _ draw.Image = m
+	sub := m.SubImage(image.Rect(2, 2, 4, 4)).(*YCbCr)
+	sub.Set(3, 2, color.Gray{0xff})
+	if got, want := m.At(3, 2), (color.YCbCr{0xff, 0x80, 0x80}); got != want {
+		t.Errorf("SubImage: At: got %v, want %v", got, want)
+	}
+}
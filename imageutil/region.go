@@ -0,0 +1,371 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageutil
+
+import (
+	"image"
+	"sort"
+)
+
+// Region is a set of points, such as the damaged or visible parts of a
+// window, that need not be rectangular. The zero value is the empty region.
+//
+// A Region is stored as disjoint rectangles in y-x banded form, the way X11
+// stores them: the rectangles are sorted by their Min.Y and then Min.X, each
+// band of rectangles with the same Min.Y has the same Max.Y, and rectangles
+// in a band never touch. Bands that touch have different rectangles, as they
+// are otherwise merged into one. So two Regions with the same points have the
+// same rectangles.
+//
+// Like image.Rectangle, the methods of a Region return new Regions, and never
+// change the one they are called on.
+type Region struct {
+	rects []image.Rectangle
+}
+
+// NewRegion returns the union of the rectangles rs.
+func NewRegion(rs ...image.Rectangle) Region {
+	var r Region
+	for _, s := range rs {
+		if s.Empty() {
+			continue
+		}
+		if r.Empty() {
+			r = Region{rects: []image.Rectangle{s}}
+			continue
+		}
+		r = r.Union(Region{rects: []image.Rectangle{s}})
+	}
+	return r
+}
+
+// Empty reports whether r contains no points.
+func (r Region) Empty() bool {
+	return len(r.rects) == 0
+}
+
+// Eq reports whether r and s contain the same points.
+func (r Region) Eq(s Region) bool {
+	if len(r.rects) != len(s.rects) {
+		return false
+	}
+	for i := range r.rects {
+		if r.rects[i] != s.rects[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Bounds returns the smallest rectangle that contains r.
+func (r Region) Bounds() image.Rectangle {
+	if r.Empty() {
+		return image.Rectangle{}
+	}
+	b := image.Rectangle{
+		Min: image.Point{r.rects[0].Min.X, r.rects[0].Min.Y},
+		Max: image.Point{r.rects[0].Max.X, r.rects[len(r.rects)-1].Max.Y},
+	}
+	for _, s := range r.rects {
+		if b.Min.X > s.Min.X {
+			b.Min.X = s.Min.X
+		}
+		if b.Max.X < s.Max.X {
+			b.Max.X = s.Max.X
+		}
+	}
+	return b
+}
+
+// Rects returns the disjoint rectangles of r, in y-x banded order. The caller
+// may modify the returned slice.
+func (r Region) Rects() []image.Rectangle {
+	return append([]image.Rectangle(nil), r.rects...)
+}
+
+// Area returns the number of points in r.
+func (r Region) Area() int {
+	return areaOf(r.rects...)
+}
+
+// Contains reports whether p is in r.
+func (r Region) Contains(p image.Point) bool {
+	for _, s := range r.rects {
+		if p.Y < s.Min.Y {
+			break
+		}
+		if p.In(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Translate returns r moved by p.
+func (r Region) Translate(p image.Point) Region {
+	if r.Empty() {
+		return r
+	}
+	rects := make([]image.Rectangle, len(r.rects))
+	for i, s := range r.rects {
+		rects[i] = s.Add(p)
+	}
+	return Region{rects: rects}
+}
+
+// Union returns the points that are in r or s.
+func (r Region) Union(s Region) Region {
+	switch {
+	case r.Empty():
+		return s
+	case s.Empty():
+		return r
+	}
+	return combine(r, s, func(a, b bool) bool { return a || b })
+}
+
+// Intersect returns the points that are in both r and s.
+func (r Region) Intersect(s Region) Region {
+	if !r.Bounds().Overlaps(s.Bounds()) {
+		return Region{}
+	}
+	return combine(r, s, func(a, b bool) bool { return a && b })
+}
+
+// Subtract returns the points that are in r but not in s.
+func (r Region) Subtract(s Region) Region {
+	if !r.Bounds().Overlaps(s.Bounds()) {
+		return r
+	}
+	return combine(r, s, func(a, b bool) bool { return a && !b })
+}
+
+// IntersectRect returns the points of r that are in the rectangle s, which
+// is the common case of clipping a region.
+func (r Region) IntersectRect(s image.Rectangle) Region {
+	return r.Intersect(NewRegion(s))
+}
+
+// Simplify returns a region that contains r in at most n rectangles, for
+// damage, where repainting a few more points is cheaper than many small
+// draws. It replaces the bands of r by their bounds, and then merges
+// neighboring bands, choosing those that add the fewest points first.
+func (r Region) Simplify(n int) Region {
+	if n < 1 {
+		n = 1
+	}
+	if len(r.rects) <= n {
+		return r
+	}
+	bands := r.bands()
+	total := len(r.rects)
+	for total > n {
+		best, waste := -1, 0
+		for i, b := range bands {
+			if len(b) == 1 {
+				continue
+			}
+			if w := areaOf(bounds(b)) - areaOf(b...); best < 0 || w < waste {
+				best, waste = i, w
+			}
+		}
+		if best < 0 {
+			break
+		}
+		total -= len(bands[best]) - 1
+		bands[best] = []image.Rectangle{bounds(bands[best])}
+	}
+	for len(bands) > n {
+		best, waste := -1, 0
+		for i := 0; i+1 < len(bands); i++ {
+			b := []image.Rectangle{bands[i][0], bands[i+1][0]}
+			if w := areaOf(bounds(b)) - areaOf(b...); best < 0 || w < waste {
+				best, waste = i, w
+			}
+		}
+		bands[best] = []image.Rectangle{bands[best][0].Union(bands[best+1][0])}
+		bands = append(bands[:best+1], bands[best+2:]...)
+	}
+	var out []image.Rectangle
+	for _, b := range bands {
+		out = append(out, b...)
+	}
+	// The rectangles are disjoint, but those of neighboring bands may now be
+	// mergeable.
+	return NewRegion(out...)
+}
+
+// bounds returns the smallest rectangle that contains rects, which are in
+// y-x banded order.
+func bounds(rects []image.Rectangle) image.Rectangle {
+	b := rects[0]
+	for _, s := range rects[1:] {
+		b = b.Union(s)
+	}
+	return b
+}
+
+func areaOf(rects ...image.Rectangle) int {
+	n := 0
+	for _, s := range rects {
+		n += s.Dx() * s.Dy()
+	}
+	return n
+}
+
+// bands returns r's rectangles, split into bands.
+func (r Region) bands() [][]image.Rectangle {
+	var bands [][]image.Rectangle
+	for i := 0; i < len(r.rects); {
+		j := i + 1
+		for j < len(r.rects) && r.rects[j].Min.Y == r.rects[i].Min.Y {
+			j++
+		}
+		bands = append(bands, r.rects[i:j])
+		i = j
+	}
+	return bands
+}
+
+// span is a half-open interval of x.
+type span struct {
+	x0, x1 int
+}
+
+// combine returns the points for which op reports true, given whether they
+// are in r and in s. op(false, false) must be false.
+func combine(r, s Region, op func(a, b bool) bool) Region {
+	// Every y at which a band of r or s starts or ends, in order.
+	ys := make([]int, 0, 2*(len(r.rects)+len(s.rects)))
+	ys = appendEdges(ys, r.bands())
+	ys = appendEdges(ys, s.bands())
+	ys = sortUnique(ys)
+
+	var (
+		out     []image.Rectangle
+		prev    = -1 // The index of the previous band of out.
+		ri, si  int
+		a, b, c []span
+	)
+	for k := 0; k+1 < len(ys); k++ {
+		y0, y1 := ys[k], ys[k+1]
+		a, ri = spansAt(a[:0], r.rects, ri, y0)
+		b, si = spansAt(b[:0], s.rects, si, y0)
+		c = combineSpans(c[:0], a, b, op)
+		if len(c) == 0 {
+			prev = -1
+			continue
+		}
+		// Merge the band with the previous one if it touches it and has
+		// the same spans.
+		if prev >= 0 && out[prev].Max.Y == y0 && sameSpans(out[prev:], c) {
+			for i := prev; i < len(out); i++ {
+				out[i].Max.Y = y1
+			}
+			continue
+		}
+		prev = len(out)
+		for _, sp := range c {
+			out = append(out, image.Rect(sp.x0, y0, sp.x1, y1))
+		}
+	}
+	return Region{rects: out}
+}
+
+func appendEdges(ys []int, bands [][]image.Rectangle) []int {
+	for _, b := range bands {
+		ys = append(ys, b[0].Min.Y, b[0].Max.Y)
+	}
+	return ys
+}
+
+// sortUnique sorts ys and removes duplicates.
+func sortUnique(ys []int) []int {
+	sort.Ints(ys)
+	n := 0
+	for i, y := range ys {
+		if i == 0 || y != ys[n-1] {
+			ys[n] = y
+			n++
+		}
+	}
+	return ys[:n]
+}
+
+// spansAt appends the spans of rects, from the band at or after i, that
+// contain y, and returns the band's index for the next, larger y.
+func spansAt(dst []span, rects []image.Rectangle, i, y int) ([]span, int) {
+	for i < len(rects) && rects[i].Max.Y <= y {
+		i++
+	}
+	for j := i; j < len(rects) && rects[j].Min.Y == rects[i].Min.Y; j++ {
+		if rects[j].Min.Y <= y {
+			dst = append(dst, span{rects[j].Min.X, rects[j].Max.X})
+		}
+	}
+	return dst, i
+}
+
+// combineSpans appends to dst the spans for which op reports true, given
+// whether they are in a and b. Spans that touch are appended as one.
+func combineSpans(dst, a, b []span, op func(a, b bool) bool) []span {
+	// Walk the edges of a and b in order of x.
+	var (
+		i, j   int
+		inA    bool
+		inB    bool
+		in     bool
+		x0     int
+		ea, eb = edge(a, 0), edge(b, 0)
+	)
+	for i < 2*len(a) || j < 2*len(b) {
+		x := ea
+		if j < 2*len(b) && (i == 2*len(a) || eb < ea) {
+			x = eb
+		}
+		for i < 2*len(a) && ea == x {
+			inA = i%2 == 0
+			i++
+			ea = edge(a, i)
+		}
+		for j < 2*len(b) && eb == x {
+			inB = j%2 == 0
+			j++
+			eb = edge(b, j)
+		}
+		now := op(inA, inB)
+		switch {
+		case now && !in:
+			x0 = x
+		case !now && in:
+			dst = append(dst, span{x0, x})
+		}
+		in = now
+	}
+	return dst
+}
+
+// edge returns the i'th edge of spans, counting the start and end of each.
+func edge(spans []span, i int) int {
+	if i >= 2*len(spans) {
+		return 0
+	}
+	if i%2 == 0 {
+		return spans[i/2].x0
+	}
+	return spans[i/2].x1
+}
+
+func sameSpans(rects []image.Rectangle, spans []span) bool {
+	if len(rects) != len(spans) {
+		return false
+	}
+	for i, s := range rects {
+		if s.Min.X != spans[i].x0 || s.Max.X != spans[i].x1 {
+			return false
+		}
+	}
+	return true
+}
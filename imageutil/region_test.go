@@ -0,0 +1,247 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageutil
+
+import (
+	"image"
+	"math/rand"
+	"testing"
+)
+
+// bitmapSize is the size of the bitmaps that the Region tests check against.
+// The rectangles stay inside, with some room to be translated.
+const bitmapSize = 32
+
+// bitmap is a reference implementation of a Region, one bool per point.
+type bitmap [bitmapSize][bitmapSize]bool
+
+func (b *bitmap) fill(r image.Rectangle) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			b[y][x] = true
+		}
+	}
+}
+
+func (b *bitmap) op(c *bitmap, op func(a, b bool) bool) *bitmap {
+	var d bitmap
+	for y := range d {
+		for x := range d[y] {
+			d[y][x] = op(b[y][x], c[y][x])
+		}
+	}
+	return &d
+}
+
+// checkRegion checks that r has the points of want, and that its rectangles
+// are in canonical y-x banded form.
+func checkRegion(t *testing.T, what string, r Region, want *bitmap) {
+	t.Helper()
+	var got bitmap
+	area := 0
+	for _, s := range r.rects {
+		if s.Empty() {
+			t.Fatalf("%s: empty rectangle %v in %v", what, s, r.rects)
+		}
+		if !s.In(image.Rect(0, 0, bitmapSize, bitmapSize)) {
+			t.Fatalf("%s: rectangle %v out of bounds", what, s)
+		}
+		got.fill(s)
+		area += s.Dx() * s.Dy()
+	}
+	n := 0
+	for y := range want {
+		for x := range want[y] {
+			if got[y][x] != want[y][x] {
+				t.Fatalf("%s: (%d, %d): got %t, want %t\nrects: %v", what, x, y, got[y][x], want[y][x], r.rects)
+			}
+			if want[y][x] {
+				n++
+				if !r.Contains(image.Point{x, y}) {
+					t.Fatalf("%s: Contains(%d, %d): got false, want true", what, x, y)
+				}
+			}
+		}
+	}
+	if area != n || r.Area() != n {
+		t.Fatalf("%s: rectangles overlap: area %d, %d points\nrects: %v", what, area, n, r.rects)
+	}
+	bands := r.bands()
+	for i, b := range bands {
+		for j, s := range b {
+			if s.Max.Y != b[0].Max.Y {
+				t.Fatalf("%s: band %v has different heights", what, b)
+			}
+			if j > 0 && s.Min.X <= b[j-1].Max.X {
+				t.Fatalf("%s: band %v is not sorted, or has touching rectangles", what, b)
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		p := bands[i-1]
+		if p[0].Max.Y > b[0].Min.Y {
+			t.Fatalf("%s: bands %v and %v are not sorted", what, p, b)
+		}
+		if p[0].Max.Y == b[0].Min.Y && len(p) == len(b) {
+			same := true
+			for j := range b {
+				same = same && p[j].Min.X == b[j].Min.X && p[j].Max.X == b[j].Max.X
+			}
+			if same {
+				t.Fatalf("%s: bands %v and %v were not merged", what, p, b)
+			}
+		}
+	}
+}
+
+// randRects returns up to n random rectangles, inside the middle of a bitmap.
+func randRects(rng *rand.Rand, n int) []image.Rectangle {
+	rs := make([]image.Rectangle, rng.Intn(n+1))
+	for i := range rs {
+		rs[i] = image.Rect(4+rng.Intn(24), 4+rng.Intn(24), 4+rng.Intn(24), 4+rng.Intn(24))
+	}
+	return rs
+}
+
+func testRegionOps(t *testing.T, ra, rb []image.Rectangle, d image.Point) {
+	t.Helper()
+	var ba, bb bitmap
+	for _, s := range ra {
+		ba.fill(s.Canon())
+	}
+	for _, s := range rb {
+		bb.fill(s.Canon())
+	}
+	a, b := NewRegion(ra...), NewRegion(rb...)
+	checkRegion(t, "a", a, &ba)
+	checkRegion(t, "b", b, &bb)
+
+	checkRegion(t, "union", a.Union(b), ba.op(&bb, func(a, b bool) bool { return a || b }))
+	checkRegion(t, "intersect", a.Intersect(b), ba.op(&bb, func(a, b bool) bool { return a && b }))
+	checkRegion(t, "subtract", a.Subtract(b), ba.op(&bb, func(a, b bool) bool { return a && !b }))
+
+	var bt bitmap
+	for y := range ba {
+		for x := range ba[y] {
+			if ba[y][x] {
+				bt[y+d.Y][x+d.X] = true
+			}
+		}
+	}
+	checkRegion(t, "translate", a.Translate(d), &bt)
+
+	// The union is the same in any order, so Eq can compare the rectangles.
+	if u, v := a.Union(b), b.Union(a); !u.Eq(v) {
+		t.Fatalf("union: a∪b %v != b∪a %v", u.rects, v.rects)
+	}
+
+	var bounds bitmap
+	bounds.fill(a.Bounds())
+	if !a.Empty() {
+		checkRegion(t, "bounds", NewRegion(a.Bounds()), &bounds)
+		if a.Subtract(NewRegion(a.Bounds())).Area() != 0 {
+			t.Fatalf("bounds %v do not contain %v", a.Bounds(), a.rects)
+		}
+	}
+
+	for _, n := range []int{1, 2, 3, 5} {
+		s := a.Simplify(n)
+		if len(s.rects) > n && len(a.rects) > n {
+			t.Fatalf("simplify(%d): got %d rectangles", n, len(s.rects))
+		}
+		if !s.Intersect(a).Eq(a) {
+			t.Fatalf("simplify(%d): %v does not contain %v", n, s.rects, a.rects)
+		}
+		if !s.Bounds().Eq(a.Bounds()) {
+			t.Fatalf("simplify(%d): bounds %v, want %v", n, s.Bounds(), a.Bounds())
+		}
+	}
+}
+
+func TestRegionRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		d := image.Point{rng.Intn(9) - 4, rng.Intn(9) - 4}
+		testRegionOps(t, randRects(rng, 6), randRects(rng, 6), d)
+	}
+}
+
+func TestRegion(t *testing.T) {
+	// Two overlapping squares make three bands.
+	r := NewRegion(image.Rect(0, 0, 10, 10), image.Rect(5, 5, 15, 15))
+	want := []image.Rectangle{
+		image.Rect(0, 0, 10, 5),
+		image.Rect(0, 5, 15, 10),
+		image.Rect(5, 10, 15, 15),
+	}
+	if got := r.Rects(); len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	} else {
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		}
+	}
+	if got, want := r.Bounds(), image.Rect(0, 0, 15, 15); got != want {
+		t.Errorf("Bounds: got %v, want %v", got, want)
+	}
+	if got, want := r.Area(), 175; got != want {
+		t.Errorf("Area: got %d, want %d", got, want)
+	}
+
+	// A frame is four rectangles in three bands.
+	frame := NewRegion(image.Rect(0, 0, 10, 10)).Subtract(NewRegion(image.Rect(2, 2, 8, 8)))
+	if got := len(frame.Rects()); got != 4 {
+		t.Errorf("frame: got %d rectangles, want 4", got)
+	}
+	if got := frame.IntersectRect(image.Rect(0, 0, 5, 1)); !got.Eq(NewRegion(image.Rect(0, 0, 5, 1))) {
+		t.Errorf("IntersectRect: got %v", got.Rects())
+	}
+	if got := frame.Simplify(1); !got.Eq(NewRegion(image.Rect(0, 0, 10, 10))) {
+		t.Errorf("Simplify(1): got %v", got.Rects())
+	}
+
+	var zero Region
+	if !zero.Empty() || !zero.Union(zero).Empty() || !zero.Subtract(r).Empty() || !r.Intersect(zero).Empty() {
+		t.Errorf("the zero Region is not empty")
+	}
+	if !r.Subtract(zero).Eq(r) || !r.Union(zero).Eq(r) {
+		t.Errorf("the zero Region changed r")
+	}
+}
+
+func FuzzRegion(f *testing.F) {
+	f.Add([]byte{0, 0, 10, 10, 5, 5, 15, 15, 1, 2})
+	f.Add([]byte{4, 4, 20, 20, 8, 8, 12, 12, 30, 1, 1, 30, 30, 1})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Each 4 bytes are a rectangle, the first half of them of a, and
+		// the rest of b. The last 2 bytes are a translation.
+		var d image.Point
+		if len(data)%4 >= 2 {
+			d = image.Point{int(data[len(data)-2]%9) - 4, int(data[len(data)-1]%9) - 4}
+		}
+		var rs []image.Rectangle
+		for i := 0; i+4 <= len(data); i += 4 {
+			c := func(b byte) int { return 4 + int(b)%24 }
+			rs = append(rs, image.Rect(c(data[i]), c(data[i+1]), c(data[i+2]), c(data[i+3])))
+		}
+		testRegionOps(t, rs[:len(rs)/2], rs[len(rs)/2:], d)
+	})
+}
+
+func BenchmarkRegion(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	var rs []image.Rectangle
+	for i := 0; i < 64; i++ {
+		x, y := rng.Intn(1000), rng.Intn(1000)
+		rs = append(rs, image.Rect(x, y, x+rng.Intn(100), y+rng.Intn(100)))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewRegion(rs...)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageutil
+
+import (
+	"image"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Filter is a resampling filter, used to scale images.
+type Filter int
+
+const (
+	// Nearest takes the source pixel nearest to each destination pixel. It
+	// is the fastest and blockiest filter.
+	Nearest Filter = iota
+	// Bilinear interpolates between the 2x2 nearest source pixels, or
+	// averages over a tent when scaling down.
+	Bilinear
+	// Box averages the source pixels that each destination pixel covers. It
+	// is also known as area-averaging.
+	Box
+	// CatmullRom is a cubic filter, which is sharper than Bilinear.
+	CatmullRom
+	// Lanczos is a windowed sinc filter with 3 lobes. It is the sharpest
+	// and slowest filter.
+	Lanczos
+)
+
+func (f Filter) String() string {
+	switch f {
+	case Nearest:
+		return "Nearest"
+	case Bilinear:
+		return "Bilinear"
+	case Box:
+		return "Box"
+	case CatmullRom:
+		return "CatmullRom"
+	case Lanczos:
+		return "Lanczos"
+	}
+	return "Filter(?)"
+}
+
+// support returns the radius of f's kernel, in source pixels, when scaling
+// up.
+func (f Filter) support() float64 {
+	switch f {
+	case Bilinear:
+		return 1
+	case Box:
+		return 0.5
+	case CatmullRom:
+		return 2
+	case Lanczos:
+		return 3
+	}
+	return 0
+}
+
+// at returns f's kernel at t, in source pixels from its center.
+func (f Filter) at(t float64) float64 {
+	if t < 0 {
+		t = -t
+	}
+	switch f {
+	case Bilinear:
+		if t < 1 {
+			return 1 - t
+		}
+	case Box:
+		if t <= 0.5 {
+			return 1
+		}
+	case CatmullRom:
+		// The cubic with B = 0 and C = 1/2, from Mitchell and Netravali.
+		switch {
+		case t < 1:
+			return (3*t*t*t - 5*t*t + 2) / 2
+		case t < 2:
+			return (-t*t*t + 5*t*t - 8*t + 4) / 2
+		}
+	case Lanczos:
+		switch {
+		case t == 0:
+			return 1
+		case t < 3:
+			x := math.Pi * t
+			return 3 * math.Sin(x) * math.Sin(x/3) / (x * x)
+		}
+	}
+	return 0
+}
+
+// Resize scales the pixels in sr of src to fill dr of dst, using the filter
+// f. Only those pixels of dr that are also inside dst's bounds are written,
+// so that a region of a larger scaled image can be computed on its own; sr
+// is clipped to src's bounds, and source pixels outside of it are never read.
+//
+// The pixels are alpha-premultiplied, so the colors of transparent pixels do
+// not bleed into their neighbors. Resize splits the work across up to
+// GOMAXPROCS goroutines.
+func Resize(dst *image.RGBA, dr image.Rectangle, src *image.RGBA, sr image.Rectangle, f Filter) {
+	sr = sr.Intersect(src.Rect)
+	clip := dr.Intersect(dst.Rect)
+	if sr.Empty() || clip.Empty() {
+		return
+	}
+	if f == Nearest {
+		resizeNearest(dst, dr, clip, src, sr)
+		return
+	}
+
+	// The filter is separable, so scale each row of the source across, into
+	// tmp, and then each column of tmp down, into dst.
+	cols := weights(dr.Dx(), sr.Dx(), f)[clip.Min.X-dr.Min.X : clip.Max.X-dr.Min.X]
+	rows := weights(dr.Dy(), sr.Dy(), f)[clip.Min.Y-dr.Min.Y : clip.Max.Y-dr.Min.Y]
+	y0, y1 := rows[0].i, 0
+	for _, c := range rows {
+		if y1 < c.i+len(c.w) {
+			y1 = c.i + len(c.w)
+		}
+	}
+	w := clip.Dx()
+	tmp := make([]float32, 4*w*(y1-y0))
+
+	parallel(y1-y0, func(lo, hi int) {
+		for y := lo; y < hi; y++ {
+			s := src.Pix[src.PixOffset(sr.Min.X, sr.Min.Y+y0+y):]
+			t := tmp[4*w*y : 4*w*(y+1)]
+			for x, c := range cols {
+				var r, g, b, a float32
+				p := s[4*c.i:]
+				for j, k := range c.w {
+					r += k * float32(p[4*j+0])
+					g += k * float32(p[4*j+1])
+					b += k * float32(p[4*j+2])
+					a += k * float32(p[4*j+3])
+				}
+				t[4*x+0], t[4*x+1], t[4*x+2], t[4*x+3] = r, g, b, a
+			}
+		}
+	})
+
+	parallel(len(rows), func(lo, hi int) {
+		for y := lo; y < hi; y++ {
+			c := rows[y]
+			d := dst.Pix[dst.PixOffset(clip.Min.X, clip.Min.Y+y):]
+			for x := 0; x < w; x++ {
+				var r, g, b, a float32
+				for j, k := range c.w {
+					t := tmp[4*(w*(c.i+j-y0)+x):]
+					r += k * t[0]
+					g += k * t[1]
+					b += k * t[2]
+					a += k * t[3]
+				}
+				// The negative lobes of CatmullRom and Lanczos can ring past
+				// the range of valid alpha-premultiplied colors.
+				a = clamp(a, 255)
+				d[4*x+0] = uint8(clamp(r, a) + 0.5)
+				d[4*x+1] = uint8(clamp(g, a) + 0.5)
+				d[4*x+2] = uint8(clamp(b, a) + 0.5)
+				d[4*x+3] = uint8(a + 0.5)
+			}
+		}
+	})
+}
+
+func resizeNearest(dst *image.RGBA, dr, clip image.Rectangle, src *image.RGBA, sr image.Rectangle) {
+	dw, dh, sw, sh := dr.Dx(), dr.Dy(), sr.Dx(), sr.Dy()
+	parallel(clip.Dy(), func(lo, hi int) {
+		for y := clip.Min.Y + lo; y < clip.Min.Y+hi; y++ {
+			// The source pixel whose center is nearest to that of (x, y).
+			sy := sr.Min.Y + (2*(y-dr.Min.Y)+1)*sh/(2*dh)
+			d := dst.Pix[dst.PixOffset(clip.Min.X, y):]
+			s := src.Pix[src.PixOffset(sr.Min.X, sy):]
+			for x := clip.Min.X; x < clip.Max.X; x++ {
+				sx := (2*(x-dr.Min.X) + 1) * sw / (2 * dw)
+				copy(d[4*(x-clip.Min.X):4*(x-clip.Min.X)+4], s[4*sx:4*sx+4])
+			}
+		}
+	})
+}
+
+// contrib is the weights of the source pixels that contribute to a
+// destination pixel, starting with the source pixel at i.
+type contrib struct {
+	i int
+	w []float32
+}
+
+// weights returns the contribs of each of dn destination pixels, scaled from
+// sn source pixels by f. Source pixels past either end are clamped to it.
+func weights(dn, sn int, f Filter) []contrib {
+	scale := float64(sn) / float64(dn)
+	// When scaling down, the filter is stretched to cover every source
+	// pixel.
+	stretch := math.Max(scale, 1)
+	support := f.support() * stretch
+
+	cs := make([]contrib, dn)
+	for x := range cs {
+		center := (float64(x)+0.5)*scale - 0.5
+		lo := int(math.Ceil(center - support))
+		hi := int(math.Floor(center + support))
+		i, j := clampInt(lo, sn), clampInt(hi, sn)
+		w := make([]float64, j-i+1)
+		sum := 0.0
+		for k := lo; k <= hi; k++ {
+			v := f.at((float64(k) - center) / stretch)
+			w[clampInt(k, sn)-i] += v
+			sum += v
+		}
+		// Every filter's support covers at least one source pixel, so sum
+		// is never zero.
+		c := contrib{i: i, w: make([]float32, len(w))}
+		for k, v := range w {
+			c.w[k] = float32(v / sum)
+		}
+		cs[x] = c
+	}
+	return cs
+}
+
+func clampInt(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+func clamp(v, max float32) float32 {
+	if v < 0 {
+		return 0
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// parallel calls fn on consecutive ranges of [0, n), from up to GOMAXPROCS
+// goroutines, and waits for them to return.
+func parallel(n int, fn func(lo, hi int)) {
+	procs := runtime.GOMAXPROCS(0)
+	if procs > n {
+		procs = n
+	}
+	if procs <= 1 {
+		fn(0, n)
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(procs)
+	for i := 0; i < procs; i++ {
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(i*n/procs, (i+1)*n/procs)
+	}
+	wg.Wait()
+}
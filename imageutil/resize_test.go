@@ -0,0 +1,220 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imageutil
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden images in testdata")
+
+var filters = []Filter{Nearest, Bilinear, Box, CatmullRom, Lanczos}
+
+// resizeSrc returns a test image with gradients, hard edges and transparent
+// pixels.
+func resizeSrc() *image.RGBA {
+	m := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			var c color.NRGBA
+			switch {
+			case y < 16:
+				c = color.NRGBA{uint8(4 * x), uint8(16 * y), 0x80, 0xff}
+			case y < 32:
+				if (x/4+y/4)%2 == 0 {
+					c = color.NRGBA{0xff, 0xff, 0xff, 0xff}
+				} else {
+					c = color.NRGBA{0x20, 0x40, 0x60, 0xff}
+				}
+			default:
+				// A red gradient that fades out, with a transparent green
+				// stripe that must not bleed into it.
+				c = color.NRGBA{0xff, 0x00, 0x00, uint8(255 - 4*x)}
+				if x%16 < 4 {
+					c = color.NRGBA{0x00, 0xff, 0x00, 0x00}
+				}
+			}
+			m.Set(x, y, c)
+		}
+	}
+	return m
+}
+
+func TestResizeGolden(t *testing.T) {
+	src := resizeSrc()
+	for _, size := range []image.Point{{24, 20}, {150, 100}} {
+		for _, f := range filters {
+			dst := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+			Resize(dst, dst.Rect, src, src.Rect, f)
+			checkGolden(t, fmt.Sprintf("resize-%s-%dx%d.png", f, size.X, size.Y), dst)
+		}
+	}
+}
+
+// checkGolden compares m to the golden image in testdata, or writes it there
+// with -update. Pixels may be off by one, for the floating point rounding of
+// different architectures.
+func checkGolden(t *testing.T, name string, m *image.RGBA) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	// Both images go through PNG, which is not alpha-premultiplied.
+	got, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Bounds() != want.Bounds() {
+		t.Fatalf("%s: bounds: got %v, want %v", name, got.Bounds(), want.Bounds())
+	}
+	r := got.Bounds()
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			g := color.NRGBAModel.Convert(got.At(x, y)).(color.NRGBA)
+			w := color.NRGBAModel.Convert(want.At(x, y)).(color.NRGBA)
+			if !near(g.R, w.R) || !near(g.G, w.G) || !near(g.B, w.B) || !near(g.A, w.A) {
+				t.Fatalf("%s: (%d, %d): got %v, want %v", name, x, y, g, w)
+			}
+		}
+	}
+}
+
+func near(a, b uint8) bool {
+	d := int(a) - int(b)
+	return -1 <= d && d <= 1
+}
+
+func TestResizeIdentity(t *testing.T) {
+	src := resizeSrc()
+	for _, f := range filters {
+		dst := image.NewRGBA(src.Rect)
+		Resize(dst, dst.Rect, src, src.Rect, f)
+		if !bytes.Equal(dst.Pix, src.Pix) {
+			t.Errorf("%v: scaling by 1 changed the image", f)
+		}
+	}
+}
+
+func TestResizeUniform(t *testing.T) {
+	c := color.RGBA{0x40, 0x30, 0x20, 0x80}
+	src := image.NewRGBA(image.Rect(0, 0, 13, 7))
+	for i := 0; i < len(src.Pix); i += 4 {
+		src.Pix[i], src.Pix[i+1], src.Pix[i+2], src.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	for _, f := range filters {
+		for _, r := range []image.Rectangle{
+			image.Rect(0, 0, 3, 2),
+			image.Rect(0, 0, 40, 31),
+			image.Rect(0, 0, 1, 50),
+		} {
+			dst := image.NewRGBA(r)
+			Resize(dst, r, src, src.Rect, f)
+			for y := r.Min.Y; y < r.Max.Y; y++ {
+				for x := r.Min.X; x < r.Max.X; x++ {
+					if got := dst.RGBAAt(x, y); got != c {
+						t.Fatalf("%v, %v: (%d, %d): got %v, want %v", f, r, x, y, got, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestResizeRegion(t *testing.T) {
+	src := resizeSrc()
+	sr := image.Rect(10, 5, 50, 40)
+	dr := image.Rect(-20, -10, 80, 60)
+	for _, f := range filters {
+		whole := image.NewRGBA(dr)
+		Resize(whole, dr, src, sr, f)
+
+		// Computing a region of the scaled image on its own gives the
+		// same pixels, and writes no others.
+		part := image.NewRGBA(image.Rect(5, 7, 33, 29))
+		Resize(part, dr, src, sr, f)
+		for y := part.Rect.Min.Y; y < part.Rect.Max.Y; y++ {
+			for x := part.Rect.Min.X; x < part.Rect.Max.X; x++ {
+				if got, want := part.RGBAAt(x, y), whole.RGBAAt(x, y); got != want {
+					t.Fatalf("%v: (%d, %d): got %v, want %v", f, x, y, got, want)
+				}
+			}
+		}
+
+		// Source pixels outside of sr are not read.
+		noisy := image.NewRGBA(src.Rect)
+		copy(noisy.Pix, src.Pix)
+		for y := src.Rect.Min.Y; y < src.Rect.Max.Y; y++ {
+			for x := src.Rect.Min.X; x < src.Rect.Max.X; x++ {
+				if !(image.Point{x, y}).In(sr) {
+					noisy.SetRGBA(x, y, color.RGBA{0xff, 0, 0xff, 0xff})
+				}
+			}
+		}
+		got := image.NewRGBA(dr)
+		Resize(got, dr, noisy, sr, f)
+		if !bytes.Equal(got.Pix, whole.Pix) {
+			t.Errorf("%v: pixels outside of sr changed the result", f)
+		}
+	}
+}
+
+func TestResizeParallel(t *testing.T) {
+	src := resizeSrc()
+	r := image.Rect(0, 0, 37, 91)
+	for _, f := range filters {
+		want := image.NewRGBA(r)
+		procs := runtime.GOMAXPROCS(1)
+		Resize(want, r, src, src.Rect, f)
+		runtime.GOMAXPROCS(8)
+		got := image.NewRGBA(r)
+		Resize(got, r, src, src.Rect, f)
+		runtime.GOMAXPROCS(procs)
+		if !bytes.Equal(got.Pix, want.Pix) {
+			t.Errorf("%v: got a different result in parallel", f)
+		}
+	}
+}
+
+func BenchmarkResize(b *testing.B) {
+	src := image.NewRGBA(image.Rect(0, 0, 1024, 768))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i * 7)
+	}
+	for _, f := range filters {
+		for _, size := range []image.Point{{256, 192}, {2048, 1536}} {
+			dst := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+			b.Run(fmt.Sprintf("%v/%dx%d", f, size.X, size.Y), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					Resize(dst, dst.Rect, src, src.Rect, f)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// The identities, which as arrays must be addressable to be sliced.
+var id3, id4, idm4 = IdentityAff3(), IdentityAff4(), IdentityMat4()
+
+func nearAll(x, y []float32) bool {
+	for i := range x {
+		if math.Abs(float64(x[i]-y[i])) > 1e-4 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestInverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		var a Aff3
+		for j := range a {
+			a[j] = rng.Float32()*4 - 2
+		}
+		if math.Abs(float64(a.Det())) < 0.1 {
+			continue
+		}
+		inv, ok := a.Inverse()
+		if !ok {
+			t.Fatalf("%v: not invertible", a)
+		}
+		if got := a.Mul(inv); !nearAll(got[:], id3[:]) {
+			t.Fatalf("%v × %v: got %v, want identity", a, inv, got)
+		}
+
+		var m Mat4
+		for j := range m {
+			m[j] = rng.Float32()*4 - 2
+		}
+		if math.Abs(float64(m.Det())) < 0.1 {
+			continue
+		}
+		minv, ok := m.Inverse()
+		if !ok {
+			t.Fatalf("%v: not invertible", m)
+		}
+		if got := m.Mul(minv); !nearAll(got[:], idm4[:]) {
+			t.Fatalf("%v × %v: got %v, want identity", m, minv, got)
+		}
+	}
+	if inv, ok := IdentityAff4().Scale(1, 0, 1).Inverse(); ok || inv != (Aff4{}) {
+		t.Errorf("singular Aff4: got %v, %t, want zero, false", inv, ok)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	a := IdentityAff3().Scale(2, 2).Rotate(math.Pi/2).Translate(1, 1)
+	if got, want := a.Transform(Vec2{1, 0}), (Vec2{1, 3}); !nearAll(got[:], want[:]) {
+		t.Errorf("Transform: got %v, want %v", got, want)
+	}
+	if got, want := a.TransformVec(Vec2{1, 0}), (Vec2{0, 2}); !nearAll(got[:], want[:]) {
+		t.Errorf("TransformVec: got %v, want %v", got, want)
+	}
+	if got, want := a.Mat3().Transpose().Transpose(), a.Mat3(); got != want {
+		t.Errorf("Transpose: got %v, want %v", got, want)
+	}
+	r := IdentityAff4().Rotate(math.Pi/2, Vec3{1, 0, 0})
+	if got, want := r.Transform(Vec3{0, 1, 0}), (Vec3{0, 0, 1}); !nearAll(got[:], want[:]) {
+		t.Errorf("Rotate: got %v, want %v", got, want)
+	}
+}
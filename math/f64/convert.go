@@ -0,0 +1,137 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+import "github.com/as/shiny/math/f32"
+
+// The float32 types are what OpenGL takes, so conversions between them and
+// the float64 types, which are better for composing transformations, are
+// here, in the package that imports the other.
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Vec2) F32() f32.Vec2 {
+	var y f32.Vec2
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Vec2 converts x to float64, which is exact.
+func FromF32Vec2(x f32.Vec2) Vec2 {
+	var y Vec2
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Vec3) F32() f32.Vec3 {
+	var y f32.Vec3
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Vec3 converts x to float64, which is exact.
+func FromF32Vec3(x f32.Vec3) Vec3 {
+	var y Vec3
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Vec4) F32() f32.Vec4 {
+	var y f32.Vec4
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Vec4 converts x to float64, which is exact.
+func FromF32Vec4(x f32.Vec4) Vec4 {
+	var y Vec4
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Mat3) F32() f32.Mat3 {
+	var y f32.Mat3
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Mat3 converts x to float64, which is exact.
+func FromF32Mat3(x f32.Mat3) Mat3 {
+	var y Mat3
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Mat4) F32() f32.Mat4 {
+	var y f32.Mat4
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Mat4 converts x to float64, which is exact.
+func FromF32Mat4(x f32.Mat4) Mat4 {
+	var y Mat4
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Aff3) F32() f32.Aff3 {
+	var y f32.Aff3
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Aff3 converts x to float64, which is exact.
+func FromF32Aff3(x f32.Aff3) Aff3 {
+	var y Aff3
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
+
+// F32 converts x to float32, rounding each element to the nearest float32.
+func (x Aff4) F32() f32.Aff4 {
+	var y f32.Aff4
+	for i, v := range x {
+		y[i] = float32(v)
+	}
+	return y
+}
+
+// FromF32Aff4 converts x to float64, which is exact.
+func FromF32Aff4(x f32.Aff4) Aff4 {
+	var y Aff4
+	for i, v := range x {
+		y[i] = float64(v)
+	}
+	return y
+}
@@ -0,0 +1,270 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/as/shiny/math/f32"
+)
+
+const eps = 1e-9
+
+// The identities, which as arrays must be addressable to be sliced.
+var id3, id4, idm4 = IdentityAff3(), IdentityAff4(), IdentityMat4()
+
+func near(x, y float64) bool { return math.Abs(x-y) <= eps*math.Max(1, math.Abs(y)) }
+
+func nearAll(x, y []float64) bool {
+	for i := range x {
+		if !near(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func randAff3(rng *rand.Rand) (a Aff3) {
+	for i := range a {
+		a[i] = rng.Float64()*4 - 2
+	}
+	return a
+}
+
+func randAff4(rng *rand.Rand) (a Aff4) {
+	for i := range a {
+		a[i] = rng.Float64()*4 - 2
+	}
+	return a
+}
+
+func randMat4(rng *rand.Rand) (m Mat4) {
+	for i := range m {
+		m[i] = rng.Float64()*4 - 2
+	}
+	return m
+}
+
+func TestInverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		a := randAff3(rng)
+		inv, ok := a.Inverse()
+		if !ok {
+			t.Fatalf("%v: not invertible", a)
+		}
+		if got := a.Mul(inv); !nearAll(got[:], id3[:]) {
+			t.Fatalf("%v × %v: got %v, want identity", a, inv, got)
+		}
+		m, _ := a.Mat3().Inverse()
+		if got := inv.Mat3(); !nearAll(got[:], m[:]) {
+			t.Fatalf("%v: Aff3 inverse %v, Mat3 inverse %v", a, got, m)
+		}
+
+		b := randAff4(rng)
+		binv, ok := b.Inverse()
+		if !ok {
+			t.Fatalf("%v: not invertible", b)
+		}
+		if got := binv.Mul(b); !nearAll(got[:], id4[:]) {
+			t.Fatalf("%v × %v: got %v, want identity", binv, b, got)
+		}
+
+		c := randMat4(rng)
+		cinv, ok := c.Inverse()
+		if !ok {
+			t.Fatalf("%v: not invertible", c)
+		}
+		if got := c.Mul(cinv); !nearAll(got[:], idm4[:]) {
+			t.Fatalf("%v × %v: got %v, want identity", c, cinv, got)
+		}
+		m4, _ := b.Mat4().Inverse()
+		if got := binv.Mat4(); !nearAll(got[:], m4[:]) {
+			t.Fatalf("%v: Aff4 inverse %v, Mat4 inverse %v", b, got, m4)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	if inv, ok := (Aff3{1, 2, 3, 2, 4, 5}).Inverse(); ok || inv != (Aff3{}) {
+		t.Errorf("Aff3: got %v, %t, want zero, false", inv, ok)
+	}
+	if inv, ok := IdentityAff3().Scale(0, 1).Inverse(); ok || inv != (Aff3{}) {
+		t.Errorf("Aff3 scaled by 0: got %v, %t, want zero, false", inv, ok)
+	}
+	if inv, ok := (Aff3{math.NaN(), 0, 0, 0, 1, 0}).Inverse(); ok {
+		t.Errorf("Aff3 with NaN: got %v, want false", inv)
+	}
+	if inv, ok := (Mat3{1, 2, 3, 4, 5, 6, 7, 8, 9}).Inverse(); ok || inv != (Mat3{}) {
+		t.Errorf("Mat3: got %v, %t, want zero, false", inv, ok)
+	}
+	if inv, ok := IdentityAff4().Scale(1, 1, 0).Inverse(); ok || inv != (Aff4{}) {
+		t.Errorf("Aff4: got %v, %t, want zero, false", inv, ok)
+	}
+	m := Mat4{1, 2, 3, 4, 2, 4, 6, 8, 0, 1, 0, 1, 1, 0, 0, 1}
+	if inv, ok := m.Inverse(); ok || inv != (Mat4{}) {
+		t.Errorf("Mat4: got %v, %t, want zero, false", inv, ok)
+	}
+	if got := m.Det(); got != 0 {
+		t.Errorf("Mat4 Det: got %v, want 0", got)
+	}
+}
+
+func TestDet(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		a, b := randAff3(rng), randAff3(rng)
+		if got, want := a.Mul(b).Det(), a.Det()*b.Det(); !near(got, want) {
+			t.Fatalf("det(ab): got %v, want %v", got, want)
+		}
+		if got, want := a.Mat3().Det(), a.Det(); !near(got, want) {
+			t.Fatalf("Mat3 Det: got %v, want %v", got, want)
+		}
+		m, n := a.Mat3(), b.Mat3()
+		if got, want := m.Mul(n).Transpose(), n.Transpose().Mul(m.Transpose()); !nearAll(got[:], want[:]) {
+			t.Fatalf("(mn)ᵀ: got %v, want %v", got, want)
+		}
+		if got := m.Transpose().Transpose(); got != m {
+			t.Fatalf("mᵀᵀ: got %v, want %v", got, m)
+		}
+
+		c, d := randMat4(rng), randMat4(rng)
+		if got, want := c.Mul(d).Det(), c.Det()*d.Det(); !near(got, want) {
+			t.Fatalf("Mat4 det(cd): got %v, want %v", got, want)
+		}
+		if got, want := c.Transpose().Det(), c.Det(); !near(got, want) {
+			t.Fatalf("Mat4 det(cᵀ): got %v, want %v", got, want)
+		}
+		if got, want := c.Mul(d).Transpose(), d.Transpose().Mul(c.Transpose()); !nearAll(got[:], want[:]) {
+			t.Fatalf("Mat4 (cd)ᵀ: got %v, want %v", got, want)
+		}
+		e := randAff4(rng)
+		if got, want := e.Mat4().Det(), e.Det(); !near(got, want) {
+			t.Fatalf("Aff4 Det: got %v, want %v", got, want)
+		}
+	}
+	if got := (Mat4{2, 0, 0, 0, 0, 3, 0, 0, 0, 0, 4, 0, 1, 2, 3, 5}).Det(); got != 120 {
+		t.Errorf("Det: got %v, want 120", got)
+	}
+	// A swap of two rows negates the determinant.
+	if got := (Mat4{0, 1, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}).Det(); got != -1 {
+		t.Errorf("Det: got %v, want -1", got)
+	}
+}
+
+func TestAff3(t *testing.T) {
+	// Each operation is applied after the ones before it.
+	a := IdentityAff3().Translate(1, 0).Scale(2, 3)
+	if got, want := a.Transform(Vec2{0, 0}), (Vec2{2, 0}); got != want {
+		t.Errorf("translate then scale: got %v, want %v", got, want)
+	}
+	a = IdentityAff3().Scale(2, 3).Translate(1, 0)
+	if got, want := a.Transform(Vec2{0, 0}), (Vec2{1, 0}); got != want {
+		t.Errorf("scale then translate: got %v, want %v", got, want)
+	}
+	if got, want := a.TransformVec(Vec2{1, 1}), (Vec2{2, 3}); got != want {
+		t.Errorf("TransformVec: got %v, want %v", got, want)
+	}
+
+	r := IdentityAff3().Rotate(math.Pi / 2)
+	if got, want := r.Transform(Vec2{1, 0}), (Vec2{0, 1}); !nearAll(got[:], want[:]) {
+		t.Errorf("Rotate: got %v, want %v", got, want)
+	}
+	if got := r.Det(); !near(got, 1) {
+		t.Errorf("Rotate: det %v, want 1", got)
+	}
+
+	s := IdentityAff3().Shear(2, 0)
+	if got, want := s.Transform(Vec2{1, 1}), (Vec2{3, 1}); got != want {
+		t.Errorf("Shear: got %v, want %v", got, want)
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		a, b := randAff3(rng), randAff3(rng)
+		p := Vec2{rng.Float64(), rng.Float64()}
+		if got, want := a.Mul(b).Transform(p), a.Transform(b.Transform(p)); !nearAll(got[:], want[:]) {
+			t.Fatalf("(ab)p: got %v, want %v", got, want)
+		}
+		v := a.Mat3().Transform(Vec3{p[0], p[1], 1})
+		if got := a.Transform(p); !nearAll(got[:], v[:2]) || v[2] != 1 {
+			t.Fatalf("Mat3 Transform: got %v, want %v", v, got)
+		}
+	}
+}
+
+func TestAff4(t *testing.T) {
+	a := IdentityAff4().Translate(1, 2, 3).Scale(2, 2, 2)
+	if got, want := a.Transform(Vec3{0, 0, 0}), (Vec3{2, 4, 6}); got != want {
+		t.Errorf("translate then scale: got %v, want %v", got, want)
+	}
+	if got, want := a.TransformVec(Vec3{1, 0, 0}), (Vec3{2, 0, 0}); got != want {
+		t.Errorf("TransformVec: got %v, want %v", got, want)
+	}
+
+	// A right-handed quarter turn about Z takes X to Y.
+	r := IdentityAff4().Rotate(math.Pi/2, Vec3{0, 0, 5})
+	if got, want := r.Transform(Vec3{1, 0, 0}), (Vec3{0, 1, 0}); !nearAll(got[:], want[:]) {
+		t.Errorf("Rotate about Z: got %v, want %v", got, want)
+	}
+	r = IdentityAff4().Rotate(2*math.Pi/3, Vec3{1, 1, 1})
+	if got, want := r.Transform(Vec3{1, 0, 0}), (Vec3{0, 1, 0}); !nearAll(got[:], want[:]) {
+		t.Errorf("Rotate about (1, 1, 1): got %v, want %v", got, want)
+	}
+	if got := IdentityAff4().Rotate(1, Vec3{}); got != IdentityAff4() {
+		t.Errorf("Rotate about 0: got %v, want identity", got)
+	}
+
+	s := IdentityAff4().Shear(1, 2, 0, 0, 0, 3)
+	if got, want := s.Transform(Vec3{1, 1, 1}), (Vec3{4, 1, 4}); got != want {
+		t.Errorf("Shear: got %v, want %v", got, want)
+	}
+
+	rng := rand.New(rand.NewSource(4))
+	for i := 0; i < 100; i++ {
+		a, b := randAff4(rng), randAff4(rng)
+		p := Vec3{rng.Float64(), rng.Float64(), rng.Float64()}
+		if got, want := a.Mul(b).Transform(p), a.Transform(b.Transform(p)); !nearAll(got[:], want[:]) {
+			t.Fatalf("(ab)p: got %v, want %v", got, want)
+		}
+		if got, want := a.Mul(b).Mat4(), a.Mat4().Mul(b.Mat4()); !nearAll(got[:], want[:]) {
+			t.Fatalf("Mat4: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestVec(t *testing.T) {
+	if got := (Vec2{3, 4}).Len(); got != 5 {
+		t.Errorf("Vec2 Len: got %v, want 5", got)
+	}
+	if got, want := (Vec3{1, 0, 0}).Cross(Vec3{0, 1, 0}), (Vec3{0, 0, 1}); got != want {
+		t.Errorf("Cross: got %v, want %v", got, want)
+	}
+	if got, want := (Vec4{1, 2, 3, 4}).Add(Vec4{1, 1, 1, 1}).Sub(Vec4{2, 2, 2, 2}).Mul(2), (Vec4{0, 2, 4, 6}); got != want {
+		t.Errorf("Vec4: got %v, want %v", got, want)
+	}
+	if got := (Vec3{1, 2, 3}).Dot(Vec3{4, 5, 6}); got != 32 {
+		t.Errorf("Dot: got %v, want 32", got)
+	}
+}
+
+func TestF32(t *testing.T) {
+	a := IdentityAff3().Rotate(0.5).Translate(10, 20)
+	b := a.F32()
+	for i := range a {
+		if b[i] != float32(a[i]) {
+			t.Fatalf("F32: got %v, want %v", b, a)
+		}
+	}
+	if got := FromF32Aff3(b).F32(); got != b {
+		t.Errorf("FromF32Aff3: got %v, want %v", got, b)
+	}
+	m := f32.Mat4{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 0.1}
+	if got := FromF32Mat4(m).F32(); got != m {
+		t.Errorf("round trip: got %v, want %v", got, m)
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f64
+
+import (
+	"math"
+)
+
+// Add returns the vector v+w.
+func (v Vec2) Add(w Vec2) Vec2 { return Vec2{v[0] + w[0], v[1] + w[1]} }
+
+// Sub returns the vector v-w.
+func (v Vec2) Sub(w Vec2) Vec2 { return Vec2{v[0] - w[0], v[1] - w[1]} }
+
+// Mul returns the vector v*k.
+func (v Vec2) Mul(k float64) Vec2 { return Vec2{v[0] * k, v[1] * k} }
+
+// Dot returns the dot product of v and w.
+func (v Vec2) Dot(w Vec2) float64 { return v[0]*w[0] + v[1]*w[1] }
+
+// Len returns the length of v.
+func (v Vec2) Len() float64 { return sqrt(v.Dot(v)) }
+
+// Add returns the vector v+w.
+func (v Vec3) Add(w Vec3) Vec3 { return Vec3{v[0] + w[0], v[1] + w[1], v[2] + w[2]} }
+
+// Sub returns the vector v-w.
+func (v Vec3) Sub(w Vec3) Vec3 { return Vec3{v[0] - w[0], v[1] - w[1], v[2] - w[2]} }
+
+// Mul returns the vector v*k.
+func (v Vec3) Mul(k float64) Vec3 { return Vec3{v[0] * k, v[1] * k, v[2] * k} }
+
+// Dot returns the dot product of v and w.
+func (v Vec3) Dot(w Vec3) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+
+// Cross returns the cross product of v and w.
+func (v Vec3) Cross(w Vec3) Vec3 {
+	return Vec3{
+		v[1]*w[2] - v[2]*w[1],
+		v[2]*w[0] - v[0]*w[2],
+		v[0]*w[1] - v[1]*w[0],
+	}
+}
+
+// Len returns the length of v.
+func (v Vec3) Len() float64 { return sqrt(v.Dot(v)) }
+
+// Add returns the vector v+w.
+func (v Vec4) Add(w Vec4) Vec4 { return Vec4{v[0] + w[0], v[1] + w[1], v[2] + w[2], v[3] + w[3]} }
+
+// Sub returns the vector v-w.
+func (v Vec4) Sub(w Vec4) Vec4 { return Vec4{v[0] - w[0], v[1] - w[1], v[2] - w[2], v[3] - w[3]} }
+
+// Mul returns the vector v*k.
+func (v Vec4) Mul(k float64) Vec4 { return Vec4{v[0] * k, v[1] * k, v[2] * k, v[3] * k} }
+
+// Dot returns the dot product of v and w.
+func (v Vec4) Dot(w Vec4) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] + v[3]*w[3] }
+
+// Len returns the length of v.
+func (v Vec4) Len() float64 { return sqrt(v.Dot(v)) }
+
+// IdentityMat3 returns the 3x3 identity matrix.
+func IdentityMat3() Mat3 { return Mat3{1, 0, 0, 0, 1, 0, 0, 0, 1} }
+
+// Mul returns the matrix product m×n.
+func (m Mat3) Mul(n Mat3) Mat3 {
+	var p Mat3
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			p[3*r+c] = m[3*r+0]*n[0*3+c] + m[3*r+1]*n[1*3+c] + m[3*r+2]*n[2*3+c]
+		}
+	}
+	return p
+}
+
+// Transform returns the vector m×v.
+func (m Mat3) Transform(v Vec3) Vec3 {
+	return Vec3{
+		m[0]*v[0] + m[1]*v[1] + m[2]*v[2],
+		m[3]*v[0] + m[4]*v[1] + m[5]*v[2],
+		m[6]*v[0] + m[7]*v[1] + m[8]*v[2],
+	}
+}
+
+// Transpose returns m with its rows and columns swapped.
+func (m Mat3) Transpose() Mat3 {
+	return Mat3{
+		m[0], m[3], m[6],
+		m[1], m[4], m[7],
+		m[2], m[5], m[8],
+	}
+}
+
+// Det returns the determinant of m.
+func (m Mat3) Det() float64 {
+	return m[0]*(m[4]*m[8]-m[5]*m[7]) -
+		m[1]*(m[3]*m[8]-m[5]*m[6]) +
+		m[2]*(m[3]*m[7]-m[4]*m[6])
+}
+
+// Inverse returns the inverse of m. If m is singular, and has no inverse,
+// ok is false and inv is the zero matrix.
+func (m Mat3) Inverse() (inv Mat3, ok bool) {
+	det := m.Det()
+	if !invertible(det) {
+		return Mat3{}, false
+	}
+	d := 1 / det
+	return Mat3{
+		(m[4]*m[8] - m[5]*m[7]) * d,
+		(m[2]*m[7] - m[1]*m[8]) * d,
+		(m[1]*m[5] - m[2]*m[4]) * d,
+		(m[5]*m[6] - m[3]*m[8]) * d,
+		(m[0]*m[8] - m[2]*m[6]) * d,
+		(m[2]*m[3] - m[0]*m[5]) * d,
+		(m[3]*m[7] - m[4]*m[6]) * d,
+		(m[1]*m[6] - m[0]*m[7]) * d,
+		(m[0]*m[4] - m[1]*m[3]) * d,
+	}, true
+}
+
+// IdentityMat4 returns the 4x4 identity matrix.
+func IdentityMat4() Mat4 { return Mat4{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1} }
+
+// Mul returns the matrix product m×n.
+func (m Mat4) Mul(n Mat4) Mat4 {
+	var p Mat4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			p[4*r+c] = m[4*r+0]*n[0*4+c] + m[4*r+1]*n[1*4+c] + m[4*r+2]*n[2*4+c] + m[4*r+3]*n[3*4+c]
+		}
+	}
+	return p
+}
+
+// Transform returns the vector m×v.
+func (m Mat4) Transform(v Vec4) Vec4 {
+	var w Vec4
+	for r := 0; r < 4; r++ {
+		w[r] = m[4*r+0]*v[0] + m[4*r+1]*v[1] + m[4*r+2]*v[2] + m[4*r+3]*v[3]
+	}
+	return w
+}
+
+// Transpose returns m with its rows and columns swapped.
+func (m Mat4) Transpose() Mat4 {
+	var t Mat4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			t[4*c+r] = m[4*r+c]
+		}
+	}
+	return t
+}
+
+// Det returns the determinant of m.
+func (m Mat4) Det() float64 {
+	det, _ := m.eliminate()
+	return det
+}
+
+// Inverse returns the inverse of m. If m is singular, and has no inverse,
+// ok is false and inv is the zero matrix.
+func (m Mat4) Inverse() (inv Mat4, ok bool) {
+	det, inv := m.eliminate()
+	if !invertible(det) {
+		return Mat4{}, false
+	}
+	return inv, true
+}
+
+// eliminate returns the determinant of m and, if it is not zero, the inverse
+// of m, by Gauss-Jordan elimination with partial pivoting.
+func (m Mat4) eliminate() (det float64, inv Mat4) {
+	inv = IdentityMat4()
+	det = 1
+	for c := 0; c < 4; c++ {
+		// Use the row with the largest magnitude in column c as the pivot,
+		// for stability.
+		p := c
+		for r := c + 1; r < 4; r++ {
+			if math.Abs(float64(m[4*r+c])) > math.Abs(float64(m[4*p+c])) {
+				p = r
+			}
+		}
+		if m[4*p+c] == 0 {
+			return 0, Mat4{}
+		}
+		if p != c {
+			for k := 0; k < 4; k++ {
+				m[4*p+k], m[4*c+k] = m[4*c+k], m[4*p+k]
+				inv[4*p+k], inv[4*c+k] = inv[4*c+k], inv[4*p+k]
+			}
+			det = -det
+		}
+		pivot := m[4*c+c]
+		det *= pivot
+		for k := 0; k < 4; k++ {
+			m[4*c+k] /= pivot
+			inv[4*c+k] /= pivot
+		}
+		for r := 0; r < 4; r++ {
+			if r == c {
+				continue
+			}
+			f := m[4*r+c]
+			for k := 0; k < 4; k++ {
+				m[4*r+k] -= f * m[4*c+k]
+				inv[4*r+k] -= f * inv[4*c+k]
+			}
+		}
+	}
+	return det, inv
+}
+
+// IdentityAff3 returns the identity transformation.
+func IdentityAff3() Aff3 { return Aff3{1, 0, 0, 0, 1, 0} }
+
+// Mul returns the product a×b, the transformation that applies b and then a.
+func (a Aff3) Mul(b Aff3) Aff3 {
+	return Aff3{
+		a[0]*b[0] + a[1]*b[3],
+		a[0]*b[1] + a[1]*b[4],
+		a[0]*b[2] + a[1]*b[5] + a[2],
+
+		a[3]*b[0] + a[4]*b[3],
+		a[3]*b[1] + a[4]*b[4],
+		a[3]*b[2] + a[4]*b[5] + a[5],
+	}
+}
+
+// Det returns the determinant of a, the factor by which a scales areas.
+func (a Aff3) Det() float64 { return a[0]*a[4] - a[1]*a[3] }
+
+// Inverse returns the inverse of a. If a is singular, for example if it
+// scales by zero, ok is false and inv is the zero matrix.
+func (a Aff3) Inverse() (inv Aff3, ok bool) {
+	det := a.Det()
+	if !invertible(det) {
+		return Aff3{}, false
+	}
+	d := 1 / det
+	return Aff3{
+		+a[4] * d,
+		-a[1] * d,
+		(a[1]*a[5] - a[2]*a[4]) * d,
+		-a[3] * d,
+		+a[0] * d,
+		(a[2]*a[3] - a[0]*a[5]) * d,
+	}, true
+}
+
+// Translate returns a followed by a translation by (x, y).
+func (a Aff3) Translate(x, y float64) Aff3 {
+	return Aff3{1, 0, x, 0, 1, y}.Mul(a)
+}
+
+// Scale returns a followed by a scale by (x, y), about the origin.
+func (a Aff3) Scale(x, y float64) Aff3 {
+	return Aff3{x, 0, 0, 0, y, 0}.Mul(a)
+}
+
+// Rotate returns a followed by a rotation by radians about the origin. With
+// the Y axis pointing down, as on a screen, positive angles turn clockwise.
+func (a Aff3) Rotate(radians float64) Aff3 {
+	s, c := sincos(radians)
+	return Aff3{c, -s, 0, s, c, 0}.Mul(a)
+}
+
+// Shear returns a followed by a shear, which adds x times the Y coordinate
+// to the X coordinate, and y times the X coordinate to the Y coordinate.
+func (a Aff3) Shear(x, y float64) Aff3 {
+	return Aff3{1, x, 0, y, 1, 0}.Mul(a)
+}
+
+// Transform returns the point p transformed by a.
+func (a Aff3) Transform(p Vec2) Vec2 {
+	return Vec2{
+		a[0]*p[0] + a[1]*p[1] + a[2],
+		a[3]*p[0] + a[4]*p[1] + a[5],
+	}
+}
+
+// TransformVec returns the vector v transformed by a. Unlike a point, a
+// vector, such as the difference of two points, is not translated.
+func (a Aff3) TransformVec(v Vec2) Vec2 {
+	return Vec2{
+		a[0]*v[0] + a[1]*v[1],
+		a[3]*v[0] + a[4]*v[1],
+	}
+}
+
+// Mat3 returns a as a 3x3 matrix, with the implicit bottom row.
+func (a Aff3) Mat3() Mat3 {
+	return Mat3{
+		a[0], a[1], a[2],
+		a[3], a[4], a[5],
+		0, 0, 1,
+	}
+}
+
+// IdentityAff4 returns the identity transformation.
+func IdentityAff4() Aff4 { return Aff4{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0} }
+
+// Mul returns the product a×b, the transformation that applies b and then a.
+func (a Aff4) Mul(b Aff4) Aff4 {
+	var p Aff4
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 4; c++ {
+			p[4*r+c] = a[4*r+0]*b[0*4+c] + a[4*r+1]*b[1*4+c] + a[4*r+2]*b[2*4+c]
+		}
+		p[4*r+3] += a[4*r+3]
+	}
+	return p
+}
+
+// Det returns the determinant of a, the factor by which a scales volumes.
+func (a Aff4) Det() float64 {
+	return a[0]*(a[5]*a[10]-a[6]*a[9]) -
+		a[1]*(a[4]*a[10]-a[6]*a[8]) +
+		a[2]*(a[4]*a[9]-a[5]*a[8])
+}
+
+// Inverse returns the inverse of a. If a is singular, for example if it
+// scales by zero, ok is false and inv is the zero matrix.
+func (a Aff4) Inverse() (inv Aff4, ok bool) {
+	// The inverse of the linear part, and then of the translation.
+	l, ok := Mat3{
+		a[0], a[1], a[2],
+		a[4], a[5], a[6],
+		a[8], a[9], a[10],
+	}.Inverse()
+	if !ok {
+		return Aff4{}, false
+	}
+	t := l.Transform(Vec3{-a[3], -a[7], -a[11]})
+	return Aff4{
+		l[0], l[1], l[2], t[0],
+		l[3], l[4], l[5], t[1],
+		l[6], l[7], l[8], t[2],
+	}, true
+}
+
+// Translate returns a followed by a translation by (x, y, z).
+func (a Aff4) Translate(x, y, z float64) Aff4 {
+	return Aff4{1, 0, 0, x, 0, 1, 0, y, 0, 0, 1, z}.Mul(a)
+}
+
+// Scale returns a followed by a scale by (x, y, z), about the origin.
+func (a Aff4) Scale(x, y, z float64) Aff4 {
+	return Aff4{x, 0, 0, 0, 0, y, 0, 0, 0, 0, z, 0}.Mul(a)
+}
+
+// Rotate returns a followed by a rotation by radians about axis, which need
+// not be of unit length, following the right-hand rule. A zero axis leaves a
+// unchanged.
+func (a Aff4) Rotate(radians float64, axis Vec3) Aff4 {
+	n := axis.Len()
+	if n == 0 {
+		return a
+	}
+	x, y, z := axis[0]/n, axis[1]/n, axis[2]/n
+	s, c := sincos(radians)
+	t := 1 - c
+	return Aff4{
+		t*x*x + c, t*x*y - s*z, t*x*z + s*y, 0,
+		t*x*y + s*z, t*y*y + c, t*y*z - s*x, 0,
+		t*x*z - s*y, t*y*z + s*x, t*z*z + c, 0,
+	}.Mul(a)
+}
+
+// Shear returns a followed by a shear, which adds xy times the Y coordinate
+// and xz times the Z coordinate to the X coordinate, and likewise for the Y
+// and Z coordinates.
+func (a Aff4) Shear(xy, xz, yx, yz, zx, zy float64) Aff4 {
+	return Aff4{1, xy, xz, 0, yx, 1, yz, 0, zx, zy, 1, 0}.Mul(a)
+}
+
+// Transform returns the point p transformed by a.
+func (a Aff4) Transform(p Vec3) Vec3 {
+	return Vec3{
+		a[0]*p[0] + a[1]*p[1] + a[2]*p[2] + a[3],
+		a[4]*p[0] + a[5]*p[1] + a[6]*p[2] + a[7],
+		a[8]*p[0] + a[9]*p[1] + a[10]*p[2] + a[11],
+	}
+}
+
+// TransformVec returns the vector v transformed by a. Unlike a point, a
+// vector is not translated.
+func (a Aff4) TransformVec(v Vec3) Vec3 {
+	return Vec3{
+		a[0]*v[0] + a[1]*v[1] + a[2]*v[2],
+		a[4]*v[0] + a[5]*v[1] + a[6]*v[2],
+		a[8]*v[0] + a[9]*v[1] + a[10]*v[2],
+	}
+}
+
+// Mat4 returns a as a 4x4 matrix, with the implicit bottom row.
+func (a Aff4) Mat4() Mat4 {
+	return Mat4{
+		a[0], a[1], a[2], a[3],
+		a[4], a[5], a[6], a[7],
+		a[8], a[9], a[10], a[11],
+		0, 0, 0, 1,
+	}
+}
+
+// invertible reports whether a matrix with the determinant det has an
+// inverse that is finite.
+func invertible(det float64) bool {
+	return det != 0 && !math.IsNaN(float64(det)) && !math.IsInf(float64(1/det), 0)
+}
+
+func sqrt(x float64) float64 { return math.Sqrt(x) }
+
+func sincos(x float64) (sin, cos float64) { return math.Sincos(x) }
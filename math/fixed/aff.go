@@ -0,0 +1,114 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixed
+
+import (
+	"github.com/as/shiny/math/f64"
+)
+
+// Aff52_12 is a 52.12 fixed-point 3x3 affine transformation matrix in row
+// major order, where the bottom row is implicitly [0 0 1].
+//
+// a[3*r + c] is the element in the r'th row and c'th column.
+//
+// It is analogous to f64.Aff3, but its arithmetic is exact up to rounding to
+// 1/4096, so the same transformation of the same point gives the same pixel on
+// every machine. Its methods saturate rather than wrap around on overflow.
+type Aff52_12 [6]Int52_12
+
+// IdentityAff52_12 returns the identity transformation.
+func IdentityAff52_12() Aff52_12 {
+	const one = 1 << 12
+	return Aff52_12{one, 0, 0, 0, one, 0}
+}
+
+// Mul returns the product a×b, the transformation that applies b and then a.
+func (a Aff52_12) Mul(b Aff52_12) Aff52_12 {
+	return Aff52_12{
+		dot(a[0], b[0], a[1], b[3]),
+		dot(a[0], b[1], a[1], b[4]),
+		dot(a[0], b[2], a[1], b[5]).SaturatingAdd(a[2]),
+
+		dot(a[3], b[0], a[4], b[3]),
+		dot(a[3], b[1], a[4], b[4]),
+		dot(a[3], b[2], a[4], b[5]).SaturatingAdd(a[5]),
+	}
+}
+
+// Det returns the determinant of a, the factor by which a scales areas.
+func (a Aff52_12) Det() Int52_12 {
+	return a[0].SaturatingMul(a[4]).SaturatingSub(a[1].SaturatingMul(a[3]))
+}
+
+// Inverse returns the inverse of a. If a is singular, or its inverse is out
+// of range, ok is false and inv is the zero matrix.
+func (a Aff52_12) Inverse() (inv Aff52_12, ok bool) {
+	p, ok0 := a[0].CheckedMul(a[4])
+	q, ok1 := a[1].CheckedMul(a[3])
+	det, ok2 := p.CheckedSub(q)
+	if !ok0 || !ok1 || !ok2 || det == 0 {
+		return Aff52_12{}, false
+	}
+	ok = true
+	d := func(x Int52_12) Int52_12 {
+		z, ok1 := x.CheckedDiv(det)
+		ok = ok && ok1
+		return z
+	}
+	neg := func(x Int52_12) Int52_12 {
+		z, ok1 := Int52_12(0).CheckedSub(x)
+		ok = ok && ok1
+		return z
+	}
+	// The translations are -(linear part of inv) × (a[2], a[5]), with the
+	// division by det done last, as for the linear part.
+	cross := func(w, x, y, z Int52_12) Int52_12 {
+		p, ok0 := w.CheckedMul(x)
+		q, ok1 := y.CheckedMul(z)
+		r, ok2 := p.CheckedSub(q)
+		ok = ok && ok0 && ok1 && ok2
+		return r
+	}
+	inv = Aff52_12{
+		d(a[4]), d(neg(a[1])), d(cross(a[1], a[5], a[2], a[4])),
+		d(neg(a[3])), d(a[0]), d(cross(a[2], a[3], a[0], a[5])),
+	}
+	if !ok {
+		return Aff52_12{}, false
+	}
+	return inv, true
+}
+
+// Transform returns the point p transformed by a.
+func (a Aff52_12) Transform(p Point52_12) Point52_12 {
+	return Point52_12{
+		dot(a[0], p.X, a[1], p.Y).SaturatingAdd(a[2]),
+		dot(a[3], p.X, a[4], p.Y).SaturatingAdd(a[5]),
+	}
+}
+
+// F64 returns a as an f64.Aff3.
+func (a Aff52_12) F64() f64.Aff3 {
+	var b f64.Aff3
+	for i, x := range a {
+		b[i] = x.Float64()
+	}
+	return b
+}
+
+// AffFromF64 returns a as an Aff52_12, with each element rounded to the
+// nearest value and clamped to the range of an Int52_12.
+func AffFromF64(a f64.Aff3) Aff52_12 {
+	var b Aff52_12
+	for i, x := range a {
+		b[i] = F52_12(x)
+	}
+	return b
+}
+
+// dot returns w*x + y*z, saturated.
+func dot(w, x, y, z Int52_12) Int52_12 {
+	return w.SaturatingMul(x).SaturatingAdd(y.SaturatingMul(z))
+}
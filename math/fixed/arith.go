@@ -0,0 +1,270 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixed
+
+import (
+	"math"
+	"math/bits"
+)
+
+// The arithmetic operators and Mul wrap around when their result is out of
+// range, like Go's integer arithmetic. The Checked variants instead report
+// whether the result is in range, and the Saturating variants clamp it to the
+// range. Mul and Div round to the nearest value, and ties are rounded up.
+
+// Div returns x/y in 26.6 fixed-point arithmetic. It panics if y is zero.
+func (x Int26_6) Div(y Int26_6) Int26_6 {
+	q, _ := div(int64(x), 6, int64(y))
+	return Int26_6(q)
+}
+
+// CheckedAdd returns x+y, and whether it did not overflow.
+func (x Int26_6) CheckedAdd(y Int26_6) (Int26_6, bool) {
+	return narrow26_6(int64(x) + int64(y))
+}
+
+// CheckedSub returns x-y, and whether it did not overflow.
+func (x Int26_6) CheckedSub(y Int26_6) (Int26_6, bool) {
+	return narrow26_6(int64(x) - int64(y))
+}
+
+// CheckedMul returns x*y, and whether it did not overflow.
+func (x Int26_6) CheckedMul(y Int26_6) (Int26_6, bool) {
+	return narrow26_6((int64(x)*int64(y) + 1<<5) >> 6)
+}
+
+// CheckedDiv returns x/y, and whether it did not overflow. Division by zero
+// overflows.
+func (x Int26_6) CheckedDiv(y Int26_6) (Int26_6, bool) {
+	if y == 0 {
+		return 0, false
+	}
+	q, ok := div(int64(x), 6, int64(y))
+	z, ok1 := narrow26_6(q)
+	return z, ok && ok1
+}
+
+// SaturatingAdd returns x+y, clamped to the range of an Int26_6.
+func (x Int26_6) SaturatingAdd(y Int26_6) Int26_6 {
+	return clamp26_6(int64(x) + int64(y))
+}
+
+// SaturatingSub returns x-y, clamped to the range of an Int26_6.
+func (x Int26_6) SaturatingSub(y Int26_6) Int26_6 {
+	return clamp26_6(int64(x) - int64(y))
+}
+
+// SaturatingMul returns x*y, clamped to the range of an Int26_6.
+func (x Int26_6) SaturatingMul(y Int26_6) Int26_6 {
+	return clamp26_6((int64(x)*int64(y) + 1<<5) >> 6)
+}
+
+// SaturatingDiv returns x/y, clamped to the range of an Int26_6. Dividing a
+// non-zero x by zero returns the extreme of x's sign, and 0/0 is 0.
+func (x Int26_6) SaturatingDiv(y Int26_6) Int26_6 {
+	if y == 0 {
+		return clamp26_6(int64(x) << 32)
+	}
+	q, ok := div(int64(x), 6, int64(y))
+	if !ok {
+		return clamp26_6(sign(int64(x), int64(y)))
+	}
+	return clamp26_6(q)
+}
+
+func narrow26_6(v int64) (Int26_6, bool) {
+	return Int26_6(v), math.MinInt32 <= v && v <= math.MaxInt32
+}
+
+func clamp26_6(v int64) Int26_6 {
+	switch {
+	case v < math.MinInt32:
+		return math.MinInt32
+	case v > math.MaxInt32:
+		return math.MaxInt32
+	}
+	return Int26_6(v)
+}
+
+// Div returns x/y in 52.12 fixed-point arithmetic. It panics if y is zero.
+func (x Int52_12) Div(y Int52_12) Int52_12 {
+	q, _ := div(int64(x), 12, int64(y))
+	return Int52_12(q)
+}
+
+// CheckedAdd returns x+y, and whether it did not overflow.
+func (x Int52_12) CheckedAdd(y Int52_12) (Int52_12, bool) {
+	z := x + y
+	return z, (z < x) == (y < 0)
+}
+
+// CheckedSub returns x-y, and whether it did not overflow.
+func (x Int52_12) CheckedSub(y Int52_12) (Int52_12, bool) {
+	z := x - y
+	return z, (z > x) == (y < 0)
+}
+
+// CheckedMul returns x*y, and whether it did not overflow.
+func (x Int52_12) CheckedMul(y Int52_12) (Int52_12, bool) {
+	const M, N = 52, 12
+	lo, hi := muli64(int64(x), int64(y))
+	z := hi<<M | lo>>N
+	// The bits of the 128-bit product above z must all be z's sign bit.
+	if int64(hi)>>N != int64(z)>>63 {
+		return x.Mul(y), false
+	}
+	if (lo>>(N-1))&1 != 0 {
+		if z == math.MaxInt64 {
+			return x.Mul(y), false
+		}
+		z++
+	}
+	return Int52_12(z), true
+}
+
+// CheckedDiv returns x/y, and whether it did not overflow. Division by zero
+// overflows.
+func (x Int52_12) CheckedDiv(y Int52_12) (Int52_12, bool) {
+	if y == 0 {
+		return 0, false
+	}
+	q, ok := div(int64(x), 12, int64(y))
+	return Int52_12(q), ok
+}
+
+// SaturatingAdd returns x+y, clamped to the range of an Int52_12.
+func (x Int52_12) SaturatingAdd(y Int52_12) Int52_12 {
+	z, ok := x.CheckedAdd(y)
+	if !ok {
+		return clamp52_12(int64(y))
+	}
+	return z
+}
+
+// SaturatingSub returns x-y, clamped to the range of an Int52_12.
+func (x Int52_12) SaturatingSub(y Int52_12) Int52_12 {
+	z, ok := x.CheckedSub(y)
+	if !ok {
+		return clamp52_12(-1 - int64(y))
+	}
+	return z
+}
+
+// SaturatingMul returns x*y, clamped to the range of an Int52_12.
+func (x Int52_12) SaturatingMul(y Int52_12) Int52_12 {
+	z, ok := x.CheckedMul(y)
+	if !ok {
+		return clamp52_12(sign(int64(x), int64(y)))
+	}
+	return z
+}
+
+// SaturatingDiv returns x/y, clamped to the range of an Int52_12. Dividing a
+// non-zero x by zero returns the extreme of x's sign, and 0/0 is 0.
+func (x Int52_12) SaturatingDiv(y Int52_12) Int52_12 {
+	if y == 0 {
+		if x == 0 {
+			return 0
+		}
+		return clamp52_12(int64(x))
+	}
+	z, ok := x.CheckedDiv(y)
+	if !ok {
+		return clamp52_12(sign(int64(x), int64(y)))
+	}
+	return z
+}
+
+// clamp52_12 returns the largest Int52_12 if v is positive, and the smallest
+// if v is negative.
+func clamp52_12(v int64) Int52_12 {
+	if v < 0 {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// sign returns a value with the sign of x*y, for x and y that are not zero.
+func sign(x, y int64) int64 {
+	if (x < 0) != (y < 0) {
+		return -1
+	}
+	return 1
+}
+
+// div returns n<<shift / d, rounded to the nearest integer with ties rounded
+// up, and whether it fits in an int64. If it does not, the result wraps
+// around. It panics if d is zero.
+func div(n int64, shift uint, d int64) (q int64, ok bool) {
+	neg := (n < 0) != (d < 0)
+	un, ud := abs64(n), abs64(d)
+
+	// Divide the 128-bit magnitude of n<<shift by that of d.
+	hi, lo := un>>(64-shift), un<<shift
+	qh := hi / ud
+	ql, r := bits.Div64(hi%ud, lo, ud)
+
+	// Round the magnitude. For a negative quotient, rounding a tie up is
+	// rounding the magnitude down.
+	if r > ud-r || (r == ud-r && !neg) {
+		var c uint64
+		ql, c = bits.Add64(ql, 1, 0)
+		qh += c
+	}
+	if neg {
+		return int64(-ql), qh == 0 && ql <= 1<<63
+	}
+	return int64(ql), qh == 0 && ql <= math.MaxInt64
+}
+
+func abs64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-x)
+	}
+	return uint64(x)
+}
+
+// Float64 returns x as a float64.
+func (x Int26_6) Float64() float64 { return float64(x) / (1 << 6) }
+
+// Float64 returns x as a float64, which is exact unless x has more than 53
+// significant bits.
+func (x Int52_12) Float64() float64 { return float64(x) / (1 << 12) }
+
+// Float64 returns x as a float64.
+func (x Int16_16) Float64() float64 { return float64(x) / (1 << 16) }
+
+// F26_6 returns f as an Int26_6, rounded to the nearest value and clamped to
+// the range of an Int26_6. NaN is 0.
+func F26_6(f float64) Int26_6 {
+	return Int26_6(fromFloat(f, 6, math.MinInt32, math.MaxInt32))
+}
+
+// F52_12 returns f as an Int52_12, rounded to the nearest value and clamped
+// to the range of an Int52_12. NaN is 0.
+func F52_12(f float64) Int52_12 {
+	return Int52_12(fromFloat(f, 12, math.MinInt64, math.MaxInt64))
+}
+
+// F16_16 returns f as an Int16_16, rounded to the nearest value and clamped
+// to the range of an Int16_16. NaN is 0.
+func F16_16(f float64) Int16_16 {
+	return Int16_16(fromFloat(f, 16, math.MinInt32, math.MaxInt32))
+}
+
+func fromFloat(f float64, shift uint, min, max int64) int64 {
+	f = math.Floor(math.Ldexp(f, int(shift)) + 0.5)
+	switch {
+	case f != f:
+		return 0
+	case f <= float64(min):
+		return min
+	case f >= float64(max):
+		// float64(math.MaxInt64) rounds up to 1<<63, so this also catches
+		// values that would overflow the conversion below.
+		return max
+	}
+	return int64(f)
+}
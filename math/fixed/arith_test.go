@@ -0,0 +1,260 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixed
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/as/shiny/math/f64"
+)
+
+// randInt64 returns a random int64 with a random number of significant bits,
+// so that small, large and extreme values are all likely.
+func randInt64(rng *rand.Rand) int64 {
+	switch rng.Intn(8) {
+	case 0:
+		return math.MaxInt64 - rng.Int63n(4)
+	case 1:
+		return math.MinInt64 + rng.Int63n(4)
+	case 2:
+		return rng.Int63n(9) - 4
+	}
+	x := int64(rng.Uint64()) >> uint(rng.Intn(64))
+	return x
+}
+
+// exact returns the exact value of the rational r rounded to the nearest
+// integer, with ties rounded up.
+func exact(r *big.Rat) *big.Int {
+	// floor(r + 1/2), where big.Int's Div rounds toward negative infinity
+	// for a positive divisor.
+	h := new(big.Rat).Add(r, big.NewRat(1, 2))
+	return new(big.Int).Div(h.Num(), h.Denom())
+}
+
+// check compares got and ok, a result of a Checked or Saturating method,
+// with the exact result want, for a type in the range [min, max].
+func check(t *testing.T, what string, got int64, ok bool, want *big.Int, min, max int64, saturating bool) {
+	t.Helper()
+	in := want.Cmp(big.NewInt(min)) >= 0 && want.Cmp(big.NewInt(max)) <= 0
+	switch {
+	case saturating && !in && want.Sign() > 0:
+		if got != max {
+			t.Fatalf("%s: got %d, want %d, saturated from %v", what, got, max, want)
+		}
+	case saturating && !in:
+		if got != min {
+			t.Fatalf("%s: got %d, want %d, saturated from %v", what, got, min, want)
+		}
+	case ok != in:
+		t.Fatalf("%s: ok: got %t, want %t, for %v", what, ok, in, want)
+	case in && got != want.Int64():
+		t.Fatalf("%s: got %d, want %v", what, got, want)
+	}
+}
+
+func rat(x int64, shift uint) *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(x), new(big.Int).Lsh(big.NewInt(1), shift))
+}
+
+func TestInt52_12Arith(t *testing.T) {
+	const min, max = math.MinInt64, math.MaxInt64
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		x, y := Int52_12(randInt64(rng)), Int52_12(randInt64(rng))
+		rx, ry := rat(int64(x), 12), rat(int64(y), 12)
+		fixed := func(r *big.Rat) *big.Int { return exact(r.Mul(r, rat(1<<24, 12))) }
+
+		want := fixed(new(big.Rat).Add(rx, ry))
+		z, ok := x.CheckedAdd(y)
+		check(t, x.String()+" + "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" +| "+y.String(), int64(x.SaturatingAdd(y)), true, want, min, max, true)
+
+		want = fixed(new(big.Rat).Sub(rx, ry))
+		z, ok = x.CheckedSub(y)
+		check(t, x.String()+" - "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" -| "+y.String(), int64(x.SaturatingSub(y)), true, want, min, max, true)
+
+		want = fixed(new(big.Rat).Mul(rx, ry))
+		z, ok = x.CheckedMul(y)
+		check(t, x.String()+" * "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" *| "+y.String(), int64(x.SaturatingMul(y)), true, want, min, max, true)
+		if got, want := x.Mul(y), Int52_12(want.Int64()); got != want {
+			t.Fatalf("%v * %v: Mul got %v, want %v, wrapped around", x, y, got, want)
+		}
+
+		if y == 0 {
+			continue
+		}
+		want = fixed(new(big.Rat).Quo(rx, ry))
+		z, ok = x.CheckedDiv(y)
+		check(t, x.String()+" / "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" /| "+y.String(), int64(x.SaturatingDiv(y)), true, want, min, max, true)
+		if got, want := x.Div(y), Int52_12(want.Int64()); got != want {
+			t.Fatalf("%v / %v: Div got %v, want %v, wrapped around", x, y, got, want)
+		}
+	}
+}
+
+func TestInt26_6Arith(t *testing.T) {
+	const min, max = math.MinInt32, math.MaxInt32
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 20000; i++ {
+		x, y := Int26_6(randInt64(rng)>>32), Int26_6(randInt64(rng)>>32)
+		rx, ry := rat(int64(x), 6), rat(int64(y), 6)
+		fixed := func(r *big.Rat) *big.Int { return exact(r.Mul(r, rat(1<<12, 6))) }
+
+		want := fixed(new(big.Rat).Add(rx, ry))
+		z, ok := x.CheckedAdd(y)
+		check(t, x.String()+" + "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" +| "+y.String(), int64(x.SaturatingAdd(y)), true, want, min, max, true)
+
+		want = fixed(new(big.Rat).Sub(rx, ry))
+		z, ok = x.CheckedSub(y)
+		check(t, x.String()+" - "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" -| "+y.String(), int64(x.SaturatingSub(y)), true, want, min, max, true)
+
+		want = fixed(new(big.Rat).Mul(rx, ry))
+		z, ok = x.CheckedMul(y)
+		check(t, x.String()+" * "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" *| "+y.String(), int64(x.SaturatingMul(y)), true, want, min, max, true)
+
+		if y == 0 {
+			continue
+		}
+		want = fixed(new(big.Rat).Quo(rx, ry))
+		z, ok = x.CheckedDiv(y)
+		check(t, x.String()+" / "+y.String(), int64(z), ok, want, min, max, false)
+		check(t, x.String()+" /| "+y.String(), int64(x.SaturatingDiv(y)), true, want, min, max, true)
+		if got, want := x.Div(y), Int26_6(want.Int64()); got != want {
+			t.Fatalf("%v / %v: Div got %v, want %v, wrapped around", x, y, got, want)
+		}
+	}
+}
+
+func TestDivByZero(t *testing.T) {
+	if _, ok := Int26_6(1).CheckedDiv(0); ok {
+		t.Errorf("Int26_6: CheckedDiv by zero: got ok")
+	}
+	if _, ok := Int52_12(1).CheckedDiv(0); ok {
+		t.Errorf("Int52_12: CheckedDiv by zero: got ok")
+	}
+	for _, tc := range []struct{ x, want int64 }{{5, math.MaxInt64}, {-5, math.MinInt64}, {0, 0}} {
+		if got := Int52_12(tc.x).SaturatingDiv(0); int64(got) != tc.want {
+			t.Errorf("Int52_12: %d/0: got %d, want %d", tc.x, got, tc.want)
+		}
+		want := tc.want >> 32
+		if want < 0 {
+			want = math.MinInt32
+		}
+		if got := Int26_6(tc.x).SaturatingDiv(0); int64(got) != want {
+			t.Errorf("Int26_6: %d/0: got %d, want %d", tc.x, got, want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Div by zero did not panic")
+		}
+	}()
+	Int52_12(1).Div(0)
+}
+
+func TestFromFloat(t *testing.T) {
+	testCases := []struct {
+		f     float64
+		i26_6 Int26_6
+		i16   Int16_16
+		s16   string
+	}{
+		{0, 0, 0, "0:00000"},
+		{1.25, 80, 1<<16 + 1<<14, "1:16384"},
+		{-1.25, -80, -(1<<16 + 1<<14), "-1:16384"},
+		{1.0 / 128, 1, 512, "0:00512"}, // A tie, which is rounded up.
+		{-1.0 / 128, 0, -512, "-0:00512"},
+		{1e20, math.MaxInt32, math.MaxInt32, "32767:65535"},
+		{-1e20, math.MinInt32, math.MinInt32, "-32768:00000"},
+		{math.NaN(), 0, 0, "0:00000"},
+	}
+	for _, tc := range testCases {
+		if got := F26_6(tc.f); got != tc.i26_6 {
+			t.Errorf("F26_6(%v): got %d, want %d", tc.f, got, tc.i26_6)
+		}
+		if got := F16_16(tc.f); got != tc.i16 {
+			t.Errorf("F16_16(%v): got %d, want %d", tc.f, got, tc.i16)
+		}
+		if got := F16_16(tc.f).String(); got != tc.s16 {
+			t.Errorf("F16_16(%v).String(): got %q, want %q", tc.f, got, tc.s16)
+		}
+	}
+	if got := F52_12(1e300); got != math.MaxInt64 {
+		t.Errorf("F52_12(1e300): got %d, want max", got)
+	}
+	if got := F52_12(2.5).Float64(); got != 2.5 {
+		t.Errorf("F52_12(2.5).Float64(): got %v, want 2.5", got)
+	}
+	if got, want := I(3).Int16_16(), Int16_16(3<<16); got != want {
+		t.Errorf("Int16_16: got %v, want %v", got, want)
+	}
+	if got := I(-1 << 20).Int16_16(); got != math.MinInt32 {
+		t.Errorf("Int16_16: got %v, want min", got)
+	}
+	if got := F16_16(-2.5); got.Floor() != -3 || got.Round() != -2 || got.Ceil() != -2 {
+		t.Errorf("%v: Floor, Round, Ceil: got %d, %d, %d, want -3, -2, -2", got, got.Floor(), got.Round(), got.Ceil())
+	}
+}
+
+func TestAff52_12(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	id := IdentityAff52_12()
+	for i := 0; i < 1000; i++ {
+		var fa f64.Aff3
+		for j := range fa {
+			fa[j] = rng.Float64()*8 - 4
+		}
+		if math.Abs(fa.Det()) < 0.5 {
+			continue
+		}
+		a := AffFromF64(fa)
+		inv, ok := a.Inverse()
+		if !ok {
+			t.Fatalf("%v: not invertible", a)
+		}
+		// Each element of a and inv is within 1/8192 of the exact value, and
+		// their product is within a few of those.
+		for j, x := range a.Mul(inv) {
+			if d := x - id[j]; d < -32 || d > 32 {
+				t.Fatalf("%v × %v: element %d is %v, want %v", a, inv, j, x, id[j])
+			}
+		}
+
+		p := Point52_12{F52_12(rng.Float64()*100 - 50), F52_12(rng.Float64()*100 - 50)}
+		q := a.Transform(p)
+		fq := fa.Transform(f64.Vec2{p.X.Float64(), p.Y.Float64()})
+		if math.Abs(q.X.Float64()-fq[0]) > 0.1 || math.Abs(q.Y.Float64()-fq[1]) > 0.1 {
+			t.Fatalf("%v: Transform(%v): got %v, want %v", a, p, q, fq)
+		}
+		if r := inv.Transform(q); math.Abs(r.X.Float64()-p.X.Float64()) > 0.1 || math.Abs(r.Y.Float64()-p.Y.Float64()) > 0.1 {
+			t.Fatalf("%v: Transform(%v) then its inverse: got %v", a, p, r)
+		}
+		if got := AffFromF64(a.F64()); got != a {
+			t.Fatalf("F64 round trip: got %v, want %v", got, a)
+		}
+	}
+
+	if inv, ok := (Aff52_12{1 << 12, 2 << 12, 0, 2 << 12, 4 << 12, 0}).Inverse(); ok || inv != (Aff52_12{}) {
+		t.Errorf("singular: got %v, %t, want zero, false", inv, ok)
+	}
+	// A tiny scale has an inverse that is out of range.
+	if _, ok := (Aff52_12{1, 0, 0, 0, 1, 1 << 62}).Inverse(); ok {
+		t.Errorf("out of range: got ok")
+	}
+	huge := Aff52_12{math.MaxInt64, 0, 0, 0, 1 << 12, 0}
+	if got := huge.Mul(huge)[0]; got != math.MaxInt64 {
+		t.Errorf("Mul: got %v, want saturated", got)
+	}
+}
@@ -7,6 +7,7 @@ package fixed
 
 import (
 	"fmt"
+	"math"
 )
 
 // TODO: implement fmt.Formatter for %f and %g.
@@ -133,6 +134,63 @@ func muli64(u, v int64) (lo, hi uint64) {
 	return uint64(u) * uint64(v), u1*v1 + w2 + uint64(int64(w1)>>s)
 }
 
+// Int16_16 is a signed 16.16 fixed-point number, the format of X11's Render
+// extension.
+//
+// The integer part ranges from -32768 to 32767, inclusive. The fractional
+// part has 16 bits of precision.
+//
+// For example, the number one-and-a-quarter is Int16_16(1<<16 + 1<<14).
+type Int16_16 int32
+
+// String returns a human-readable representation of a 16.16 fixed-point
+// number.
+//
+// For example, the number one-and-a-quarter becomes "1:16384".
+func (x Int16_16) String() string {
+	const shift, mask = 16, 1<<16 - 1
+	if x >= 0 {
+		return fmt.Sprintf("%d:%05d", int32(x>>shift), int32(x&mask))
+	}
+	x = -x
+	if x >= 0 {
+		return fmt.Sprintf("-%d:%05d", int32(x>>shift), int32(x&mask))
+	}
+	return "-32768:00000" // The minimum value is -(1<<15).
+}
+
+// Floor returns the greatest integer value less than or equal to x.
+//
+// Its return type is int, not Int16_16.
+func (x Int16_16) Floor() int { return int((x + 0x0000) >> 16) }
+
+// Round returns the nearest integer value to x. Ties are rounded up.
+//
+// Its return type is int, not Int16_16.
+func (x Int16_16) Round() int { return int((int64(x) + 0x8000) >> 16) }
+
+// Ceil returns the least integer value greater than or equal to x.
+//
+// Its return type is int, not Int16_16.
+func (x Int16_16) Ceil() int { return int((int64(x) + 0xffff) >> 16) }
+
+// Mul returns x*y in 16.16 fixed-point arithmetic.
+func (x Int16_16) Mul(y Int16_16) Int16_16 {
+	return Int16_16((int64(x)*int64(y) + 1<<15) >> 16)
+}
+
+// Int16_16 returns x as an Int16_16, clamped to its range.
+func (x Int26_6) Int16_16() Int16_16 {
+	v := int64(x) << 10
+	switch {
+	case v < math.MinInt32:
+		return math.MinInt32
+	case v > math.MaxInt32:
+		return math.MaxInt32
+	}
+	return Int16_16(v)
+}
+
 // P returns the integer values x and y as a Point26_6.
 //
 // For example, passing the integer values (2, -3) yields Point26_6{128, -192}.
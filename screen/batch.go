@@ -0,0 +1,127 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/as/shiny/math/f64"
+)
+
+// Batch is a list of drawing operations that are recorded, and later
+// submitted to a Drawer all at once. Drivers that implement BatchDrawer can
+// then draw many operations with less overhead than making each call
+// separately, for example by drawing many quads from the same Texture in one
+// GPU draw call. For other Drawers, Submit makes the calls one by one.
+//
+// A Batch is itself a Drawer, so that code that draws onto a Drawer can
+// record into a Batch instead. The Textures that a Batch refers to must not
+// be released or modified before the Batch is submitted.
+//
+// The zero value is an empty Batch.
+type Batch struct {
+	ops []BatchOp
+}
+
+// BatchOp is a drawing operation recorded in a Batch. Copy, Scale and Fill
+// are recorded as the equivalent Draw or DrawUniform.
+type BatchOp struct {
+	// Src is the Texture to draw. If nil, the operation is a DrawUniform of
+	// the Color.
+	Src   Texture
+	Color color.Color
+
+	Src2dst f64.Aff3
+	SR      image.Rectangle
+	Op      Op
+
+	// Opts is a copy of the DrawOptions passed when the operation was
+	// recorded. Its zero value is equivalent to nil DrawOptions.
+	Opts DrawOptions
+}
+
+// DrawTo makes op's call on dst.
+func (op *BatchOp) DrawTo(dst Drawer) {
+	if op.Src != nil {
+		dst.Draw(op.Src2dst, op.Src, op.SR, op.Op, &op.Opts)
+	} else {
+		dst.DrawUniform(op.Src2dst, op.Color, op.SR, op.Op, &op.Opts)
+	}
+}
+
+// BatchDrawer is an optional interface for Drawers that can draw the
+// operations of a Batch more efficiently than one at a time. The result must
+// be the same as calling DrawTo for each operation in order.
+type BatchDrawer interface {
+	DrawBatch(ops []BatchOp)
+}
+
+// Len returns the number of recorded operations.
+func (b *Batch) Len() int { return len(b.ops) }
+
+// Reset removes all of the recorded operations, keeping the Batch's memory
+// for reuse.
+func (b *Batch) Reset() {
+	for i := range b.ops {
+		b.ops[i] = BatchOp{}
+	}
+	b.ops = b.ops[:0]
+}
+
+// Submit draws the recorded operations onto dst, in the order that they were
+// recorded. It does not Reset the Batch, so that it can be submitted again.
+func (b *Batch) Submit(dst Drawer) {
+	if len(b.ops) == 0 {
+		return
+	}
+	if bd, ok := dst.(BatchDrawer); ok {
+		bd.DrawBatch(b.ops)
+		return
+	}
+	for i := range b.ops {
+		b.ops[i].DrawTo(dst)
+	}
+}
+
+func (b *Batch) add(op BatchOp, opts *DrawOptions) {
+	if opts != nil {
+		op.Opts = *opts
+	}
+	b.ops = append(b.ops, op)
+}
+
+// Draw records a Draw call.
+func (b *Batch) Draw(src2dst f64.Aff3, src Texture, sr image.Rectangle, op Op, opts *DrawOptions) {
+	b.add(BatchOp{Src: src, Src2dst: src2dst, SR: sr, Op: op}, opts)
+}
+
+// DrawUniform records a DrawUniform call.
+func (b *Batch) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op Op, opts *DrawOptions) {
+	b.add(BatchOp{Color: src, Src2dst: src2dst, SR: sr, Op: op}, opts)
+}
+
+// Copy records a Copy call.
+func (b *Batch) Copy(dp image.Point, src Texture, sr image.Rectangle, op Op, opts *DrawOptions) {
+	b.Draw(f64.Aff3{
+		1, 0, float64(dp.X - sr.Min.X),
+		0, 1, float64(dp.Y - sr.Min.Y),
+	}, src, sr, op, opts)
+}
+
+// Scale records a Scale call.
+func (b *Batch) Scale(dr image.Rectangle, src Texture, sr image.Rectangle, op Op, opts *DrawOptions) {
+	rx := float64(dr.Dx()) / float64(sr.Dx())
+	ry := float64(dr.Dy()) / float64(sr.Dy())
+	b.Draw(f64.Aff3{
+		rx, 0, float64(dr.Min.X) - rx*float64(sr.Min.X),
+		0, ry, float64(dr.Min.Y) - ry*float64(sr.Min.Y),
+	}, src, sr, op, opts)
+}
+
+// Fill records a Fill call.
+func (b *Batch) Fill(dr image.Rectangle, src color.Color, op Op) {
+	b.DrawUniform(f64.Aff3{1, 0, 0, 0, 1, 0}, src, dr, op, nil)
+}
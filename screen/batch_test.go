@@ -0,0 +1,96 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/as/shiny/math/f64"
+)
+
+// recorder is a Drawer that records the calls made on it.
+type recorder struct {
+	Texture // Only used as a src.
+	ops     []BatchOp
+}
+
+func (r *recorder) Draw(src2dst f64.Aff3, src Texture, sr image.Rectangle, op Op, opts *DrawOptions) {
+	r.ops = append(r.ops, BatchOp{Src: src, Src2dst: src2dst, SR: sr, Op: op, Opts: *opts})
+}
+
+func (r *recorder) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op Op, opts *DrawOptions) {
+	r.ops = append(r.ops, BatchOp{Color: src, Src2dst: src2dst, SR: sr, Op: op, Opts: *opts})
+}
+
+// batchRecorder is a recorder that is also a BatchDrawer.
+type batchRecorder struct {
+	recorder
+	batches int
+}
+
+func (r *batchRecorder) DrawBatch(ops []BatchOp) {
+	r.batches++
+	r.ops = append(r.ops, ops...)
+}
+
+func TestBatch(t *testing.T) {
+	src := &recorder{}
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	opts := &DrawOptions{Opacity: 0.5}
+
+	var b Batch
+	b.Copy(image.Point{10, 20}, src, image.Rect(1, 2, 5, 6), Over, nil)
+	b.Scale(image.Rect(0, 0, 8, 8), src, image.Rect(0, 0, 4, 4), Src, opts)
+	b.Fill(image.Rect(1, 1, 3, 3), red, Over)
+	b.Draw(f64.Aff3{0, -1, 5, 1, 0, 0}, src, image.Rect(0, 0, 2, 2), Over, nil)
+
+	// Modifying the options after recording does not affect the Batch.
+	opts.Opacity = 1
+
+	want := []BatchOp{
+		{Src: src, Src2dst: f64.Aff3{1, 0, 9, 0, 1, 18}, SR: image.Rect(1, 2, 5, 6), Op: Over},
+		{Src: src, Src2dst: f64.Aff3{2, 0, 0, 0, 2, 0}, SR: image.Rect(0, 0, 4, 4), Op: Src, Opts: DrawOptions{Opacity: 0.5}},
+		{Color: red, Src2dst: f64.Aff3{1, 0, 0, 0, 1, 0}, SR: image.Rect(1, 1, 3, 3), Op: Over},
+		{Src: src, Src2dst: f64.Aff3{0, -1, 5, 1, 0, 0}, SR: image.Rect(0, 0, 2, 2), Op: Over},
+	}
+	if b.Len() != len(want) {
+		t.Fatalf("Len: got %d, want %d", b.Len(), len(want))
+	}
+
+	check := func(name string, got []BatchOp) {
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d ops, want %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: op %d: got %+v, want %+v", name, i, got[i], want[i])
+			}
+		}
+	}
+
+	r := &recorder{}
+	b.Submit(r)
+	check("Drawer", r.ops)
+
+	br := &batchRecorder{}
+	b.Submit(br)
+	b.Submit(br)
+	if br.batches != 2 {
+		t.Errorf("BatchDrawer: got %d DrawBatch calls, want 2", br.batches)
+	}
+	check("BatchDrawer", br.ops[:len(want)])
+
+	b.Reset()
+	if b.Len() != 0 {
+		t.Errorf("after Reset: Len: got %d, want 0", b.Len())
+	}
+	r.ops = nil
+	b.Submit(r)
+	if len(r.ops) != 0 {
+		t.Errorf("after Reset: got %d ops, want 0", len(r.ops))
+	}
+}
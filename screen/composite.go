@@ -0,0 +1,125 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// Composite composites src onto dst with the given Op, like draw.Draw. It
+// aligns r.Min in dst with sp in src, and only affects the dst pixels in r
+// that have a corresponding src pixel.
+//
+// It is a straightforward, slow, software implementation of every Op. It is
+// intended as a reference for validating drivers, not for drawing in
+// production. An unknown Op leaves dst unchanged.
+func Composite(dst draw.Image, r image.Rectangle, src image.Image, sp image.Point, op Op) {
+	delta := sp.Sub(r.Min)
+	r = r.Intersect(dst.Bounds()).Intersect(src.Bounds().Sub(delta))
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			s := color.RGBA64Model.Convert(src.At(x+delta.X, y+delta.Y)).(color.RGBA64)
+			d := color.RGBA64Model.Convert(dst.At(x, y)).(color.RGBA64)
+			if c, ok := composite(s, d, op); ok {
+				dst.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// composite returns the result of compositing the src color s onto the dst
+// color d with op, and whether op is known.
+func composite(s, d color.RGBA64, op Op) (color.RGBA64, bool) {
+	sc := [4]float64{float64(s.R) / 0xffff, float64(s.G) / 0xffff, float64(s.B) / 0xffff, float64(s.A) / 0xffff}
+	dc := [4]float64{float64(d.R) / 0xffff, float64(d.G) / 0xffff, float64(d.B) / 0xffff, float64(d.A) / 0xffff}
+	sa, da := sc[3], dc[3]
+
+	// Porter-Duff operators are fa×src + fb×dst, for all four channels.
+	var fa, fb float64
+	var blend func(cs, cd float64) float64
+	switch op {
+	case Clear:
+		fa, fb = 0, 0
+	case Src:
+		fa, fb = 1, 0
+	case Dst:
+		fa, fb = 0, 1
+	case Over:
+		fa, fb = 1, 1-sa
+	case DstOver:
+		fa, fb = 1-da, 1
+	case In:
+		fa, fb = da, 0
+	case DstIn:
+		fa, fb = 0, sa
+	case Out:
+		fa, fb = 1-da, 0
+	case DstOut:
+		fa, fb = 0, 1-sa
+	case Atop:
+		fa, fb = da, 1-sa
+	case DstAtop:
+		fa, fb = 1-da, sa
+	case Xor:
+		fa, fb = 1-da, 1-sa
+	case Add:
+		fa, fb = 1, 1
+	case BlendMultiply:
+		blend = func(cs, cd float64) float64 { return cs * cd }
+	case BlendScreen:
+		blend = func(cs, cd float64) float64 { return cs + cd - cs*cd }
+	case BlendOverlay:
+		blend = func(cs, cd float64) float64 {
+			if cd <= 0.5 {
+				return 2 * cs * cd
+			}
+			return 1 - 2*(1-cs)*(1-cd)
+		}
+	case BlendDarken:
+		blend = math.Min
+	case BlendLighten:
+		blend = math.Max
+	case BlendDifference:
+		blend = func(cs, cd float64) float64 { return math.Abs(cs - cd) }
+	default:
+		return color.RGBA64{}, false
+	}
+
+	var out [4]float64
+	if blend == nil {
+		for i := range out {
+			out[i] = fa*sc[i] + fb*dc[i]
+		}
+	} else {
+		for i := 0; i < 3; i++ {
+			out[i] = sc[i]*(1-da) + dc[i]*(1-sa)
+			if sa > 0 && da > 0 {
+				out[i] += sa * da * blend(sc[i]/sa, dc[i]/da)
+			}
+		}
+		out[3] = sa + da - sa*da
+	}
+	return color.RGBA64{
+		R: unit16(out[0]),
+		G: unit16(out[1]),
+		B: unit16(out[2]),
+		A: unit16(out[3]),
+	}, true
+}
+
+// unit16 converts x from the range [0, 1], saturating if it is out of that
+// range, to the range [0, 0xffff].
+func unit16(x float64) uint16 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 0xffff
+	}
+	return uint16(x*0xffff + 0.5)
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+)
+
+func TestCompositeOps(t *testing.T) {
+	// Half-transparent red onto half-transparent blue, premultiplied.
+	s := color.RGBA64{R: 0x8000, A: 0x8000}
+	d := color.RGBA64{B: 0x8000, A: 0x8000}
+
+	testCases := []struct {
+		op   Op
+		want color.RGBA64
+	}{
+		{Clear, color.RGBA64{}},
+		{Src, s},
+		{Dst, d},
+		{Over, color.RGBA64{R: 0x8000, B: 0x4000, A: 0xc000}},
+		{DstOver, color.RGBA64{R: 0x4000, B: 0x8000, A: 0xc000}},
+		{In, color.RGBA64{R: 0x4000, A: 0x4000}},
+		{DstIn, color.RGBA64{B: 0x4000, A: 0x4000}},
+		{Out, color.RGBA64{R: 0x4000, A: 0x4000}},
+		{DstOut, color.RGBA64{B: 0x4000, A: 0x4000}},
+		{Atop, color.RGBA64{R: 0x4000, B: 0x4000, A: 0x8000}},
+		{DstAtop, color.RGBA64{R: 0x4000, B: 0x4000, A: 0x8000}},
+		{Xor, color.RGBA64{R: 0x4000, B: 0x4000, A: 0x8000}},
+		{Add, color.RGBA64{R: 0x8000, B: 0x8000, A: 0xffff}},
+		// cs = (1, 0, 0) and cd = (0, 0, 1), so both are 0 for Multiply and
+		// Darken, and leave only the src-only and dst-only terms.
+		{BlendMultiply, color.RGBA64{R: 0x4000, B: 0x4000, A: 0xc000}},
+		{BlendDarken, color.RGBA64{R: 0x4000, B: 0x4000, A: 0xc000}},
+		{BlendScreen, color.RGBA64{R: 0x8000, B: 0x8000, A: 0xc000}},
+		{BlendLighten, color.RGBA64{R: 0x8000, B: 0x8000, A: 0xc000}},
+		{BlendDifference, color.RGBA64{R: 0x8000, B: 0x8000, A: 0xc000}},
+		{BlendOverlay, color.RGBA64{R: 0x4000, B: 0x8000, A: 0xc000}},
+	}
+	for _, tc := range testCases {
+		got, ok := composite(s, d, tc.op)
+		if !ok {
+			t.Errorf("op %d: unknown", tc.op)
+			continue
+		}
+		if !near(got, tc.want) {
+			t.Errorf("op %d: got %v, want %v", tc.op, got, tc.want)
+		}
+	}
+	if _, ok := composite(s, d, Op(1000)); ok {
+		t.Errorf("op 1000: got known, want unknown")
+	}
+}
+
+func near(a, b color.RGBA64) bool {
+	diff := func(x, y uint16) bool { return int(x)-int(y) > 1 || int(y)-int(x) > 1 }
+	return !diff(a.R, b.R) && !diff(a.G, b.G) && !diff(a.B, b.B) && !diff(a.A, b.A)
+}
+
+// TestCompositeDraw checks that Composite agrees with the image/draw package
+// for the Ops that it supports.
+func TestCompositeDraw(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randImage := func() *image.RGBA64 {
+		m := image.NewRGBA64(image.Rect(0, 0, 16, 16))
+		for i := 0; i < len(m.Pix); i += 8 {
+			a := uint16(rng.Intn(0x10000))
+			c := color.RGBA64{
+				R: uint16(rng.Intn(int(a) + 1)),
+				G: uint16(rng.Intn(int(a) + 1)),
+				B: uint16(rng.Intn(int(a) + 1)),
+				A: a,
+			}
+			m.SetRGBA64(i/8%16, i/8/16, c)
+		}
+		return m
+	}
+	r := image.Rect(2, 3, 12, 14)
+	sp := image.Pt(4, 1)
+	for _, op := range []Op{Over, Src} {
+		src, dst := randImage(), randImage()
+		want := image.NewRGBA64(dst.Bounds())
+		copy(want.Pix, dst.Pix)
+		draw.Draw(want, r, src, sp, op)
+		Composite(dst, r, src, sp, op)
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				if g, w := dst.RGBA64At(x, y), want.RGBA64At(x, y); !near(g, w) {
+					t.Fatalf("op %d at (%d, %d): got %v, want %v", op, x, y, g, w)
+				}
+			}
+		}
+	}
+}
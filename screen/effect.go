@@ -0,0 +1,75 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+
+	"github.com/as/shiny/math/f64"
+)
+
+// Effect is a small fragment shader, and the values of its uniforms, that a
+// Texture is drawn through, such as a blur or a color matrix. The effect
+// package has built-in Effects, and draws an Effect onto any Drawer.
+type Effect struct {
+	// Source is GLSL ES 1.00 code that defines the function
+	//
+	//	vec4 effect(vec2 p)
+	//
+	// which returns the alpha-premultiplied color at the point p in src
+	// space. Pixel centers are at half-integer points. Source can call
+	//
+	//	vec4 src(vec2 p)
+	//
+	// which samples the src Texture at p, and is transparent outside of the
+	// src rectangle, and read the uniform
+	//
+	//	vec4 srcRect
+	//
+	// which holds the src rectangle's Min.X, Min.Y, Max.X and Max.Y. Source
+	// declares the uniforms in Uniforms itself. It must not define main, or
+	// the names that the driver's blending code uses: blend, blendFunc,
+	// mode, dst and dstRect.
+	Source string
+
+	// Uniforms are the values of the float uniforms that Source declares,
+	// by name. The number of values gives the uniform's type: 1 to 4 for
+	// float to vec4, 9 for mat3 and 16 for mat4, in column major order.
+	Uniforms map[string][]float32
+
+	// Margin is how many src pixels beyond the src rectangle the effect
+	// colors, such as the radius of a blur. Effect is called for the src
+	// rectangle inset by -Margin.
+	Margin int
+
+	// Func, if non-nil, is Source written in Go, for drivers that can not
+	// run shaders.
+	Func EffectFunc
+}
+
+// EffectFunc computes what an Effect's Source computes, in software. It
+// returns what effect(p) returns for p = (x, y), with color channels from 0
+// to 1.
+type EffectFunc func(a *EffectArgs, x, y float32) [4]float32
+
+// EffectArgs are what an EffectFunc can use, other than its point.
+type EffectArgs struct {
+	// Src samples the src Texture, like src in Source.
+	Src func(x, y float32) [4]float32
+	// SR is the src rectangle, srcRect in Source.
+	SR image.Rectangle
+	// Uniforms are the Effect's Uniforms.
+	Uniforms map[string][]float32
+}
+
+// EffectDrawer is an optional interface for Drawers that can run an Effect's
+// Source themselves, such as on a GPU, instead of computing it in software.
+type EffectDrawer interface {
+	// DrawEffect is like Draw, but draws the src rectangle sr, inset by
+	// -e.Margin, with each pixel's color given by e. It returns an error
+	// if e's Source does not compile. The Mask in opts, if any, is
+	// ignored.
+	DrawEffect(src2dst f64.Aff3, src Texture, sr image.Rectangle, e *Effect, op Op, opts *DrawOptions) error
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/as/shiny/imageutil"
+)
+
+// Format is the pixel format of a Buffer.
+//
+// Drivers upload the formats that their backend accepts directly without
+// converting them, and convert the others. Which formats are native depends
+// on the driver: the X11 driver's is FormatBGRA and the GL driver's is
+// FormatRGBA, for example.
+type Format int
+
+const (
+	// FormatRGBA is 8-bit, alpha-premultiplied R, G, B, A, as *image.RGBA.
+	FormatRGBA Format = iota
+	// FormatBGRA is 8-bit, alpha-premultiplied B, G, R, A, as
+	// *imageutil.BGRA.
+	FormatBGRA
+	// FormatRGB565 is opaque, 16-bit, little-endian 5-6-5 R, G, B, as
+	// *imageutil.RGB565.
+	FormatRGB565
+	// FormatGray is opaque, 8-bit gray, as *image.Gray.
+	FormatGray
+	// FormatYCbCr420 is opaque Y'CbCr with 4:2:0 chroma subsampling, as
+	// *imageutil.YCbCr.
+	FormatYCbCr420
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatRGBA:
+		return "RGBA"
+	case FormatBGRA:
+		return "BGRA"
+	case FormatRGB565:
+		return "RGB565"
+	case FormatGray:
+		return "Gray"
+	case FormatYCbCr420:
+		return "YCbCr420"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Valid returns whether f is one of the formats defined by this package.
+func (f Format) Valid() bool {
+	return FormatRGBA <= f && f <= FormatYCbCr420
+}
+
+// NewImage returns a new image of format f with the given bounds, of the
+// type that Buffer.Image returns for that format. It returns nil if f is not
+// Valid.
+func (f Format) NewImage(r image.Rectangle) draw.Image {
+	switch f {
+	case FormatRGBA:
+		return image.NewRGBA(r)
+	case FormatBGRA:
+		return imageutil.NewBGRA(r)
+	case FormatRGB565:
+		return imageutil.NewRGB565(r)
+	case FormatGray:
+		return image.NewGray(r)
+	case FormatYCbCr420:
+		return imageutil.NewYCbCr(r, image.YCbCrSubsampleRatio420)
+	}
+	return nil
+}
+
+// NewBufferOptions are optional arguments to NewBuffer.
+type NewBufferOptions struct {
+	// Format is the pixel format of the Buffer. Its zero value is
+	// FormatRGBA.
+	Format Format
+}
+
+// GetFormat returns the Format, or FormatRGBA if o is nil.
+func (o *NewBufferOptions) GetFormat() Format {
+	if o == nil {
+		return FormatRGBA
+	}
+	return o.Format
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"fmt"
+	"image"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	r := image.Rect(1, 2, 5, 8)
+	testCases := []struct {
+		f        Format
+		name     string
+		wantType string
+	}{
+		{FormatRGBA, "RGBA", "*image.RGBA"},
+		{FormatBGRA, "BGRA", "*imageutil.BGRA"},
+		{FormatRGB565, "RGB565", "*imageutil.RGB565"},
+		{FormatGray, "Gray", "*image.Gray"},
+		{FormatYCbCr420, "YCbCr420", "*imageutil.YCbCr"},
+	}
+	for _, tc := range testCases {
+		if got := tc.f.String(); got != tc.name {
+			t.Errorf("%d: String: got %q, want %q", tc.f, got, tc.name)
+		}
+		if !tc.f.Valid() {
+			t.Errorf("%v: Valid: got false, want true", tc.f)
+		}
+		m := tc.f.NewImage(r)
+		if got := fmt.Sprintf("%T", m); got != tc.wantType {
+			t.Errorf("%v: NewImage: got %s, want %s", tc.f, got, tc.wantType)
+		} else if m.Bounds() != r {
+			t.Errorf("%v: NewImage: bounds: got %v, want %v", tc.f, m.Bounds(), r)
+		}
+	}
+
+	bad := FormatYCbCr420 + 1
+	if bad.Valid() || bad.NewImage(r) != nil {
+		t.Errorf("%v: got valid, want invalid", bad)
+	}
+	if got := (*NewBufferOptions)(nil).GetFormat(); got != FormatRGBA {
+		t.Errorf("nil options: GetFormat: got %v, want RGBA", got)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"sync"
+	"time"
+
+	"github.com/as/shiny/imageutil"
+)
+
+// A FrameClock numbers the frames of one window, and estimates when they
+// will be presented. Drivers keep one for each window, and send the window's
+// paint and Frame events through it, so that a window's frame numbers count
+// only its own publishes.
+//
+// The events themselves do not say which window they are for, and all
+// windows share the Device's channels, so a program with more than one
+// window can not tell the windows' frames apart. See paint.Event.
+//
+// The zero value is ready to use.
+type FrameClock struct {
+	// Period is the refresh period of the window's screen. It must be set
+	// before the clock is used, if at all. If zero, 1/60 of a second is
+	// assumed, so the Target times are only an estimate unless the driver
+	// knows the actual refresh rate.
+	Period time.Duration
+
+	mu    sync.Mutex
+	frame uint64
+	// last is when the last frame was published, or the zero time if none
+	// was.
+	last time.Time
+}
+
+// defaultPeriod is the refresh period of a FrameClock with a zero Period.
+const defaultPeriod = time.Second / 60
+
+// target returns when a frame that is published after now is expected to be
+// presented: the first refresh after now, assuming that the screen refreshed
+// when the last frame was published.
+func (c *FrameClock) target(now time.Time) time.Time {
+	if c.last.IsZero() {
+		return time.Time{}
+	}
+	period := c.Period
+	if period <= 0 {
+		period = defaultPeriod
+	}
+	n := now.Sub(c.last)/period + 1
+	return c.last.Add(n * period)
+}
+
+func (c *FrameClock) stamp(e *Paint) {
+	c.mu.Lock()
+	e.Frame, e.Target = c.frame, c.target(time.Now())
+	c.mu.Unlock()
+}
+
+func (c *FrameClock) published(at time.Time) Frame {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frame++
+	c.last = at
+	return Frame{Frame: c.frame, Target: c.target(at)}
+}
+
+// SendPaint is like the package's SendPaint, but first numbers e with the
+// window's current frame.
+func (c *FrameClock) SendPaint(e Paint) {
+	c.stamp(&e)
+	SendPaint(e)
+}
+
+// Published is called by the drivers when the window was published at the
+// given time. It starts the window's next frame, and sends a Frame event to
+// say that the window is ready for it.
+func (c *FrameClock) Published(at time.Time) {
+	SendFrame(c.published(at))
+}
+
+// mergePaint returns a paint event for both a and b, which are for the same
+// frame or for b's later one. With more than one window, a and b may be for
+// different windows, whose Damage is then merged as if it was one window's.
+func mergePaint(a, b Paint) Paint {
+	switch {
+	case a.Whole() || b.Whole():
+		b.Damage = imageutil.Region{}
+	default:
+		b.Damage = a.Damage.Union(b.Damage)
+	}
+	// A program that ignores external paint events must still see its own.
+	b.External = a.External && b.External
+	return b
+}
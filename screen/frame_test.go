@@ -0,0 +1,109 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/as/shiny/imageutil"
+)
+
+func TestFrameClock(t *testing.T) {
+	c := FrameClock{Period: 10 * time.Millisecond}
+	var e Paint
+	c.stamp(&e)
+	if e.Frame != 0 || !e.Target.IsZero() {
+		t.Fatalf("before publishing: got frame %d, target %v", e.Frame, e.Target)
+	}
+
+	t0 := time.Unix(100, 0)
+	f := c.published(t0)
+	if f.Frame != 1 || !f.Target.Equal(t0.Add(10*time.Millisecond)) {
+		t.Fatalf("published: got %+v", f)
+	}
+	f = c.published(t0.Add(10 * time.Millisecond))
+	if f.Frame != 2 {
+		t.Fatalf("published again: got frame %d, want 2", f.Frame)
+	}
+	// A frame drawn late targets the next refresh.
+	if got, want := c.target(t0.Add(35*time.Millisecond)), t0.Add(40*time.Millisecond); !got.Equal(want) {
+		t.Errorf("target: got %v, want %v", got, want)
+	}
+}
+
+func TestFrameClockPerWindow(t *testing.T) {
+	// Each window's frames are numbered by its own clock, so publishing one
+	// window does not skip the other's frame numbers.
+	var a, b FrameClock
+	t0 := time.Unix(100, 0)
+	for i := uint64(1); i <= 3; i++ {
+		if f := a.published(t0); f.Frame != i {
+			t.Fatalf("a: got frame %d, want %d", f.Frame, i)
+		}
+		if f := b.published(t0); f.Frame != i {
+			t.Fatalf("b: got frame %d, want %d", f.Frame, i)
+		}
+	}
+
+	// A zero Period is an estimate of 60 Hz.
+	if got, want := a.target(t0), t0.Add(defaultPeriod); !got.Equal(want) {
+		t.Errorf("target with a zero Period: got %v, want %v", got, want)
+	}
+}
+
+func TestMergePaint(t *testing.T) {
+	a := imageutil.NewRegion(image.Rect(0, 0, 10, 10))
+	b := imageutil.NewRegion(image.Rect(20, 0, 30, 10))
+	testCases := []struct {
+		a, b Paint
+		want Paint
+	}{
+		{
+			Paint{External: true, Damage: a},
+			Paint{External: true, Damage: b, Frame: 1},
+			Paint{External: true, Damage: a.Union(b), Frame: 1},
+		},
+		{
+			Paint{External: true, Damage: a},
+			Paint{External: true},
+			Paint{External: true},
+		},
+		{
+			Paint{},
+			Paint{External: true, Damage: b},
+			Paint{},
+		},
+	}
+	for i, tc := range testCases {
+		got := mergePaint(tc.a, tc.b)
+		if got.External != tc.want.External || got.Frame != tc.want.Frame || !got.Damage.Eq(tc.want.Damage) {
+			t.Errorf("%d: got %+v, want %+v", i, got, tc.want)
+		}
+	}
+}
+
+func TestSendPaint(t *testing.T) {
+	for len(Dev.Paint) > 0 {
+		<-Dev.Paint
+	}
+	SendPaint(Paint{External: true, Damage: imageutil.NewRegion(image.Rect(0, 0, 1, 1))})
+	SendPaint(Paint{External: true, Damage: imageutil.NewRegion(image.Rect(5, 5, 6, 6))})
+	e := <-Dev.Paint
+	if got, want := e.Damage.Area(), 2; got != want {
+		t.Errorf("pending damage was lost: got an area of %d, want %d", got, want)
+	}
+	if len(Dev.Paint) != 0 {
+		t.Errorf("got %d more paint events, want 0", len(Dev.Paint))
+	}
+
+	var c FrameClock
+	c.Published(time.Now())
+	c.Published(time.Now())
+	if f := <-Dev.Frame; f.Frame != 2 {
+		t.Errorf("Frame: got frame %d, want the latest, 2", f.Frame)
+	}
+}
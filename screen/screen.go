@@ -5,6 +5,7 @@
 package screen // import "github.com/as/shiny/screen"
 
 import (
+	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
@@ -16,27 +17,52 @@ import (
 	"github.com/as/shiny/event/paint"
 	"github.com/as/shiny/event/size"
 	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/math/fixed"
 )
 
 // Screen creates Buffers, Textures and Windows.
 type Screen interface {
-	NewBuffer(size image.Point) (Buffer, error)
-	NewTexture(size image.Point) (Texture, error)
+	NewBuffer(size image.Point, opts *NewBufferOptions) (Buffer, error)
+	NewTexture(size image.Point, opts *NewTextureOptions) (Texture, error)
 	NewWindow(opts *NewWindowOptions) (Window, error)
 }
 
+// Buffer is an in-memory pixel buffer, in one of the Formats. Its pixels can
+// be modified directly, and uploaded to a Window or Texture.
 type Buffer interface {
 	Release()
 	Size() image.Point
 	Bounds() image.Rectangle
+	// RGBA returns the pixels of a FormatRGBA Buffer, and nil for the other
+	// Formats.
 	RGBA() *image.RGBA
+	// Image returns the pixels, as the image type that the Buffer's Format
+	// documents.
+	Image() draw.Image
+	Format() Format
 }
 
+// Texture is a pixel buffer, but not one that is directly accessible as a
+// []byte. Conceptually, it could live on a GPU, in another process or even be
+// across a network, instead of on a CPU in this process.
+//
+// A Texture is also a render target: other Textures can be drawn onto it
+// without a round trip through a CPU-side Buffer, for example to compose a
+// cached layer once and then draw that layer many times.
 type Texture interface {
 	Release()
 	Size() image.Point
 	Bounds() image.Rectangle
 	Uploader
+	Downloader
+	Drawer
+
+	// GenerateMipmaps rebuilds the Texture's mipmaps from its pixels. The
+	// mipmaps are not updated by uploading to or drawing onto the Texture,
+	// so GenerateMipmaps should be called after changing its pixels and
+	// before drawing it scaled down. It does nothing if the Texture was not
+	// created with NewTextureOptions.Mipmaps.
+	GenerateMipmaps()
 }
 
 // Window is a top-level, double-buffered GUI window.
@@ -44,6 +70,7 @@ type Window interface {
 	Device() *Device
 	Release()
 	Uploader
+	Downloader
 	Drawer
 	Publish() PublishResult
 }
@@ -55,6 +82,7 @@ type (
 	Key       = key.Event
 	Size      = size.Event
 	Paint     = paint.Event
+	Frame     = paint.Frame
 )
 
 var Dev = &Device{
@@ -63,6 +91,7 @@ var Dev = &Device{
 	Key:       make(chan Key, 1),
 	Size:      make(chan Size, 1),
 	Paint:     make(chan Paint, 1),
+	Frame:     make(chan Frame, 1),
 	Lifecycle: make(chan Lifecycle, 1),
 }
 
@@ -73,6 +102,7 @@ type Device struct {
 	Key       chan Key
 	Size      chan Size
 	Paint     chan Paint
+	Frame     chan Frame
 }
 
 func SendMouse(e Mouse) {
@@ -101,10 +131,38 @@ func SendSize(e Size) {
 	}
 }
 
+// SendPaint sends e. If a paint event is already pending, it is replaced by
+// one that paints both of their damage, so that none is lost. Drivers send
+// their windows' paint events with FrameClock.SendPaint, which numbers them
+// with the window's frame.
 func SendPaint(e Paint) {
-	select {
-	case Dev.Paint <- e:
-	default:
+	for {
+		select {
+		case Dev.Paint <- e:
+			return
+		default:
+			select {
+			case old := <-Dev.Paint:
+				e = mergePaint(old, e)
+			default:
+			}
+		}
+	}
+}
+
+// SendFrame sends e, replacing any Frame event that is pending, which is
+// for an older frame.
+func SendFrame(e Frame) {
+	for {
+		select {
+		case Dev.Frame <- e:
+			return
+		default:
+			select {
+			case <-Dev.Frame:
+			default:
+			}
+		}
 	}
 }
 
@@ -147,6 +205,28 @@ type NewWindowOptions struct {
 	Overlay bool
 }
 
+// NewTextureOptions are optional arguments to NewTexture.
+type NewTextureOptions struct {
+	// Mipmaps is whether the Texture has mipmaps: smaller copies of it,
+	// each half the size of the previous one. Drawing the Texture scaled
+	// down, with FilterDefault or FilterBest, samples the mipmaps instead
+	// of the Texture's own pixels, which avoids aliasing. See
+	// Texture.GenerateMipmaps.
+	//
+	// A driver may not support mipmaps for every size. OpenGL ES 2.0, for
+	// example, has none for sizes that are not powers of two. Such a
+	// Texture is drawn as if it had no mipmaps.
+	Mipmaps bool
+}
+
+// GetMipmaps returns o.Mipmaps, or false if o is nil.
+func (o *NewTextureOptions) GetMipmaps() bool {
+	if o == nil {
+		return false
+	}
+	return o.Mipmaps
+}
+
 func (o *NewWindowOptions) GetTitle() string {
 	if o == nil {
 		return ""
@@ -171,10 +251,45 @@ func sanitizeUTF8(s string, n int) string {
 
 // Uploader is something you can upload a Buffer to.
 type Uploader interface {
+	// Upload copies the pixels in sr of src to the Uploader, such that
+	// sr.Min maps to dp. Once Upload returns, the src pixels may be
+	// modified without changing what is uploaded. Uploaders that can
+	// return sooner implement AsyncUploader.
 	Upload(dp image.Point, src Buffer, sr image.Rectangle)
 	Fill(dr image.Rectangle, src color.Color, op draw.Op)
 }
 
+// Downloader is something you can read pixels back from, into a Buffer.
+type Downloader interface {
+	// Download copies the pixels in sr, in the Downloader's coordinate space,
+	// to dst, such that sr.Min in the source maps to dp in dst. Pixels that
+	// fall outside of either the source or dst bounds are left unchanged.
+	//
+	// The bytes are in the same layout that Upload reads, so downloading
+	// what was uploaded gives back the same Buffer contents. When
+	// downloading from a Window, the result is whatever the driver is
+	// currently drawing to, and is not necessarily what is visible on the
+	// screen.
+	//
+	// Download blocks until the pixels have been written to dst.
+	Download(sr image.Rectangle, dst Buffer, dp image.Point)
+}
+
+// Drawer is something you can draw Textures on.
+//
+// Draw is the most general purpose of this interface's methods. It supports
+// arbitrary affine transformations, such as translations, scales and
+// rotations.
+//
+// Copy and Scale are more specific versions of Draw. The affected dst pixels
+// are an axis-aligned rectangle, quantized to the pixel grid. Copy copies
+// pixels in a 1:1 manner, Scale is more general. They have simpler parameters
+// than Draw, using ints instead of float64s.
+//
+// When drawing on a Window, there will not be any visible effect until
+// Publish is called.
+//
+// The src Texture must not be the same as the dst Drawer.
 type Drawer interface {
 	Draw(src2dst f64.Aff3, src Texture, sr image.Rectangle, op draw.Op, opts *DrawOptions)
 	DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *DrawOptions)
@@ -182,10 +297,181 @@ type Drawer interface {
 	Scale(dr image.Rectangle, src Texture, sr image.Rectangle, op draw.Op, opts *DrawOptions)
 }
 
+// TrapezoidDrawer is an optional interface for Drawers that can rasterize
+// anti-aliased trapezoids themselves, such as with the X11 Render extension,
+// instead of drawing a mask that was rasterized in software.
+type TrapezoidDrawer interface {
+	// DrawTrapezoids fills the union of the trapezoids, in dst space, with
+	// the uniform color src. The trapezoids must not overlap. The Mask in
+	// opts, if any, is ignored.
+	DrawTrapezoids(src color.Color, traps []Trapezoid, op Op, opts *DrawOptions)
+}
+
+// Trapezoid is a trapezoid with horizontal top and bottom edges. Its left and
+// right sides are the parts of the Left and Right lines, each given by two
+// distinct points on it, that are between Top and Bottom.
+type Trapezoid struct {
+	Top, Bottom fixed.Int26_6
+	Left, Right [2]fixed.Point26_6
+}
+
+// Op is a compositing operator: one of the Porter-Duff operators, or one of
+// the separable blend modes described in the W3C Compositing and Blending
+// specification. It is an alias of draw.Op, so that draw.Over and draw.Src
+// remain valid Ops, but the image/draw package itself only understands
+// those two. For the others, Composite is a reference implementation.
+//
+// In the descriptions below, both src and dst are alpha-premultiplied, and
+// the unqualified src and dst refer to their colors. The blend modes combine
+// src and dst by the given function of their non-premultiplied colors cs and
+// cd, where both are opaque, and act like Over where either is transparent.
+//
+// Some drivers only support a subset of the Ops. Drawing with an unsupported
+// Op has no effect.
+type Op = draw.Op
+
 const (
-	Over = draw.Over
-	Src  = draw.Src
+	// Over is src + dst×(1-src.alpha).
+	Over Op = draw.Over
+	// Src is src.
+	Src Op = draw.Src
+
+	// Clear is zero.
+	Clear Op = iota
+	// Dst is dst.
+	Dst
+	// DstOver is src×(1-dst.alpha) + dst.
+	DstOver
+	// In is src×dst.alpha.
+	In
+	// DstIn is dst×src.alpha.
+	DstIn
+	// Out is src×(1-dst.alpha).
+	Out
+	// DstOut is dst×(1-src.alpha).
+	DstOut
+	// Atop is src×dst.alpha + dst×(1-src.alpha).
+	Atop
+	// DstAtop is src×(1-dst.alpha) + dst×src.alpha.
+	DstAtop
+	// Xor is src×(1-dst.alpha) + dst×(1-src.alpha).
+	Xor
+	// Add is src + dst, saturating at 1.
+	Add
+
+	// BlendMultiply blends by cs×cd.
+	BlendMultiply
+	// BlendScreen blends by cs + cd - cs×cd.
+	BlendScreen
+	// BlendOverlay blends by 2×cs×cd if cd <= 0.5, and by
+	// 1 - 2×(1-cs)×(1-cd) otherwise.
+	BlendOverlay
+	// BlendDarken blends by min(cs, cd).
+	BlendDarken
+	// BlendLighten blends by max(cs, cd).
+	BlendLighten
+	// BlendDifference blends by |cs - cd|.
+	BlendDifference
 )
 
+// DrawOptions are optional arguments to the Drawer methods. A nil
+// *DrawOptions is equivalent to a pointer to the zero value.
 type DrawOptions struct {
+	// Clip, if non-empty, restricts drawing to the dst pixels inside it, in
+	// dst space. The zero value means no clipping.
+	Clip image.Rectangle
+
+	// Opacity scales the alpha of every src pixel, after any Mask has been
+	// applied. Values in (0, 1] are used as they are, and values above 1
+	// are the same as 1. The zero value means fully opaque, the same as 1,
+	// so a fully transparent draw, which matters for ops such as Src and
+	// In, is asked for with any negative value.
+	//
+	// Code that copies an Opacity into other DrawOptions must copy the field
+	// itself, not the result of GetOpacity, which is 0 for a negative value.
+	Opacity float64
+
+	// Filter is how src pixels are sampled when a Draw or Scale does not
+	// map them one-to-one onto dst pixels.
+	Filter Filter
+
+	// Mask, if non-nil, is a Texture whose alpha channel scales the alpha
+	// of the src. It is sampled in src space: the mask pixel at a point p
+	// applies to the src pixel at p, so that the mask is transformed along
+	// with the src. For DrawUniform, src space is that of the sr argument.
+	// Mask pixels outside of the Mask's bounds are taken from its nearest
+	// edge.
+	//
+	// The Mask must not be the same as the dst Drawer.
+	Mask Texture
+}
+
+// GetClip returns o's Clip, or the empty rectangle, which means no clipping,
+// if o is nil.
+func (o *DrawOptions) GetClip() image.Rectangle {
+	if o == nil {
+		return image.Rectangle{}
+	}
+	return o.Clip
+}
+
+// GetOpacity returns the factor that o's Opacity scales the src alpha by: 1
+// if o is nil or its Opacity is zero or above 1, 0 if its Opacity is
+// negative, and its Opacity otherwise.
+func (o *DrawOptions) GetOpacity() float64 {
+	if o == nil || o.Opacity == 0 || o.Opacity > 1 {
+		return 1
+	}
+	if o.Opacity < 0 {
+		return 0
+	}
+	return o.Opacity
+}
+
+// GetFilter returns o's Filter, or FilterDefault if o is nil.
+func (o *DrawOptions) GetFilter() Filter {
+	if o == nil {
+		return FilterDefault
+	}
+	return o.Filter
+}
+
+// GetMask returns o's Mask, or nil if o is nil.
+func (o *DrawOptions) GetMask() Texture {
+	if o == nil {
+		return nil
+	}
+	return o.Mask
+}
+
+// Filter is a method of sampling src pixels when drawing.
+type Filter int
+
+const (
+	// FilterDefault is the driver's default, which is typically
+	// FilterBilinear.
+	FilterDefault Filter = iota
+	// FilterNearest picks the nearest src pixel. It is the fastest filter,
+	// and keeps hard edges when scaling pixel art up by integer factors.
+	FilterNearest
+	// FilterBilinear linearly interpolates between the four nearest src
+	// pixels.
+	FilterBilinear
+	// FilterBest is the highest quality filter the driver provides. It may
+	// be the same as FilterBilinear.
+	FilterBest
+)
+
+func (f Filter) String() string {
+	switch f {
+	case FilterDefault:
+		return "default"
+	case FilterNearest:
+		return "nearest"
+	case FilterBilinear:
+		return "bilinear"
+	case FilterBest:
+		return "best"
+	}
+	return fmt.Sprintf("Filter(%d)", int(f))
 }
@@ -51,3 +51,23 @@ func TestSanitizeUTF8(t *testing.T) {
 		}
 	}
 }
+
+func TestDrawOptionsOpacity(t *testing.T) {
+	testCases := []struct {
+		opts *DrawOptions
+		want float64
+	}{
+		{nil, 1},
+		{&DrawOptions{}, 1},
+		{&DrawOptions{Opacity: 0.25}, 0.25},
+		{&DrawOptions{Opacity: 1}, 1},
+		{&DrawOptions{Opacity: 2}, 1},
+		{&DrawOptions{Opacity: -1}, 0},
+		{&DrawOptions{Opacity: -0.5}, 0},
+	}
+	for _, tc := range testCases {
+		if got := tc.opts.GetOpacity(); got != tc.want {
+			t.Errorf("%+v: got %v, want %v", tc.opts, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import "image"
+
+// AsyncUploader is an optional interface for Uploaders that can upload a
+// Buffer without waiting for the upload to complete, such as to a GPU or to
+// an X11 server through shared memory.
+type AsyncUploader interface {
+	// UploadAsync is like Upload, but returns as soon as the upload is
+	// queued. The returned channel is closed once the upload is complete,
+	// after which the src pixels in sr may be modified, or src released.
+	// Until then, modifying them may change what is uploaded.
+	//
+	// Releasing src before the upload is complete is allowed: the Buffer's
+	// resources are freed once it is complete.
+	UploadAsync(dp image.Point, src Buffer, sr image.Rectangle) <-chan struct{}
+}
+
+// Closed is a channel that is always closed. An AsyncUploader returns it for
+// an upload that is already complete.
+var Closed <-chan struct{} = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// UploadAsync uploads the src rectangle sr of src to dst, such that sr.Min
+// maps to dp, and returns a channel that is closed once the src pixels may be
+// modified. If dst is not an AsyncUploader, it calls Upload, and the channel
+// is already closed.
+//
+// For example, a video player can double-buffer its frames, decoding each
+// frame into one Buffer while the previous frame, in the other Buffer, is
+// being uploaded:
+//
+//	var done [2]<-chan struct{}
+//	for i := 0; ; i ^= 1 {
+//		if done[i] != nil {
+//			<-done[i]
+//		}
+//		decode(bufs[i])
+//		done[i] = screen.UploadAsync(tex, image.Point{}, bufs[i], bufs[i].Bounds())
+//	}
+func UploadAsync(dst Uploader, dp image.Point, src Buffer, sr image.Rectangle) <-chan struct{} {
+	if au, ok := dst.(AsyncUploader); ok {
+		return au.UploadAsync(dp, src, sr)
+	}
+	dst.Upload(dp, src, sr)
+	return Closed
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package screen
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// recordUploader is an Uploader that records its last upload.
+type recordUploader struct {
+	dp image.Point
+	sr image.Rectangle
+}
+
+func (u *recordUploader) Upload(dp image.Point, src Buffer, sr image.Rectangle) { u.dp, u.sr = dp, sr }
+func (u *recordUploader) Fill(dr image.Rectangle, src color.Color, op draw.Op)  {}
+
+// asyncUploader is an AsyncUploader whose uploads complete once c is closed.
+type asyncUploader struct {
+	recordUploader
+	c chan struct{}
+}
+
+func (u *asyncUploader) UploadAsync(dp image.Point, src Buffer, sr image.Rectangle) <-chan struct{} {
+	u.dp, u.sr = dp, sr
+	return u.c
+}
+
+func TestUploadAsync(t *testing.T) {
+	dp, sr := image.Point{1, 2}, image.Rect(3, 4, 5, 6)
+
+	u := &recordUploader{}
+	select {
+	case <-UploadAsync(u, dp, nil, sr):
+	default:
+		t.Errorf("Uploader: the channel is not closed")
+	}
+	if u.dp != dp || u.sr != sr {
+		t.Errorf("Uploader: got %v, %v, want %v, %v", u.dp, u.sr, dp, sr)
+	}
+
+	au := &asyncUploader{c: make(chan struct{})}
+	done := UploadAsync(au, dp, nil, sr)
+	if au.dp != dp || au.sr != sr {
+		t.Errorf("AsyncUploader: got %v, %v, want %v, %v", au.dp, au.sr, dp, sr)
+	}
+	select {
+	case <-done:
+		t.Errorf("AsyncUploader: the channel is closed before the upload is complete")
+	default:
+	}
+	close(au.c)
+	<-done
+}
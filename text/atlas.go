@@ -0,0 +1,366 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"container/list"
+	"image"
+	"image/draw"
+
+	"github.com/as/shiny/math/fixed"
+	"github.com/as/shiny/screen"
+)
+
+// subpixels is the number of horizontal sub-pixel positions at which each
+// glyph is cached. Vertical positions are rounded to whole pixels.
+const subpixels = 4
+
+// padding is the number of empty pixels to the right of and below each glyph
+// in the atlas, so that filtering at a glyph's edge does not pick up its
+// neighbors.
+const padding = 1
+
+// AtlasOptions are optional arguments to NewAtlas.
+type AtlasOptions struct {
+	// Size is the initial size of the Atlas Texture. The zero value means
+	// 256x256.
+	Size image.Point
+
+	// MaxSize is the size beyond which the Atlas does not grow, and evicts
+	// the least recently used glyphs instead. The zero value means
+	// 2048x2048.
+	MaxSize image.Point
+}
+
+// Atlas is a cache of glyph masks, packed into the shelves of a Texture.
+// When it is full it grows, up to a maximum size, after which the least
+// recently used glyphs are evicted.
+//
+// Each glyph is stored as opaque white, alpha-premultiplied by its coverage,
+// so that the Atlas can be used either as the src of a Copy, or as the Mask
+// of a DrawUniform.
+//
+// An Atlas is not safe for concurrent use.
+type Atlas struct {
+	s screen.Screen
+
+	// tex holds the glyphs, and buf is a CPU-side copy of it. Glyphs are
+	// rasterized into buf, and the dirty part of buf is uploaded to tex
+	// before tex is drawn from.
+	tex   screen.Texture
+	buf   screen.Buffer
+	dirty image.Rectangle
+
+	size, maxSize image.Point
+	shelves       []*shelf
+	glyphs        map[glyphKey]*glyph
+	lru           list.List // Of *glyph, most recently used first.
+
+	// gen is the current generation. Glyphs used in the current generation
+	// are pinned: they may be drawn from after they were looked up, so they
+	// are not evicted.
+	gen uint64
+}
+
+type glyphKey struct {
+	face Face
+	r    rune
+	subX fixed.Int26_6
+}
+
+type glyph struct {
+	key glyphKey
+
+	// r is where the glyph is in the atlas, and off is the offset from the
+	// dot, rounded down to a whole pixel, to r.Min.
+	r       image.Rectangle
+	off     image.Point
+	advance fixed.Int26_6
+
+	shelf *shelf
+	elem  *list.Element
+	gen   uint64
+}
+
+// shelf is a horizontal strip of the atlas, filled from left to right.
+type shelf struct {
+	y, h int
+	// x is the left edge of the free space at the right of the shelf.
+	x int
+	// holes are the free spaces, left of x, of evicted glyphs, sorted by x.
+	holes []span
+	// n is the number of glyphs in the shelf.
+	n int
+}
+
+// span is a horizontal range of pixels.
+type span struct {
+	x, w int
+}
+
+// fit returns the x coordinate of the first free space in sh that is at
+// least w pixels wide, in an atlas that is width pixels wide.
+func (sh *shelf) fit(w, width int) (x int, ok bool) {
+	for _, s := range sh.holes {
+		if s.w >= w {
+			return s.x, true
+		}
+	}
+	return sh.x, sh.x+w <= width
+}
+
+// take marks the w pixels at x, as returned by fit, as used.
+func (sh *shelf) take(x, w int) {
+	sh.n++
+	if x == sh.x {
+		sh.x += w
+		return
+	}
+	for i := range sh.holes {
+		if s := &sh.holes[i]; s.x == x {
+			s.x, s.w = s.x+w, s.w-w
+			if s.w == 0 {
+				sh.holes = append(sh.holes[:i], sh.holes[i+1:]...)
+			}
+			return
+		}
+	}
+}
+
+// free marks the w pixels at x as free, merging them with their neighbors.
+func (sh *shelf) free(x, w int) {
+	sh.n--
+	if sh.n == 0 {
+		sh.x, sh.holes = 0, sh.holes[:0]
+		return
+	}
+	i := 0
+	for i < len(sh.holes) && sh.holes[i].x < x {
+		i++
+	}
+	sh.holes = append(sh.holes, span{})
+	copy(sh.holes[i+1:], sh.holes[i:])
+	sh.holes[i] = span{x, w}
+	if i+1 < len(sh.holes) && x+w == sh.holes[i+1].x {
+		sh.holes[i].w += sh.holes[i+1].w
+		sh.holes = append(sh.holes[:i+1], sh.holes[i+2:]...)
+	}
+	if i > 0 && sh.holes[i-1].x+sh.holes[i-1].w == x {
+		sh.holes[i-1].w += sh.holes[i].w
+		sh.holes = append(sh.holes[:i], sh.holes[i+1:]...)
+	}
+	// A hole at the right of the shelf is part of the free space there.
+	if last := sh.holes[len(sh.holes)-1]; last.x+last.w == sh.x {
+		sh.x = last.x
+		sh.holes = sh.holes[:len(sh.holes)-1]
+	}
+}
+
+// NewAtlas returns a new, empty Atlas whose Textures are created by s.
+func NewAtlas(s screen.Screen, opts *AtlasOptions) (*Atlas, error) {
+	a := &Atlas{
+		s:       s,
+		size:    image.Point{256, 256},
+		maxSize: image.Point{2048, 2048},
+		glyphs:  make(map[glyphKey]*glyph),
+	}
+	if opts != nil {
+		if opts.Size.X > 0 && opts.Size.Y > 0 {
+			a.size = opts.Size
+		}
+		if opts.MaxSize.X > 0 && opts.MaxSize.Y > 0 {
+			a.maxSize = opts.MaxSize
+		}
+	}
+	if a.maxSize.X < a.size.X {
+		a.maxSize.X = a.size.X
+	}
+	if a.maxSize.Y < a.size.Y {
+		a.maxSize.Y = a.size.Y
+	}
+	if err := a.resize(a.size); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Release releases the Atlas' resources. It must not be used afterwards.
+func (a *Atlas) Release() {
+	if a.tex != nil {
+		a.tex.Release()
+		a.tex = nil
+	}
+	if a.buf != nil {
+		a.buf.Release()
+		a.buf = nil
+	}
+	a.glyphs = nil
+	a.shelves = nil
+	a.lru.Init()
+}
+
+// Texture returns the Atlas Texture, after uploading any glyphs that were
+// added since it was last called. The Texture changes when the Atlas grows.
+func (a *Atlas) Texture() screen.Texture {
+	if !a.dirty.Empty() {
+		a.tex.Upload(a.dirty.Min, a.buf, a.dirty)
+		a.dirty = image.Rectangle{}
+	}
+	return a.tex
+}
+
+// Len returns the number of glyphs in the Atlas.
+func (a *Atlas) Len() int { return len(a.glyphs) }
+
+// begin starts a new generation, unpinning every glyph.
+func (a *Atlas) begin() { a.gen++ }
+
+// lookup returns the glyph for r in f, drawn at the sub-pixel offset subX,
+// rasterizing it into the atlas if it is not already there, and pins it. It
+// returns nil if there is no room for the glyph, because it is too large or
+// because every glyph in the atlas is pinned.
+func (a *Atlas) lookup(f Face, r rune, subX fixed.Int26_6) (*glyph, error) {
+	k := glyphKey{f, r, subX}
+	if g := a.glyphs[k]; g != nil {
+		g.gen = a.gen
+		a.lru.MoveToFront(g.elem)
+		return g, nil
+	}
+
+	dr, mask, maskp, advance, _ := f.Glyph(fixed.Point26_6{X: subX}, r)
+	g := &glyph{
+		key:     k,
+		off:     dr.Min,
+		advance: advance,
+		gen:     a.gen,
+	}
+	if !dr.Empty() && mask != nil {
+		size := dr.Size().Add(image.Point{padding, padding})
+		sh, p, err := a.alloc(size)
+		if err != nil {
+			return nil, err
+		}
+		if sh == nil {
+			return nil, nil
+		}
+		g.shelf = sh
+		g.r = image.Rectangle{p, p.Add(dr.Size())}
+		// Clear the padding, which may hold part of an evicted glyph.
+		padded := image.Rectangle{p, p.Add(size)}
+		draw.Draw(a.buf.RGBA(), padded, image.Transparent, image.Point{}, draw.Src)
+		draw.DrawMask(a.buf.RGBA(), g.r, image.White, image.Point{}, mask, maskp, draw.Src)
+		a.dirty = a.dirty.Union(padded)
+	}
+	g.elem = a.lru.PushFront(g)
+	a.glyphs[k] = g
+	return g, nil
+}
+
+// alloc returns space in the atlas for a glyph of the given size, growing the
+// atlas or evicting glyphs to make room. It returns a nil shelf if there is
+// no room.
+func (a *Atlas) alloc(size image.Point) (*shelf, image.Point, error) {
+	if size.X > a.maxSize.X || size.Y > a.maxSize.Y {
+		return nil, image.Point{}, nil
+	}
+	for {
+		if sh, x := a.pack(size); sh != nil {
+			sh.take(x, size.X)
+			return sh, image.Point{x, sh.y}, nil
+		}
+		if a.size != a.maxSize {
+			if err := a.resize(image.Point{
+				X: min(2*a.size.X, a.maxSize.X),
+				Y: min(2*a.size.Y, a.maxSize.Y),
+			}); err != nil {
+				return nil, image.Point{}, err
+			}
+			continue
+		}
+		if !a.evict() {
+			return nil, image.Point{}, nil
+		}
+	}
+}
+
+// pack returns the shelf, and the x coordinate in it, that best fits a glyph
+// of the given size, or nil if none does. It may add a new shelf.
+func (a *Atlas) pack(size image.Point) (best *shelf, bestX int) {
+	for _, sh := range a.shelves {
+		if sh.h < size.Y {
+			continue
+		}
+		// Prefer the shortest shelf, and avoid wasting much of a tall shelf,
+		// unless it is empty and can be reused at any height.
+		if sh.n != 0 && sh.h > 2*size.Y {
+			continue
+		}
+		if best != nil && sh.h >= best.h {
+			continue
+		}
+		if x, ok := sh.fit(size.X, a.size.X); ok {
+			best, bestX = sh, x
+		}
+	}
+	if best != nil {
+		return best, bestX
+	}
+	y := 0
+	if n := len(a.shelves); n > 0 {
+		y = a.shelves[n-1].y + a.shelves[n-1].h
+	}
+	if y+size.Y > a.size.Y || size.X > a.size.X {
+		return nil, 0
+	}
+	sh := &shelf{y: y, h: size.Y}
+	a.shelves = append(a.shelves, sh)
+	return sh, 0
+}
+
+// evict removes the least recently used glyph that is not pinned, and
+// reports whether there was one.
+func (a *Atlas) evict() bool {
+	e := a.lru.Back()
+	if e == nil {
+		return false
+	}
+	g := e.Value.(*glyph)
+	if g.gen == a.gen {
+		return false
+	}
+	a.lru.Remove(e)
+	delete(a.glyphs, g.key)
+	if sh := g.shelf; sh != nil {
+		sh.free(g.r.Min.X, g.r.Dx()+padding)
+	}
+	// Reclaim the empty shelves at the bottom, so that their space can be
+	// used for shelves of a different height.
+	for n := len(a.shelves); n > 0 && a.shelves[n-1].n == 0; n-- {
+		a.shelves = a.shelves[:n-1]
+	}
+	return true
+}
+
+// resize replaces the atlas Texture and Buffer with ones of the given size,
+// keeping their contents.
+func (a *Atlas) resize(size image.Point) error {
+	buf, err := a.s.NewBuffer(size, nil)
+	if err != nil {
+		return err
+	}
+	tex, err := a.s.NewTexture(size, nil)
+	if err != nil {
+		buf.Release()
+		return err
+	}
+	if a.buf != nil {
+		draw.Draw(buf.RGBA(), a.buf.Bounds(), a.buf.RGBA(), image.Point{}, draw.Src)
+		a.buf.Release()
+		a.tex.Release()
+	}
+	a.buf, a.tex, a.size = buf, tex, size
+	a.dirty = buf.Bounds()
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"unicode/utf8"
+
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/math/fixed"
+	"github.com/as/shiny/screen"
+)
+
+// Drawer draws text on a screen.Drawer, with glyphs cached in an Atlas.
+//
+// The glyphs of each string are rasterized into the Atlas first, which is
+// then uploaded at most once, before they are all drawn as one screen.Batch.
+type Drawer struct {
+	// Dst is what to draw on.
+	Dst screen.Drawer
+	// Atlas caches the glyphs. It may be shared by Drawers that draw on the
+	// same Screen, with the same or different Faces.
+	Atlas *Atlas
+	// Face is the font face to draw with.
+	Face Face
+	// Color is the color of the text. If nil, the glyphs are copied from the
+	// Atlas as they are, as opaque white, which needs no Mask.
+	Color color.Color
+	// Op is the operator to draw the glyphs with.
+	Op screen.Op
+	// Clip, if non-empty, restricts drawing to the Dst pixels inside it.
+	Clip image.Rectangle
+	// Dot is the baseline location to draw the next glyph at. It is
+	// advanced past each glyph as it is drawn.
+	Dot fixed.Point26_6
+
+	pending []placement
+	batch   screen.Batch
+}
+
+// placement is a glyph waiting to be drawn at dp in dst space.
+type placement struct {
+	g  *glyph
+	dp image.Point
+}
+
+// DrawString draws s at the dot and advances the dot's location. If it fails
+// to add a glyph to the Atlas, it draws the glyphs before that one, leaves
+// the dot after them, and returns the error.
+func (d *Drawer) DrawString(s string) error {
+	d.Atlas.begin()
+	prev := rune(-1)
+	for _, r := range s {
+		if err := d.drawRune(prev, r); err != nil {
+			d.flush()
+			return err
+		}
+		prev = r
+	}
+	d.flush()
+	return nil
+}
+
+// DrawBytes is like DrawString but for a []byte.
+func (d *Drawer) DrawBytes(b []byte) error {
+	d.Atlas.begin()
+	prev := rune(-1)
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		b = b[n:]
+		if err := d.drawRune(prev, r); err != nil {
+			d.flush()
+			return err
+		}
+		prev = r
+	}
+	d.flush()
+	return nil
+}
+
+// BoundString returns the bounding box of s, drawn at the current dot, and
+// the advance width of s. It does not draw anything or move the dot.
+func (d *Drawer) BoundString(s string) (bounds fixed.Rectangle26_6, advance fixed.Int26_6) {
+	bounds, advance = BoundString(d.Face, s)
+	return bounds.Add(d.Dot), advance
+}
+
+// MeasureString returns how far the dot would advance by drawing s.
+func (d *Drawer) MeasureString(s string) fixed.Int26_6 {
+	return MeasureString(d.Face, s)
+}
+
+func (d *Drawer) drawRune(prev, r rune) error {
+	if prev >= 0 {
+		d.Dot.X += d.Face.Kern(prev, r)
+	}
+
+	// Round the dot to the nearest of the sub-pixel positions that glyphs
+	// are cached at.
+	x := d.Dot.X.Floor()
+	q := (int(d.Dot.X&63)*subpixels + 32) >> 6
+	if q == subpixels {
+		x, q = x+1, 0
+	}
+	subX := fixed.Int26_6(q * 64 / subpixels)
+
+	g, err := d.Atlas.lookup(d.Face, r, subX)
+	if g == nil && err == nil {
+		// The atlas is full of glyphs that are waiting to be drawn, so draw
+		// them, which unpins them.
+		d.flush()
+		d.Atlas.begin()
+		g, err = d.Atlas.lookup(d.Face, r, subX)
+	}
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		// The glyph is too large for the atlas.
+		a, _ := d.Face.GlyphAdvance(r)
+		d.Dot.X += a
+		return nil
+	}
+	if !g.r.Empty() {
+		d.pending = append(d.pending, placement{
+			g:  g,
+			dp: image.Point{x, d.Dot.Y.Round()}.Add(g.off),
+		})
+	}
+	d.Dot.X += g.advance
+	return nil
+}
+
+// flush draws the pending glyphs, as one screen.Batch.
+func (d *Drawer) flush() {
+	if len(d.pending) == 0 {
+		return
+	}
+	tex := d.Atlas.Texture()
+	var opts *screen.DrawOptions
+	if !d.Clip.Empty() {
+		opts = &screen.DrawOptions{Clip: d.Clip}
+	}
+	if d.Color == nil {
+		for _, p := range d.pending {
+			d.batch.Copy(p.dp, tex, p.g.r, d.Op, opts)
+		}
+	} else {
+		mopts := &screen.DrawOptions{
+			Clip:   d.Clip,
+			Filter: screen.FilterNearest,
+			Mask:   tex,
+		}
+		for _, p := range d.pending {
+			delta := p.dp.Sub(p.g.r.Min)
+			d.batch.DrawUniform(f64.Aff3{
+				1, 0, float64(delta.X),
+				0, 1, float64(delta.Y),
+			}, d.Color, p.g.r, d.Op, mopts)
+		}
+	}
+	d.batch.Submit(d.Dst)
+	d.batch.Reset()
+	d.pending = d.pending[:0]
+}
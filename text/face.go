@@ -0,0 +1,129 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package text draws text onto a screen.Drawer.
+//
+// Glyphs are rasterized by a Face, cached in an Atlas Texture, and drawn
+// from there, so that drawing a glyph again costs only a Copy or
+// DrawUniform of part of the Atlas.
+package text // import "github.com/as/shiny/text"
+
+import (
+	"image"
+	"unicode/utf8"
+
+	"github.com/as/shiny/math/fixed"
+)
+
+// Face is a font face: a font at a particular size and style. It is modeled
+// on the golang.org/x/image/font package's Face, but is in terms of this
+// repository's fixed point types.
+//
+// A Face is used as a map key by an Atlas, so its dynamic type must be
+// comparable, such as a pointer.
+type Face interface {
+	// Glyph returns the draw.DrawMask parameters (dr, mask, maskp) to draw r
+	// with its origin at the sub-pixel point dot, and r's advance width.
+	//
+	// If r is not in the face, ok is false, but the other values may still
+	// describe a replacement glyph, such as a box.
+	Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool)
+
+	// GlyphBounds returns the bounding box of r, drawn with its origin at
+	// the zero point, and r's advance width.
+	GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool)
+
+	// GlyphAdvance returns r's advance width.
+	GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool)
+
+	// Kern returns the horizontal adjustment for the kerning pair (r0, r1).
+	// A positive kern means to move the glyphs further apart.
+	Kern(r0, r1 rune) fixed.Int26_6
+
+	// Metrics returns the metrics of the face.
+	Metrics() Metrics
+}
+
+// Metrics holds the metrics for a Face. A visual depiction is at
+// https://developer.apple.com/library/mac/documentation/TextFonts/Conceptual/CocoaTextArchitecture/Art/glyph_metrics_2x.png
+type Metrics struct {
+	// Height is the recommended amount of vertical space between two lines
+	// of text.
+	Height fixed.Int26_6
+
+	// Ascent is the distance from the top of a line to its baseline.
+	Ascent fixed.Int26_6
+
+	// Descent is the distance from the bottom of a line to its baseline. The
+	// value is typically positive, even though a descender goes below the
+	// baseline.
+	Descent fixed.Int26_6
+}
+
+// BoundString returns the bounding box of s, drawn with f at the origin, and
+// its advance width. The bounds may extend to the left of the origin, and
+// above it, which is the negative y direction.
+func BoundString(f Face, s string) (bounds fixed.Rectangle26_6, advance fixed.Int26_6) {
+	prev := rune(-1)
+	for _, r := range s {
+		bounds, advance = boundRune(f, prev, r, bounds, advance)
+		prev = r
+	}
+	return bounds, advance
+}
+
+// BoundBytes is like BoundString but for a []byte.
+func BoundBytes(f Face, b []byte) (bounds fixed.Rectangle26_6, advance fixed.Int26_6) {
+	prev := rune(-1)
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		b = b[n:]
+		bounds, advance = boundRune(f, prev, r, bounds, advance)
+		prev = r
+	}
+	return bounds, advance
+}
+
+func boundRune(f Face, prev, r rune, bounds fixed.Rectangle26_6, advance fixed.Int26_6) (fixed.Rectangle26_6, fixed.Int26_6) {
+	if prev >= 0 {
+		advance += f.Kern(prev, r)
+	}
+	b, a, _ := f.GlyphBounds(r)
+	if !b.Empty() {
+		b.Min.X += advance
+		b.Max.X += advance
+		bounds = bounds.Union(b)
+	}
+	return bounds, advance + a
+}
+
+// MeasureString returns how far dot would advance by drawing s with f.
+func MeasureString(f Face, s string) (advance fixed.Int26_6) {
+	prev := rune(-1)
+	for _, r := range s {
+		if prev >= 0 {
+			advance += f.Kern(prev, r)
+		}
+		a, _ := f.GlyphAdvance(r)
+		advance += a
+		prev = r
+	}
+	return advance
+}
+
+// MeasureBytes is like MeasureString but for a []byte.
+func MeasureBytes(f Face, b []byte) (advance fixed.Int26_6) {
+	prev := rune(-1)
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		b = b[n:]
+		if prev >= 0 {
+			advance += f.Kern(prev, r)
+		}
+		a, _ := f.GlyphAdvance(r)
+		advance += a
+		prev = r
+	}
+	return advance
+}
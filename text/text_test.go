@@ -0,0 +1,304 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package text
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/math/fixed"
+	"github.com/as/shiny/screen"
+)
+
+// boxFace is a Face whose glyphs are solid boxes, except for space, which is
+// empty. 'A' followed by 'V' is kerned closer together.
+type boxFace struct {
+	w, h, ascent int
+	advance      fixed.Int26_6
+}
+
+func (f *boxFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	if r == ' ' {
+		return image.Rectangle{}, nil, image.Point{}, f.advance, true
+	}
+	x, y := dot.X.Floor(), dot.Y.Floor()
+	dr := image.Rect(x, y-f.ascent, x+f.w, y-f.ascent+f.h)
+	return dr, image.Opaque, image.Point{}, f.advance, true
+}
+
+func (f *boxFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	if r == ' ' {
+		return fixed.Rectangle26_6{}, f.advance, true
+	}
+	return fixed.R(0, -f.ascent, f.w, f.h-f.ascent), f.advance, true
+}
+
+func (f *boxFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) { return f.advance, true }
+
+func (f *boxFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	if r0 == 'A' && r1 == 'V' {
+		return -2 * 64
+	}
+	return 0
+}
+
+func (f *boxFace) Metrics() Metrics {
+	return Metrics{
+		Height:  fixed.I(f.h + 2),
+		Ascent:  fixed.I(f.ascent),
+		Descent: fixed.I(f.h - f.ascent),
+	}
+}
+
+// testScreen is a screen.Screen whose Buffers and Textures are image.RGBAs.
+type testScreen struct {
+	screen.Screen
+	textures int
+	// err, if non-nil, is returned by NewTexture.
+	err error
+}
+
+type testBuffer struct{ m *image.RGBA }
+
+func (b *testBuffer) Release()                {}
+func (b *testBuffer) Size() image.Point       { return b.m.Bounds().Size() }
+func (b *testBuffer) Bounds() image.Rectangle { return b.m.Bounds() }
+func (b *testBuffer) RGBA() *image.RGBA       { return b.m }
+func (b *testBuffer) Image() draw.Image       { return b.m }
+func (b *testBuffer) Format() screen.Format   { return screen.FormatRGBA }
+
+// testTexture is both a Texture and the Drawer to draw text on.
+type testTexture struct {
+	screen.Texture
+	s *testScreen
+	m *image.RGBA
+}
+
+func (s *testScreen) NewBuffer(size image.Point, opts *screen.NewBufferOptions) (screen.Buffer, error) {
+	return &testBuffer{image.NewRGBA(image.Rectangle{Max: size})}, nil
+}
+
+func (s *testScreen) NewTexture(size image.Point, opts *screen.NewTextureOptions) (screen.Texture, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.textures++
+	return &testTexture{s: s, m: image.NewRGBA(image.Rectangle{Max: size})}, nil
+}
+
+func (t *testTexture) Release()                { t.s.textures-- }
+func (t *testTexture) Size() image.Point       { return t.m.Bounds().Size() }
+func (t *testTexture) Bounds() image.Rectangle { return t.m.Bounds() }
+
+func (t *testTexture) Upload(dp image.Point, src screen.Buffer, sr image.Rectangle) {
+	draw.Draw(t.m, sr.Sub(sr.Min).Add(dp), src.RGBA(), sr.Min, draw.Src)
+}
+
+// Draw and DrawUniform only support translations, which is all that a Drawer
+// uses.
+func (t *testTexture) Draw(src2dst f64.Aff3, src screen.Texture, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	dp := image.Point{int(src2dst[2]), int(src2dst[5])}
+	draw.Draw(t.m, sr.Add(dp), src.(*testTexture).m, sr.Min, op)
+}
+
+func (t *testTexture) DrawUniform(src2dst f64.Aff3, src color.Color, sr image.Rectangle, op draw.Op, opts *screen.DrawOptions) {
+	dp := image.Point{int(src2dst[2]), int(src2dst[5])}
+	mask := opts.GetMask().(*testTexture).m
+	draw.DrawMask(t.m, sr.Add(dp), image.NewUniform(src), image.Point{}, mask, sr.Min, op)
+}
+
+func newTestDrawer(t *testing.T, opts *AtlasOptions) (*Drawer, *testScreen, *testTexture) {
+	s := &testScreen{}
+	a, err := NewAtlas(s, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, _ := s.NewTexture(image.Point{100, 20}, nil)
+	return &Drawer{
+		Dst:   dst.(*testTexture),
+		Atlas: a,
+		Face:  &boxFace{w: 4, h: 6, ascent: 5, advance: 5 * 64},
+		Dot:   fixed.P(2, 10),
+	}, s, dst.(*testTexture)
+}
+
+func TestDrawString(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	for _, c := range []color.Color{nil, red} {
+		d, _, dst := newTestDrawer(t, nil)
+		d.Color = c
+		if err := d.DrawString("A V"); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := d.Dot.X, fixed.I(2+3*5); got != want {
+			t.Errorf("color %v: dot: got %v, want %v", c, got, want)
+		}
+		want := c
+		if want == nil {
+			want = color.RGBA{0xff, 0xff, 0xff, 0xff}
+		}
+		for _, tc := range []struct {
+			x, y int
+			want color.Color
+		}{
+			{2, 5, want},
+			{5, 10, want},
+			{6, 5, color.RGBA{}},
+			{12, 5, want},
+			{16, 10, color.RGBA{}},
+			{2, 11, color.RGBA{}},
+		} {
+			if got := dst.m.At(tc.x, tc.y); got != tc.want {
+				t.Errorf("color %v: (%d, %d): got %v, want %v", c, tc.x, tc.y, got, tc.want)
+			}
+		}
+		// The space has no pixels, but its advance is still cached.
+		if got, want := d.Atlas.Len(), 3; got != want {
+			t.Errorf("color %v: atlas has %d glyphs, want %d", c, got, want)
+		}
+	}
+}
+
+func TestKerning(t *testing.T) {
+	d, _, _ := newTestDrawer(t, nil)
+	if got, want := d.MeasureString("AV"), fixed.I(8); got != want {
+		t.Errorf("MeasureString: got %v, want %v", got, want)
+	}
+	if got, want := MeasureBytes(d.Face, []byte("AV")), fixed.I(8); got != want {
+		t.Errorf("MeasureBytes: got %v, want %v", got, want)
+	}
+	d.DrawString("AV")
+	if got, want := d.Dot.X, fixed.I(2+8); got != want {
+		t.Errorf("dot: got %v, want %v", got, want)
+	}
+}
+
+func TestBoundString(t *testing.T) {
+	f := &boxFace{w: 4, h: 6, ascent: 5, advance: 5 * 64}
+	testCases := []struct {
+		s           string
+		wantBounds  fixed.Rectangle26_6
+		wantAdvance fixed.Int26_6
+	}{
+		{"", fixed.Rectangle26_6{}, 0},
+		{" ", fixed.Rectangle26_6{}, fixed.I(5)},
+		{"ab", fixed.R(0, -5, 9, 1), fixed.I(10)},
+		{" b", fixed.R(5, -5, 9, 1), fixed.I(10)},
+		{"AV", fixed.R(0, -5, 7, 1), fixed.I(8)},
+	}
+	for _, tc := range testCases {
+		bounds, advance := BoundString(f, tc.s)
+		if bounds != tc.wantBounds || advance != tc.wantAdvance {
+			t.Errorf("%q: got %v, %v, want %v, %v", tc.s, bounds, advance, tc.wantBounds, tc.wantAdvance)
+		}
+		bounds, advance = BoundBytes(f, []byte(tc.s))
+		if bounds != tc.wantBounds || advance != tc.wantAdvance {
+			t.Errorf("%q: BoundBytes: got %v, %v, want %v, %v", tc.s, bounds, advance, tc.wantBounds, tc.wantAdvance)
+		}
+	}
+}
+
+func TestSubpixel(t *testing.T) {
+	d, _, _ := newTestDrawer(t, nil)
+	d.Face = &boxFace{w: 4, h: 6, ascent: 5, advance: 5*64 + 16}
+	d.DrawString("aaaa")
+	// The advance is a quarter pixel more than a whole pixel, so each of the
+	// four glyphs is at a different sub-pixel position.
+	if got, want := d.Atlas.Len(), 4; got != want {
+		t.Errorf("got %d glyphs, want %d", got, want)
+	}
+}
+
+func TestAtlasGrowAndEvict(t *testing.T) {
+	// Each glyph takes 5x7 pixels with its padding, so a 16x16 atlas holds
+	// 6 glyphs, and a 32x32 atlas holds 24.
+	d, s, _ := newTestDrawer(t, &AtlasOptions{
+		Size:    image.Point{16, 16},
+		MaxSize: image.Point{32, 32},
+	})
+	a := d.Atlas
+
+	d.DrawString("abcdef")
+	if a.size != (image.Point{16, 16}) {
+		t.Fatalf("atlas size: got %v, want 16x16", a.size)
+	}
+	d.DrawString("g")
+	if a.size != (image.Point{32, 32}) {
+		t.Fatalf("atlas size: got %v, want 32x32", a.size)
+	}
+	// The atlas Texture and the dst.
+	if s.textures != 2 {
+		t.Errorf("got %d textures, want 2", s.textures)
+	}
+
+	for r := 'h'; r < 'h'+17; r++ {
+		d.DrawString(string(r))
+	}
+	if got, want := a.Len(), 24; got != want {
+		t.Fatalf("got %d glyphs, want %d", got, want)
+	}
+	// Using 'a' makes 'b' the least recently used, so it is evicted first.
+	d.DrawString("a")
+	d.DrawString("z")
+	if a.Len() != 24 {
+		t.Errorf("got %d glyphs, want 24", a.Len())
+	}
+	for _, r := range "az" {
+		if a.glyphs[glyphKey{d.Face, r, 0}] == nil {
+			t.Errorf("%q was evicted", r)
+		}
+	}
+	if a.glyphs[glyphKey{d.Face, 'b', 0}] != nil {
+		t.Errorf("'b' was not evicted")
+	}
+}
+
+func TestAtlasPinned(t *testing.T) {
+	// A string with more glyphs than fit in the atlas is drawn in parts.
+	d, _, dst := newTestDrawer(t, &AtlasOptions{
+		Size:    image.Point{16, 16},
+		MaxSize: image.Point{16, 16},
+	})
+	d.Dot = fixed.P(0, 10)
+	if err := d.DrawString("abcdefghij"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if got := dst.m.RGBAAt(5*i, 5); got.A != 0xff {
+			t.Errorf("glyph %d was not drawn: got %v", i, got)
+		}
+	}
+}
+
+func TestDrawStringError(t *testing.T) {
+	// The atlas can not grow to fit the whole string. The glyphs before the
+	// failure are drawn, and not left pending for a later call.
+	d, s, dst := newTestDrawer(t, &AtlasOptions{
+		Size:    image.Point{16, 16},
+		MaxSize: image.Point{32, 32},
+	})
+	d.Dot = fixed.P(0, 10)
+	s.err = errors.New("no more textures")
+	if err := d.DrawString("abcdefghij"); err != s.err {
+		t.Fatalf("got error %v, want %v", err, s.err)
+	}
+	if len(d.pending) != 0 {
+		t.Errorf("got %d pending glyphs, want 0", len(d.pending))
+	}
+	if got := dst.m.RGBAAt(0, 5); got.A != 0xff {
+		t.Errorf("the first glyph was not drawn: got %v", got)
+	}
+	if got := dst.m.RGBAAt(45, 5); got.A != 0 {
+		t.Errorf("the last glyph was drawn: got %v", got)
+	}
+	// Each glyph in the atlas was drawn, and advanced the dot by 5 pixels.
+	if got, want := d.Dot.X, fixed.I(5*d.Atlas.Len()); got != want {
+		t.Errorf("dot: got %v, want %v", got, want)
+	}
+}
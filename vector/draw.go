@@ -0,0 +1,98 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+import (
+	"errors"
+	"image"
+	"image/color"
+
+	"github.com/as/shiny/math/f64"
+	"github.com/as/shiny/screen"
+)
+
+var errMask = errors.New("vector: DrawOptions.Mask is not supported")
+
+// maxTrapezoidEdges is the most edges that a Path may have for Fill to send
+// it to a screen.TrapezoidDrawer. The number of trapezoids grows with the
+// square of the edges, since each edge crossing splits a band: a stroked
+// polyline of 200 points has over 3000 edges and makes over 20000
+// trapezoids. Above the limit, rasterizing in software is faster.
+const maxTrapezoidEdges = 256
+
+// Fill fills the area of p, whose coordinates are in dst space, according to
+// rule, with the uniform color src.
+//
+// If dst is a screen.TrapezoidDrawer, and p has few enough edges, the driver
+// rasterizes p. Otherwise, p is rasterized in software to a mask Texture,
+// created with s, that is then drawn with dst.DrawUniform.
+//
+// The Clip and Opacity in opts are honored. A Mask is not supported.
+func Fill(s screen.Screen, dst screen.Drawer, p *Path, rule FillRule, src color.Color, op screen.Op, opts *screen.DrawOptions) error {
+	if opts.GetMask() != nil {
+		return errMask
+	}
+	if td, ok := dst.(screen.TrapezoidDrawer); ok {
+		if es := p.edges(); len(es) <= maxTrapezoidEdges {
+			if traps := trapezoids(es, rule); len(traps) != 0 {
+				td.DrawTrapezoids(src, traps, op, opts)
+			}
+			return nil
+		}
+	}
+
+	r := pixelBounds(p.Bounds())
+	if clip := opts.GetClip(); !clip.Empty() {
+		r = r.Intersect(clip)
+	}
+	if r.Empty() {
+		return nil
+	}
+	a := p.Rasterize(r, rule)
+
+	buf, err := s.NewBuffer(r.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer buf.Release()
+	rgba := buf.RGBA()
+	for y := 0; y < r.Dy(); y++ {
+		src := a.Pix[y*a.Stride : y*a.Stride+r.Dx()]
+		dst := rgba.Pix[y*rgba.Stride:]
+		for x, c := range src {
+			dst[4*x+0] = c
+			dst[4*x+1] = c
+			dst[4*x+2] = c
+			dst[4*x+3] = c
+		}
+	}
+	mask, err := s.NewTexture(r.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer mask.Release()
+	mask.Upload(image.Point{}, buf, buf.Bounds())
+
+	mopts := screen.DrawOptions{
+		Filter: screen.FilterNearest,
+		Mask:   mask,
+	}
+	if opts != nil {
+		// Copy the Opacity as it is: a negative one, for a transparent draw,
+		// must not become the zero value, which is opaque.
+		mopts.Clip, mopts.Opacity = opts.Clip, opts.Opacity
+	}
+	dst.DrawUniform(f64.Aff3{
+		1, 0, float64(r.Min.X),
+		0, 1, float64(r.Min.Y),
+	}, src, mask.Bounds(), op, &mopts)
+	return nil
+}
+
+// StrokePath draws the outline of p, as given by s, with the uniform color
+// src. It is like Fill, with the same restrictions.
+func StrokePath(sc screen.Screen, dst screen.Drawer, p *Path, s *Stroke, src color.Color, op screen.Op, opts *screen.DrawOptions) error {
+	return Fill(sc, dst, s.Outline(p), NonZero, src, op, opts)
+}
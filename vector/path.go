@@ -0,0 +1,258 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package vector provides vector paths, and draws them onto a screen.Drawer.
+//
+// A Path is a sequence of lines and Bézier curves. It can be filled, by
+// either the non-zero or the even-odd winding rule, and a Stroke turns it
+// into the Path of its outline, which is then filled with the non-zero rule.
+//
+// Paths are rasterized with anti-aliasing, either in software to an alpha
+// mask that is then drawn with screen.DrawOptions.Mask, or by the driver
+// itself if the dst implements screen.TrapezoidDrawer.
+package vector // import "github.com/as/shiny/vector"
+
+import (
+	"image"
+	"math"
+
+	"github.com/as/shiny/math/fixed"
+)
+
+// FillRule is how to decide which points are inside a Path.
+type FillRule int
+
+const (
+	// NonZero fills the points around which the Path winds a non-zero number
+	// of times.
+	NonZero FillRule = iota
+	// EvenOdd fills the points around which the Path winds an odd number of
+	// times.
+	EvenOdd
+)
+
+func (r FillRule) inside(winding int) bool {
+	if r == EvenOdd {
+		return winding&1 != 0
+	}
+	return winding != 0
+}
+
+type segKind uint8
+
+const (
+	segMove segKind = iota
+	segLine
+	segQuad
+	segCube
+	segClose
+)
+
+type segment struct {
+	kind segKind
+	// p holds the segment's points, not including its start point, which is
+	// the end point of the previous segment. A segMove has one point and a
+	// segClose has none.
+	p [3]fixed.Point26_6
+}
+
+// Path is a sequence of subpaths, each of which starts with MoveTo. The zero
+// value is an empty Path.
+//
+// Drawing a segment before the first MoveTo implicitly starts at the origin.
+type Path struct {
+	segs []segment
+}
+
+// Reset empties p, keeping its memory for reuse.
+func (p *Path) Reset() { p.segs = p.segs[:0] }
+
+// MoveTo starts a new subpath at a.
+func (p *Path) MoveTo(a fixed.Point26_6) {
+	p.segs = append(p.segs, segment{kind: segMove, p: [3]fixed.Point26_6{a}})
+}
+
+// LineTo adds a line from the current point to b.
+func (p *Path) LineTo(b fixed.Point26_6) {
+	p.segs = append(p.segs, segment{kind: segLine, p: [3]fixed.Point26_6{b}})
+}
+
+// QuadTo adds a quadratic Bézier curve from the current point, with control
+// point b, to c.
+func (p *Path) QuadTo(b, c fixed.Point26_6) {
+	p.segs = append(p.segs, segment{kind: segQuad, p: [3]fixed.Point26_6{b, c}})
+}
+
+// CubeTo adds a cubic Bézier curve from the current point, with control
+// points b and c, to d.
+func (p *Path) CubeTo(b, c, d fixed.Point26_6) {
+	p.segs = append(p.segs, segment{kind: segCube, p: [3]fixed.Point26_6{b, c, d}})
+}
+
+// Close closes the current subpath with a line back to its start. When
+// filling, every subpath is implicitly closed, but when stroking, only closed
+// subpaths are joined at their start instead of having caps.
+func (p *Path) Close() {
+	p.segs = append(p.segs, segment{kind: segClose})
+}
+
+// Bounds returns the smallest rectangle containing every point of p,
+// including control points, which may make it larger than the area that p
+// covers.
+func (p *Path) Bounds() fixed.Rectangle26_6 {
+	if len(p.segs) == 0 {
+		return fixed.Rectangle26_6{}
+	}
+	b := fixed.Rectangle26_6{
+		Min: fixed.Point26_6{X: math.MaxInt32, Y: math.MaxInt32},
+		Max: fixed.Point26_6{X: math.MinInt32, Y: math.MinInt32},
+	}
+	add := func(q fixed.Point26_6) {
+		if q.X < b.Min.X {
+			b.Min.X = q.X
+		}
+		if q.Y < b.Min.Y {
+			b.Min.Y = q.Y
+		}
+		if q.X > b.Max.X {
+			b.Max.X = q.X
+		}
+		if q.Y > b.Max.Y {
+			b.Max.Y = q.Y
+		}
+	}
+	if p.segs[0].kind != segMove {
+		add(fixed.Point26_6{})
+	}
+	for _, s := range p.segs {
+		for _, q := range s.p[:s.kind.numPoints()] {
+			add(q)
+		}
+	}
+	return b
+}
+
+func (k segKind) numPoints() int {
+	switch k {
+	case segMove, segLine:
+		return 1
+	case segQuad:
+		return 2
+	case segCube:
+		return 3
+	}
+	return 0
+}
+
+// pixelBounds returns the smallest rectangle of whole pixels containing b.
+func pixelBounds(b fixed.Rectangle26_6) image.Rectangle {
+	return image.Rect(b.Min.X.Floor(), b.Min.Y.Floor(), b.Max.X.Ceil(), b.Max.Y.Ceil())
+}
+
+// point is a point in floating point pixel space, used once a Path has been
+// flattened.
+type point struct {
+	x, y float64
+}
+
+func pt(q fixed.Point26_6) point {
+	return point{float64(q.X) / 64, float64(q.Y) / 64}
+}
+
+func (a point) add(b point) point     { return point{a.x + b.x, a.y + b.y} }
+func (a point) sub(b point) point     { return point{a.x - b.x, a.y - b.y} }
+func (a point) mul(k float64) point   { return point{a.x * k, a.y * k} }
+func (a point) dot(b point) float64   { return a.x*b.x + a.y*b.y }
+func (a point) cross(b point) float64 { return a.x*b.y - a.y*b.x }
+func (a point) len() float64          { return math.Hypot(a.x, a.y) }
+func (a point) lerp(b point, t float64) point {
+	return point{a.x + (b.x-a.x)*t, a.y + (b.y-a.y)*t}
+}
+
+// polyline is a flattened subpath.
+type polyline struct {
+	pts    []point
+	closed bool
+}
+
+// tolerance is the maximum distance, in pixels, between a curve and the
+// lines that approximate it.
+const tolerance = 0.1
+
+// flatten approximates p's curves by lines, returning one polyline per
+// subpath. Consecutive duplicate points are removed.
+func (p *Path) flatten() []polyline {
+	var (
+		out   []polyline
+		cur   polyline
+		start point
+		last  point
+	)
+	flush := func() {
+		if len(cur.pts) > 1 || cur.closed {
+			out = append(out, cur)
+		}
+		cur = polyline{}
+	}
+	lineTo := func(q point) {
+		if len(cur.pts) == 0 {
+			cur.pts = append(cur.pts, last)
+		}
+		if q != cur.pts[len(cur.pts)-1] {
+			cur.pts = append(cur.pts, q)
+		}
+		last = q
+	}
+	for _, s := range p.segs {
+		switch s.kind {
+		case segMove:
+			flush()
+			start, last = pt(s.p[0]), pt(s.p[0])
+			cur.pts = append(cur.pts, start)
+		case segLine:
+			lineTo(pt(s.p[0]))
+		case segQuad:
+			p0, p1, p2 := last, pt(s.p[0]), pt(s.p[1])
+			// By Wang's formula, n lines are within the tolerance.
+			dd := p0.sub(p1.mul(2)).add(p2).len()
+			n := int(math.Ceil(math.Sqrt(dd / (4 * tolerance))))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				lineTo(p0.lerp(p1, t).lerp(p1.lerp(p2, t), t))
+			}
+			if n == 0 {
+				lineTo(p2)
+			}
+		case segCube:
+			p0, p1, p2, p3 := last, pt(s.p[0]), pt(s.p[1]), pt(s.p[2])
+			dd := math.Max(
+				p0.sub(p1.mul(2)).add(p2).len(),
+				p1.sub(p2.mul(2)).add(p3).len(),
+			)
+			n := int(math.Ceil(math.Sqrt(dd * 3 / (4 * tolerance))))
+			for i := 1; i <= n; i++ {
+				t := float64(i) / float64(n)
+				a, b, c := p0.lerp(p1, t), p1.lerp(p2, t), p2.lerp(p3, t)
+				lineTo(a.lerp(b, t).lerp(b.lerp(c, t), t))
+			}
+			if n == 0 {
+				lineTo(p3)
+			}
+		case segClose:
+			if len(cur.pts) == 0 {
+				continue
+			}
+			if n := len(cur.pts); n > 1 && cur.pts[n-1] == start {
+				cur.pts = cur.pts[:n-1]
+			}
+			cur.closed = true
+			flush()
+			// A segment after Close, without a MoveTo, starts a new subpath
+			// at the same start point.
+			last = start
+		}
+	}
+	flush()
+	return out
+}
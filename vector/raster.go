@@ -0,0 +1,242 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"github.com/as/shiny/math/fixed"
+	"github.com/as/shiny/screen"
+)
+
+// edge is a non-horizontal line of a flattened, closed path, with y0 < y1.
+type edge struct {
+	x0, y0, x1, y1 float64
+	// dir is +1 if the path goes down the edge, in the direction of
+	// increasing y, and -1 if it goes up.
+	dir int
+}
+
+func (e *edge) xAt(y float64) float64 {
+	return e.x0 + (y-e.y0)*(e.x1-e.x0)/(e.y1-e.y0)
+}
+
+// edges returns the edges of p's subpaths, each of which is implicitly
+// closed.
+func (p *Path) edges() []edge {
+	var es []edge
+	for _, pl := range p.flatten() {
+		n := len(pl.pts)
+		for i := 0; i < n; i++ {
+			a, b := pl.pts[i], pl.pts[(i+1)%n]
+			switch {
+			case a.y < b.y:
+				es = append(es, edge{a.x, a.y, b.x, b.y, +1})
+			case a.y > b.y:
+				es = append(es, edge{b.x, b.y, a.x, a.y, -1})
+			}
+		}
+	}
+	return es
+}
+
+// subsamples is the number of scanlines per pixel row sampled by Rasterize.
+// Coverage along each scanline is exact.
+const subsamples = 16
+
+// Rasterize returns the coverage of the area that p fills, according to
+// rule, for those pixels in r. The coverage is anti-aliased.
+func (p *Path) Rasterize(r image.Rectangle, rule FillRule) *image.Alpha {
+	m := image.NewAlpha(r)
+	if r.Empty() {
+		return m
+	}
+	es := p.edges()
+	sort.Slice(es, func(i, j int) bool { return es[i].y0 < es[j].y0 })
+
+	var (
+		acc    = make([]float64, r.Dx())
+		active []*edge
+		xs     []crossing
+		next   int // The index of the next edge in es to become active.
+	)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		fy := float64(y)
+		// Find the edges that overlap this row.
+		for next < len(es) && es[next].y0 < fy+1 {
+			next++
+		}
+		active = active[:0]
+		for i := range es[:next] {
+			if e := &es[i]; e.y1 > fy {
+				active = append(active, e)
+			}
+		}
+		if len(active) == 0 {
+			continue
+		}
+
+		for i := range acc {
+			acc[i] = 0
+		}
+		for s := 0; s < subsamples; s++ {
+			sy := fy + (float64(s)+0.5)/subsamples
+			xs = xs[:0]
+			for _, e := range active {
+				if e.y0 <= sy && sy < e.y1 {
+					xs = append(xs, crossing{e.xAt(sy), e.dir})
+				}
+			}
+			sort.Slice(xs, func(i, j int) bool { return xs[i].x < xs[j].x })
+			winding, start := 0, 0.0
+			for _, c := range xs {
+				was := rule.inside(winding)
+				winding += c.dir
+				if is := rule.inside(winding); is && !was {
+					start = c.x
+				} else if was && !is {
+					accumulate(acc, start-float64(r.Min.X), c.x-float64(r.Min.X))
+				}
+			}
+		}
+		row := m.Pix[(y-r.Min.Y)*m.Stride:]
+		for i, a := range acc {
+			a = a * 255 / subsamples
+			if a >= 255 {
+				row[i] = 0xff
+			} else {
+				row[i] = uint8(a + 0.5)
+			}
+		}
+	}
+	return m
+}
+
+type crossing struct {
+	x   float64
+	dir int
+}
+
+// accumulate adds the coverage of the span from x0 to x1, clipped to the
+// pixels of acc, to acc.
+func accumulate(acc []float64, x0, x1 float64) {
+	x0 = math.Max(x0, 0)
+	x1 = math.Min(x1, float64(len(acc)))
+	if x0 >= x1 {
+		return
+	}
+	i0, i1 := int(x0), int(x1)
+	if i0 == i1 {
+		acc[i0] += x1 - x0
+		return
+	}
+	acc[i0] += float64(i0+1) - x0
+	for i := i0 + 1; i < i1; i++ {
+		acc[i]++
+	}
+	if i1 < len(acc) {
+		acc[i1] += x1 - float64(i1)
+	}
+}
+
+// Trapezoids returns non-overlapping trapezoids whose union is the area that
+// p fills, according to rule.
+//
+// The number of trapezoids grows with the square of p's edges.
+func (p *Path) Trapezoids(rule FillRule) []screen.Trapezoid {
+	return trapezoids(p.edges(), rule)
+}
+
+// trapezoids returns the trapezoids of the area that the edges es fill.
+func trapezoids(es []edge, rule FillRule) []screen.Trapezoid {
+	if len(es) == 0 {
+		return nil
+	}
+
+	// The bands between consecutive vertex y coordinates are split further
+	// wherever edges cross, so that within each band the edges are ordered
+	// by x, and every other span between them is either inside or outside.
+	ys := make([]float64, 0, 2*len(es))
+	for _, e := range es {
+		ys = append(ys, e.y0, e.y1)
+	}
+	sort.Float64s(ys)
+
+	var (
+		traps  []screen.Trapezoid
+		active []*edge
+	)
+	for i := 0; i+1 < len(ys); i++ {
+		y0, y1 := ys[i], ys[i+1]
+		for y0 < y1 {
+			active = active[:0]
+			for j := range es {
+				if e := &es[j]; e.y0 <= y0 && y1 <= e.y1 {
+					active = append(active, e)
+				}
+			}
+			// Find the first crossing of any two edges within the band.
+			yc := y1
+			for j, a := range active {
+				for _, b := range active[j+1:] {
+					if y := crossingY(a, b, y0, yc); y < yc {
+						yc = y
+					}
+				}
+			}
+			ym := (y0 + yc) / 2
+			sort.Slice(active, func(i, j int) bool { return active[i].xAt(ym) < active[j].xAt(ym) })
+			traps = appendTraps(traps, active, rule, y0, yc)
+			y0 = yc
+		}
+	}
+	return traps
+}
+
+// crossingY returns the y coordinate, strictly between y0 and y1, at which a
+// and b cross, or y1 if they do not.
+func crossingY(a, b *edge, y0, y1 float64) float64 {
+	d0 := b.xAt(y0) - a.xAt(y0)
+	d1 := b.xAt(y1) - a.xAt(y1)
+	if (d0 >= 0) == (d1 >= 0) {
+		return y1
+	}
+	y := y0 + (y1-y0)*d0/(d0-d1)
+	// Avoid splitting a band into slivers that make no progress.
+	const epsilon = 1.0 / 256
+	if y-y0 < epsilon || y1-y < epsilon {
+		return y1
+	}
+	return y
+}
+
+func appendTraps(traps []screen.Trapezoid, active []*edge, rule FillRule, y0, y1 float64) []screen.Trapezoid {
+	if y1-y0 <= 0 {
+		return traps
+	}
+	top, bottom := fixed.Int26_6(math.Round(y0*64)), fixed.Int26_6(math.Round(y1*64))
+	if top == bottom {
+		return traps
+	}
+	winding := 0
+	var left *edge
+	for _, e := range active {
+		was := rule.inside(winding)
+		winding += e.dir
+		if is := rule.inside(winding); is && !was {
+			left = e
+		} else if was && !is {
+			traps = append(traps, screen.Trapezoid{
+				Top:    top,
+				Bottom: bottom,
+				Left:   [2]fixed.Point26_6{fpt(point{left.x0, left.y0}), fpt(point{left.x1, left.y1})},
+				Right:  [2]fixed.Point26_6{fpt(point{e.x0, e.y0}), fpt(point{e.x1, e.y1})},
+			})
+		}
+	}
+	return traps
+}
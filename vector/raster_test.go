@@ -0,0 +1,153 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/as/shiny/math/fixed"
+	"github.com/as/shiny/screen"
+)
+
+// fp returns the 26.6 fixed point for the pixel coordinates x and y.
+func fp(x, y float64) fixed.Point26_6 {
+	return fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)}
+}
+
+func rect(p *Path, x0, y0, x1, y1 float64) {
+	p.MoveTo(fp(x0, y0))
+	p.LineTo(fp(x1, y0))
+	p.LineTo(fp(x1, y1))
+	p.LineTo(fp(x0, y1))
+	p.Close()
+}
+
+// rasterArea returns the area, in pixels, covered by m.
+func rasterArea(m *image.Alpha) float64 {
+	sum := 0
+	for _, a := range m.Pix {
+		sum += int(a)
+	}
+	return float64(sum) / 0xff
+}
+
+// trapArea returns the total area of the trapezoids.
+func trapArea(traps []screen.Trapezoid) float64 {
+	xAt := func(l [2]fixed.Point26_6, y fixed.Int26_6) float64 {
+		x0, y0 := float64(l[0].X), float64(l[0].Y)
+		x1, y1 := float64(l[1].X), float64(l[1].Y)
+		return x0 + (float64(y)-y0)*(x1-x0)/(y1-y0)
+	}
+	sum := 0.0
+	for _, t := range traps {
+		top := xAt(t.Right, t.Top) - xAt(t.Left, t.Top)
+		bottom := xAt(t.Right, t.Bottom) - xAt(t.Left, t.Bottom)
+		sum += (top + bottom) / 2 * float64(t.Bottom-t.Top)
+	}
+	return sum / (64 * 64)
+}
+
+func TestRasterizeRect(t *testing.T) {
+	p := &Path{}
+	rect(p, 1.5, 2, 4.5, 5)
+	m := p.Rasterize(image.Rect(0, 0, 6, 6), NonZero)
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 6; x++ {
+			want := uint8(0)
+			if 2 <= y && y < 5 {
+				switch {
+				case x == 1 || x == 4:
+					want = 0x80
+				case 1 < x && x < 4:
+					want = 0xff
+				}
+			}
+			if got := m.AlphaAt(x, y).A; got != want {
+				t.Errorf("(%d, %d): got %#02x, want %#02x", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestFillRule(t *testing.T) {
+	// Two overlapping squares, both wound the same way, overlap in a 2x2
+	// square that NonZero fills but EvenOdd does not.
+	p := &Path{}
+	rect(p, 0, 0, 4, 4)
+	rect(p, 2, 2, 6, 6)
+	r := image.Rect(0, 0, 6, 6)
+
+	testCases := []struct {
+		rule FillRule
+		want float64
+	}{
+		{NonZero, 28},
+		{EvenOdd, 24},
+	}
+	for _, tc := range testCases {
+		if got := rasterArea(p.Rasterize(r, tc.rule)); got != tc.want {
+			t.Errorf("rule %d: Rasterize area: got %v, want %v", tc.rule, got, tc.want)
+		}
+		if got := trapArea(p.Trapezoids(tc.rule)); got != tc.want {
+			t.Errorf("rule %d: Trapezoids area: got %v, want %v", tc.rule, got, tc.want)
+		}
+		if m := p.Rasterize(r, tc.rule); m.AlphaAt(3, 3).A != 0 != (tc.rule == NonZero) {
+			t.Errorf("rule %d: overlap has alpha %#02x", tc.rule, m.AlphaAt(3, 3).A)
+		}
+	}
+}
+
+func TestTrapezoidsStar(t *testing.T) {
+	// A self-intersecting five-pointed star. Its edges cross, so the bands
+	// between its vertices must be split at the crossings.
+	p := &Path{}
+	for i := 0; i < 5; i++ {
+		θ := 2*math.Pi*float64(2*i)/5 - math.Pi/2
+		q := fp(50+40*math.Cos(θ), 50+40*math.Sin(θ))
+		if i == 0 {
+			p.MoveTo(q)
+		} else {
+			p.LineTo(q)
+		}
+	}
+	p.Close()
+
+	r := image.Rect(0, 0, 100, 100)
+	for _, rule := range []FillRule{NonZero, EvenOdd} {
+		want := rasterArea(p.Rasterize(r, rule))
+		got := trapArea(p.Trapezoids(rule))
+		if math.Abs(got-want) > 0.01*want {
+			t.Errorf("rule %d: got area %v, want %v", rule, got, want)
+		}
+	}
+	nonZero := rasterArea(p.Rasterize(r, NonZero))
+	evenOdd := rasterArea(p.Rasterize(r, EvenOdd))
+	if evenOdd >= nonZero {
+		t.Errorf("EvenOdd area %v is not less than NonZero area %v", evenOdd, nonZero)
+	}
+}
+
+func TestRasterizeCurves(t *testing.T) {
+	// A circle of radius 20 made of four cubic Bézier curves.
+	const (
+		cx, cy, radius = 30, 30, 20
+		k              = 0.5522847498 * radius
+	)
+	p := &Path{}
+	p.MoveTo(fp(cx+radius, cy))
+	p.CubeTo(fp(cx+radius, cy+k), fp(cx+k, cy+radius), fp(cx, cy+radius))
+	p.CubeTo(fp(cx-k, cy+radius), fp(cx-radius, cy+k), fp(cx-radius, cy))
+	p.CubeTo(fp(cx-radius, cy-k), fp(cx-k, cy-radius), fp(cx, cy-radius))
+	p.CubeTo(fp(cx+k, cy-radius), fp(cx+radius, cy-k), fp(cx+radius, cy))
+	p.Close()
+
+	got := rasterArea(p.Rasterize(pixelBounds(p.Bounds()), NonZero))
+	want := math.Pi * radius * radius
+	if math.Abs(got-want) > 0.005*want {
+		t.Errorf("got area %v, want %v", got, want)
+	}
+}
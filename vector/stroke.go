@@ -0,0 +1,295 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+import (
+	"math"
+
+	"github.com/as/shiny/math/fixed"
+)
+
+// Join is how the outline of a Stroke is drawn where two segments meet.
+type Join int
+
+const (
+	// MiterJoin extends the outer edges of the segments until they meet,
+	// unless that would exceed the Stroke's MiterLimit, in which case it is
+	// the same as BevelJoin.
+	MiterJoin Join = iota
+	// RoundJoin joins the segments with an arc.
+	RoundJoin
+	// BevelJoin joins the outer corners of the segments with a line.
+	BevelJoin
+)
+
+// Cap is how the outline of a Stroke is drawn at the ends of an open
+// subpath.
+type Cap int
+
+const (
+	// ButtCap ends the outline exactly at the end point.
+	ButtCap Cap = iota
+	// RoundCap ends the outline with a semicircle around the end point.
+	RoundCap
+	// SquareCap ends the outline with a half square around the end point.
+	SquareCap
+)
+
+// Stroke is a way to draw along a Path, rather than to fill it.
+type Stroke struct {
+	// Width is the width of the outline, centered on the Path.
+	Width fixed.Int26_6
+
+	Join Join
+	Cap  Cap
+
+	// MiterLimit is the longest that a MiterJoin may be, as a multiple of
+	// Width, before it is drawn as a BevelJoin instead. The zero value means
+	// 4.
+	MiterLimit float64
+
+	// Dashes, if non-empty, are the alternating lengths of the dashes and the
+	// gaps between them, starting with a dash. If there is an odd number of
+	// lengths, they are repeated to make an even number. Each dash is capped
+	// as if it were an open subpath.
+	Dashes []fixed.Int26_6
+
+	// DashOffset is how far into the dash pattern the start of each subpath
+	// is.
+	DashOffset fixed.Int26_6
+}
+
+// Outline returns the outline of stroking p, which is to be filled with the
+// NonZero rule.
+func (s *Stroke) Outline(p *Path) *Path {
+	o := &outliner{
+		path: &Path{},
+		hw:   float64(s.Width) / 128,
+		join: s.Join,
+		cap:  s.Cap,
+	}
+	o.limit = s.MiterLimit
+	if o.limit <= 0 {
+		o.limit = 4
+	}
+	if o.hw <= 0 {
+		return o.path
+	}
+	lines := p.flatten()
+	if d := s.dashes(); d != nil {
+		lines = dash(lines, d, float64(s.DashOffset)/64)
+	}
+	for _, pl := range lines {
+		o.polyline(pl)
+	}
+	return o.path
+}
+
+// dashes returns the dash pattern in pixels, or nil if there is none, or if it
+// is degenerate.
+func (s *Stroke) dashes() []float64 {
+	if len(s.Dashes) == 0 {
+		return nil
+	}
+	d := make([]float64, 0, 2*len(s.Dashes))
+	total := 0.0
+	for _, x := range s.Dashes {
+		if x < 0 {
+			return nil
+		}
+		d = append(d, float64(x)/64)
+		total += float64(x) / 64
+	}
+	if total == 0 {
+		return nil
+	}
+	if len(d)%2 != 0 {
+		d = append(d, d...)
+	}
+	return d
+}
+
+// dash splits each polyline into the parts of it that are dashes.
+func dash(lines []polyline, pattern []float64, offset float64) []polyline {
+	period := 0.0
+	for _, x := range pattern {
+		period += x
+	}
+	var out []polyline
+	for _, pl := range lines {
+		pts := pl.pts
+		if pl.closed {
+			pts = append(pts[:len(pts):len(pts)], pts[0])
+		}
+
+		// Find where in the pattern the polyline starts.
+		i, rem := 0, math.Mod(offset, period)
+		if rem < 0 {
+			rem += period
+		}
+		for rem >= pattern[i] {
+			rem -= pattern[i]
+			i = (i + 1) % len(pattern)
+		}
+		left := pattern[i] - rem // The length left in the current dash or gap.
+
+		var cur []point
+		if i%2 == 0 {
+			cur = []point{pts[0]}
+		}
+		for j := 0; j+1 < len(pts); j++ {
+			a, b := pts[j], pts[j+1]
+			l := b.sub(a).len()
+			t := 0.0 // How far along a to b has been dashed.
+			for l-t > left {
+				t += left
+				q := a.lerp(b, t/l)
+				if i%2 == 0 {
+					out = append(out, polyline{pts: append(cur, q)})
+					cur = nil
+				} else {
+					cur = []point{q}
+				}
+				i = (i + 1) % len(pattern)
+				left = pattern[i]
+			}
+			left -= l - t
+			if i%2 == 0 {
+				cur = append(cur, b)
+			}
+		}
+		if len(cur) > 1 {
+			out = append(out, polyline{pts: cur})
+		}
+	}
+	return out
+}
+
+// outliner builds the outline of a Stroke as a union of polygons that all
+// wind in the same direction, so that filling them with the NonZero rule
+// fills their union.
+type outliner struct {
+	path  *Path
+	hw    float64 // Half of the width.
+	join  Join
+	cap   Cap
+	limit float64
+}
+
+func (o *outliner) polyline(pl polyline) {
+	pts := pl.pts
+	n := len(pts)
+	if n < 2 {
+		return
+	}
+	segs := n - 1
+	if pl.closed {
+		segs = n
+	}
+	for i := 0; i < segs; i++ {
+		a, b := pts[i], pts[(i+1)%n]
+		d := o.normal(a, b)
+		o.polygon(a.add(d), b.add(d), b.sub(d), a.sub(d))
+	}
+	for i := 1; i < n-1; i++ {
+		o.joinAt(pts[i-1], pts[i], pts[i+1])
+	}
+	if pl.closed {
+		o.joinAt(pts[n-2], pts[n-1], pts[0])
+		o.joinAt(pts[n-1], pts[0], pts[1])
+		return
+	}
+	o.capAt(pts[1], pts[0])
+	o.capAt(pts[n-2], pts[n-1])
+}
+
+// normal returns the vector perpendicular to the line from a to b whose
+// length is half of the width.
+func (o *outliner) normal(a, b point) point {
+	d := b.sub(a)
+	return point{-d.y, d.x}.mul(o.hw / d.len())
+}
+
+// joinAt adds the join at v between the segments from u to v and v to w.
+func (o *outliner) joinAt(u, v, w point) {
+	if o.join == RoundJoin {
+		o.circle(v)
+		return
+	}
+	n0, n1 := o.normal(u, v), o.normal(v, w)
+	// The outer side of the join is opposite to the direction of the turn.
+	if v.sub(u).cross(w.sub(v)) > 0 {
+		n0, n1 = n0.mul(-1), n1.mul(-1)
+	}
+	if o.join == MiterJoin {
+		// The miter is as long, relative to the width, as the secant of half
+		// the angle between the normals.
+		m := n0.add(n1)
+		if ml := m.len(); ml > 0 && 2*o.hw/ml <= o.limit {
+			o.polygon(v, v.add(n0), v.add(m.mul(2*o.hw*o.hw/(ml*ml))), v.add(n1))
+			return
+		}
+	}
+	o.polygon(v, v.add(n0), v.add(n1))
+}
+
+// capAt adds the cap at the end point v of the segment from u to v.
+func (o *outliner) capAt(u, v point) {
+	switch o.cap {
+	case RoundCap:
+		o.circle(v)
+	case SquareCap:
+		n := o.normal(u, v)
+		d := point{n.y, -n.x}
+		o.polygon(v.add(n), v.add(n).add(d), v.sub(n).add(d), v.sub(n))
+	}
+}
+
+// circle adds a circle around v whose diameter is the width.
+func (o *outliner) circle(v point) {
+	// Use enough sides that the polygon is within the tolerance of the
+	// circle.
+	sides := 8
+	if o.hw > tolerance {
+		if n := int(math.Ceil(math.Pi / math.Acos(1-tolerance/o.hw))); n > sides {
+			sides = n
+		}
+	}
+	pts := make([]point, sides)
+	for i := range pts {
+		θ := 2 * math.Pi * float64(i) / float64(sides)
+		pts[i] = point{v.x + o.hw*math.Cos(θ), v.y + o.hw*math.Sin(θ)}
+	}
+	o.polygon(pts...)
+}
+
+// polygon adds the closed polygon with the given vertices, reversing them if
+// necessary so that they wind clockwise on the screen.
+func (o *outliner) polygon(pts ...point) {
+	area := 0.0
+	for i, a := range pts {
+		area += a.cross(pts[(i+1)%len(pts)])
+	}
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+	o.path.MoveTo(fpt(pts[0]))
+	for _, q := range pts[1:] {
+		o.path.LineTo(fpt(q))
+	}
+	o.path.Close()
+}
+
+func fpt(p point) fixed.Point26_6 {
+	return fixed.Point26_6{
+		X: fixed.Int26_6(math.Round(p.x * 64)),
+		Y: fixed.Int26_6(math.Round(p.y * 64)),
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vector
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/as/shiny/math/fixed"
+)
+
+func TestStrokeArea(t *testing.T) {
+	line := &Path{}
+	line.MoveTo(fp(10, 10))
+	line.LineTo(fp(30, 10))
+
+	corner := &Path{}
+	corner.MoveTo(fp(10, 10))
+	corner.LineTo(fp(30, 10))
+	corner.LineTo(fp(30, 30))
+
+	square := &Path{}
+	rect(square, 10, 10, 30, 30)
+
+	const w = 4
+	testCases := []struct {
+		desc string
+		path *Path
+		s    Stroke
+		want float64
+	}{
+		{"butt", line, Stroke{Width: w * 64, Cap: ButtCap}, 20 * w},
+		{"square", line, Stroke{Width: w * 64, Cap: SquareCap}, (20 + w) * w},
+		{"round", line, Stroke{Width: w * 64, Cap: RoundCap}, 20*w + math.Pi*w*w/4},
+		{"dashed", line, Stroke{Width: w * 64, Dashes: []fixed.Int26_6{5 * 64}}, 10 * w},
+		{"dash offset", line, Stroke{Width: w * 64, Dashes: []fixed.Int26_6{5 * 64, 10 * 64}, DashOffset: 10 * 64}, 5 * w},
+		// The segments of the corner overlap in a square on its inside, and
+		// the join fills in some of the square on its outside.
+		{"miter", corner, Stroke{Width: w * 64, Join: MiterJoin}, 40*w - w*w/4 + w*w/4},
+		{"bevel", corner, Stroke{Width: w * 64, Join: BevelJoin}, 40*w - w*w/4 + w*w/8},
+		{"round join", corner, Stroke{Width: w * 64, Join: RoundJoin}, 40*w - w*w/4 + math.Pi*w*w/16},
+		{"miter limit", corner, Stroke{Width: w * 64, Join: MiterJoin, MiterLimit: 1.2}, 40*w - w*w/4 + w*w/8},
+		{"closed", square, Stroke{Width: w * 64, Cap: RoundCap}, 24*24 - 16*16},
+	}
+	r := image.Rect(0, 0, 40, 40)
+	for _, tc := range testCases {
+		got := rasterArea(tc.s.Outline(tc.path).Rasterize(r, NonZero))
+		if math.Abs(got-tc.want) > 0.01*tc.want {
+			t.Errorf("%s: got area %v, want %v", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestStrokeZeroWidth(t *testing.T) {
+	p := &Path{}
+	p.MoveTo(fp(1, 1))
+	p.LineTo(fp(5, 5))
+	s := Stroke{}
+	if o := s.Outline(p); len(o.segs) != 0 {
+		t.Errorf("got %d segments, want 0", len(o.segs))
+	}
+}